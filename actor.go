@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	_actorKey = "actor"
+
+	// _actorPseudonymSize is the number of bytes of the HMAC kept in
+	// pseudonyms, which is plenty to avoid collisions.
+	_actorPseudonymSize = 16
+)
+
+// ActorOption configures the fields built by Actor.
+type ActorOption interface {
+	apply(*actorOptions)
+}
+
+type actorOptions struct {
+	key   []byte
+	rawID *bool
+}
+
+// with returns a copy of the options with opts applied. o may be nil.
+func (o *actorOptions) with(opts []ActorOption) *actorOptions {
+	c := new(actorOptions)
+	if o != nil {
+		*c = *o
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+type actorOptionFunc func(*actorOptions)
+
+func (f actorOptionFunc) apply(o *actorOptions) {
+	f(o)
+}
+
+// ActorPseudonym logs an HMAC-SHA256 pseudonym of the ID, keyed with key,
+// instead of the raw ID. The same ID and key always produce the same
+// pseudonym, so an actor's activity can still be correlated across log
+// lines without the logs revealing who they are. Use ActorRawID to log both.
+//
+// ActorPseudonym panics if key is empty, as it would be if it were read from
+// an unset environment variable, rather than let raw IDs be logged.
+func ActorPseudonym(key []byte) ActorOption {
+	if len(key) == 0 {
+		panic("zap: ActorPseudonym requires a non-empty key")
+	}
+	return actorOptionFunc(func(o *actorOptions) {
+		o.key = key
+	})
+}
+
+// ActorRawID controls whether the raw ID is logged. By default, it's logged
+// unless ActorPseudonym is used.
+func ActorRawID(include bool) ActorOption {
+	return actorOptionFunc(func(o *actorOptions) {
+		o.rawID = &include
+	})
+}
+
+// _actorDefaults holds the options set with ReplaceActorOptions, already
+// applied, so that building fields doesn't need to lock.
+var _actorDefaults atomic.Pointer[actorOptions]
+
+// ReplaceActorOptions sets the options applied to every Actor field before
+// the field's own options, so that the policy of whether user identifiers
+// appear in logs is decided in one place. It returns a function to restore
+// the previous options. It's safe for concurrent use.
+func ReplaceActorOptions(opts ...ActorOption) func() {
+	prev := _actorDefaults.Swap((*actorOptions)(nil).with(opts))
+	return func() {
+		_actorDefaults.Store(prev)
+	}
+}
+
+// Actor constructs a field that identifies the user or service performing
+// an action under the "actor" key. The object has the keys id, pseudonym,
+// or both, depending on the options set with ReplaceActorOptions and
+// passed to Actor. With no options, only the raw ID is logged. Pseudonyms
+// are only computed when the entry is encoded.
+func Actor(id string, opts ...ActorOption) Field {
+	o := _actorDefaults.Load()
+	if len(opts) > 0 {
+		o = o.with(opts)
+	}
+
+	a := actor{id: id, hasID: true}
+	if o != nil {
+		a.key = o.key
+		a.hasID = o.key == nil
+		if o.rawID != nil {
+			a.hasID = *o.rawID
+		}
+	}
+	return Object(_actorKey, a)
+}
+
+type actor struct {
+	id    string
+	key   []byte // nil if no pseudonym is logged
+	hasID bool
+}
+
+func (a actor) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if a.hasID {
+		enc.AddString("id", a.id)
+	}
+	if a.key != nil {
+		mac := hmac.New(sha256.New, a.key)
+		mac.Write([]byte(a.id))
+		enc.AddString("pseudonym", hex.EncodeToString(mac.Sum(nil)[:_actorPseudonymSize]))
+	}
+	return nil
+}
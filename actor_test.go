@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestActor(t *testing.T) {
+	key := []byte("secret")
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("user-42"))
+	pseudonym := hex.EncodeToString(mac.Sum(nil)[:16])
+
+	tests := []struct {
+		desc     string
+		defaults []ActorOption
+		give     []ActorOption
+		want     map[string]interface{}
+	}{
+		{
+			desc: "raw ID by default",
+			want: map[string]interface{}{"id": "user-42"},
+		},
+		{
+			desc: "pseudonym",
+			give: []ActorOption{ActorPseudonym(key)},
+			want: map[string]interface{}{"pseudonym": pseudonym},
+		},
+		{
+			desc: "both",
+			give: []ActorOption{ActorPseudonym(key), ActorRawID(true)},
+			want: map[string]interface{}{"id": "user-42", "pseudonym": pseudonym},
+		},
+		{
+			desc: "neither",
+			give: []ActorOption{ActorRawID(false)},
+			want: map[string]interface{}{},
+		},
+		{
+			desc:     "global policy",
+			defaults: []ActorOption{ActorPseudonym(key)},
+			want:     map[string]interface{}{"pseudonym": pseudonym},
+		},
+		{
+			desc:     "call overrides global policy",
+			defaults: []ActorOption{ActorPseudonym(key)},
+			give:     []ActorOption{ActorRawID(true)},
+			want:     map[string]interface{}{"id": "user-42", "pseudonym": pseudonym},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			defer ReplaceActorOptions(tt.defaults...)()
+
+			enc := zapcore.NewMapObjectEncoder()
+			Actor("user-42", tt.give...).AddTo(enc)
+			assert.Equal(t, tt.want, enc.Fields["actor"], "Unexpected actor object.")
+		})
+	}
+}
+
+func TestActorPseudonymEmptyKey(t *testing.T) {
+	assert.Panics(t, func() { ActorPseudonym(nil) }, "Expected a nil key to panic.")
+	assert.Panics(t, func() { ActorPseudonym([]byte{}) }, "Expected an empty key to panic.")
+}
+
+func TestReplaceActorOptionsRestores(t *testing.T) {
+	undo := ReplaceActorOptions(ActorRawID(false))
+	enc := zapcore.NewMapObjectEncoder()
+	Actor("a").AddTo(enc)
+	assert.Equal(t, map[string]interface{}{}, enc.Fields["actor"], "Expected the replaced options to apply.")
+
+	undo()
+	enc = zapcore.NewMapObjectEncoder()
+	Actor("a").AddTo(enc)
+	assert.Equal(t, map[string]interface{}{"id": "a"}, enc.Fields["actor"], "Expected the original options to be restored.")
+}
+
+var _actorFieldSink Field
+
+func TestActorAllocs(t *testing.T) {
+	defer ReplaceActorOptions(ActorPseudonym([]byte("secret")))()
+	allocs := testing.AllocsPerRun(100, func() { _actorFieldSink = Actor("user-42") })
+	assert.Equal(t, 1.0, allocs, "Expected Actor to defer computing the pseudonym until encoding.")
+}
@@ -21,6 +21,7 @@
 package zap
 
 import (
+	"encoding"
 	"fmt"
 	"time"
 
@@ -226,6 +227,30 @@ func (os stringers[T]) MarshalLogArray(arr zapcore.ArrayEncoder) error {
 	return nil
 }
 
+// TextSlice constructs a field with the given key, holding a list of the
+// output provided by the values' MarshalText methods. Like Text, it avoids
+// reflection. If any MarshalText call fails, the error is logged under the
+// key with an "Error" suffix.
+//
+// As with Stringers, the MarshalText method must be declared on the element
+// type itself, not its pointer.
+func TextSlice[T encoding.TextMarshaler](key string, values []T) Field {
+	return Array(key, texts[T](values))
+}
+
+type texts[T encoding.TextMarshaler] []T
+
+func (ts texts[T]) MarshalLogArray(arr zapcore.ArrayEncoder) error {
+	for _, t := range ts {
+		text, err := t.MarshalText()
+		if err != nil {
+			return err
+		}
+		arr.AppendByteString(text)
+	}
+	return nil
+}
+
 // Times constructs a field that carries a slice of time.Times.
 func Times(key string, ts []time.Time) Field {
 	return Array(key, times(ts))
@@ -23,6 +23,7 @@ package zap
 import (
 	"errors"
 	"fmt"
+	"net"
 	"testing"
 	"time"
 
@@ -314,3 +315,54 @@ func TestStringers(t *testing.T) {
 		})
 	}
 }
+
+type textObject string
+
+func (o textObject) MarshalText() ([]byte, error) {
+	if o == "" {
+		return nil, errors.New("empty text")
+	}
+	return []byte(o), nil
+}
+
+func TestTextSlice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc    string
+		give    Field
+		want    []any
+		wantErr string
+	}{
+		{
+			desc: "TextSlice",
+			give: TextSlice("k", []textObject{"foo", "bar"}),
+			want: []any{"foo", "bar"},
+		},
+		{
+			desc: "TextSlice with net.IPs",
+			give: TextSlice("k", []net.IP{net.IPv4(127, 0, 0, 1)}),
+			want: []any{"127.0.0.1"},
+		},
+		{
+			desc:    "TextSlice with error",
+			give:    TextSlice("k", []textObject{"foo", ""}),
+			want:    []any{"foo"},
+			wantErr: "empty text",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+
+			enc := zapcore.NewMapObjectEncoder()
+			tt.give.AddTo(enc)
+			assert.Equal(t, tt.want, enc.Fields["k"], "Unexpected array.")
+			if tt.wantErr != "" {
+				assert.Equal(t, tt.wantErr, enc.Fields["kError"], "Unexpected error.")
+			}
+		})
+	}
+}
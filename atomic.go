@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// The Atomic field constructors log the current value of a sync/atomic
+// variable, such as a counter or gauge. The value is loaded when the field
+// is encoded: for fields passed to a logging method, that's when the entry
+// is written. Fields added with Logger.With are encoded right away, and
+// those added with Logger.WithLazy when first used, so use them on log
+// calls to get a fresh snapshot each time.
+
+// AtomicInt64 constructs a field that logs the current value of an
+// atomic.Int64.
+func AtomicInt64(key string, val *atomic.Int64) Field {
+	if val == nil {
+		return nilField(key)
+	}
+	return Inline(atomicInt64{key: key, val: val})
+}
+
+type atomicInt64 struct {
+	key string
+	val *atomic.Int64
+}
+
+func (a atomicInt64) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt64(a.key, a.val.Load())
+	return nil
+}
+
+// AtomicInt32 constructs a field that logs the current value of an
+// atomic.Int32.
+func AtomicInt32(key string, val *atomic.Int32) Field {
+	if val == nil {
+		return nilField(key)
+	}
+	return Inline(atomicInt32{key: key, val: val})
+}
+
+type atomicInt32 struct {
+	key string
+	val *atomic.Int32
+}
+
+func (a atomicInt32) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt32(a.key, a.val.Load())
+	return nil
+}
+
+// AtomicUint64 constructs a field that logs the current value of an
+// atomic.Uint64.
+func AtomicUint64(key string, val *atomic.Uint64) Field {
+	if val == nil {
+		return nilField(key)
+	}
+	return Inline(atomicUint64{key: key, val: val})
+}
+
+type atomicUint64 struct {
+	key string
+	val *atomic.Uint64
+}
+
+func (a atomicUint64) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddUint64(a.key, a.val.Load())
+	return nil
+}
+
+// AtomicUint32 constructs a field that logs the current value of an
+// atomic.Uint32.
+func AtomicUint32(key string, val *atomic.Uint32) Field {
+	if val == nil {
+		return nilField(key)
+	}
+	return Inline(atomicUint32{key: key, val: val})
+}
+
+type atomicUint32 struct {
+	key string
+	val *atomic.Uint32
+}
+
+func (a atomicUint32) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddUint32(a.key, a.val.Load())
+	return nil
+}
+
+// AtomicBool constructs a field that logs the current value of an
+// atomic.Bool.
+func AtomicBool(key string, val *atomic.Bool) Field {
+	if val == nil {
+		return nilField(key)
+	}
+	return Inline(atomicBool{key: key, val: val})
+}
+
+type atomicBool struct {
+	key string
+	val *atomic.Bool
+}
+
+func (a atomicBool) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddBool(a.key, a.val.Load())
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/internal/ztest"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestAtomicFields(t *testing.T) {
+	var (
+		i64 atomic.Int64
+		i32 atomic.Int32
+		u64 atomic.Uint64
+		u32 atomic.Uint32
+		b   atomic.Bool
+	)
+	i64.Store(-64)
+	i32.Store(-32)
+	u64.Store(64)
+	u32.Store(32)
+	b.Store(true)
+
+	tests := []struct {
+		desc string
+		give Field
+		want interface{}
+	}{
+		{"AtomicInt64", AtomicInt64("k", &i64), int64(-64)},
+		{"AtomicInt32", AtomicInt32("k", &i32), int32(-32)},
+		{"AtomicUint64", AtomicUint64("k", &u64), uint64(64)},
+		{"AtomicUint32", AtomicUint32("k", &u32), uint32(32)},
+		{"AtomicBool", AtomicBool("k", &b), true},
+		{"AtomicInt64 nil", AtomicInt64("k", nil), nil},
+		{"AtomicInt32 nil", AtomicInt32("k", nil), nil},
+		{"AtomicUint64 nil", AtomicUint64("k", nil), nil},
+		{"AtomicUint32 nil", AtomicUint32("k", nil), nil},
+		{"AtomicBool nil", AtomicBool("k", nil), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := zapcore.NewMapObjectEncoder()
+			tt.give.AddTo(enc)
+			assert.Equal(t, tt.want, enc.Fields["k"], "Unexpected field value.")
+		})
+	}
+}
+
+func TestAtomicFieldLoadsAtWrite(t *testing.T) {
+	var requests atomic.Int64
+	buf := &ztest.Buffer{}
+	logger := New(zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}),
+		buf,
+		DebugLevel,
+	))
+
+	field := AtomicInt64("requests", &requests)
+	requests.Add(3)
+	logger.Info("first", field)
+	requests.Add(2)
+	logger.Info("second", field)
+
+	assert.Equal(t, []string{
+		`{"msg":"first","requests":3}`,
+		`{"msg":"second","requests":5}`,
+	}, buf.Lines(), "Expected each entry to log the value at the time of writing.")
+}
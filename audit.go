@@ -0,0 +1,232 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// _defaultAuditFields are the keys an audit entry needs if the AuditConfig
+// doesn't list any.
+var _defaultAuditFields = []string{"actor", "action", "resource", "outcome"}
+
+// AuditConfig configures a logger for audit or compliance trails. Unlike
+// ordinary logs, every audit entry must say who did what to which resource
+// and how it turned out, and each entry is synced to its outputs as soon as
+// it's written.
+type AuditConfig struct {
+	// RequiredFields are the keys every audit entry must have, either from
+	// the log site or from context added with With. Entries missing any of
+	// them aren't written. If empty, the keys "actor", "action", "resource",
+	// and "outcome" are required.
+	RequiredFields []string `json:"requiredFields" yaml:"requiredFields"`
+	// Development makes entries missing required fields panic, the way
+	// DPanicLevel logs do in development. Otherwise they're reported to
+	// ErrorOutputPaths and dropped.
+	Development bool `json:"development" yaml:"development"`
+	// EncoderConfig configures the JSON encoder used for audit entries. If
+	// nil, NewProductionEncoderConfig is used.
+	EncoderConfig *zapcore.EncoderConfig `json:"encoderConfig" yaml:"encoderConfig"`
+	// OutputPaths is a list of URLs or file paths to write audit entries to.
+	// It must not be empty, and should usually be kept separate from the
+	// application's other logs. See Open for details.
+	OutputPaths []string `json:"outputPaths" yaml:"outputPaths"`
+	// ErrorOutputPaths is a list of URLs to write internal logger errors to,
+	// including refused entries. If empty, errors go to standard error.
+	ErrorOutputPaths []string `json:"errorOutputPaths" yaml:"errorOutputPaths"`
+}
+
+// NewAudit builds an audit Logger from the configuration. The logger writes
+// entries of every level as JSON, syncs its outputs after each entry, and
+// refuses entries that don't have all of the configuration's required
+// fields.
+//
+//	audit, err := zap.NewAudit(zap.AuditConfig{
+//		OutputPaths: []string{"/var/log/myapp/audit.log"},
+//	})
+//	...
+//	audit.With(zap.String("actor", user)).Info("deleted document",
+//		zap.String("action", "delete"),
+//		zap.String("resource", docID),
+//		zap.String("outcome", "success"),
+//	)
+func NewAudit(cfg AuditConfig, opts ...Option) (*Logger, error) {
+	if len(cfg.OutputPaths) == 0 {
+		return nil, errors.New("audit logger needs at least one output path")
+	}
+	required := cfg.RequiredFields
+	if len(required) == 0 {
+		required = _defaultAuditFields
+	}
+	for _, key := range required {
+		if key == "" {
+			return nil, errors.New("audit logger can't require an empty key")
+		}
+	}
+	encCfg := NewProductionEncoderConfig()
+	if cfg.EncoderConfig != nil {
+		encCfg = *cfg.EncoderConfig
+	}
+	errPaths := cfg.ErrorOutputPaths
+	if len(errPaths) == 0 {
+		errPaths = []string{"stderr"}
+	}
+
+	sink, closeOut, err := Open(cfg.OutputPaths...)
+	if err != nil {
+		return nil, err
+	}
+	errSink, _, err := Open(errPaths...)
+	if err != nil {
+		closeOut()
+		return nil, err
+	}
+
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(encCfg),
+		syncingWriteSyncer{sink},
+		zapcore.TraceLevel,
+	)
+	log := New(
+		newAuditCore(core, required, cfg.Development),
+		ErrorOutput(errSink),
+		AddCaller(),
+	)
+	if len(opts) > 0 {
+		log = log.WithOptions(opts...)
+	}
+	return log, nil
+}
+
+// syncingWriteSyncer syncs the wrapped WriteSyncer after every write.
+type syncingWriteSyncer struct {
+	zapcore.WriteSyncer
+}
+
+func (s syncingWriteSyncer) Write(p []byte) (int, error) {
+	n, err := s.WriteSyncer.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, s.WriteSyncer.Sync()
+}
+
+// auditCore refuses to write entries that are missing any required fields.
+type auditCore struct {
+	zapcore.Core
+
+	required []string
+	present  []bool // present[i] is true if With added required[i]
+	nested   bool   // whether With opened a namespace
+	panics   bool   // whether refused entries panic
+}
+
+var _ zapcore.Core = (*auditCore)(nil)
+
+func newAuditCore(core zapcore.Core, required []string, panics bool) *auditCore {
+	return &auditCore{
+		Core:     core,
+		required: required,
+		present:  make([]bool, len(required)),
+		panics:   panics,
+	}
+}
+
+func (c *auditCore) Level() zapcore.Level {
+	return zapcore.LevelOf(c.Core)
+}
+
+func (c *auditCore) With(fields []zapcore.Field) zapcore.Core {
+	present := append([]bool(nil), c.present...)
+	nested := c.nested
+	for _, f := range fields {
+		if nested {
+			break
+		}
+		if f.Type == zapcore.NamespaceType {
+			nested = true
+			continue
+		}
+		for i, key := range c.required {
+			if !present[i] && hasKey(f, key) {
+				present[i] = true
+			}
+		}
+	}
+	return &auditCore{
+		Core:     c.Core.With(fields),
+		required: c.required,
+		present:  present,
+		nested:   nested,
+		panics:   c.panics,
+	}
+}
+
+func (c *auditCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *auditCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	var missing []string
+	for i, key := range c.required {
+		if !c.present[i] && !c.logged(fields, key) {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return c.Core.Write(ent, fields)
+	}
+
+	err := fmt.Errorf("refusing audit entry %q: missing required fields %s",
+		ent.Message, strings.Join(missing, ", "))
+	if c.panics {
+		panic(err)
+	}
+	return err
+}
+
+// logged reports whether the top level of an entry has the given key among
+// the fields from the log site.
+func (c *auditCore) logged(fields []zapcore.Field, key string) bool {
+	if c.nested {
+		return false
+	}
+	for _, f := range fields {
+		if f.Type == zapcore.NamespaceType {
+			return false
+		}
+		if hasKey(f, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasKey(f zapcore.Field, key string) bool {
+	return f.Key == key && f.Type != zapcore.SkipType
+}
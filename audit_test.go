@@ -0,0 +1,210 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/internal/ztest"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNewAudit(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "audit.log")
+	errOut := filepath.Join(dir, "errors.log")
+
+	audit, err := NewAudit(AuditConfig{
+		OutputPaths:      []string{out},
+		ErrorOutputPaths: []string{errOut},
+	})
+	require.NoError(t, err, "Unexpected error building audit logger.")
+
+	audit.With(String("actor", "alice")).Debug("deleted document",
+		String("action", "delete"),
+		String("resource", "doc-1"),
+		String("outcome", "success"),
+	)
+	// Entries are synced as they're written, so they're on disk without
+	// calling Sync.
+	contents, err := os.ReadFile(out)
+	require.NoError(t, err, "Failed to read audit log.")
+	assert.Contains(t, string(contents), `"msg":"deleted document"`, "Unexpected audit entry.")
+	assert.Contains(t, string(contents), `"actor":"alice"`, "Expected context in audit entry.")
+
+	audit.Info("viewed document", String("actor", "bob"))
+	contents, err = os.ReadFile(out)
+	require.NoError(t, err, "Failed to read audit log.")
+	assert.Equal(t, 1, strings.Count(string(contents), "\n"), "Expected incomplete entry to be refused.")
+
+	errContents, err := os.ReadFile(errOut)
+	require.NoError(t, err, "Failed to read error log.")
+	assert.Contains(t, string(errContents),
+		`refusing audit entry "viewed document": missing required fields action, resource, outcome`,
+		"Expected refused entry to be reported.")
+}
+
+func TestNewAuditErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    AuditConfig
+		wantErr string
+	}{
+		{
+			desc:    "no outputs",
+			give:    AuditConfig{},
+			wantErr: "needs at least one output path",
+		},
+		{
+			desc:    "empty required key",
+			give:    AuditConfig{OutputPaths: []string{"stdout"}, RequiredFields: []string{"actor", ""}},
+			wantErr: "can't require an empty key",
+		},
+		{
+			desc:    "bad output",
+			give:    AuditConfig{OutputPaths: []string{"unknown://foo"}},
+			wantErr: `no sink found for scheme "unknown"`,
+		},
+		{
+			desc: "bad error output",
+			give: AuditConfig{
+				OutputPaths:      []string{"stdout"},
+				ErrorOutputPaths: []string{"unknown://foo"},
+			},
+			wantErr: `no sink found for scheme "unknown"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := NewAudit(tt.give)
+			require.Error(t, err, "Expected an error.")
+			assert.Contains(t, err.Error(), tt.wantErr, "Unexpected error message.")
+		})
+	}
+}
+
+func TestAuditCoreRequiredFields(t *testing.T) {
+	required := []string{"actor", "action"}
+
+	tests := []struct {
+		desc    string
+		context []Field
+		give    []Field
+		want    bool
+	}{
+		{
+			desc: "all fields at log site",
+			give: []Field{String("actor", "alice"), String("action", "login")},
+			want: true,
+		},
+		{
+			desc:    "fields split across context and log site",
+			context: []Field{String("actor", "alice")},
+			give:    []Field{String("action", "login")},
+			want:    true,
+		},
+		{
+			desc: "missing field",
+			give: []Field{String("actor", "alice")},
+			want: false,
+		},
+		{
+			desc: "skipped field",
+			give: []Field{String("actor", "alice"), Skip(), Error(nil)},
+			want: false,
+		},
+		{
+			desc: "field in namespace",
+			give: []Field{String("actor", "alice"), Namespace("details"), String("action", "login")},
+			want: false,
+		},
+		{
+			desc:    "log site fields in context namespace",
+			context: []Field{String("actor", "alice"), Namespace("details")},
+			give:    []Field{String("action", "login")},
+			want:    false,
+		},
+		{
+			desc:    "namespace named like a required field",
+			context: []Field{String("actor", "alice"), Namespace("action")},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			obs, logs := observer.New(DebugLevel)
+			errOut := &ztest.Buffer{}
+			log := New(newAuditCore(obs, required, false), ErrorOutput(errOut)).
+				With(tt.context...)
+
+			log.Info("hello", tt.give...)
+			if tt.want {
+				assert.Equal(t, 1, logs.Len(), "Expected entry to be written.")
+				assert.Empty(t, errOut.String(), "Unexpected error output.")
+			} else {
+				assert.Zero(t, logs.Len(), "Expected entry to be refused.")
+				assert.Contains(t, errOut.String(), "refusing audit entry", "Expected refused entry to be reported.")
+			}
+		})
+	}
+}
+
+func TestAuditCoreContextIsolation(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	log := New(newAuditCore(obs, []string{"actor", "action"}, false), ErrorOutput(&ztest.Buffer{}))
+
+	alice := log.With(String("actor", "alice"))
+	// Adding a namespace to a child mustn't affect its parent.
+	_ = alice.With(Namespace("details"))
+	alice.Info("login", String("action", "login"))
+	log.Info("logout", String("action", "logout"))
+
+	require.Equal(t, 1, logs.Len(), "Expected only one entry to be written.")
+	assert.Equal(t, "login", logs.All()[0].Message, "Unexpected entry written.")
+}
+
+func TestAuditCorePanicsInDevelopment(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	log := New(newAuditCore(obs, []string{"actor"}, true))
+
+	assert.PanicsWithError(t, `refusing audit entry "hello": missing required fields actor`, func() {
+		log.Info("hello")
+	}, "Expected incomplete entry to panic in development.")
+	assert.Zero(t, logs.Len(), "Expected entry to be refused.")
+}
+
+func TestAuditCoreLevel(t *testing.T) {
+	core := newAuditCore(zapcore.NewNopCore(), _defaultAuditFields, false)
+	assert.Equal(t, zapcore.InvalidLevel, zapcore.LevelOf(core), "Unexpected level.")
+
+	obs, _ := observer.New(WarnLevel)
+	core = newAuditCore(obs, _defaultAuditFields, false)
+	assert.Equal(t, WarnLevel, zapcore.LevelOf(core), "Unexpected level.")
+	assert.False(t, core.Enabled(InfoLevel), "Expected level to come from the wrapped core.")
+}
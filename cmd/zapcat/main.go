@@ -0,0 +1,291 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// zapcat pretty-prints logs written by zap's JSON encoder.
+//
+// Usage:
+//
+//	zapcat [flags] [files]
+//
+// zapcat reads the named files, or standard input if there are none, and
+// re-renders each entry with zap's console encoder, colorizing levels when
+// writing to a terminal. Entries can be filtered by level, logger name, and
+// field values. Lines that aren't JSON log entries are passed through as-is,
+// unless a filter is set.
+//
+// Key names default to those of zap.NewProductionEncoderConfig; use
+// -preset development for zap.NewDevelopmentEncoderConfig, or the -*-key
+// flags to match a custom EncoderConfig.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/internal/term"
+	"go.uber.org/zap/zapcore"
+)
+
+const _maxLineSize = 1 << 20
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// fieldFilters is a flag.Value collecting key=value field filters.
+type fieldFilters map[string][]string
+
+func (f fieldFilters) String() string { return "" }
+
+func (f fieldFilters) Set(s string) error {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("field filter %q must have the form key=value", s)
+	}
+	f[k] = append(f[k], v)
+	return nil
+}
+
+type options struct {
+	minLevel *zapcore.Level
+	name     string
+	fields   fieldFilters
+	decoder  *zapcore.EntryDecoder
+	encoder  zapcore.Encoder
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("zapcat", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: zapcat [flags] [files]\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	var (
+		level  = flags.String("level", "", "only show entries at or above this `level`")
+		name   = flags.String("name", "", "only show entries from this logger `name` or its children")
+		fields = make(fieldFilters)
+		format = flags.String("format", "console", "output `format`: console or json")
+		color  = flags.String("color", "auto", "colorize levels: auto, always, or never")
+		preset = flags.String("preset", "production", "default key names: production or development")
+
+		keys = map[string]*string{}
+	)
+	flags.Var(fields, "field", "only show entries with the field `key=value` (repeatable)")
+	for _, k := range []string{"message", "level", "time", "name", "caller", "function", "event", "stacktrace"} {
+		keys[k] = flags.String(k+"-key", "", "JSON key holding the entry's "+k+" (default from -preset)")
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, err := inputConfig(*preset, keys)
+	if err != nil {
+		fmt.Fprintln(stderr, "zapcat:", err)
+		return 2
+	}
+	opts := options{name: *name, fields: fields, decoder: zapcore.NewEntryDecoder(cfg)}
+	if *level != "" {
+		lvl, err := zapcore.ParseLevel(*level)
+		if err != nil {
+			fmt.Fprintln(stderr, "zapcat:", err)
+			return 2
+		}
+		opts.minLevel = &lvl
+	}
+	if opts.encoder, err = outputEncoder(cfg, *format, *color, stdout); err != nil {
+		fmt.Fprintln(stderr, "zapcat:", err)
+		return 2
+	}
+
+	out := bufio.NewWriter(stdout)
+	defer out.Flush()
+
+	if flags.NArg() == 0 {
+		if err := opts.cat(stdin, out); err != nil {
+			fmt.Fprintln(stderr, "zapcat:", err)
+			return 1
+		}
+		return 0
+	}
+
+	code := 0
+	for _, path := range flags.Args() {
+		if err := opts.catFile(path, out); err != nil {
+			fmt.Fprintln(stderr, "zapcat:", err)
+			code = 1
+		}
+	}
+	return code
+}
+
+// inputConfig builds the EncoderConfig describing the input's keys.
+func inputConfig(preset string, keys map[string]*string) (zapcore.EncoderConfig, error) {
+	var cfg zapcore.EncoderConfig
+	switch preset {
+	case "production":
+		cfg = zap.NewProductionEncoderConfig()
+	case "development":
+		cfg = zap.NewDevelopmentEncoderConfig()
+	default:
+		return cfg, fmt.Errorf("unknown preset %q", preset)
+	}
+
+	for k, dst := range map[string]*string{
+		"message":    &cfg.MessageKey,
+		"level":      &cfg.LevelKey,
+		"time":       &cfg.TimeKey,
+		"name":       &cfg.NameKey,
+		"caller":     &cfg.CallerKey,
+		"function":   &cfg.FunctionKey,
+		"event":      &cfg.EventKey,
+		"stacktrace": &cfg.StacktraceKey,
+	} {
+		if v := *keys[k]; v != "" {
+			*dst = v
+		}
+	}
+	return cfg, nil
+}
+
+// outputEncoder builds the Encoder used to re-render entries.
+func outputEncoder(cfg zapcore.EncoderConfig, format, color string, stdout io.Writer) (zapcore.Encoder, error) {
+	cfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	cfg.EncodeDuration = zapcore.StringDurationEncoder
+	cfg.EncodeCaller = zapcore.FullCallerEncoder // decoded callers are already formatted
+	cfg.EncodeName = zapcore.FullNameEncoder
+
+	switch color {
+	case "always":
+		cfg.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	case "never":
+		cfg.EncodeLevel = zapcore.CapitalLevelEncoder
+	case "auto":
+		cfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		if f, ok := stdout.(*os.File); ok && term.IsTerminal(f) {
+			cfg.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		}
+	default:
+		return nil, fmt.Errorf("unknown color mode %q", color)
+	}
+
+	switch format {
+	case "console":
+		return zapcore.RNewConsoleEncoder(cfg), nil
+	case "json":
+		cfg.EncodeLevel = zapcore.LowercaseLevelEncoder
+		return zapcore.NewJSONEncoder(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func (o *options) catFile(path string, out io.Writer) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := o.cat(f, out); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func (o *options) cat(r io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), _maxLineSize)
+	for scanner.Scan() {
+		if err := o.catLine(scanner.Bytes(), out); err != nil {
+			return err
+		}
+	}
+	if errors.Is(scanner.Err(), bufio.ErrTooLong) {
+		return fmt.Errorf("line longer than %d bytes", _maxLineSize)
+	}
+	return scanner.Err()
+}
+
+func (o *options) catLine(line []byte, out io.Writer) error {
+	ent, fields, err := o.decoder.DecodeJSON(line)
+	if err != nil {
+		if o.filtering() {
+			return nil
+		}
+		_, err := fmt.Fprintf(out, "%s\n", line)
+		return err
+	}
+	if !o.matches(ent, fields) {
+		return nil
+	}
+
+	buf, err := o.encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+	_, err = out.Write(buf.Bytes())
+	return err
+}
+
+func (o *options) filtering() bool {
+	return o.minLevel != nil || o.name != "" || len(o.fields) > 0
+}
+
+func (o *options) matches(ent zapcore.Entry, fields []zapcore.Field) bool {
+	if o.minLevel != nil && ent.Level < *o.minLevel {
+		return false
+	}
+	if o.name != "" && ent.LoggerName != o.name && !strings.HasPrefix(ent.LoggerName, o.name+".") {
+		return false
+	}
+	if len(o.fields) == 0 {
+		return true
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	for k, wants := range o.fields {
+		v, ok := enc.Fields[k]
+		if !ok || !contains(wants, fmt.Sprint(v)) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
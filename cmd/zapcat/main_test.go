@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	// Epoch timestamps are rendered in the local time zone; pin it so that
+	// expected output doesn't depend on the machine running the tests.
+	time.Local = time.UTC
+}
+
+const _testInput = `{"level":"info","ts":1700000000.5,"logger":"app","caller":"app/main.go:10","msg":"started","port":8080}
+not a log line
+{"level":"debug","ts":1700000001,"logger":"app.db","msg":"query","table":"users","rows":3}
+{"level":"error","ts":1700000002,"logger":"worker","msg":"failed","err":"boom","stacktrace":"main.main\n\tmain.go:1"}
+`
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		desc       string
+		give       []string
+		input      string
+		wantCode   int
+		wantOut    string
+		wantStderr string
+	}{
+		{
+			desc:  "pretty-print",
+			input: _testInput,
+			wantOut: "2023-11-14T22:13:20.500Z\tINFO\tapp\tapp/main.go:10\tstarted\t{\"port\": 8080}\n" +
+				"not a log line\n" +
+				"2023-11-14T22:13:21.000Z\tDEBUG\tapp.db\tquery\t{\"table\": \"users\", \"rows\": 3}\n" +
+				"2023-11-14T22:13:22.000Z\tERROR\tworker\tfailed\t{\"err\": \"boom\"}\nmain.main\n\tmain.go:1\n",
+		},
+		{
+			desc:    "level filter",
+			give:    []string{"-level", "info"},
+			input:   _testInput,
+			wantOut: "2023-11-14T22:13:20.500Z\tINFO\tapp\tapp/main.go:10\tstarted\t{\"port\": 8080}\n" + "2023-11-14T22:13:22.000Z\tERROR\tworker\tfailed\t{\"err\": \"boom\"}\nmain.main\n\tmain.go:1\n",
+		},
+		{
+			desc:    "name filter",
+			give:    []string{"-name", "app", "-level", "debug"},
+			input:   _testInput,
+			wantOut: "2023-11-14T22:13:20.500Z\tINFO\tapp\tapp/main.go:10\tstarted\t{\"port\": 8080}\n" + "2023-11-14T22:13:21.000Z\tDEBUG\tapp.db\tquery\t{\"table\": \"users\", \"rows\": 3}\n",
+		},
+		{
+			desc:    "field filter",
+			give:    []string{"-field", "rows=3"},
+			input:   _testInput,
+			wantOut: "2023-11-14T22:13:21.000Z\tDEBUG\tapp.db\tquery\t{\"table\": \"users\", \"rows\": 3}\n",
+		},
+		{
+			desc:    "json output",
+			give:    []string{"-format", "json", "-field", "port=8080"},
+			input:   _testInput,
+			wantOut: `{"level":"info","ts":"2023-11-14T22:13:20.500Z","logger":"app","caller":"app/main.go:10","msg":"started","port":8080}` + "\n",
+		},
+		{
+			desc:     "colors",
+			give:     []string{"-color", "always", "-level", "error"},
+			input:    _testInput,
+			wantOut:  "2023-11-14T22:13:22.000Z\t\x1b[31mERROR\x1b[0m\tworker\tfailed\t{\"err\": \"boom\"}\nmain.main\n\tmain.go:1\n",
+			wantCode: 0,
+		},
+		{
+			desc:    "development keys",
+			give:    []string{"-preset", "development", "-message-key", "message"},
+			input:   `{"L":"WARN","T":"2023-11-14T22:13:20.000Z","message":"careful"}` + "\n",
+			wantOut: "2023-11-14T22:13:20.000Z\tWARN\tcareful\n",
+		},
+		{
+			desc:       "bad level",
+			give:       []string{"-level", "loud"},
+			wantCode:   2,
+			wantStderr: `zapcat: unrecognized level: "loud"`,
+		},
+		{
+			desc:       "bad field filter",
+			give:       []string{"-field", "nokey"},
+			wantCode:   2,
+			wantStderr: `field filter "nokey" must have the form key=value`,
+		},
+		{
+			desc:       "bad format",
+			give:       []string{"-format", "yaml"},
+			wantCode:   2,
+			wantStderr: `zapcat: unknown format "yaml"`,
+		},
+		{
+			desc:       "bad preset",
+			give:       []string{"-preset", "custom"},
+			wantCode:   2,
+			wantStderr: `zapcat: unknown preset "custom"`,
+		},
+		{
+			desc:       "missing file",
+			give:       []string{"does-not-exist.log"},
+			wantCode:   1,
+			wantStderr: "zapcat: open does-not-exist.log",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.give, strings.NewReader(tt.input), &stdout, &stderr)
+			assert.Equal(t, tt.wantCode, code, "Unexpected exit code. Stderr:\n%s", stderr.String())
+			assert.Equal(t, tt.wantOut, stdout.String(), "Unexpected output.")
+			if tt.wantStderr != "" {
+				assert.Contains(t, stderr.String(), tt.wantStderr, "Unexpected error output.")
+			}
+		})
+	}
+}
+
+func TestRunFiles(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for i, msg := range []string{"first", "second"} {
+		path := filepath.Join(dir, msg+".log")
+		line := `{"level":"info","ts":` + string(rune('1'+i)) + `,"msg":"` + msg + `"}` + "\n"
+		require.NoError(t, os.WriteFile(path, []byte(line), 0o644), "Failed to write log file.")
+		paths = append(paths, path)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run(append([]string{"-color", "never"}, paths...), strings.NewReader(""), &stdout, &stderr)
+	assert.Zero(t, code, "Unexpected exit code. Stderr:\n%s", stderr.String())
+	assert.Equal(t,
+		"1970-01-01T00:00:01.000Z\tINFO\tfirst\n1970-01-01T00:00:02.000Z\tINFO\tsecond\n",
+		stdout.String(), "Expected files to be printed in order.")
+}
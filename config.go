@@ -22,9 +22,12 @@ package zap
 
 import (
 	"errors"
+	"fmt"
+	"os"
 	"sort"
 	"time"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -40,6 +43,9 @@ type SamplingConfig struct {
 	Initial    int                                           `json:"initial" yaml:"initial"`
 	Thereafter int                                           `json:"thereafter" yaml:"thereafter"`
 	Hook       func(zapcore.Entry, zapcore.SamplingDecision) `json:"-" yaml:"-"`
+	// ByCaller counts entries by call site rather than by message. See
+	// zapcore.SamplerByCaller.
+	ByCaller bool `json:"byCaller" yaml:"byCaller"`
 }
 
 // Config offers a declarative way to construct a logger. It doesn't do
@@ -70,11 +76,25 @@ type Config struct {
 	// default, stacktraces are captured for WarnLevel and above logs in
 	// development and ErrorLevel and above in production.
 	DisableStacktrace bool `json:"disableStacktrace" yaml:"disableStacktrace"`
+	// DisableTimestamp, DisableLevel, and DisableMessage omit the entry's
+	// time, level, and message from every log. They're equivalent to
+	// clearing TimeKey, LevelKey, and MessageKey in EncoderConfig, which is
+	// done before the encoder is constructed, so encoders registered with
+	// RegisterEncoder see the same empty keys as the built-in ones.
+	DisableTimestamp bool `json:"disableTimestamp" yaml:"disableTimestamp"`
+	DisableLevel     bool `json:"disableLevel" yaml:"disableLevel"`
+	DisableMessage   bool `json:"disableMessage" yaml:"disableMessage"`
 	// Sampling sets a sampling policy. A nil SamplingConfig disables sampling.
 	Sampling *SamplingConfig `json:"sampling" yaml:"sampling"`
 	// Encoding sets the logger's encoding. Valid values are "json" and
 	// "console", as well as any third-party encodings registered via
 	// RegisterEncoder.
+	//
+	// The special value "auto" uses the console encoding if every one of the
+	// OutputPaths is a terminal, and the JSON encoding otherwise. This lets
+	// command-line tools print readable logs interactively and structured
+	// logs when their output is piped or collected by a supervisor like
+	// systemd or Docker. The choice is made once, when the logger is built.
 	Encoding string `json:"encoding" yaml:"encoding"`
 	// EncoderConfig sets options for the chosen encoder. See
 	// zapcore.EncoderConfig for details.
@@ -129,6 +149,7 @@ func NewProductionEncoderConfig() zapcore.EncoderConfig {
 		CallerKey:      "caller",
 		FunctionKey:    zapcore.OmitKey,
 		MessageKey:     "msg",
+		EventKey:       "event",
 		StacktraceKey:  "stacktrace",
 		lineEnding:     zapcore.DefaultLineEnding,
 		EncodeLevel:    zapcore.LowercaseLevelEncoder,
@@ -206,6 +227,7 @@ func NewDevelopmentEncoderConfig() zapcore.EncoderConfig {
 		CallerKey:      "C",
 		FunctionKey:    zapcore.OmitKey,
 		MessageKey:     "M",
+		EventKey:       "E",
 		StacktraceKey:  "S",
 		lineEnding:     zapcore.DefaultLineEnding,
 		EncodeLevel:    zapcore.CapitalLevelEncoder,
@@ -235,6 +257,98 @@ func NewDevelopmentConfig() Config {
 	}
 }
 
+// NewCLIEncoderConfig returns an opinionated EncoderConfig for command-line
+// tools.
+//
+// Messages encoded with this configuration are meant for people watching a
+// terminal, so they include only the log level, the logger name (if any), and
+// the message. Timestamps and callers are omitted. If standard error is a
+// terminal, levels are colored.
+func NewCLIEncoderConfig() zapcore.EncoderConfig {
+	encodeLevel := zapcore.CapitalLevelEncoder
+	if _isTerminal(os.Stderr) {
+		encodeLevel = zapcore.CapitalColorLevelEncoder
+	}
+	return zapcore.EncoderConfig{
+		TimeKey:        zapcore.OmitKey,
+		LevelKey:       "L",
+		NameKey:        "N",
+		CallerKey:      zapcore.OmitKey,
+		FunctionKey:    zapcore.OmitKey,
+		MessageKey:     "M",
+		EventKey:       "E",
+		StacktraceKey:  "S",
+		lineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    encodeLevel,
+		EncodeDuration: zapcore.StringDurationEncoder,
+	}
+}
+
+// NewCLIConfig builds a reasonable default logging configuration for
+// command-line tools.
+// Logging is enabled at InfoLevel and above, and uses a console encoder.
+// Logs are written to standard error, leaving standard out for the tool's
+// output.
+// Callers and stacktraces are never included.
+//
+// Use VerbosityLevel to map -v and -q flags to a level:
+//
+//	cfg := zap.NewCLIConfig()
+//	cfg.Level.SetLevel(zap.VerbosityLevel(*verbose, *quiet))
+//
+// See [NewCLIEncoderConfig] for information
+// on the default encoder configuration.
+func NewCLIConfig() Config {
+	return Config{
+		Level:             NewAtomicLevelAt(InfoLevel),
+		DisableCaller:     true,
+		DisableStacktrace: true,
+		Encoding:          "console",
+		EncoderConfig:     NewCLIEncoderConfig(),
+		OutputPaths:       []string{"stderr"},
+		ErrorOutputPaths:  []string{"stderr"},
+	}
+}
+
+// Validate reports every problem with the Config that Build would run into
+// or that would make the logger misbehave: a missing Level, an unknown
+// encoding, an invalid EncoderConfig, negative sampling values, and output
+// paths that can't be opened by any registered sink. Outputs aren't opened,
+// so problems like missing permissions are only found by Build. Problems are
+// combined with multierr.
+func (cfg Config) Validate() error {
+	var err error
+	if cfg.Level == (AtomicLevel{}) {
+		err = multierr.Append(err, errors.New("missing Level"))
+	}
+
+	encoding := cfg.Encoding
+	if encoding == _autoEncoding {
+		encoding = "json" // either choice is registered
+	}
+	if _, encErr := encoderConstructor(encoding); encErr != nil {
+		err = multierr.Append(err, encErr)
+	}
+	err = multierr.Append(err, cfg.encoderConfig().Validate())
+
+	if s := cfg.Sampling; s != nil && (s.Initial < 0 || s.Thereafter < 0) {
+		err = multierr.Append(err, fmt.Errorf(
+			"invalid sampling %d:%d: values must not be negative", s.Initial, s.Thereafter))
+	}
+
+	for _, path := range cfg.OutputPaths {
+		if pathErr := _sinkRegistry.checkURL(path); pathErr != nil {
+			err = multierr.Append(err, fmt.Errorf("invalid output path %q: %v", path, pathErr))
+		}
+	}
+	for _, path := range cfg.ErrorOutputPaths {
+		if pathErr := _sinkRegistry.checkURL(path); pathErr != nil {
+			err = multierr.Append(err, fmt.Errorf("invalid error output path %q: %v", path, pathErr))
+		}
+	}
+	return err
+}
+
 // Build constructs a logger from the Config and Options.
 func (cfg Config) Build(opts ...Option) (*Logger, error) {
 	enc, err := cfg.buildEncoder()
@@ -286,6 +400,9 @@ func (cfg Config) buildOptions(errSink zapcore.WriteSyncer) []Option {
 			if scfg.Hook != nil {
 				samplerOpts = append(samplerOpts, zapcore.SamplerHook(scfg.Hook))
 			}
+			if scfg.ByCaller {
+				samplerOpts = append(samplerOpts, zapcore.SamplerByCaller())
+			}
 			return zapcore.NewSamplerWithOptions(
 				core,
 				time.Second,
@@ -294,6 +411,9 @@ func (cfg Config) buildOptions(errSink zapcore.WriteSyncer) []Option {
 				samplerOpts...,
 			)
 		}))
+		if scfg.ByCaller {
+			opts = append(opts, AddCallerPC())
+		}
 	}
 
 	if len(cfg.InitialFields) > 0 {
@@ -326,5 +446,28 @@ func (cfg Config) openSinks() (zapcore.WriteSyncer, zapcore.WriteSyncer, error)
 }
 
 func (cfg Config) buildEncoder() (zapcore.Encoder, error) {
-	return newEncoder(cfg.Encoding, cfg.EncoderConfig)
+	encoding := cfg.Encoding
+	if encoding == _autoEncoding {
+		encoding = "json"
+		if outputsAreTerminals(cfg.OutputPaths) {
+			encoding = "console"
+		}
+	}
+	return newEncoder(encoding, cfg.encoderConfig())
+}
+
+// encoderConfig returns the EncoderConfig with the keys of disabled parts
+// of the entry cleared.
+func (cfg Config) encoderConfig() zapcore.EncoderConfig {
+	ec := cfg.EncoderConfig
+	if cfg.DisableTimestamp {
+		ec.TimeKey = zapcore.OmitKey
+	}
+	if cfg.DisableLevel {
+		ec.LevelKey = zapcore.OmitKey
+	}
+	if cfg.DisableMessage {
+		ec.MessageKey = zapcore.OmitKey
+	}
+	return ec
 }
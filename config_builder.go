@@ -0,0 +1,261 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"fmt"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// A ConfigBuilder assembles a Config one setting at a time:
+//
+//	logger, err := zap.NewConfigBuilder().
+//		Level(zap.DebugLevel).
+//		Console().
+//		File("/var/log/app.log").
+//		Sampling(100, 100).
+//		Build()
+//
+// It starts from NewProductionConfig, and keeps the parts of the Config
+// consistent with each other: for example, Console switches to an encoder
+// configuration suited to the console encoding, and the first output added
+// replaces the default of standard error instead of adding to it. Invalid
+// settings are collected and reported by Config and Build, so a chain of
+// calls needs only one error check.
+//
+// The zero value isn't usable; construct builders with NewConfigBuilder.
+type ConfigBuilder struct {
+	cfg        Config
+	outputsSet bool
+	errOutSet  bool
+	err        error
+}
+
+// NewConfigBuilder returns a ConfigBuilder that starts from
+// NewProductionConfig.
+func NewConfigBuilder() *ConfigBuilder {
+	return &ConfigBuilder{cfg: NewProductionConfig()}
+}
+
+func (b *ConfigBuilder) fail(format string, args ...interface{}) *ConfigBuilder {
+	b.err = multierr.Append(b.err, fmt.Errorf(format, args...))
+	return b
+}
+
+// Level sets the minimum enabled logging level.
+func (b *ConfigBuilder) Level(lvl zapcore.Level) *ConfigBuilder {
+	if lvl < zapcore.TraceLevel || lvl > zapcore.FatalLevel {
+		return b.fail("invalid level %d", lvl)
+	}
+	b.cfg.Level = NewAtomicLevelAt(lvl)
+	return b
+}
+
+// AtomicLevel uses the given level, so that the logger's level can be
+// changed at runtime.
+func (b *ConfigBuilder) AtomicLevel(lvl AtomicLevel) *ConfigBuilder {
+	if lvl == (AtomicLevel{}) {
+		return b.fail("missing Level")
+	}
+	b.cfg.Level = lvl
+	return b
+}
+
+// Development puts the logger in development mode. See Config.Development.
+func (b *ConfigBuilder) Development() *ConfigBuilder {
+	b.cfg.Development = true
+	return b
+}
+
+// JSON uses the JSON encoding with NewProductionEncoderConfig, replacing
+// any changes made to the encoder configuration so far.
+func (b *ConfigBuilder) JSON() *ConfigBuilder {
+	b.cfg.Encoding = "json"
+	b.cfg.EncoderConfig = NewProductionEncoderConfig()
+	return b
+}
+
+// Console uses the console encoding with NewDevelopmentEncoderConfig,
+// replacing any changes made to the encoder configuration so far.
+func (b *ConfigBuilder) Console() *ConfigBuilder {
+	b.cfg.Encoding = "console"
+	b.cfg.EncoderConfig = NewDevelopmentEncoderConfig()
+	return b
+}
+
+// Encoding uses the named encoding, which may be registered with
+// RegisterEncoder, keeping the current encoder configuration.
+func (b *ConfigBuilder) Encoding(name string) *ConfigBuilder {
+	if name == "" {
+		return b.fail("missing encoding name")
+	}
+	b.cfg.Encoding = name
+	return b
+}
+
+// EncoderConfig calls f to modify the encoder configuration.
+func (b *ConfigBuilder) EncoderConfig(f func(*zapcore.EncoderConfig)) *ConfigBuilder {
+	f(&b.cfg.EncoderConfig)
+	return b
+}
+
+// Output adds URLs or file paths to write logs to; see Open for the
+// supported forms. The first output added replaces the default of standard
+// error.
+func (b *ConfigBuilder) Output(paths ...string) *ConfigBuilder {
+	for _, p := range paths {
+		if p == "" {
+			return b.fail("empty output path")
+		}
+	}
+	if !b.outputsSet {
+		b.cfg.OutputPaths = nil
+		b.outputsSet = true
+	}
+	b.cfg.OutputPaths = append(b.cfg.OutputPaths, paths...)
+	return b
+}
+
+// File adds a file to write logs to, like Output.
+func (b *ConfigBuilder) File(path string) *ConfigBuilder {
+	return b.Output(path)
+}
+
+// Stdout adds standard out to the outputs, like Output.
+func (b *ConfigBuilder) Stdout() *ConfigBuilder {
+	return b.Output("stdout")
+}
+
+// Stderr adds standard error to the outputs, like Output.
+func (b *ConfigBuilder) Stderr() *ConfigBuilder {
+	return b.Output("stderr")
+}
+
+// ErrorOutput adds URLs or file paths to write internal logger errors to.
+// The first path added replaces the default of standard error.
+func (b *ConfigBuilder) ErrorOutput(paths ...string) *ConfigBuilder {
+	for _, p := range paths {
+		if p == "" {
+			return b.fail("empty error output path")
+		}
+	}
+	if !b.errOutSet {
+		b.cfg.ErrorOutputPaths = nil
+		b.errOutSet = true
+	}
+	b.cfg.ErrorOutputPaths = append(b.cfg.ErrorOutputPaths, paths...)
+	return b
+}
+
+// Sampling logs the first initial entries with the same level and message
+// each second, and every thereafter-th entry after that. See SamplingConfig.
+func (b *ConfigBuilder) Sampling(initial, thereafter int) *ConfigBuilder {
+	if initial < 0 || thereafter < 0 {
+		return b.fail("invalid sampling %d:%d: values must not be negative", initial, thereafter)
+	}
+	b.cfg.Sampling = &SamplingConfig{Initial: initial, Thereafter: thereafter}
+	return b
+}
+
+// NoSampling disables sampling.
+func (b *ConfigBuilder) NoSampling() *ConfigBuilder {
+	b.cfg.Sampling = nil
+	return b
+}
+
+// DisableCaller stops annotating logs with the calling function's file
+// name and line number.
+func (b *ConfigBuilder) DisableCaller() *ConfigBuilder {
+	b.cfg.DisableCaller = true
+	return b
+}
+
+// DisableStacktrace disables automatic stacktrace capturing.
+func (b *ConfigBuilder) DisableStacktrace() *ConfigBuilder {
+	b.cfg.DisableStacktrace = true
+	return b
+}
+
+// DisableTimestamp omits the time from every log. See
+// Config.DisableTimestamp.
+func (b *ConfigBuilder) DisableTimestamp() *ConfigBuilder {
+	b.cfg.DisableTimestamp = true
+	return b
+}
+
+// DisableLevel omits the level from every log.
+func (b *ConfigBuilder) DisableLevel() *ConfigBuilder {
+	b.cfg.DisableLevel = true
+	return b
+}
+
+// DisableMessage omits the message from every log.
+func (b *ConfigBuilder) DisableMessage() *ConfigBuilder {
+	b.cfg.DisableMessage = true
+	return b
+}
+
+// Field adds a field to the root logger.
+func (b *ConfigBuilder) Field(key string, value interface{}) *ConfigBuilder {
+	if key == "" {
+		return b.fail("empty initial field key")
+	}
+	if b.cfg.InitialFields == nil {
+		b.cfg.InitialFields = make(map[string]interface{})
+	}
+	b.cfg.InitialFields[key] = value
+	return b
+}
+
+// Config returns the assembled Config, or every error encountered while
+// building it, including those reported by Config.Validate.
+func (b *ConfigBuilder) Config() (Config, error) {
+	cfg := b.cfg
+	cfg.OutputPaths = append([]string(nil), cfg.OutputPaths...)
+	cfg.ErrorOutputPaths = append([]string(nil), cfg.ErrorOutputPaths...)
+	if cfg.Sampling != nil {
+		s := *cfg.Sampling
+		cfg.Sampling = &s
+	}
+	if cfg.InitialFields != nil {
+		fields := make(map[string]interface{}, len(cfg.InitialFields))
+		for k, v := range cfg.InitialFields {
+			fields[k] = v
+		}
+		cfg.InitialFields = fields
+	}
+	if err := multierr.Append(b.err, cfg.Validate()); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Build constructs a logger from the assembled Config and the given
+// Options.
+func (b *ConfigBuilder) Build(opts ...Option) (*Logger, error) {
+	cfg, err := b.Config()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Build(opts...)
+}
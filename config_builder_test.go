@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestConfigBuilder(t *testing.T) {
+	logOut := filepath.Join(t.TempDir(), "test.log")
+
+	cfg, err := NewConfigBuilder().
+		Level(DebugLevel).
+		Console().
+		EncoderConfig(func(ec *zapcore.EncoderConfig) { ec.TimeKey = "" }).
+		File(logOut).
+		Sampling(10, 5).
+		DisableCaller().
+		Field("service", "api").
+		Config()
+	require.NoError(t, err, "Unexpected error building config.")
+
+	assert.Equal(t, DebugLevel, cfg.Level.Level(), "Unexpected level.")
+	assert.Equal(t, "console", cfg.Encoding, "Unexpected encoding.")
+	assert.Equal(t, "M", cfg.EncoderConfig.MessageKey, "Expected development encoder config.")
+	assert.Equal(t, []string{logOut}, cfg.OutputPaths, "Expected file to replace the default output.")
+	assert.Equal(t, []string{"stderr"}, cfg.ErrorOutputPaths, "Unexpected error outputs.")
+	assert.Equal(t, &SamplingConfig{Initial: 10, Thereafter: 5}, cfg.Sampling, "Unexpected sampling.")
+	assert.True(t, cfg.DisableCaller, "Expected caller to be disabled.")
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error building logger.")
+	logger.Debug("hello")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing logger.")
+
+	contents, err := os.ReadFile(logOut)
+	require.NoError(t, err, "Couldn't read log file.")
+	assert.Equal(t, "DEBUG\thello\t{\"service\": \"api\"}\n", string(contents), "Unexpected log output.")
+}
+
+func TestConfigBuilderDefaults(t *testing.T) {
+	cfg, err := NewConfigBuilder().Config()
+	require.NoError(t, err, "Unexpected error building config.")
+	want := NewProductionConfig()
+	assert.Equal(t, want.Level.Level(), cfg.Level.Level(), "Unexpected level.")
+	assert.Equal(t, want.Encoding, cfg.Encoding, "Unexpected encoding.")
+	assert.Equal(t, want.OutputPaths, cfg.OutputPaths, "Unexpected outputs.")
+	assert.Equal(t, want.Sampling, cfg.Sampling, "Unexpected sampling.")
+}
+
+func TestConfigBuilderOutputs(t *testing.T) {
+	cfg, err := NewConfigBuilder().
+		Stdout().
+		Stderr().
+		ErrorOutput("stdout").
+		NoSampling().
+		DisableTimestamp().
+		DisableLevel().
+		DisableMessage().
+		Config()
+	require.NoError(t, err, "Unexpected error building config.")
+	assert.Equal(t, []string{"stdout", "stderr"}, cfg.OutputPaths, "Unexpected outputs.")
+	assert.Equal(t, []string{"stdout"}, cfg.ErrorOutputPaths, "Unexpected error outputs.")
+	assert.Nil(t, cfg.Sampling, "Expected sampling to be disabled.")
+	assert.True(t, cfg.DisableTimestamp && cfg.DisableLevel && cfg.DisableMessage,
+		"Expected entry keys to be disabled.")
+}
+
+func TestConfigBuilderErrors(t *testing.T) {
+	tests := []struct {
+		desc string
+		give func(*ConfigBuilder) *ConfigBuilder
+		want []string
+	}{
+		{
+			desc: "invalid level",
+			give: func(b *ConfigBuilder) *ConfigBuilder { return b.Level(zapcore.InvalidLevel) },
+			want: []string{"invalid level 6"},
+		},
+		{
+			desc: "zero atomic level",
+			give: func(b *ConfigBuilder) *ConfigBuilder { return b.AtomicLevel(AtomicLevel{}) },
+			want: []string{"missing Level"},
+		},
+		{
+			desc: "unknown encoding",
+			give: func(b *ConfigBuilder) *ConfigBuilder { return b.Encoding("yaml") },
+			want: []string{`no encoder registered for name "yaml"`},
+		},
+		{
+			desc: "several errors",
+			give: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.File("").Sampling(-1, 1).ErrorOutput("").Field("", 1)
+			},
+			want: []string{
+				"empty output path",
+				"invalid sampling -1:1: values must not be negative",
+				"empty error output path",
+				"empty initial field key",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := tt.give(NewConfigBuilder()).Build()
+			require.Error(t, err, "Expected an error.")
+			for _, msg := range tt.want {
+				assert.Contains(t, err.Error(), msg, "Expected error to be reported.")
+			}
+		})
+	}
+}
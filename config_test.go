@@ -21,6 +21,7 @@
 package zap
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"sync/atomic"
@@ -201,3 +202,232 @@ func TestConfigWithSamplingHook(t *testing.T) {
 	assert.Equal(t, int64(expectDropped), dcount.Load())
 	assert.Equal(t, int64(expectSampled), scount.Load())
 }
+
+func TestConfigWithSamplingByCaller(t *testing.T) {
+	shook, dcount, scount := makeSamplerCountingHook()
+	cfg := NewProductionConfig()
+	cfg.OutputPaths = []string{filepath.Join(t.TempDir(), "test.log")}
+	cfg.Sampling = &SamplingConfig{
+		Initial:    10,
+		Thereafter: 0,
+		Hook:       shook,
+		ByCaller:   true,
+	}
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+
+	for i := 0; i < 100; i++ {
+		logger.Info(fmt.Sprintf("message %d", i))
+	}
+	assert.Equal(t, int64(90), dcount.Load(), "Expected entries to be counted by call site.")
+	assert.Equal(t, int64(10), scount.Load(), "Expected entries to be counted by call site.")
+}
+
+func TestConfigAutoEncoding(t *testing.T) {
+	tests := []struct {
+		desc     string
+		paths    []string
+		terminal bool
+		want     string
+	}{
+		{
+			desc:     "terminal",
+			paths:    []string{"stderr"},
+			terminal: true,
+			want:     "console",
+		},
+		{
+			desc:     "all terminals",
+			paths:    []string{"stdout", "stderr"},
+			terminal: true,
+			want:     "console",
+		},
+		{
+			desc:  "pipe",
+			paths: []string{"stderr"},
+			want:  "json",
+		},
+		{
+			desc:     "file",
+			paths:    []string{"stderr", "/var/log/app.log"},
+			terminal: true,
+			want:     "json",
+		},
+		{
+			desc:     "no outputs",
+			terminal: true,
+			want:     "json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			defer func(orig func(*os.File) bool) { _isTerminal = orig }(_isTerminal)
+			_isTerminal = func(*os.File) bool { return tt.terminal }
+
+			cfg := Config{Encoding: "auto", EncoderConfig: NewProductionEncoderConfig(), OutputPaths: tt.paths}
+			enc, err := cfg.buildEncoder()
+			require.NoError(t, err, "Unexpected error building encoder.")
+
+			want, err := newEncoder(tt.want, cfg.EncoderConfig)
+			require.NoError(t, err, "Unexpected error building expected encoder.")
+			assert.IsType(t, want, enc, "Unexpected encoder type.")
+		})
+	}
+}
+
+func TestCLIConfig(t *testing.T) {
+	defer func(orig func(*os.File) bool) { _isTerminal = orig }(_isTerminal)
+	_isTerminal = func(*os.File) bool { return false }
+
+	logOut := filepath.Join(t.TempDir(), "test.log")
+	cfg := NewCLIConfig()
+	cfg.OutputPaths = []string{logOut}
+	cfg.Level.SetLevel(VerbosityLevel(1, false))
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+
+	logger.Debug("debug")
+	logger.Named("sub").Error("error", String("k", "v"))
+
+	byteContents, err := os.ReadFile(logOut)
+	require.NoError(t, err, "Couldn't read log contents from temp file.")
+	assert.Equal(t, "DEBUG\tdebug\nERROR\tsub\terror\t{\"k\": \"v\"}\n", string(byteContents), "Unexpected log output.")
+}
+
+func TestCLIEncoderConfigColor(t *testing.T) {
+	defer func(orig func(*os.File) bool) { _isTerminal = orig }(_isTerminal)
+
+	_isTerminal = func(*os.File) bool { return true }
+	enc := zapcore.RNewConsoleEncoder(NewCLIEncoderConfig())
+	buf, err := enc.EncodeEntry(zapcore.Entry{Level: InfoLevel, Message: "hello"}, nil)
+	require.NoError(t, err, "Unexpected error encoding entry.")
+	assert.Equal(t, "\x1b[34mINFO\x1b[0m\thello\n", buf.String(), "Expected colored level on terminals.")
+}
+
+func TestNewCLI(t *testing.T) {
+	logger, err := NewCLI()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	assert.Equal(t, InfoLevel, logger.Level(), "Unexpected level.")
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		desc string
+		give func(*Config)
+		want []string
+	}{
+		{
+			desc: "production",
+			give: func(*Config) {},
+		},
+		{
+			desc: "auto encoding and file URL",
+			give: func(cfg *Config) {
+				cfg.Encoding = "auto"
+				cfg.OutputPaths = []string{"file:///tmp/app.log?buffer=4kb", "stdout"}
+			},
+		},
+		{
+			desc: "every problem at once",
+			give: func(cfg *Config) {
+				cfg.Level = AtomicLevel{}
+				cfg.Encoding = "yaml"
+				cfg.EncoderConfig.EncodeTime = nil
+				cfg.EncoderConfig.LevelKey = "msg"
+				cfg.Sampling = &SamplingConfig{Initial: -1, Thereafter: 100}
+				cfg.OutputPaths = []string{"stderr", "nope://foo", "file://host/log"}
+				cfg.ErrorOutputPaths = []string{"file:///log?buffer=big"}
+			},
+			want: []string{
+				"missing Level",
+				`no encoder registered for name "yaml"`,
+				"missing EncodeTime in EncoderConfig",
+				`MessageKey and LevelKey are both "msg"`,
+				"invalid sampling -1:100: values must not be negative",
+				`invalid output path "nope://foo": no sink found for scheme "nope"`,
+				`invalid output path "file://host/log": file URLs must leave host empty`,
+				`invalid error output path "file:///log?buffer=big"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := NewProductionConfig()
+			tt.give(&cfg)
+			err := cfg.Validate()
+			if len(tt.want) == 0 {
+				assert.NoError(t, err, "Unexpected validation error.")
+				return
+			}
+			require.Error(t, err, "Expected a validation error.")
+			for _, msg := range tt.want {
+				assert.Contains(t, err.Error(), msg, "Expected problem to be reported.")
+			}
+		})
+	}
+}
+
+func TestConfigDisableEntryKeys(t *testing.T) {
+	tests := []struct {
+		desc string
+		give func(*Config)
+		want string
+	}{
+		{
+			desc: "json timestamp",
+			give: func(cfg *Config) { cfg.DisableTimestamp = true },
+			want: `{"level":"info","msg":"hello","k":"v"}` + "\n",
+		},
+		{
+			desc: "json everything",
+			give: func(cfg *Config) {
+				cfg.DisableTimestamp = true
+				cfg.DisableLevel = true
+				cfg.DisableMessage = true
+			},
+			want: `{"k":"v"}` + "\n",
+		},
+		{
+			desc: "console",
+			give: func(cfg *Config) {
+				cfg.Encoding = "console"
+				cfg.DisableTimestamp = true
+				cfg.DisableLevel = true
+			},
+			want: "hello\t" + `{"k": "v"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			logOut := filepath.Join(t.TempDir(), "test.log")
+			cfg := NewProductionConfig()
+			cfg.OutputPaths = []string{logOut}
+			cfg.DisableCaller = true
+			tt.give(&cfg)
+
+			logger, err := cfg.Build()
+			require.NoError(t, err, "Unexpected error constructing logger.")
+			logger.Info("hello", String("k", "v"))
+			require.NoError(t, logger.Sync(), "Unexpected error syncing logger.")
+
+			contents, err := os.ReadFile(logOut)
+			require.NoError(t, err, "Couldn't read log contents from temp file.")
+			assert.Equal(t, tt.want, string(contents), "Unexpected log output.")
+		})
+	}
+}
+
+func TestConfigDisableTimestampValidate(t *testing.T) {
+	cfg := NewProductionConfig()
+	cfg.EncoderConfig.EncodeTime = nil
+	assert.Error(t, cfg.Validate(), "Expected an error without EncodeTime.")
+
+	cfg.DisableTimestamp = true
+	assert.NoError(t, cfg.Validate(), "Expected EncodeTime not to be needed without timestamps.")
+	_, err := cfg.Build()
+	assert.NoError(t, err, "Unexpected error constructing logger.")
+}
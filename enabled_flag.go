@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// levelGeneration counts changes to dynamic levels. Every AtomicLevel and
+// LevelSpec bumps it after storing a new level, so an EnabledFlag can tell
+// that its cached answer may be stale with a single atomic load.
+var levelGeneration atomic.Uint64
+
+func levelChanged() { levelGeneration.Add(1) }
+
+// An EnabledFlag is a cached view of whether a Logger logs at a particular
+// level. It's meant for ultra-hot paths where even the cost of Check is too
+// much:
+//
+//	debug := logger.DebugEnabled()
+//	for _, item := range items {
+//		if debug.Load() {
+//			logger.Debug("processing item", zap.Any("item", item))
+//		}
+//	}
+//
+// The cached answer is re-computed only after an AtomicLevel or LevelSpec
+// changes, so Load is usually a pair of atomic loads. Cores whose enablement
+// changes by other means (for example, a custom LevelEnabler backed by
+// mutable state) aren't tracked; build a new EnabledFlag after changing them.
+//
+// EnabledFlags are safe for concurrent use.
+type EnabledFlag struct {
+	core zapcore.Core
+	lvl  zapcore.Level
+
+	// state packs the level generation the answer was computed at, shifted
+	// left by one, with the answer itself in the lowest bit.
+	state atomic.Uint64
+}
+
+func newEnabledFlag(core zapcore.Core, lvl zapcore.Level) *EnabledFlag {
+	f := &EnabledFlag{core: core, lvl: lvl}
+	f.refresh(levelGeneration.Load())
+	return f
+}
+
+// Load reports whether the Logger the flag was taken from is enabled at the
+// flag's level.
+func (f *EnabledFlag) Load() bool {
+	gen := levelGeneration.Load()
+	if s := f.state.Load(); s>>1 == gen {
+		return s&1 == 1
+	}
+	return f.refresh(gen)
+}
+
+// Level returns the level the flag reports on.
+func (f *EnabledFlag) Level() zapcore.Level {
+	return f.lvl
+}
+
+func (f *EnabledFlag) refresh(gen uint64) bool {
+	enabled := f.core.Enabled(f.lvl)
+	s := gen << 1
+	if enabled {
+		s |= 1
+	}
+	f.state.Store(s)
+	return enabled
+}
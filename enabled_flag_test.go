@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"sync"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnabledFlagFollowsAtomicLevel(t *testing.T) {
+	lvl := NewAtomicLevelAt(InfoLevel)
+	core, _ := observer.New(lvl)
+	logger := New(core)
+
+	debug := logger.DebugEnabled()
+	warn := logger.EnabledAt(WarnLevel)
+	assert.Equal(t, DebugLevel, debug.Level(), "Unexpected flag level.")
+	assert.False(t, debug.Load(), "Expected debug to be disabled at InfoLevel.")
+	assert.True(t, warn.Load(), "Expected warn to be enabled at InfoLevel.")
+
+	lvl.SetLevel(DebugLevel)
+	assert.True(t, debug.Load(), "Expected debug flag to follow AtomicLevel change.")
+	assert.True(t, logger.Sugar().DebugEnabled().Load(), "Expected sugared flag to agree.")
+
+	lvl.SetLevel(ErrorLevel)
+	assert.False(t, debug.Load(), "Expected debug flag to follow AtomicLevel change.")
+	assert.False(t, warn.Load(), "Expected warn flag to follow AtomicLevel change.")
+}
+
+func TestEnabledFlagFollowsLevelSpec(t *testing.T) {
+	spec, err := ParseLevelSpec("info")
+	require.NoError(t, err, "Unexpected error parsing level spec.")
+	core, _ := observer.New(DebugLevel)
+	logger := New(spec.Core(core)).Named("db")
+
+	debug := logger.DebugEnabled()
+	assert.False(t, debug.Load(), "Expected debug to be disabled by spec.")
+
+	require.NoError(t, spec.Set("info,db=debug"), "Unexpected error updating spec.")
+	assert.True(t, debug.Load(), "Expected debug flag to follow spec change.")
+}
+
+func TestEnabledFlagStaticCore(t *testing.T) {
+	core, _ := observer.New(WarnLevel)
+	logger := New(core)
+
+	assert.False(t, logger.EnabledAt(InfoLevel).Load(), "Expected info to be disabled.")
+	assert.True(t, logger.EnabledAt(ErrorLevel).Load(), "Expected error to be enabled.")
+	assert.False(t, NewNop().DebugEnabled().Load(), "Expected nop logger to be disabled.")
+}
+
+func TestEnabledFlagConcurrentChanges(t *testing.T) {
+	lvl := NewAtomicLevelAt(InfoLevel)
+	core, _ := observer.New(lvl)
+	debug := New(core).DebugEnabled()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				debug.Load()
+			}
+		}()
+	}
+	for j := 0; j < 100; j++ {
+		lvl.SetLevel(zapcore.Level(j%2) - 1)
+	}
+	wg.Wait()
+
+	lvl.SetLevel(DebugLevel)
+	assert.True(t, debug.Load(), "Expected flag to settle on the final level.")
+}
+
+func BenchmarkEnabledFlag(b *testing.B) {
+	core, _ := observer.New(NewAtomicLevelAt(InfoLevel))
+	logger := New(zapcore.NewTee(core, core))
+
+	b.Run("Check", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if ce := logger.Check(DebugLevel, "msg"); ce != nil {
+				ce.Write()
+			}
+		}
+	})
+	b.Run("EnabledFlag", func(b *testing.B) {
+		debug := logger.DebugEnabled()
+		for i := 0; i < b.N; i++ {
+			if debug.Load() {
+				logger.Debug("msg")
+			}
+		}
+	})
+}
@@ -66,6 +66,15 @@ func newEncoder(name string, encoderConfig zapcore.EncoderConfig) (zapcore.Encod
 		return nil, errors.New("missing EncodeTime in EncoderConfig")
 	}
 
+	constructor, err := encoderConstructor(name)
+	if err != nil {
+		return nil, err
+	}
+	return constructor(encoderConfig)
+}
+
+// encoderConstructor looks up the constructor registered for name.
+func encoderConstructor(name string) (func(zapcore.EncoderConfig) (zapcore.Encoder, error), error) {
 	_encoderMutex.RLock()
 	defer _encoderMutex.RUnlock()
 	if name == "" {
@@ -75,5 +84,5 @@ func newEncoder(name string, encoderConfig zapcore.EncoderConfig) (zapcore.Encod
 	if !ok {
 		return nil, fmt.Errorf("no encoder registered for name %q", name)
 	}
-	return constructor(encoderConfig)
+	return constructor, nil
 }
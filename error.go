@@ -48,6 +48,29 @@ func NamedError(key string, err error) Field {
 	return Field{Key: key, Type: zapcore.ErrorType, Interface: err}
 }
 
+// ErrorVerbose constructs a field that lazily stores err.Error() under the
+// provided key, like NamedError, but gives control over the verbose
+// representation of errors that implement fmt.Formatter (like those produced
+// by github.com/pkg/errors).
+//
+// The output of fmt.Sprintf("%+v", err) is stored under verboseKey instead
+// of key+"Verbose", and is truncated to at most maxSize bytes. An empty
+// verboseKey omits the verbose representation entirely, and a maxSize of zero
+// or less imposes no limit. The same policy applies to the causes of errors
+// that wrap multiple errors. If passed a nil error, the field is a no-op.
+func ErrorVerbose(key string, err error, verboseKey string, maxSize int) Field {
+	if err == nil {
+		return Skip()
+	}
+	return Field{
+		Key:       key,
+		Type:      zapcore.VerboseErrorType,
+		Integer:   int64(maxSize),
+		String:    verboseKey,
+		Interface: err,
+	}
+}
+
 type errArray []error
 
 func (errs errArray) MarshalLogArray(arr zapcore.ArrayEncoder) error {
@@ -43,6 +43,12 @@ func TestErrorConstructors(t *testing.T) {
 		{"Error", Field{Key: "error", Type: zapcore.ErrorType, Interface: fail}, Error(fail)},
 		{"NamedError", Skip(), NamedError("foo", nil)},
 		{"NamedError", Field{Key: "foo", Type: zapcore.ErrorType, Interface: fail}, NamedError("foo", fail)},
+		{"ErrorVerbose", Skip(), ErrorVerbose("foo", nil, "stack", 0)},
+		{
+			"ErrorVerbose",
+			Field{Key: "foo", Type: zapcore.VerboseErrorType, String: "stack", Integer: 10, Interface: fail},
+			ErrorVerbose("foo", fail, "stack", 10),
+		},
 		{"Any:Error", Any("k", errors.New("v")), NamedError("k", errors.New("v"))},
 		{"Any:Errors", Any("k", []error{errors.New("v")}), Errors("k", []error{errors.New("v")})},
 	}
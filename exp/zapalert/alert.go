@@ -0,0 +1,304 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapalert provides a core that sends high-severity entries to a
+// webhook, like a Slack or Microsoft Teams channel or the PagerDuty Events
+// API, so that small teams get alerted without running a log pipeline.
+//
+// The core renders each entry into a request body with a template, and
+// sends it in the background, retrying failures. To avoid flooding the
+// channel, repeats of an alert are suppressed for a while, and the number
+// of alerts sent per interval is limited. Suppressed alerts are counted and
+// reported in the next alert sent.
+//
+// Tee the core with your usual one:
+//
+//	alerts, err := zapalert.New(zapalert.Config{
+//		URL:      "https://hooks.slack.com/services/...",
+//		Template: zapalert.SlackTemplate,
+//	})
+//	logger = logger.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+//		return zapcore.NewTee(c, alerts)
+//	}))
+//	defer alerts.Close()
+//
+// This package is experimental: its API may change in backwards
+// incompatible ways.
+package zapalert
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"text/template"
+	"time"
+
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+// Defaults for the zero values of Config's fields.
+const (
+	DefaultMaxAlerts   = 10
+	DefaultRateWindow  = time.Minute
+	DefaultDedupWindow = 5 * time.Minute
+
+	// _maxSeen bounds the number of alerts remembered for deduplication
+	// before expired ones are forgotten.
+	_maxSeen = 1000
+)
+
+// Config configures a Core.
+type Config struct {
+	// URL is the webhook to send alerts to. Required.
+	URL string
+
+	// Level is the lowest level alerted on. Defaults to ErrorLevel.
+	Level zapcore.LevelEnabler
+
+	// Template renders an Alert into a request body. Defaults to
+	// JSONTemplate. See the predefined templates for Slack, Teams, and
+	// PagerDuty.
+	Template string
+
+	// ContentType is the Content-Type of requests. Defaults to
+	// "application/json".
+	ContentType string
+
+	// Headers are added to every request, for example for authorization.
+	Headers map[string]string
+
+	// Vars are passed to the template, for values like a PagerDuty routing
+	// key.
+	Vars map[string]string
+
+	// MaxAlerts is the most alerts sent per RateWindow; more are dropped.
+	// Defaults to DefaultMaxAlerts and DefaultRateWindow. Set MaxAlerts to
+	// a negative value to disable rate limiting.
+	MaxAlerts  int
+	RateWindow time.Duration
+
+	// DedupWindow is how long repeats of an alert, with the same level,
+	// logger name, and message, are suppressed after it's sent. Defaults
+	// to DefaultDedupWindow. Set it to a negative value to disable
+	// deduplication.
+	DedupWindow time.Duration
+
+	// Batch configures queueing and retries. Each alert is sent in its own
+	// request, so MaxItems is ignored.
+	Batch zapbatch.Config
+
+	// Client sends requests. Defaults to zapbatch.DefaultClient.
+	Client *http.Client
+}
+
+// Core is a zapcore.Core that sends entries to a webhook. It must be closed
+// to stop its background sending.
+type Core struct {
+	zapcore.LevelEnabler
+
+	fields  []zapcore.Field
+	alerter *alerter
+}
+
+var _ zapcore.Core = (*Core)(nil)
+
+type alerter struct {
+	cfg      Config
+	tmpl     *template.Template
+	hostname string
+	batcher  *zapbatch.Batcher
+	now      func() time.Time
+
+	mu          sync.Mutex
+	seen        map[string]*seenAlert
+	windowStart time.Time
+	sent        int // in the current rate window
+	rateLimited int // since the last alert sent
+}
+
+type seenAlert struct {
+	sent       time.Time
+	suppressed int
+}
+
+// New builds a Core and starts its background sending.
+func New(cfg Config) (*Core, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid alert webhook URL %q: must be an http or https URL", cfg.URL)
+	}
+	if cfg.Level == nil {
+		cfg.Level = zapcore.ErrorLevel
+	}
+	if cfg.Template == "" {
+		cfg.Template = JSONTemplate
+	}
+	if cfg.ContentType == "" {
+		cfg.ContentType = "application/json"
+	}
+	if cfg.MaxAlerts == 0 {
+		cfg.MaxAlerts = DefaultMaxAlerts
+	}
+	if cfg.RateWindow <= 0 {
+		cfg.RateWindow = DefaultRateWindow
+	}
+	if cfg.DedupWindow == 0 {
+		cfg.DedupWindow = DefaultDedupWindow
+	}
+	tmpl, err := template.New("alert").Funcs(_funcs).Parse(cfg.Template)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alert template: %w", err)
+	}
+	hostname, _ := os.Hostname()
+
+	a := &alerter{
+		cfg:      cfg,
+		tmpl:     tmpl,
+		hostname: hostname,
+		now:      time.Now,
+		seen:     make(map[string]*seenAlert),
+	}
+	batch := cfg.Batch
+	batch.MaxItems = 1
+	a.batcher = zapbatch.New(a.send, batch)
+	return &Core{LevelEnabler: cfg.Level, alerter: a}, nil
+}
+
+// With adds structured context to the Core.
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(c.fields[:len(c.fields):len(c.fields)], fields...)
+	return &clone
+}
+
+// Check adds the Core to ce if the entry is severe enough to alert on.
+func (c *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write queues an alert for the entry, unless it's suppressed as a repeat
+// or by rate limiting.
+func (c *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.alerter.alert(ent, append(c.fields[:len(c.fields):len(c.fields)], fields...))
+}
+
+// Sync sends all queued alerts, returning any errors sending alerts since
+// the last Sync.
+func (c *Core) Sync() error {
+	return c.alerter.batcher.Flush()
+}
+
+// Close sends all queued alerts and stops the Core. It's shared by all
+// Cores derived from this one with With.
+func (c *Core) Close() error {
+	return c.alerter.batcher.Close()
+}
+
+func (a *alerter) alert(ent zapcore.Entry, fields []zapcore.Field) error {
+	key := alertKey(ent)
+	suppressed, rateLimited, ok := a.admit(key, a.now())
+	if !ok {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	data := newAlert(ent, fields, key, suppressed, rateLimited, a.hostname, a.cfg.Vars)
+	if err := a.tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("can't render alert: %w", err)
+	}
+	return a.batcher.Add(buf.Bytes())
+}
+
+// admit decides whether to send an alert with the given key, returning the
+// number of its repeats suppressed and the number of alerts rate limited
+// since the last one sent.
+func (a *alerter) admit(key string, now time.Time) (suppressed, rateLimited int, ok bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	seen := a.seen[key]
+	if a.cfg.DedupWindow > 0 && seen != nil && now.Sub(seen.sent) < a.cfg.DedupWindow {
+		seen.suppressed++
+		return 0, 0, false
+	}
+
+	if now.Sub(a.windowStart) >= a.cfg.RateWindow {
+		a.windowStart, a.sent = now, 0
+	}
+	if a.cfg.MaxAlerts > 0 && a.sent >= a.cfg.MaxAlerts {
+		a.rateLimited++
+		return 0, 0, false
+	}
+	a.sent++
+
+	if seen != nil {
+		suppressed = seen.suppressed
+	}
+	rateLimited, a.rateLimited = a.rateLimited, 0
+	if a.cfg.DedupWindow > 0 {
+		if len(a.seen) >= _maxSeen {
+			a.forget(now)
+		}
+		a.seen[key] = &seenAlert{sent: now}
+	}
+	return suppressed, rateLimited, true
+}
+
+// forget removes alerts whose repeats are no longer suppressed.
+func (a *alerter) forget(now time.Time) {
+	for k, s := range a.seen {
+		if now.Sub(s.sent) >= a.cfg.DedupWindow {
+			delete(a.seen, k)
+		}
+	}
+}
+
+// alertKey identifies repeats of an alert.
+func alertKey(ent zapcore.Entry) string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d\x00%s\x00%s", ent.Level, ent.LoggerName, ent.Message)
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+func (a *alerter) send(batch [][]byte) error {
+	var err error
+	for _, body := range batch {
+		req, reqErr := http.NewRequest(http.MethodPost, a.cfg.URL, bytes.NewReader(body))
+		if reqErr != nil {
+			return zapbatch.Permanent(reqErr)
+		}
+		req.Header.Set("Content-Type", a.cfg.ContentType)
+		for k, v := range a.cfg.Headers {
+			req.Header.Set(k, v)
+		}
+		if sendErr := zapbatch.Do(a.cfg.Client, req); sendErr != nil {
+			err = sendErr
+		}
+	}
+	return err
+}
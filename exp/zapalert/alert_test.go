@@ -0,0 +1,274 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapalert
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeWebhook records the bodies posted to it.
+type fakeWebhook struct {
+	mu      sync.Mutex
+	bodies  []string
+	headers []http.Header
+	status  int
+}
+
+func (f *fakeWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.bodies = append(f.bodies, string(body))
+	f.headers = append(f.headers, r.Header)
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+	}
+}
+
+func (f *fakeWebhook) Bodies() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.bodies...)
+}
+
+func newTestCore(t *testing.T, cfg Config) (*Core, *fakeWebhook) {
+	hook := &fakeWebhook{}
+	srv := httptest.NewServer(hook)
+	t.Cleanup(srv.Close)
+
+	cfg.URL = srv.URL
+	if cfg.Batch.FlushInterval == 0 {
+		cfg.Batch.FlushInterval = time.Hour
+	}
+	core, err := New(cfg)
+	require.NoError(t, err, "Unexpected error building core.")
+	t.Cleanup(func() { _ = core.Close() })
+	core.alerter.hostname = "host1"
+	return core, hook
+}
+
+func TestCoreSendsAlerts(t *testing.T) {
+	core, hook := newTestCore(t, Config{
+		Headers: map[string]string{"X-Token": "secret"},
+	})
+	logger := zap.New(core).Named("payments").With(zap.String("region", "us"))
+
+	logger.Info("not alerted")
+	logger.Warn("not alerted")
+	logger.Error("charge failed", zap.Int("amount", 42), zap.Error(errors.New("declined")))
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+
+	bodies := hook.Bodies()
+	require.Len(t, bodies, 1, "Expected only error entries to be alerted.")
+	assert.Equal(t, "secret", hook.headers[0].Get("X-Token"), "Expected configured headers.")
+	assert.Equal(t, "application/json", hook.headers[0].Get("Content-Type"), "Unexpected content type.")
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(bodies[0]), &got), "Expected the default template to render JSON.")
+	assert.Equal(t, "error", got["level"], "Unexpected level.")
+	assert.Equal(t, "payments", got["logger"], "Unexpected logger.")
+	assert.Equal(t, "charge failed", got["message"], "Unexpected message.")
+	assert.Equal(t, map[string]interface{}{"region": "us", "amount": float64(42), "error": "declined"}, got["fields"], "Expected context and entry fields.")
+	assert.Equal(t, "host1", got["host"], "Unexpected host.")
+	assert.Equal(t, float64(0), got["suppressed"], "Unexpected suppressed count.")
+}
+
+func TestCoreDeduplicatesAndRateLimits(t *testing.T) {
+	core, hook := newTestCore(t, Config{
+		Template:    `{{.Summary}}`,
+		ContentType: "text/plain",
+		MaxAlerts:   2,
+		RateWindow:  time.Minute,
+		DedupWindow: 10 * time.Minute,
+	})
+	now := time.Unix(0, 0)
+	core.alerter.now = func() time.Time { return now }
+	logger := zap.New(core)
+
+	logger.Error("disk full")
+	logger.Error("disk full") // repeat
+	logger.Error("disk full") // repeat
+	logger.Error("db down")
+	logger.Error("cache down") // rate limited
+	now = now.Add(time.Minute)
+	logger.Error("queue stuck")
+	logger.Error("disk full") // still a repeat
+	now = now.Add(10 * time.Minute)
+	logger.Error("disk full")
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+
+	assert.Equal(t, []string{
+		"ERROR disk full",
+		"ERROR db down",
+		"ERROR queue stuck (1 alerts rate limited)",
+		"ERROR disk full (3 repeats suppressed)",
+	}, hook.Bodies(), "Unexpected alerts.")
+	assert.Equal(t, "text/plain", hook.headers[0].Get("Content-Type"), "Unexpected content type.")
+}
+
+func TestCoreDisabledLimits(t *testing.T) {
+	core, hook := newTestCore(t, Config{
+		Template:    `{{.Message}}`,
+		Level:       zapcore.WarnLevel,
+		MaxAlerts:   -1,
+		DedupWindow: -1,
+	})
+	logger := zap.New(core)
+	for i := 0; i < 20; i++ {
+		logger.Warn("again")
+	}
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	assert.Len(t, hook.Bodies(), 20, "Expected every alert to be sent.")
+	assert.Empty(t, core.alerter.seen, "Expected nothing to be remembered without deduplication.")
+}
+
+func TestForget(t *testing.T) {
+	core, _ := newTestCore(t, Config{Template: `x`, MaxAlerts: -1, DedupWindow: time.Minute})
+	now := time.Unix(0, 0)
+	for i := 0; i < _maxSeen; i++ {
+		_, _, ok := core.alerter.admit(string(rune(i)), now)
+		require.True(t, ok, "Expected new alerts to be admitted.")
+	}
+	_, _, ok := core.alerter.admit("late", now.Add(time.Minute))
+	require.True(t, ok, "Expected new alerts to be admitted.")
+	assert.Len(t, core.alerter.seen, 1, "Expected expired alerts to be forgotten.")
+}
+
+func TestTemplates(t *testing.T) {
+	ent := zapcore.Entry{
+		Level:      zapcore.FatalLevel,
+		Time:       time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		LoggerName: "api",
+		Message:    `quote " and newline` + "\n",
+		Caller:     zapcore.NewEntryCaller(0, "/src/app/main.go", 12, true),
+		Stack:      "main.main()\n\t/src/app/main.go:12",
+	}
+	fields := []zapcore.Field{zap.Int("attempt", 3), zap.Strings("tags", []string{"a"})}
+	alert := newAlert(ent, fields, alertKey(ent), 2, 0, "host1", map[string]string{"routing_key": "R123"})
+
+	assert.Equal(t, "FATAL api: quote \" and newline\n (2 repeats suppressed)", alert.Summary, "Unexpected summary.")
+	assert.Equal(t, "host: host1\ncaller: app/main.go:12\nattempt: 3\ntags: [\"a\"]\nmain.main()\n\t/src/app/main.go:12", alert.Details, "Unexpected details.")
+
+	tests := []struct {
+		desc string
+		give string
+		want map[string]interface{}
+	}{
+		{
+			desc: "JSON",
+			give: JSONTemplate,
+			want: map[string]interface{}{
+				"level": "fatal", "time": "2024-05-01T12:00:00Z", "logger": "api",
+				"message": ent.Message, "caller": "app/main.go:12",
+				"fields":     map[string]interface{}{"attempt": float64(3), "tags": []interface{}{"a"}},
+				"stacktrace": ent.Stack, "host": "host1", "suppressed": float64(2), "rate_limited": float64(0),
+			},
+		},
+		{
+			desc: "Slack",
+			give: SlackTemplate,
+			want: map[string]interface{}{"text": "*" + alert.Summary + "*\n```\n" + alert.Details + "\n```"},
+		},
+		{
+			desc: "Teams",
+			give: TeamsTemplate,
+			want: map[string]interface{}{"text": "**" + alert.Summary + "**\n\n```\n" + alert.Details + "\n```"},
+		},
+		{
+			desc: "PagerDuty",
+			give: PagerDutyTemplate,
+			want: map[string]interface{}{
+				"routing_key":  "R123",
+				"event_action": "trigger",
+				"dedup_key":    alert.Key,
+				"payload": map[string]interface{}{
+					"summary":        alert.Summary,
+					"source":         "host1",
+					"severity":       "critical",
+					"timestamp":      "2024-05-01T12:00:00Z",
+					"component":      "api",
+					"custom_details": map[string]interface{}{"attempt": float64(3), "tags": []interface{}{"a"}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			core, hook := newTestCore(t, Config{Template: tt.give})
+			tmpl := core.alerter.tmpl
+
+			var sb strings.Builder
+			require.NoError(t, tmpl.Execute(&sb, alert), "Unexpected error rendering.")
+			var got map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(sb.String()), &got), "Expected valid JSON, got %s.", sb.String())
+			assert.Equal(t, tt.want, got, "Unexpected rendering.")
+			assert.Empty(t, hook.Bodies(), "Expected nothing to be sent.")
+		})
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		give zapcore.Level
+		want string
+	}{
+		{zapcore.DebugLevel, "info"},
+		{zapcore.InfoLevel, "info"},
+		{zapcore.WarnLevel, "warning"},
+		{zapcore.ErrorLevel, "error"},
+		{zapcore.DPanicLevel, "critical"},
+		{zapcore.PanicLevel, "critical"},
+		{zapcore.FatalLevel, "critical"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, severity(tt.give), "Unexpected severity for %v.", tt.give)
+	}
+}
+
+func TestCoreErrors(t *testing.T) {
+	core, hook := newTestCore(t, Config{Batch: zapbatch.Config{FlushInterval: time.Hour}})
+	hook.status = http.StatusBadRequest
+	zap.New(core).Error("rejected")
+	assert.ErrorContains(t, core.Sync(), "400 Bad Request", "Expected send errors to be returned by Sync.")
+
+	core, _ = newTestCore(t, Config{Template: `{{.Nope}}`})
+	assert.ErrorContains(t, core.Write(zapcore.Entry{Level: zapcore.ErrorLevel}, nil), "can't render alert", "Expected template errors from Write.")
+
+	_, err := New(Config{URL: "http://hook", Template: `{{`})
+	assert.ErrorContains(t, err, "invalid alert template", "Expected invalid templates to be rejected.")
+	_, err = New(Config{URL: "mailto:ops@example.com"})
+	assert.ErrorContains(t, err, "must be an http or https URL", "Expected non-HTTP URLs to be rejected.")
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapalert
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Predefined templates. They expect a JSON Content-Type.
+const (
+	// JSONTemplate renders the alert as a JSON object.
+	JSONTemplate = `{"level":{{json .Level.String}},"time":{{json .Time}},"logger":{{json .LoggerName}},` +
+		`"message":{{json .Message}},"caller":{{json .Caller}},"fields":{{json .Fields}},` +
+		`"stacktrace":{{json .Stack}},"host":{{json .Hostname}},` +
+		`"suppressed":{{.Suppressed}},"rate_limited":{{.RateLimited}}}`
+
+	// SlackTemplate posts the alert to a Slack incoming webhook.
+	SlackTemplate = `{"text":{{json (printf "*%s*\n%s" .Summary (codeBlock .Details))}}}`
+
+	// TeamsTemplate posts the alert to a Microsoft Teams incoming webhook.
+	TeamsTemplate = `{"text":{{json (printf "**%s**\n\n%s" .Summary (codeBlock .Details))}}}`
+
+	// PagerDutyTemplate triggers a PagerDuty incident with the Events API
+	// v2, at https://events.pagerduty.com/v2/enqueue. Set the
+	// "routing_key" var to the integration key. Repeats of the alert are
+	// grouped into the same incident.
+	PagerDutyTemplate = `{"routing_key":{{json .Vars.routing_key}},"event_action":"trigger",` +
+		`"dedup_key":{{json .Key}},"payload":{"summary":{{json .Summary}},` +
+		`"source":{{json .Hostname}},"severity":{{json .Severity}},"timestamp":{{json .Time}},` +
+		`"component":{{json .LoggerName}},"custom_details":{{json .Fields}}}}`
+)
+
+// Alert is the data passed to templates.
+type Alert struct {
+	zapcore.Entry
+
+	// Fields holds the entry's fields, including those added with With.
+	Fields map[string]interface{}
+
+	// Key identifies repeats of the alert: entries with the same level,
+	// logger name, and message.
+	Key string
+
+	// Summary is a one-line description, like
+	// "ERROR payments: charge failed (3 repeats suppressed)".
+	Summary string
+
+	// Details lists the caller, fields, and stack trace, one per line.
+	Details string
+
+	// Severity is the PagerDuty severity of the entry's level: "critical",
+	// "error", "warning", or "info".
+	Severity string
+
+	// Suppressed is the number of repeats of this alert that weren't sent,
+	// and RateLimited the number of alerts that weren't sent because of
+	// rate limiting, since the last alert.
+	Suppressed  int
+	RateLimited int
+
+	// Hostname is the name of the host.
+	Hostname string
+
+	// Vars holds Config.Vars.
+	Vars map[string]string
+}
+
+var _funcs = template.FuncMap{
+	"json":      toJSON,
+	"codeBlock": func(s string) string { return "```\n" + s + "\n```" },
+}
+
+// toJSON renders v as JSON, for embedding in JSON templates. Times are
+// rendered in RFC 3339 format, and callers as strings.
+func toJSON(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case time.Time:
+		return `"` + v.Format(time.RFC3339Nano) + `"`, nil
+	case zapcore.EntryCaller:
+		if !v.Defined {
+			return `""`, nil
+		}
+		return toJSON(v.TrimmedPath())
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+func newAlert(ent zapcore.Entry, fields []zapcore.Field, key string, suppressed, rateLimited int, hostname string, vars map[string]string) *Alert {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	a := &Alert{
+		Entry:       ent,
+		Fields:      enc.Fields,
+		Key:         key,
+		Severity:    severity(ent.Level),
+		Suppressed:  suppressed,
+		RateLimited: rateLimited,
+		Hostname:    hostname,
+		Vars:        vars,
+	}
+	a.Summary, a.Details = a.describe()
+	return a
+}
+
+func (a *Alert) describe() (summary, details string) {
+	var sb strings.Builder
+	sb.WriteString(a.Level.CapitalString())
+	sb.WriteByte(' ')
+	if a.LoggerName != "" {
+		sb.WriteString(a.LoggerName)
+		sb.WriteString(": ")
+	}
+	sb.WriteString(a.Message)
+	var notes []string
+	if a.Suppressed > 0 {
+		notes = append(notes, fmt.Sprintf("%d repeats suppressed", a.Suppressed))
+	}
+	if a.RateLimited > 0 {
+		notes = append(notes, fmt.Sprintf("%d alerts rate limited", a.RateLimited))
+	}
+	if len(notes) > 0 {
+		sb.WriteString(" (" + strings.Join(notes, ", ") + ")")
+	}
+	summary = sb.String()
+
+	sb.Reset()
+	if a.Hostname != "" {
+		fmt.Fprintf(&sb, "host: %s\n", a.Hostname)
+	}
+	if a.Caller.Defined {
+		fmt.Fprintf(&sb, "caller: %s\n", a.Caller.TrimmedPath())
+	}
+	keys := make([]string, 0, len(a.Fields))
+	for k := range a.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := a.Fields[k]
+		if _, ok := v.(string); !ok {
+			if b, err := json.Marshal(v); err == nil {
+				v = string(b)
+			}
+		}
+		fmt.Fprintf(&sb, "%s: %v\n", k, v)
+	}
+	if a.Stack != "" {
+		sb.WriteString(a.Stack)
+	}
+	return summary, strings.TrimSuffix(sb.String(), "\n")
+}
+
+func severity(lvl zapcore.Level) string {
+	switch {
+	case lvl >= zapcore.DPanicLevel:
+		return "critical"
+	case lvl == zapcore.ErrorLevel:
+		return "error"
+	case lvl == zapcore.WarnLevel:
+		return "warning"
+	default:
+		return "info"
+	}
+}
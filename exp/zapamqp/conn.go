@@ -0,0 +1,608 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapamqp
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AMQP 0-9-1 frame types.
+const (
+	_frameMethod    = 1
+	_frameHeader    = 2
+	_frameBody      = 3
+	_frameHeartbeat = 8
+	_frameEnd       = 0xCE
+)
+
+// AMQP 0-9-1 methods, as class and method IDs.
+type method struct{ class, id uint16 }
+
+var (
+	_connectionStart   = method{10, 10}
+	_connectionStartOk = method{10, 11}
+	_connectionTune    = method{10, 30}
+	_connectionTuneOk  = method{10, 31}
+	_connectionOpen    = method{10, 40}
+	_connectionOpenOk  = method{10, 41}
+	_connectionClose   = method{10, 50}
+	_connectionCloseOk = method{10, 51}
+	_channelOpen       = method{20, 10}
+	_channelOpenOk     = method{20, 11}
+	_channelClose      = method{20, 40}
+	_channelCloseOk    = method{20, 41}
+	_basicPublish      = method{60, 40}
+	_basicAck          = method{60, 80}
+	_basicNack         = method{60, 120}
+	_confirmSelect     = method{85, 10}
+	_confirmSelectOk   = method{85, 11}
+)
+
+const (
+	_protocolHeader = "AMQP\x00\x00\x09\x01"
+
+	// _frameMax is the largest frame we accept, and the largest we ask the
+	// broker to accept.
+	_frameMax = 128 << 10
+
+	// _channel is the channel we publish on.
+	_channel = 1
+
+	_basicClass = 60
+)
+
+var (
+	errConnClosed = errors.New("AMQP connection closed")
+	errNacked     = errors.New("message rejected by broker")
+)
+
+// conn is a connection to an AMQP 0-9-1 broker with a single channel in
+// confirm mode. Publishing is safe for concurrent use; a background
+// goroutine reads confirmations and another sends heartbeats.
+type conn struct {
+	nc        net.Conn
+	br        *bufio.Reader // only used by the handshake, then readLoop
+	frameMax  int
+	heartbeat time.Duration
+
+	writeMu sync.Mutex
+	bw      *bufio.Writer
+
+	mu      sync.Mutex
+	lastTag uint64
+	pending map[uint64]chan error
+	err     error
+
+	done chan struct{}
+}
+
+// dial connects to the broker, opens a channel, and puts it in confirm
+// mode.
+func dial(cfg *Config) (*conn, error) {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	var (
+		nc  net.Conn
+		err error
+	)
+	if cfg.TLSConfig != nil {
+		nc, err = tls.DialWithDialer(dialer, "tcp", cfg.Addr, cfg.TLSConfig)
+	} else {
+		nc, err = dialer.Dial("tcp", cfg.Addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &conn{
+		nc:       nc,
+		br:       bufio.NewReader(nc),
+		bw:       bufio.NewWriter(nc),
+		frameMax: _frameMax,
+		pending:  make(map[uint64]chan error),
+		done:     make(chan struct{}),
+	}
+	if err := c.handshake(cfg); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	go c.readLoop()
+	if c.heartbeat > 0 {
+		go c.heartbeatLoop()
+	}
+	return c, nil
+}
+
+func (c *conn) handshake(cfg *Config) error {
+	if err := c.nc.SetDeadline(time.Now().Add(cfg.Timeout)); err != nil {
+		return err
+	}
+	if _, err := c.bw.WriteString(_protocolHeader); err != nil {
+		return err
+	}
+	if err := c.bw.Flush(); err != nil {
+		return err
+	}
+
+	args, err := c.expect(_connectionStart)
+	if err != nil {
+		return err
+	}
+	d := decoder{b: args}
+	d.octet() // version
+	d.octet()
+	d.longstr() // server properties, as a table
+	if mechanisms := d.longstr(); d.err == nil && !containsWord(mechanisms, "PLAIN") {
+		return fmt.Errorf("AMQP broker doesn't support PLAIN authentication, only %q", mechanisms)
+	}
+	if d.err != nil {
+		return d.err
+	}
+
+	var e encoder
+	e.table(map[string]string{"product": "zap"})
+	e.shortstr("PLAIN")
+	e.longstr("\x00" + cfg.Username + "\x00" + cfg.Password)
+	e.shortstr("en_US")
+	if err := c.writeMethod(0, _connectionStartOk, e.b); err != nil {
+		return err
+	}
+
+	if args, err = c.expect(_connectionTune); err != nil {
+		return err
+	}
+	d = decoder{b: args}
+	channelMax, frameMax, heartbeat := d.short(), int(d.long()), time.Duration(d.short())*time.Second
+	if d.err != nil {
+		return d.err
+	}
+	if frameMax > 0 && frameMax < c.frameMax {
+		c.frameMax = frameMax
+	}
+	// Use the shorter heartbeat interval, in whole seconds, unless we've
+	// disabled heartbeats.
+	switch {
+	case cfg.Heartbeat < 0:
+		heartbeat = 0
+	case heartbeat == 0 || cfg.Heartbeat < heartbeat:
+		heartbeat = cfg.Heartbeat.Truncate(time.Second)
+		if heartbeat == 0 {
+			heartbeat = time.Second
+		}
+	}
+	c.heartbeat = heartbeat
+
+	e = encoder{}
+	e.short(channelMax)
+	e.long(uint32(c.frameMax))
+	e.short(uint16(heartbeat / time.Second))
+	if err := c.writeMethod(0, _connectionTuneOk, e.b); err != nil {
+		return err
+	}
+
+	e = encoder{}
+	e.shortstr(cfg.VHost)
+	e.shortstr("") // reserved
+	e.octet(0)     // reserved
+	if err := c.writeMethod(0, _connectionOpen, e.b); err != nil {
+		return err
+	}
+	if _, err := c.expect(_connectionOpenOk); err != nil {
+		return err
+	}
+
+	e = encoder{}
+	e.shortstr("") // reserved
+	if err := c.writeMethod(_channel, _channelOpen, e.b); err != nil {
+		return err
+	}
+	if _, err := c.expect(_channelOpenOk); err != nil {
+		return err
+	}
+
+	if err := c.writeMethod(_channel, _confirmSelect, []byte{0} /* nowait */); err != nil {
+		return err
+	}
+	if _, err := c.expect(_confirmSelectOk); err != nil {
+		return err
+	}
+	return c.nc.SetDeadline(time.Time{})
+}
+
+// expect flushes any buffered frames and reads frames until it gets a method
+// frame, which must be m. Only the handshake uses it.
+func (c *conn) expect(m method) ([]byte, error) {
+	if err := c.bw.Flush(); err != nil {
+		return nil, err
+	}
+	for {
+		typ, _, payload, err := readFrame(c.br)
+		if err != nil {
+			return nil, err
+		}
+		if typ != _frameMethod {
+			continue
+		}
+		got, args, err := parseMethod(payload)
+		if err != nil {
+			return nil, err
+		}
+		switch got {
+		case m:
+			return args, nil
+		case _connectionClose, _channelClose:
+			return nil, closeError(got, args)
+		default:
+			return nil, fmt.Errorf("unexpected AMQP method %d.%d, expected %d.%d", got.class, got.id, m.class, m.id)
+		}
+	}
+}
+
+// closeError describes why the broker closed the connection or channel.
+func closeError(m method, args []byte) error {
+	d := decoder{b: args}
+	code, text := d.short(), d.shortstr()
+	what := "connection"
+	if m == _channelClose {
+		what = "channel"
+	}
+	return fmt.Errorf("AMQP %s closed by broker: %d %s", what, code, text)
+}
+
+// publish writes a message in confirm mode, returning a channel that
+// receives the broker's confirmation. The message is buffered until flush is
+// called.
+func (c *conn) publish(exchange, key string, body []byte, persistent bool) (<-chan error, error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := c.Err(); err != nil {
+		return nil, err
+	}
+
+	var e encoder
+	e.short(0) // reserved
+	e.shortstr(exchange)
+	e.shortstr(key)
+	e.octet(0) // not mandatory or immediate
+	if err := c.writeMethod(_channel, _basicPublish, e.b); err != nil {
+		return nil, c.fail(err)
+	}
+
+	e = encoder{}
+	e.short(_basicClass)
+	e.short(0) // weight
+	e.longlong(uint64(len(body)))
+	flags := uint16(1 << 15) // content-type
+	if persistent {
+		flags |= 1 << 12 // delivery-mode
+	}
+	e.short(flags)
+	e.shortstr("application/json")
+	if persistent {
+		e.octet(2)
+	}
+	if err := c.writeFrame(_frameHeader, _channel, e.b); err != nil {
+		return nil, c.fail(err)
+	}
+	for max := c.frameMax - 8; len(body) > 0; {
+		n := len(body)
+		if n > max {
+			n = max
+		}
+		if err := c.writeFrame(_frameBody, _channel, body[:n]); err != nil {
+			return nil, c.fail(err)
+		}
+		body = body[n:]
+	}
+
+	ack := make(chan error, 1)
+	c.mu.Lock()
+	c.lastTag++
+	c.pending[c.lastTag] = ack
+	c.mu.Unlock()
+	return ack, nil
+}
+
+// flush sends buffered messages.
+func (c *conn) flush(timeout time.Duration) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := c.nc.SetWriteDeadline(time.Now().Add(timeout)); err != nil {
+		return c.fail(err)
+	}
+	if err := c.bw.Flush(); err != nil {
+		return c.fail(err)
+	}
+	return nil
+}
+
+// writeMethod buffers a method frame. Callers must hold writeMu, except
+// during the handshake.
+func (c *conn) writeMethod(channel uint16, m method, args []byte) error {
+	payload := make([]byte, 4, 4+len(args))
+	binary.BigEndian.PutUint16(payload, m.class)
+	binary.BigEndian.PutUint16(payload[2:], m.id)
+	return c.writeFrame(_frameMethod, channel, append(payload, args...))
+}
+
+// writeFrame buffers a frame. Callers must hold writeMu, except during the
+// handshake.
+func (c *conn) writeFrame(typ byte, channel uint16, payload []byte) error {
+	var header [7]byte
+	header[0] = typ
+	binary.BigEndian.PutUint16(header[1:], channel)
+	binary.BigEndian.PutUint32(header[3:], uint32(len(payload)))
+	c.bw.Write(header[:])
+	c.bw.Write(payload)
+	return c.bw.WriteByte(_frameEnd)
+}
+
+func readFrame(r *bufio.Reader) (typ byte, channel uint16, payload []byte, err error) {
+	var header [7]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, 0, nil, err
+	}
+	typ, channel = header[0], binary.BigEndian.Uint16(header[1:])
+	size := binary.BigEndian.Uint32(header[3:])
+	if size > _frameMax {
+		return 0, 0, nil, fmt.Errorf("AMQP frame of %d bytes is too large", size)
+	}
+	payload = make([]byte, size+1)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, 0, nil, err
+	}
+	if payload[size] != _frameEnd {
+		return 0, 0, nil, errors.New("malformed AMQP frame")
+	}
+	return typ, channel, payload[:size], nil
+}
+
+func parseMethod(payload []byte) (method, []byte, error) {
+	if len(payload) < 4 {
+		return method{}, nil, errors.New("malformed AMQP method frame")
+	}
+	m := method{binary.BigEndian.Uint16(payload), binary.BigEndian.Uint16(payload[2:])}
+	return m, payload[4:], nil
+}
+
+// readLoop handles frames from the broker until the connection fails.
+func (c *conn) readLoop() {
+	for {
+		if c.heartbeat > 0 {
+			// The broker's gone quiet if we miss two heartbeats.
+			if err := c.nc.SetReadDeadline(time.Now().Add(2 * c.heartbeat)); err != nil {
+				c.fail(err)
+				return
+			}
+		}
+		typ, _, payload, err := readFrame(c.br)
+		if err != nil {
+			c.fail(err)
+			return
+		}
+		if typ != _frameMethod {
+			continue // heartbeats
+		}
+		m, args, err := parseMethod(payload)
+		if err != nil {
+			c.fail(err)
+			return
+		}
+
+		switch m {
+		case _basicAck, _basicNack:
+			d := decoder{b: args}
+			tag, bits := d.longlong(), d.octet()
+			if d.err != nil {
+				c.fail(d.err)
+				return
+			}
+			var err error
+			if m == _basicNack {
+				err = errNacked
+			}
+			c.confirm(tag, bits&1 != 0, err)
+		case _channelClose, _connectionClose:
+			reply := _connectionCloseOk
+			channel := uint16(0)
+			if m == _channelClose {
+				reply, channel = _channelCloseOk, _channel
+			}
+			c.writeMu.Lock()
+			_ = c.writeMethod(channel, reply, nil)
+			_ = c.bw.Flush()
+			c.writeMu.Unlock()
+			c.fail(closeError(m, args))
+			return
+		case _connectionCloseOk:
+			c.fail(errConnClosed)
+			return
+		}
+	}
+}
+
+// confirm resolves the pending message with the given delivery tag, and
+// all earlier ones if multiple is set.
+func (c *conn) confirm(tag uint64, multiple bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for t, ack := range c.pending {
+		if t == tag || (multiple && t < tag) {
+			ack <- err
+			delete(c.pending, t)
+		}
+	}
+}
+
+func (c *conn) heartbeatLoop() {
+	ticker := time.NewTicker(c.heartbeat / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.writeMu.Lock()
+			err := c.writeFrame(_frameHeartbeat, 0, nil)
+			if err == nil {
+				err = c.bw.Flush()
+			}
+			c.writeMu.Unlock()
+			if err != nil {
+				c.fail(err)
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// fail closes the connection with the given error, unless it's already
+// failed, and returns the connection's error.
+func (c *conn) fail(err error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil {
+		c.err = err
+		close(c.done)
+		c.nc.Close()
+		for t, ack := range c.pending {
+			ack <- err
+			delete(c.pending, t)
+		}
+	}
+	return c.err
+}
+
+// Err returns the error that closed the connection, if any.
+func (c *conn) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// close closes the connection gracefully, waiting briefly for the broker to
+// acknowledge.
+func (c *conn) close(timeout time.Duration) error {
+	if c.Err() != nil {
+		return nil // already closed
+	}
+	c.writeMu.Lock()
+	var e encoder
+	e.short(200)
+	e.shortstr("")
+	e.short(0) // class and method that caused the close
+	e.short(0)
+	err := c.writeMethod(0, _connectionClose, e.b)
+	if err == nil {
+		err = c.bw.Flush()
+	}
+	c.writeMu.Unlock()
+	if err == nil {
+		select {
+		case <-c.done:
+		case <-time.After(timeout):
+		}
+	}
+	c.fail(errConnClosed)
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+func containsWord(s, word string) bool {
+	for _, w := range strings.Fields(s) {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+// encoder builds AMQP method arguments.
+type encoder struct{ b []byte }
+
+func (e *encoder) octet(v byte)      { e.b = append(e.b, v) }
+func (e *encoder) short(v uint16)    { e.b = binary.BigEndian.AppendUint16(e.b, v) }
+func (e *encoder) long(v uint32)     { e.b = binary.BigEndian.AppendUint32(e.b, v) }
+func (e *encoder) longlong(v uint64) { e.b = binary.BigEndian.AppendUint64(e.b, v) }
+
+func (e *encoder) shortstr(s string) {
+	if len(s) > 255 {
+		s = s[:255]
+	}
+	e.octet(byte(len(s)))
+	e.b = append(e.b, s...)
+}
+
+func (e *encoder) longstr(s string) {
+	e.long(uint32(len(s)))
+	e.b = append(e.b, s...)
+}
+
+// table encodes a field table of strings.
+func (e *encoder) table(fields map[string]string) {
+	var t encoder
+	for k, v := range fields {
+		t.shortstr(k)
+		t.octet('S')
+		t.longstr(v)
+	}
+	e.longstr(string(t.b))
+}
+
+// decoder reads AMQP method arguments, recording the first error.
+type decoder struct {
+	b   []byte
+	err error
+}
+
+// next consumes n bytes. If there aren't enough, it returns zeros, or
+// nothing for strings.
+func (d *decoder) next(n int) []byte {
+	if d.err != nil || len(d.b) < n {
+		if d.err == nil {
+			d.err = errors.New("malformed AMQP method arguments")
+		}
+		if n > 8 {
+			return nil
+		}
+		return make([]byte, n)
+	}
+	b := d.b[:n]
+	d.b = d.b[n:]
+	return b
+}
+
+func (d *decoder) octet() byte      { return d.next(1)[0] }
+func (d *decoder) short() uint16    { return binary.BigEndian.Uint16(d.next(2)) }
+func (d *decoder) long() uint32     { return binary.BigEndian.Uint32(d.next(4)) }
+func (d *decoder) longlong() uint64 { return binary.BigEndian.Uint64(d.next(8)) }
+func (d *decoder) shortstr() string { return string(d.next(int(d.octet()))) }
+func (d *decoder) longstr() string  { return string(d.next(int(d.long()))) }
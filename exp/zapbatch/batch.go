@@ -0,0 +1,326 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapbatch provides the buffering, batching, and retry machinery
+// shared by zap's experimental network sinks. It's also useful for building
+// sinks of your own.
+//
+// A Batcher accepts items (typically encoded log entries) from any number
+// of goroutines and hands them to a send function in batches from a single
+// background goroutine, so that logging never waits on the network. Failed
+// batches are retried with exponential backoff, unless the send function
+// marks the error as Permanent.
+//
+// This package is experimental: its API may change in backwards
+// incompatible ways.
+package zapbatch
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// Defaults for the zero values of Config's fields.
+const (
+	DefaultMaxItems      = 500
+	DefaultMaxBytes      = 1 << 20 // 1 MiB
+	DefaultFlushInterval = time.Second
+	DefaultQueueSize     = 10000
+	DefaultMaxRetries    = 3
+	DefaultMinBackoff    = 100 * time.Millisecond
+	DefaultMaxBackoff    = 10 * time.Second
+)
+
+var (
+	// ErrQueueFull is returned by Add when the Batcher is holding as many
+	// items as it's allowed to; the item is dropped.
+	ErrQueueFull = errors.New("zapbatch: queue full, dropped item")
+
+	// ErrClosed is returned when using a Batcher after closing it.
+	ErrClosed = errors.New("zapbatch: batcher closed")
+)
+
+// Config configures a Batcher. The zero value uses the defaults above.
+type Config struct {
+	// MaxItems is the largest number of items sent in a single batch.
+	MaxItems int `json:"maxItems" yaml:"maxItems"`
+	// MaxBytes is the largest total size of the items in a single batch. A
+	// single item larger than MaxBytes is sent in a batch of its own.
+	MaxBytes int `json:"maxBytes" yaml:"maxBytes"`
+	// FlushInterval is the longest an item waits before being sent.
+	FlushInterval time.Duration `json:"flushInterval" yaml:"flushInterval"`
+	// QueueSize is the largest number of items held while waiting to be
+	// sent. Once it's reached, new items are dropped.
+	QueueSize int `json:"queueSize" yaml:"queueSize"`
+	// MaxRetries is the number of times a failed batch is retried before
+	// it's dropped. Set it to a negative value to disable retries.
+	MaxRetries int `json:"maxRetries" yaml:"maxRetries"`
+	// MinBackoff and MaxBackoff bound the wait between retries, which
+	// doubles after each attempt.
+	MinBackoff time.Duration `json:"minBackoff" yaml:"minBackoff"`
+	MaxBackoff time.Duration `json:"maxBackoff" yaml:"maxBackoff"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxItems <= 0 {
+		c.MaxItems = DefaultMaxItems
+	}
+	if c.MaxBytes <= 0 {
+		c.MaxBytes = DefaultMaxBytes
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = DefaultFlushInterval
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = DefaultQueueSize
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = DefaultMaxRetries
+	}
+	if c.MinBackoff <= 0 {
+		c.MinBackoff = DefaultMinBackoff
+	}
+	if c.MaxBackoff < c.MinBackoff {
+		c.MaxBackoff = DefaultMaxBackoff
+		if c.MaxBackoff < c.MinBackoff {
+			c.MaxBackoff = c.MinBackoff
+		}
+	}
+	return c
+}
+
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error returned by a send function as permanent, so
+// that the batch isn't retried. Use it for errors like malformed requests
+// that retrying can't fix.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+// A Batcher collects items and sends them in batches. It's safe for
+// concurrent use.
+type Batcher struct {
+	cfg  Config
+	send func([][]byte) error
+
+	mu     sync.Mutex
+	queue  [][]byte
+	err    error // send failures since the last Flush
+	closed bool
+
+	dropped atomic.Int64
+
+	wake    chan struct{}
+	flushes chan chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// New starts a Batcher that hands batches to send. The slices passed to
+// send are only valid until it returns.
+func New(send func(batch [][]byte) error, cfg Config) *Batcher {
+	b := &Batcher{
+		cfg:     cfg.withDefaults(),
+		send:    send,
+		wake:    make(chan struct{}, 1),
+		flushes: make(chan chan struct{}),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Add queues a copy of item to be sent.
+func (b *Batcher) Add(item []byte) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrClosed
+	}
+	if len(b.queue) >= b.cfg.QueueSize {
+		b.mu.Unlock()
+		b.dropped.Add(1)
+		return ErrQueueFull
+	}
+	b.queue = append(b.queue, append([]byte(nil), item...))
+	full := len(b.queue) >= b.cfg.MaxItems
+	b.mu.Unlock()
+
+	if full {
+		select {
+		case b.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Flush sends everything queued so far, waiting for it to be sent (or
+// dropped), and returns any errors sending batches since the last Flush.
+func (b *Batcher) Flush() error {
+	ack := make(chan struct{})
+	select {
+	case b.flushes <- ack:
+		<-ack
+	case <-b.done:
+		return ErrClosed
+	}
+	return b.takeErr()
+}
+
+// Close flushes any queued items and stops the Batcher. Items added after
+// Close are rejected.
+func (b *Batcher) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrClosed
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	close(b.stop)
+	<-b.done
+	return b.takeErr()
+}
+
+// Dropped returns the number of items dropped because the queue was full.
+func (b *Batcher) Dropped() int64 {
+	return b.dropped.Load()
+}
+
+func (b *Batcher) takeErr() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	err := b.err
+	b.err = nil
+	return err
+}
+
+func (b *Batcher) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.cfg.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.wake:
+			b.sendQueued(true)
+		case <-ticker.C:
+			b.sendQueued(false)
+		case ack := <-b.flushes:
+			b.sendQueued(false)
+			close(ack)
+		case <-b.stop:
+			b.sendQueued(false)
+			return
+		}
+	}
+}
+
+// sendQueued sends the queued items in batches. If onlyFull is true, it
+// stops once less than a full batch remains.
+func (b *Batcher) sendQueued(onlyFull bool) {
+	for {
+		batch, full := b.next()
+		if len(batch) == 0 || (onlyFull && !full) {
+			b.requeue(batch)
+			return
+		}
+		if err := b.sendWithRetries(batch); err != nil {
+			b.mu.Lock()
+			b.err = multierr.Append(b.err, err)
+			b.mu.Unlock()
+		}
+	}
+}
+
+// next removes the next batch from the queue, reporting whether it's full.
+func (b *Batcher) next() (batch [][]byte, full bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n, size := 0, 0
+	for n < len(b.queue) && n < b.cfg.MaxItems {
+		if n > 0 && size+len(b.queue[n]) > b.cfg.MaxBytes {
+			full = true
+			break
+		}
+		size += len(b.queue[n])
+		n++
+	}
+	full = full || n == b.cfg.MaxItems || size >= b.cfg.MaxBytes
+	batch = b.queue[:n:n]
+	b.queue = b.queue[n:]
+	if len(b.queue) == 0 {
+		b.queue = nil // let the backing array be collected
+	}
+	return batch, full
+}
+
+// requeue puts a batch that wasn't sent back at the front of the queue.
+func (b *Batcher) requeue(batch [][]byte) {
+	if len(batch) == 0 {
+		return
+	}
+	b.mu.Lock()
+	b.queue = append(batch, b.queue...)
+	b.mu.Unlock()
+}
+
+func (b *Batcher) sendWithRetries(batch [][]byte) error {
+	backoff := b.cfg.MinBackoff
+	for attempt := 0; ; attempt++ {
+		err := b.send(batch)
+		if err == nil || IsPermanent(err) || attempt >= b.cfg.MaxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-b.stop:
+			// We're shutting down: make one last attempt without waiting.
+			timer.Stop()
+			return b.send(batch)
+		}
+		if backoff *= 2; backoff > b.cfg.MaxBackoff {
+			backoff = b.cfg.MaxBackoff
+		}
+	}
+}
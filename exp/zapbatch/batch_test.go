@@ -0,0 +1,289 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapbatch
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// recorder is a send function that records the batches it receives.
+type recorder struct {
+	mu      sync.Mutex
+	batches []string
+	errs    []error // returned by successive calls, then nil
+}
+
+func (r *recorder) send(batch [][]byte) error {
+	items := make([]string, len(batch))
+	for i, item := range batch {
+		items[i] = string(item)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, strings.Join(items, ","))
+	if len(r.errs) > 0 {
+		err := r.errs[0]
+		r.errs = r.errs[1:]
+		return err
+	}
+	return nil
+}
+
+func (r *recorder) Batches() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.batches...)
+}
+
+func addAll(t testing.TB, b *Batcher, items ...string) {
+	for _, item := range items {
+		require.NoError(t, b.Add([]byte(item)), "Unexpected error adding item.")
+	}
+}
+
+func TestBatcherFlush(t *testing.T) {
+	var r recorder
+	b := New(r.send, Config{MaxItems: 2, FlushInterval: time.Hour})
+	defer b.Close()
+
+	buf := []byte("a")
+	require.NoError(t, b.Add(buf), "Unexpected error adding item.")
+	buf[0] = 'x' // Add must copy its input.
+	addAll(t, b, "b", "c")
+
+	require.NoError(t, b.Flush(), "Unexpected error flushing.")
+	assert.Equal(t, []string{"a,b", "c"}, r.Batches(), "Unexpected batches.")
+}
+
+func TestBatcherSendsFullBatches(t *testing.T) {
+	var r recorder
+	b := New(r.send, Config{MaxItems: 2, FlushInterval: time.Hour})
+	defer b.Close()
+
+	addAll(t, b, "a", "b", "c")
+	assert.Eventually(t, func() bool {
+		return len(r.Batches()) == 1
+	}, time.Second, time.Millisecond, "Expected a full batch to be sent without flushing.")
+	assert.Equal(t, []string{"a,b"}, r.Batches(), "Expected the partial batch to wait.")
+}
+
+func TestBatcherMaxBytes(t *testing.T) {
+	var r recorder
+	b := New(r.send, Config{MaxBytes: 4, FlushInterval: time.Hour})
+	defer b.Close()
+
+	addAll(t, b, "aa", "bb", "ccccc", "d")
+	require.NoError(t, b.Flush(), "Unexpected error flushing.")
+	assert.Equal(t, []string{"aa,bb", "ccccc", "d"}, r.Batches(), "Expected batches to respect MaxBytes.")
+}
+
+func TestBatcherFlushInterval(t *testing.T) {
+	var r recorder
+	b := New(r.send, Config{FlushInterval: time.Millisecond})
+	defer b.Close()
+
+	addAll(t, b, "a")
+	assert.Eventually(t, func() bool {
+		return len(r.Batches()) == 1
+	}, time.Second, time.Millisecond, "Expected items to be sent after the flush interval.")
+}
+
+func TestBatcherRetries(t *testing.T) {
+	failed := errors.New("failed")
+	tests := []struct {
+		desc        string
+		errs        []error
+		cfg         Config
+		wantBatches int
+		wantErr     error
+	}{
+		{
+			desc:        "recovers",
+			errs:        []error{failed, failed},
+			wantBatches: 3,
+		},
+		{
+			desc:        "gives up",
+			errs:        []error{failed, failed, failed},
+			cfg:         Config{MaxRetries: 2},
+			wantBatches: 3,
+			wantErr:     failed,
+		},
+		{
+			desc:        "no retries",
+			errs:        []error{failed},
+			cfg:         Config{MaxRetries: -1},
+			wantBatches: 1,
+			wantErr:     failed,
+		},
+		{
+			desc:        "permanent",
+			errs:        []error{Permanent(failed)},
+			wantBatches: 1,
+			wantErr:     failed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			r := recorder{errs: tt.errs}
+			tt.cfg.FlushInterval = time.Hour
+			tt.cfg.MinBackoff = time.Microsecond
+			b := New(r.send, tt.cfg)
+			defer b.Close()
+
+			addAll(t, b, "a")
+			err := b.Flush()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr, "Expected the send error to be returned.")
+			} else {
+				assert.NoError(t, err, "Unexpected error flushing.")
+			}
+			assert.Len(t, r.Batches(), tt.wantBatches, "Unexpected number of attempts.")
+			assert.NoError(t, b.Flush(), "Expected errors to be reported once.")
+		})
+	}
+}
+
+func TestBatcherQueueFull(t *testing.T) {
+	block := make(chan struct{})
+	b := New(func([][]byte) error {
+		<-block
+		return nil
+	}, Config{MaxItems: 1, QueueSize: 2, FlushInterval: time.Hour})
+
+	// The first item is picked up by the blocked sender; two more fill
+	// the queue.
+	addAll(t, b, "a")
+	require.Eventually(t, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return len(b.queue) == 0
+	}, time.Second, time.Millisecond, "Expected the sender to take the first item.")
+	addAll(t, b, "b", "c")
+
+	assert.ErrorIs(t, b.Add([]byte("d")), ErrQueueFull, "Expected items past the queue size to be dropped.")
+	assert.Equal(t, int64(1), b.Dropped(), "Unexpected number of dropped items.")
+
+	close(block)
+	assert.NoError(t, b.Close(), "Unexpected error closing.")
+}
+
+func TestBatcherClose(t *testing.T) {
+	var r recorder
+	b := New(r.send, Config{FlushInterval: time.Hour})
+
+	addAll(t, b, "a", "b")
+	require.NoError(t, b.Close(), "Unexpected error closing.")
+	assert.Equal(t, []string{"a,b"}, r.Batches(), "Expected Close to flush.")
+
+	assert.ErrorIs(t, b.Add([]byte("c")), ErrClosed, "Expected Add after Close to fail.")
+	assert.ErrorIs(t, b.Flush(), ErrClosed, "Expected Flush after Close to fail.")
+	assert.ErrorIs(t, b.Close(), ErrClosed, "Expected a second Close to fail.")
+}
+
+func TestPermanent(t *testing.T) {
+	assert.Nil(t, Permanent(nil), "Expected nil errors to stay nil.")
+	err := errors.New("bad request")
+	assert.True(t, IsPermanent(Permanent(err)), "Expected a permanent error.")
+	assert.False(t, IsPermanent(err), "Expected a regular error.")
+	assert.ErrorIs(t, Permanent(err), err, "Expected permanent errors to wrap the original.")
+}
+
+func TestConfigFromParams(t *testing.T) {
+	u, err := url.Parse("x://?batch_items=10&batch_size=1kb&flush=5s&queue=100&retries=-1")
+	require.NoError(t, err, "Failed to parse URL.")
+
+	params := zap.NewSinkParams(u)
+	cfg := ConfigFromParams(params)
+	require.NoError(t, params.Err(), "Unexpected error reading parameters.")
+	assert.Equal(t, Config{
+		MaxItems:      10,
+		MaxBytes:      1024,
+		FlushInterval: 5 * time.Second,
+		QueueSize:     100,
+		MaxRetries:    -1,
+	}, cfg, "Unexpected config.")
+}
+
+func TestDo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code, _ := strconv.Atoi(r.URL.Query().Get("code"))
+		w.WriteHeader(code)
+		_, _ = io.WriteString(w, "details\n")
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		code          int
+		wantErr       string
+		wantPermanent bool
+	}{
+		{code: http.StatusNoContent},
+		{code: http.StatusBadRequest, wantErr: "400 Bad Request: details", wantPermanent: true},
+		{code: http.StatusTooManyRequests, wantErr: "429 Too Many Requests: details"},
+		{code: http.StatusBadGateway, wantErr: "502 Bad Gateway: details"},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"?code="+strconv.Itoa(tt.code), nil)
+		require.NoError(t, err, "Failed to build request.")
+
+		err = Do(nil, req)
+		if tt.wantErr == "" {
+			assert.NoError(t, err, "Unexpected error for status %d.", tt.code)
+			continue
+		}
+		assert.ErrorContains(t, err, tt.wantErr, "Unexpected error for status %d.", tt.code)
+		assert.Equal(t, tt.wantPermanent, IsPermanent(err), "Unexpected permanence for status %d.", tt.code)
+	}
+}
+
+func TestDoTimesOut(t *testing.T) {
+	assert.Equal(t, DefaultHTTPTimeout, DefaultClient.Timeout, "Expected the default client to time out requests.")
+
+	hung := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-hung
+	}))
+	defer srv.Close()
+	defer close(hung)
+
+	defer func(c *http.Client) { DefaultClient = c }(DefaultClient)
+	DefaultClient = &http.Client{Timeout: 10 * time.Millisecond}
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL, nil)
+	require.NoError(t, err, "Failed to build request.")
+	assert.ErrorContains(t, Do(nil, req), "Client.Timeout", "Expected a hung request to time out.")
+}
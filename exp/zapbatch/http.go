@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapbatch
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultHTTPTimeout is the time limit on requests sent by DefaultClient.
+const DefaultHTTPTimeout = 30 * time.Second
+
+// DefaultClient is the HTTP client used by Do, and by the sinks built on this
+// package, when none is configured. Unlike http.DefaultClient, it times out
+// requests, so an endpoint that stops responding can't block a Batcher, and
+// with it Sync and Close, forever.
+var DefaultClient = &http.Client{Timeout: DefaultHTTPTimeout}
+
+// ConfigFromParams reads a Config from the query parameters of a sink URL,
+// for use by sink factories passed to zap.RegisterSink. The parameters are
+//
+//	batch_items  MaxItems
+//	batch_size   MaxBytes, like "512kb"
+//	flush        FlushInterval, like "5s"
+//	queue        QueueSize
+//	retries      MaxRetries
+//
+// Errors are accumulated in params.
+func ConfigFromParams(params *zap.SinkParams) Config {
+	return Config{
+		MaxItems:      params.Int("batch_items", 0),
+		MaxBytes:      params.Size("batch_size", 0),
+		FlushInterval: params.Duration("flush", 0),
+		QueueSize:     params.Int("queue", 0),
+		MaxRetries:    params.Int("retries", 0),
+	}
+}
+
+// CheckResponse converts an unsuccessful HTTP response into an error,
+// including the start of the response body. Client errors other than 408
+// (Request Timeout) and 429 (Too Many Requests) are marked Permanent, since
+// resending the same batch won't fix them. It doesn't close the body.
+func CheckResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err := fmt.Errorf("%s %s: %s: %s", resp.Request.Method, resp.Request.URL.Redacted(), resp.Status, strings.TrimSpace(string(body)))
+	switch code := resp.StatusCode; {
+	case code == http.StatusRequestTimeout || code == http.StatusTooManyRequests:
+		return err
+	case code >= 400 && code < 500:
+		return Permanent(err)
+	default:
+		return err
+	}
+}
+
+// Do sends an HTTP request and checks its response with CheckResponse,
+// draining and closing the body. If client is nil, it uses DefaultClient.
+func Do(client *http.Client, req *http.Request) error {
+	if client == nil {
+		client = DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = CheckResponse(resp)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return err
+}
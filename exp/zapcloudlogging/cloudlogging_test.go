@@ -0,0 +1,231 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcloudlogging
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeLogging records entries.write requests.
+type fakeLogging struct {
+	mu       sync.Mutex
+	requests []map[string]interface{}
+	auth     []string
+	status   []int // statuses to return, in order
+}
+
+func (f *fakeLogging) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v2/entries:write" {
+		http.NotFound(w, r)
+		return
+	}
+	var req map[string]interface{}
+	body, _ := io.ReadAll(r.Body)
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	if len(f.status) > 0 {
+		status := f.status[0]
+		f.status = f.status[1:]
+		w.WriteHeader(status)
+		return
+	}
+	_, _ = io.WriteString(w, "{}")
+}
+
+func (f *fakeLogging) Requests() []map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]map[string]interface{}(nil), f.requests...)
+}
+
+func newTestLogger(sink zap.Sink) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	return zap.New(zapcore.NewCore(enc, sink, zap.DebugLevel), zap.AddCaller())
+}
+
+func TestSinkWrites(t *testing.T) {
+	clearEnv(t)
+	startMetadata(t, map[string]string{
+		"project/project-id": "proj",
+		"instance/id":        "42",
+		"instance/zone":      "projects/123/zones/europe-west1-b",
+	})
+	api := &fakeLogging{}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	sink, err := New(Config{
+		LogID:    "my/log",
+		Labels:   map[string]string{"env": "test"},
+		Endpoint: srv.URL,
+		Batch:    zapbatch.Config{FlushInterval: time.Hour},
+	})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	logger := newTestLogger(sink)
+	logger.Named("db").Warn("slow query", zap.Int("ms", 250))
+	_, err = sink.Write([]byte("plain text\n"))
+	require.NoError(t, err, "Unexpected error writing.")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+
+	reqs := api.Requests()
+	require.Len(t, reqs, 1, "Expected one request.")
+	assert.Equal(t, "Bearer tok-1", api.auth[0], "Expected the metadata server's token.")
+
+	req := reqs[0]
+	assert.Equal(t, "projects/proj/logs/my%2Flog", req["logName"], "Unexpected log name.")
+	assert.Equal(t, map[string]interface{}{
+		"type":   "gce_instance",
+		"labels": map[string]interface{}{"project_id": "proj", "instance_id": "42", "zone": "europe-west1-b"},
+	}, req["resource"], "Expected the detected resource.")
+	assert.Equal(t, map[string]interface{}{"env": "test"}, req["labels"], "Unexpected labels.")
+	assert.Equal(t, true, req["partialSuccess"], "Expected partial success.")
+
+	entries := req["entries"].([]interface{})
+	require.Len(t, entries, 2, "Expected an entry per line.")
+	first := entries[0].(map[string]interface{})
+	assert.Equal(t, "WARNING", first["severity"], "Unexpected severity.")
+	assert.Equal(t, map[string]interface{}{"message": "slow query", "logger": "db", "ms": float64(250)}, first["jsonPayload"], "Unexpected payload.")
+	loc := first["sourceLocation"].(map[string]interface{})
+	assert.Contains(t, loc["file"], "zapcloudlogging/cloudlogging_test.go", "Unexpected source file.")
+	assert.NotEmpty(t, loc["line"], "Expected a source line.")
+
+	second := entries[1].(map[string]interface{})
+	assert.Equal(t, "DEFAULT", second["severity"], "Unexpected severity for text.")
+	assert.Equal(t, "plain text", second["textPayload"], "Expected undecodable lines as text.")
+}
+
+func TestEntry(t *testing.T) {
+	sink, err := New(Config{ProjectID: "p", Resource: &Resource{Type: "global"}})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	e := sink.entry([]byte(`{"level":"fatal","ts":1700000000.5,"caller":"a/b.go:7","msg":"boom","stacktrace":"main.main()"}`), time.Now())
+	assert.Equal(t, logEntry{
+		Timestamp:      "2023-11-14T22:13:20.5Z",
+		Severity:       "EMERGENCY",
+		JSONPayload:    json.RawMessage(`{"message":"boom","stack_trace":"main.main()"}`),
+		SourceLocation: &sourceLocation{File: "a/b.go", Line: "7"},
+	}, e, "Unexpected entry.")
+}
+
+func TestSinkRefreshesRejectedToken(t *testing.T) {
+	clearEnv(t)
+	md := startMetadata(t, nil)
+	api := &fakeLogging{status: []int{http.StatusUnauthorized}}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	sink, err := New(Config{
+		ProjectID: "proj",
+		Resource:  &Resource{Type: "global"},
+		Endpoint:  srv.URL,
+		Batch:     zapbatch.Config{FlushInterval: time.Hour, MinBackoff: time.Millisecond},
+	})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	newTestLogger(sink).Info("hello")
+	require.NoError(t, sink.Sync(), "Expected the batch to be retried with a new token.")
+	assert.Equal(t, []string{"Bearer tok-1", "Bearer tok-2"}, api.auth, "Expected the token to be refreshed.")
+
+	newTestLogger(sink).Info("again")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, 2, md.tokens, "Expected valid tokens to be cached.")
+}
+
+func TestSinkErrors(t *testing.T) {
+	api := &fakeLogging{status: []int{http.StatusBadRequest}}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	sink, err := New(Config{
+		ProjectID: "proj",
+		Resource:  &Resource{Type: "global"},
+		Endpoint:  srv.URL,
+		Token:     func() (string, error) { return "custom", nil },
+		Batch:     zapbatch.Config{FlushInterval: time.Hour},
+	})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	newTestLogger(sink).Info("rejected")
+	assert.ErrorContains(t, sink.Sync(), "400 Bad Request", "Expected write errors to be returned by Sync.")
+	assert.Equal(t, []string{"Bearer custom"}, api.auth, "Expected the custom token and no retries.")
+
+	sink.cfg.Token = func() (string, error) { return "", errors.New("no creds") }
+	assert.EqualError(t, sink.send([][]byte{[]byte("x")}), "no creds", "Expected token errors to be returned.")
+
+	_, err = New(Config{Endpoint: "ftp://logging"})
+	assert.ErrorContains(t, err, "must be an http or https URL", "Expected non-HTTP endpoints to be rejected.")
+}
+
+func TestSinkUnknownProject(t *testing.T) {
+	clearEnv(t)
+	startMetadata(t, map[string]string{})
+	sink, err := New(Config{Batch: zapbatch.Config{MaxRetries: -1}})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	assert.ErrorContains(t, sink.send([][]byte{[]byte("x")}), "can't determine Google Cloud project", "Expected a missing project to fail.")
+}
+
+func TestNewSink(t *testing.T) {
+	u, err := url.Parse("gcplogging:?project=p&log=api&labels=env=prod,team=core")
+	require.NoError(t, err, "Failed to parse URL.")
+	sink, err := NewSink(u)
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	cfg := sink.(*Sink).cfg
+	assert.Equal(t, "p", cfg.ProjectID, "Unexpected project.")
+	assert.Equal(t, "api", cfg.LogID, "Unexpected log.")
+	assert.Equal(t, map[string]string{"env": "prod", "team": "core"}, cfg.Labels, "Unexpected labels.")
+
+	for _, bad := range []string{"gcplogging:?labels=novalue", "gcplogging:?flush=soon"} {
+		u, err := url.Parse(bad)
+		require.NoError(t, err, "Failed to parse URL.")
+		_, err = NewSink(u)
+		assert.Error(t, err, "Expected %q to be rejected.", bad)
+	}
+}
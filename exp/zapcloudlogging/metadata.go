@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcloudlogging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const _metadataFlavor = "Google"
+
+// metadataClient queries the metadata server available on GCE, GKE, and
+// Cloud Run.
+type metadataClient struct {
+	host   string
+	client *http.Client
+}
+
+func newMetadataClient() *metadataClient {
+	host := os.Getenv("GCE_METADATA_HOST") // honored by Google's own libraries
+	if host == "" {
+		host = "metadata.google.internal"
+	}
+	return &metadataClient{
+		host:   host,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// get returns the value at path, relative to /computeMetadata/v1/.
+func (m *metadataClient) get(path string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, "http://"+m.host+"/computeMetadata/v1/"+path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", _metadataFlavor)
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("metadata server: GET %s: %s", path, resp.Status)
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// token returns an access token for the default service account, and its
+// expiry.
+func (m *metadataClient) token(now time.Time) (string, time.Time, error) {
+	body, err := m.get("instance/service-accounts/default/token")
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal([]byte(body), &tok); err != nil {
+		return "", time.Time{}, fmt.Errorf("metadata server: invalid token response: %w", err)
+	}
+	if tok.AccessToken == "" {
+		return "", time.Time{}, errors.New("metadata server: empty access token")
+	}
+	return tok.AccessToken, now.Add(time.Duration(tok.ExpiresIn) * time.Second), nil
+}
+
+// Resource is the monitored resource that entries are attributed to, like a
+// GCE instance or a Cloud Run revision.
+type Resource struct {
+	Type   string            `json:"type"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// detectResource identifies the environment the process is running in:
+// Cloud Run, GKE, or GCE. Outside Google Cloud, it returns the "global"
+// resource.
+func detectResource(m *metadataClient, projectID string) *Resource {
+	// The region and zone are returned as paths, like
+	// "projects/123/zones/us-central1-a".
+	last := func(path string) string {
+		v, _ := m.get(path)
+		return v[strings.LastIndexByte(v, '/')+1:]
+	}
+
+	if service := os.Getenv("K_SERVICE"); service != "" {
+		return &Resource{
+			Type: "cloud_run_revision",
+			Labels: map[string]string{
+				"project_id":         projectID,
+				"service_name":       service,
+				"revision_name":      os.Getenv("K_REVISION"),
+				"configuration_name": os.Getenv("K_CONFIGURATION"),
+				"location":           last("instance/region"),
+			},
+		}
+	}
+
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		cluster, _ := m.get("instance/attributes/cluster-name")
+		location, _ := m.get("instance/attributes/cluster-location")
+		return &Resource{
+			Type: "k8s_container",
+			Labels: map[string]string{
+				"project_id":     projectID,
+				"location":       location,
+				"cluster_name":   cluster,
+				"namespace_name": podNamespace(),
+				"pod_name":       firstEnv("POD_NAME", "HOSTNAME"),
+				"container_name": os.Getenv("CONTAINER_NAME"),
+			},
+		}
+	}
+
+	if id, err := m.get("instance/id"); err == nil {
+		return &Resource{
+			Type: "gce_instance",
+			Labels: map[string]string{
+				"project_id":  projectID,
+				"instance_id": id,
+				"zone":        last("instance/zone"),
+			},
+		}
+	}
+
+	return &Resource{
+		Type:   "global",
+		Labels: map[string]string{"project_id": projectID},
+	}
+}
+
+func podNamespace() string {
+	if ns := firstEnv("POD_NAMESPACE", "NAMESPACE"); ns != "" {
+		return ns
+	}
+	b, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+func firstEnv(names ...string) string {
+	for _, n := range names {
+		if v := os.Getenv(n); v != "" {
+			return v
+		}
+	}
+	return ""
+}
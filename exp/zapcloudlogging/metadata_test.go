@@ -0,0 +1,170 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcloudlogging
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMetadata serves metadata server values.
+type fakeMetadata struct {
+	mu      sync.Mutex
+	values  map[string]string
+	tokens  int
+	flavors []string
+}
+
+func (f *fakeMetadata) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.flavors = append(f.flavors, r.Header.Get("Metadata-Flavor"))
+	path := r.URL.Path[len("/computeMetadata/v1/"):]
+	if path == "instance/service-accounts/default/token" {
+		f.tokens++
+		fmt.Fprintf(w, `{"access_token":"tok-%d","expires_in":3600,"token_type":"Bearer"}`, f.tokens)
+		return
+	}
+	v, ok := f.values[path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	fmt.Fprint(w, v)
+}
+
+// startMetadata starts a fake metadata server and points the sink at it.
+func startMetadata(t *testing.T, values map[string]string) *fakeMetadata {
+	md := &fakeMetadata{values: values}
+	srv := httptest.NewServer(md)
+	t.Cleanup(srv.Close)
+	t.Setenv("GCE_METADATA_HOST", srv.Listener.Addr().String())
+	return md
+}
+
+// clearEnv unsets the variables that resource detection looks at.
+func clearEnv(t *testing.T) {
+	for _, k := range []string{
+		"K_SERVICE", "K_REVISION", "K_CONFIGURATION", "KUBERNETES_SERVICE_HOST",
+		"POD_NAME", "POD_NAMESPACE", "NAMESPACE", "CONTAINER_NAME", "GOOGLE_CLOUD_PROJECT",
+	} {
+		t.Setenv(k, "")
+	}
+}
+
+func TestDetectResource(t *testing.T) {
+	values := map[string]string{
+		"instance/id":                          "42",
+		"instance/zone":                        "projects/123/zones/us-central1-a",
+		"instance/region":                      "projects/123/regions/us-central1",
+		"instance/attributes/cluster-name":     "prod",
+		"instance/attributes/cluster-location": "us-central1",
+	}
+
+	tests := []struct {
+		desc   string
+		env    map[string]string
+		values map[string]string
+		want   *Resource
+	}{
+		{
+			desc: "Cloud Run",
+			env:  map[string]string{"K_SERVICE": "api", "K_REVISION": "api-001", "K_CONFIGURATION": "api"},
+			want: &Resource{Type: "cloud_run_revision", Labels: map[string]string{
+				"project_id": "proj", "service_name": "api", "revision_name": "api-001",
+				"configuration_name": "api", "location": "us-central1",
+			}},
+		},
+		{
+			desc: "GKE",
+			env: map[string]string{
+				"KUBERNETES_SERVICE_HOST": "10.0.0.1", "HOSTNAME": "api-7d9f",
+				"POD_NAMESPACE": "default", "CONTAINER_NAME": "server",
+			},
+			want: &Resource{Type: "k8s_container", Labels: map[string]string{
+				"project_id": "proj", "location": "us-central1", "cluster_name": "prod",
+				"namespace_name": "default", "pod_name": "api-7d9f", "container_name": "server",
+			}},
+		},
+		{
+			desc: "GCE",
+			want: &Resource{Type: "gce_instance", Labels: map[string]string{
+				"project_id": "proj", "instance_id": "42", "zone": "us-central1-a",
+			}},
+		},
+		{
+			desc:   "elsewhere",
+			values: map[string]string{},
+			want:   &Resource{Type: "global", Labels: map[string]string{"project_id": "proj"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			clearEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			give := values
+			if tt.values != nil {
+				give = tt.values
+			}
+			md := startMetadata(t, give)
+
+			assert.Equal(t, tt.want, detectResource(newMetadataClient(), "proj"), "Unexpected resource.")
+			for _, f := range md.flavors {
+				assert.Equal(t, "Google", f, "Expected the Metadata-Flavor header on every request.")
+			}
+		})
+	}
+}
+
+func TestMetadataToken(t *testing.T) {
+	startMetadata(t, nil)
+	now := time.Unix(1000, 0)
+	token, expiry, err := newMetadataClient().token(now)
+	require.NoError(t, err, "Unexpected error getting token.")
+	assert.Equal(t, "tok-1", token, "Unexpected token.")
+	assert.Equal(t, now.Add(time.Hour), expiry, "Unexpected expiry.")
+}
+
+func TestMetadataErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":""}`)
+	}))
+	defer srv.Close()
+	t.Setenv("GCE_METADATA_HOST", srv.Listener.Addr().String())
+
+	_, _, err := newMetadataClient().token(time.Now())
+	assert.ErrorContains(t, err, "empty access token", "Expected empty tokens to be rejected.")
+
+	startMetadata(t, map[string]string{})
+	_, err = newMetadataClient().get("project/project-id")
+	assert.ErrorContains(t, err, "404 Not Found", "Expected missing values to fail.")
+}
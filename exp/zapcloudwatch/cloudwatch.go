@@ -0,0 +1,463 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapcloudwatch provides a sink that sends logs to Amazon
+// CloudWatch Logs, so that services can ship logs without running the
+// CloudWatch agent.
+//
+// The sink sends the lines it receives to a log stream with PutLogEvents,
+// in batches that respect the API's limits. Lines holding JSON-encoded
+// entries, like those written by zap's production configuration, are
+// timestamped with the entry's time. Requests are signed with Signature
+// Version 4, using credentials from the environment by default. Since
+// CloudWatch no longer requires sequence tokens, the sink doesn't track
+// them.
+//
+// To use it in a zap.Config, register the "cloudwatch" scheme:
+//
+//	zap.RegisterSink("cloudwatch", zapcloudwatch.NewSink)
+//	cfg.OutputPaths = []string{"stderr", "cloudwatch:?group=/my/app&stream=web-1&create=true&retention=30"}
+//
+// This package is experimental: its API may change in backwards
+// incompatible ways.
+package zapcloudwatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+// Limits of the PutLogEvents API.
+const (
+	// MaxBatchEvents is the largest number of events in a request.
+	MaxBatchEvents = 10000
+	// MaxBatchSize is the largest size of a request, counting each
+	// event's message plus EventOverhead bytes.
+	MaxBatchSize = 1048576
+	// MaxEventSize is the largest size of a single event, counting
+	// EventOverhead. Longer messages are truncated.
+	MaxEventSize = 262144
+	// EventOverhead is the size CloudWatch adds to each event's message.
+	EventOverhead = 26
+
+	// maxBatchSpan is the largest time span of the events in a request.
+	maxBatchSpan = 24 * time.Hour
+)
+
+// _retentionDays lists the retention periods CloudWatch accepts.
+var _retentionDays = []int{1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1096, 1827, 2192, 2557, 2922, 3288, 3653}
+
+// Config configures a Sink.
+type Config struct {
+	// Region is the AWS region, like "us-east-1". Defaults to the
+	// AWS_REGION environment variable.
+	Region string
+
+	// Group and Stream name the log group and stream to send to. Required.
+	Group  string
+	Stream string
+
+	// CreateGroup creates the log group and stream if they don't exist.
+	CreateGroup bool
+
+	// RetentionDays, if set, is applied to the log group before the first
+	// batch is sent. It must be one of the periods CloudWatch accepts,
+	// like 1, 7, 30, or 365.
+	RetentionDays int
+
+	// Credentials returns the credentials used to sign each request.
+	// Defaults to EnvCredentials.
+	Credentials func() (Credentials, error)
+
+	// Endpoint overrides the CloudWatch Logs endpoint, which is normally
+	// derived from Region. Useful for VPC endpoints and local testing.
+	Endpoint string
+
+	// EncoderConfig describes the JSON the sink receives. Defaults to
+	// zap.NewProductionEncoderConfig.
+	EncoderConfig *zapcore.EncoderConfig
+
+	// Batch configures batching and retries. MaxItems and MaxBytes are
+	// capped to stay within the API's limits.
+	Batch zapbatch.Config
+
+	// Client sends requests to CloudWatch. Defaults to zapbatch.DefaultClient.
+	Client *http.Client
+}
+
+// Sink is a zap.Sink that sends entries to a CloudWatch Logs stream.
+type Sink struct {
+	cfg      Config
+	endpoint string
+	dec      *zapcore.EntryDecoder
+	batcher  *zapbatch.Batcher
+
+	// Only accessed by the batcher's goroutine.
+	ready bool
+	now   func() time.Time
+}
+
+var _ zap.Sink = (*Sink)(nil)
+
+// New builds a Sink and starts its background batching.
+func New(cfg Config) (*Sink, error) {
+	if cfg.Region == "" {
+		cfg.Region = os.Getenv("AWS_REGION")
+	}
+	if cfg.Region == "" && cfg.Endpoint == "" {
+		return nil, errors.New("missing CloudWatch region: set Region or AWS_REGION")
+	}
+	if cfg.Group == "" || cfg.Stream == "" {
+		return nil, errors.New("missing CloudWatch log group or stream name")
+	}
+	if cfg.RetentionDays != 0 && !validRetention(cfg.RetentionDays) {
+		return nil, fmt.Errorf("invalid CloudWatch retention of %d days", cfg.RetentionDays)
+	}
+	if cfg.Credentials == nil {
+		cfg.Credentials = EnvCredentials
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "https://logs." + cfg.Region + ".amazonaws.com/"
+	}
+	if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid CloudWatch endpoint %q: must be an http or https URL", endpoint)
+	}
+
+	// The batcher counts only message bytes, so reserve room for the
+	// per-event overhead.
+	batch := cfg.Batch
+	if batch.MaxItems <= 0 || batch.MaxItems > MaxBatchEvents {
+		batch.MaxItems = MaxBatchEvents
+	}
+	if limit := MaxBatchSize - batch.MaxItems*EventOverhead; batch.MaxBytes <= 0 || batch.MaxBytes > limit {
+		batch.MaxBytes = limit
+	}
+
+	encCfg := zap.NewProductionEncoderConfig()
+	if cfg.EncoderConfig != nil {
+		encCfg = *cfg.EncoderConfig
+	}
+
+	s := &Sink{
+		cfg:      cfg,
+		endpoint: endpoint,
+		dec:      zapcore.NewEntryDecoder(encCfg),
+		now:      time.Now,
+	}
+	s.batcher = zapbatch.New(s.send, batch)
+	return s, nil
+}
+
+func validRetention(days int) bool {
+	i := sort.SearchInts(_retentionDays, days)
+	return i < len(_retentionDays) && _retentionDays[i] == days
+}
+
+// NewSink builds a Sink from a URL, for use with zap.RegisterSink. The URL
+//
+//	cloudwatch:?group=/my/app&stream=web-1&region=us-east-1&create=true&retention=30
+//
+// sends to the given group and stream. Besides the parameters shown, it
+// accepts endpoint, and the batching parameters described by
+// zapbatch.ConfigFromParams. Credentials are read from the environment.
+func NewSink(u *url.URL) (zap.Sink, error) {
+	params := zap.NewSinkParams(u)
+	cfg := Config{
+		Region:        params.String("region", ""),
+		Group:         params.String("group", ""),
+		Stream:        params.String("stream", ""),
+		CreateGroup:   params.Bool("create", false),
+		RetentionDays: params.Int("retention", 0),
+		Endpoint:      params.String("endpoint", ""),
+		Batch:         zapbatch.ConfigFromParams(params),
+	}
+	if err := params.Err(); err != nil {
+		return nil, fmt.Errorf("invalid CloudWatch sink URL %v: %v", u.Redacted(), err)
+	}
+	return New(cfg)
+}
+
+// Write queues each line of p to be sent to CloudWatch.
+func (s *Sink) Write(p []byte) (int, error) {
+	var err error
+	for _, line := range bytes.Split(p, []byte{'\n'}) {
+		if len(line) > 0 {
+			err = multierr.Append(err, s.batcher.Add(line))
+		}
+	}
+	return len(p), err
+}
+
+// Sync sends all queued entries, returning any errors sending entries since
+// the last Sync.
+func (s *Sink) Sync() error {
+	return s.batcher.Flush()
+}
+
+// Close sends all queued entries and stops the sink.
+func (s *Sink) Close() error {
+	return s.batcher.Close()
+}
+
+type logEvent struct {
+	Timestamp int64  `json:"timestamp"`
+	Message   string `json:"message"`
+}
+
+type putLogEventsRequest struct {
+	LogGroupName  string     `json:"logGroupName"`
+	LogStreamName string     `json:"logStreamName"`
+	LogEvents     []logEvent `json:"logEvents"`
+}
+
+type putLogEventsResponse struct {
+	RejectedLogEventsInfo *struct {
+		TooNewLogEventStartIndex *int `json:"tooNewLogEventStartIndex"`
+		TooOldLogEventEndIndex   *int `json:"tooOldLogEventEndIndex"`
+		ExpiredLogEventEndIndex  *int `json:"expiredLogEventEndIndex"`
+	} `json:"rejectedLogEventsInfo"`
+}
+
+func (s *Sink) send(batch [][]byte) error {
+	if !s.ready {
+		if err := s.setup(); err != nil {
+			return err
+		}
+		s.ready = true
+	}
+
+	var err error
+	for _, events := range splitSpan(s.events(batch)) {
+		err = multierr.Append(err, s.put(events))
+	}
+	return err
+}
+
+func (s *Sink) put(events []logEvent) error {
+	req := putLogEventsRequest{
+		LogGroupName:  s.cfg.Group,
+		LogStreamName: s.cfg.Stream,
+		LogEvents:     events,
+	}
+	var resp putLogEventsResponse
+	err := s.call("PutLogEvents", req, &resp)
+	if s.cfg.CreateGroup && isAPIError(err, "ResourceNotFoundException") {
+		// The group or stream was deleted after setup.
+		if err = s.create(); err == nil {
+			err = s.call("PutLogEvents", req, &resp)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if info := resp.RejectedLogEventsInfo; info != nil {
+		var reasons []string
+		if info.TooNewLogEventStartIndex != nil {
+			reasons = append(reasons, "too new")
+		}
+		if info.TooOldLogEventEndIndex != nil {
+			reasons = append(reasons, "too old")
+		}
+		if info.ExpiredLogEventEndIndex != nil {
+			reasons = append(reasons, "expired")
+		}
+		if len(reasons) > 0 {
+			return fmt.Errorf("CloudWatch rejected some log events: %s", strings.Join(reasons, ", "))
+		}
+	}
+	return nil
+}
+
+// setup creates the log group and stream and applies the retention
+// policy, as configured.
+func (s *Sink) setup() error {
+	if s.cfg.CreateGroup {
+		if err := s.create(); err != nil {
+			return err
+		}
+	}
+	if s.cfg.RetentionDays > 0 {
+		return s.call("PutRetentionPolicy", map[string]interface{}{
+			"logGroupName":    s.cfg.Group,
+			"retentionInDays": s.cfg.RetentionDays,
+		}, nil)
+	}
+	return nil
+}
+
+func (s *Sink) create() error {
+	err := s.call("CreateLogGroup", map[string]string{"logGroupName": s.cfg.Group}, nil)
+	if err != nil && !isAPIError(err, "ResourceAlreadyExistsException") {
+		return err
+	}
+	err = s.call("CreateLogStream", map[string]string{
+		"logGroupName":  s.cfg.Group,
+		"logStreamName": s.cfg.Stream,
+	}, nil)
+	if err != nil && !isAPIError(err, "ResourceAlreadyExistsException") {
+		return err
+	}
+	return nil
+}
+
+// events converts a batch of lines into log events, sorted by time as
+// CloudWatch requires. Lines that can't be decoded are timestamped now.
+func (s *Sink) events(batch [][]byte) []logEvent {
+	now := s.now().UnixMilli()
+	events := make([]logEvent, len(batch))
+	for i, line := range batch {
+		ts := now
+		if ent, _, err := s.dec.DecodeJSON(line); err == nil && !ent.Time.IsZero() {
+			ts = ent.Time.UnixMilli()
+		}
+		if len(line) > MaxEventSize-EventOverhead {
+			line = line[:MaxEventSize-EventOverhead]
+		}
+		events[i] = logEvent{Timestamp: ts, Message: string(line)}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp < events[j].Timestamp
+	})
+	return events
+}
+
+// splitSpan splits sorted events so that no request spans more than 24
+// hours.
+func splitSpan(events []logEvent) [][]logEvent {
+	var out [][]logEvent
+	for len(events) > 0 {
+		n := 1
+		for n < len(events) && events[n].Timestamp-events[0].Timestamp < maxBatchSpan.Milliseconds() {
+			n++
+		}
+		out = append(out, events[:n])
+		events = events[n:]
+	}
+	return out
+}
+
+// APIError is an error returned by the CloudWatch Logs API.
+type APIError struct {
+	StatusCode int
+	Type       string // like "ResourceNotFoundException"
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("CloudWatch Logs: %s (HTTP %d): %s", e.Type, e.StatusCode, e.Message)
+}
+
+func isAPIError(err error, typ string) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Type == typ
+}
+
+// _retryableErrors are the client errors worth retrying.
+var _retryableErrors = map[string]bool{
+	"ThrottlingException":         true,
+	"LimitExceededException":      true,
+	"ServiceUnavailableException": true,
+	"ResourceNotFoundException":   true, // may be created concurrently
+}
+
+// call invokes a CloudWatch Logs action, decoding its response into out if
+// it's not nil.
+func (s *Sink) call(action string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return zapbatch.Permanent(err)
+	}
+	creds, err := s.cfg.Credentials()
+	if err != nil {
+		return fmt.Errorf("can't get AWS credentials: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return zapbatch.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "Logs_20140328."+action)
+	sign(req, body, creds, s.cfg.Region, "logs", s.now())
+
+	client := s.cfg.Client
+	if client == nil {
+		client = zapbatch.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if out == nil || len(respBody) == 0 {
+			return nil
+		}
+		return json.Unmarshal(respBody, out)
+	}
+
+	var apiResp struct {
+		Type    string `json:"__type"`
+		Message string `json:"message"`
+		// Some errors capitalize the message key.
+		MessageUpper string `json:"Message"`
+	}
+	_ = json.Unmarshal(respBody, &apiResp)
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Type:       apiResp.Type,
+		Message:    apiResp.Message,
+	}
+	if i := strings.LastIndexByte(apiErr.Type, '#'); i >= 0 {
+		apiErr.Type = apiErr.Type[i+1:]
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = apiResp.MessageUpper
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(respBody))
+	}
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && !_retryableErrors[apiErr.Type] {
+		return zapbatch.Permanent(apiErr)
+	}
+	return apiErr
+}
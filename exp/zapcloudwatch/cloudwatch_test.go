@@ -0,0 +1,363 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcloudwatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeCloudWatch implements just enough of the CloudWatch Logs API.
+type fakeCloudWatch struct {
+	mu       sync.Mutex
+	actions  []string
+	groups   map[string]int // retention by group
+	streams  map[string]bool
+	events   []logEvent
+	requests []putLogEventsRequest
+	fail     map[string]string // action to error type
+}
+
+func newFakeCloudWatch() *fakeCloudWatch {
+	return &fakeCloudWatch{
+		groups:  make(map[string]int),
+		streams: make(map[string]bool),
+		fail:    make(map[string]string),
+	}
+}
+
+func (f *fakeCloudWatch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AK/") {
+		writeAPIError(w, http.StatusForbidden, "UnrecognizedClientException", "bad signature")
+		return
+	}
+	action := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "Logs_20140328.")
+	f.actions = append(f.actions, action)
+	if typ, ok := f.fail[action]; ok {
+		status := http.StatusBadRequest
+		if typ == "ServiceUnavailableException" {
+			status = http.StatusServiceUnavailable
+		}
+		writeAPIError(w, status, typ, "injected failure")
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	var req struct {
+		putLogEventsRequest
+		RetentionInDays int `json:"retentionInDays"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "SerializationException", err.Error())
+		return
+	}
+
+	stream := req.LogGroupName + ":" + req.LogStreamName
+	switch action {
+	case "CreateLogGroup":
+		if _, ok := f.groups[req.LogGroupName]; ok {
+			writeAPIError(w, http.StatusBadRequest, "ResourceAlreadyExistsException", "group exists")
+			return
+		}
+		f.groups[req.LogGroupName] = 0
+	case "CreateLogStream":
+		if f.streams[stream] {
+			writeAPIError(w, http.StatusBadRequest, "ResourceAlreadyExistsException", "stream exists")
+			return
+		}
+		f.streams[stream] = true
+	case "PutRetentionPolicy":
+		f.groups[req.LogGroupName] = req.RetentionInDays
+	case "PutLogEvents":
+		if !f.streams[stream] {
+			writeAPIError(w, http.StatusBadRequest, "ResourceNotFoundException", "no stream")
+			return
+		}
+		f.requests = append(f.requests, req.putLogEventsRequest)
+		f.events = append(f.events, req.LogEvents...)
+		_, _ = io.WriteString(w, `{"nextSequenceToken":"1"}`)
+	}
+}
+
+func writeAPIError(w http.ResponseWriter, status int, typ, msg string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `{"__type":"com.amazonaws.logs#%s","message":%q}`, typ, msg)
+}
+
+func (f *fakeCloudWatch) Actions() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.actions...)
+}
+
+func testConfig(url string) Config {
+	return Config{
+		Region:   "us-east-1",
+		Group:    "/app",
+		Stream:   "web-1",
+		Endpoint: url,
+		Credentials: func() (Credentials, error) {
+			return Credentials{AccessKeyID: "AK", SecretAccessKey: "SK"}, nil
+		},
+		Batch: zapbatch.Config{FlushInterval: time.Hour, MaxRetries: -1},
+	}
+}
+
+func newTestLogger(sink zap.Sink) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	return zap.New(zapcore.NewCore(enc, sink, zap.DebugLevel))
+}
+
+func TestSinkCreatesAndSends(t *testing.T) {
+	cw := newFakeCloudWatch()
+	srv := httptest.NewServer(cw)
+	defer srv.Close()
+
+	cfg := testConfig(srv.URL)
+	cfg.CreateGroup = true
+	cfg.RetentionDays = 30
+	sink, err := New(cfg)
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	_, err = sink.Write([]byte(`{"level":"info","ts":1700000002,"msg":"later"}` + "\n" +
+		`{"level":"info","ts":1700000001.5,"msg":"earlier"}` + "\n"))
+	require.NoError(t, err, "Unexpected error writing.")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+
+	newTestLogger(sink).Info("again")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+
+	assert.Equal(t, []string{
+		"CreateLogGroup", "CreateLogStream", "PutRetentionPolicy", "PutLogEvents", "PutLogEvents",
+	}, cw.Actions(), "Expected setup to happen once, before the first batch.")
+	assert.Equal(t, 30, cw.groups["/app"], "Expected retention to be applied.")
+	require.Len(t, cw.events, 3, "Expected all events to be sent.")
+	assert.Equal(t, logEvent{Timestamp: 1700000001500, Message: `{"level":"info","ts":1700000001.5,"msg":"earlier"}`}, cw.events[0], "Expected events sorted by entry time.")
+	assert.Equal(t, int64(1700000002000), cw.events[1].Timestamp, "Unexpected second event.")
+	assert.Contains(t, cw.events[2].Message, `"msg":"again"`, "Unexpected third event.")
+}
+
+func TestSinkRecreatesDeletedStream(t *testing.T) {
+	cw := newFakeCloudWatch()
+	srv := httptest.NewServer(cw)
+	defer srv.Close()
+
+	cfg := testConfig(srv.URL)
+	cfg.CreateGroup = true
+	sink, err := New(cfg)
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	newTestLogger(sink).Info("one")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+
+	cw.mu.Lock()
+	delete(cw.streams, "/app:web-1")
+	cw.mu.Unlock()
+	newTestLogger(sink).Info("two")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+
+	assert.Equal(t, []string{
+		"CreateLogGroup", "CreateLogStream", "PutLogEvents",
+		"PutLogEvents", "CreateLogGroup", "CreateLogStream", "PutLogEvents",
+	}, cw.Actions(), "Expected the stream to be recreated.")
+	assert.Len(t, cw.events, 2, "Expected both events to be sent.")
+}
+
+func TestSinkErrors(t *testing.T) {
+	tests := []struct {
+		desc      string
+		fail      string
+		wantErr   string
+		permanent bool
+	}{
+		{
+			desc:    "missing stream",
+			wantErr: "ResourceNotFoundException (HTTP 400): no stream",
+		},
+		{
+			desc:      "invalid parameter",
+			fail:      "InvalidParameterException",
+			wantErr:   "InvalidParameterException (HTTP 400): injected failure",
+			permanent: true,
+		},
+		{
+			desc:    "unavailable",
+			fail:    "ServiceUnavailableException",
+			wantErr: "ServiceUnavailableException (HTTP 503): injected failure",
+		},
+		{
+			desc:    "throttled",
+			fail:    "ThrottlingException",
+			wantErr: "ThrottlingException (HTTP 400): injected failure",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cw := newFakeCloudWatch()
+			if tt.fail != "" {
+				cw.fail["PutLogEvents"] = tt.fail
+			}
+			srv := httptest.NewServer(cw)
+			defer srv.Close()
+
+			sink, err := New(testConfig(srv.URL))
+			require.NoError(t, err, "Unexpected error building sink.")
+			defer sink.Close()
+
+			err = sink.send([][]byte{[]byte("hello")})
+			assert.ErrorContains(t, err, tt.wantErr, "Unexpected error.")
+			assert.Equal(t, tt.permanent, zapbatch.IsPermanent(err), "Unexpected permanence.")
+		})
+	}
+}
+
+func TestSinkRejectedEvents(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"rejectedLogEventsInfo":{"tooOldLogEventEndIndex":0}}`)
+	}))
+	defer srv.Close()
+
+	sink, err := New(testConfig(srv.URL))
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	assert.EqualError(t, sink.send([][]byte{[]byte("old")}), "CloudWatch rejected some log events: too old", "Expected rejected events to be reported.")
+}
+
+func TestEvents(t *testing.T) {
+	sink, err := New(testConfig("http://localhost"))
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+	sink.now = func() time.Time { return time.UnixMilli(5000) }
+
+	long := make([]byte, MaxEventSize)
+	events := sink.events([][]byte{
+		[]byte("plain"),
+		[]byte(`{"ts":4,"msg":"json"}`),
+		long,
+	})
+	require.Len(t, events, 3, "Unexpected number of events.")
+	assert.Equal(t, logEvent{Timestamp: 4000, Message: `{"ts":4,"msg":"json"}`}, events[0], "Unexpected decoded event.")
+	assert.Equal(t, logEvent{Timestamp: 5000, Message: "plain"}, events[1], "Expected undecodable lines to be timestamped now.")
+	assert.Len(t, events[2].Message, MaxEventSize-EventOverhead, "Expected long events to be truncated.")
+}
+
+func TestSplitSpan(t *testing.T) {
+	day := (24 * time.Hour).Milliseconds()
+	events := []logEvent{{Timestamp: 0}, {Timestamp: day - 1}, {Timestamp: day}, {Timestamp: 3 * day}}
+	assert.Equal(t, [][]logEvent{
+		{{Timestamp: 0}, {Timestamp: day - 1}},
+		{{Timestamp: day}},
+		{{Timestamp: 3 * day}},
+	}, splitSpan(events), "Expected no request to span 24 hours.")
+	assert.Empty(t, splitSpan(nil), "Expected no requests for no events.")
+}
+
+func TestNewValidation(t *testing.T) {
+	base := testConfig("http://localhost")
+	tests := []struct {
+		desc    string
+		give    func(*Config)
+		wantErr string
+	}{
+		{
+			desc:    "no region",
+			give:    func(c *Config) { c.Region, c.Endpoint = "", "" },
+			wantErr: "missing CloudWatch region",
+		},
+		{
+			desc:    "no stream",
+			give:    func(c *Config) { c.Stream = "" },
+			wantErr: "missing CloudWatch log group or stream name",
+		},
+		{
+			desc:    "retention",
+			give:    func(c *Config) { c.RetentionDays = 31 },
+			wantErr: "invalid CloudWatch retention of 31 days",
+		},
+		{
+			desc:    "endpoint",
+			give:    func(c *Config) { c.Endpoint = "ftp://logs" },
+			wantErr: "must be an http or https URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Setenv("AWS_REGION", "")
+			cfg := base
+			tt.give(&cfg)
+			_, err := New(cfg)
+			assert.ErrorContains(t, err, tt.wantErr, "Unexpected error.")
+		})
+	}
+}
+
+func TestDefaultEndpoint(t *testing.T) {
+	sink, err := New(Config{Region: "us-east-1", Group: "g", Stream: "s"})
+	require.NoError(t, err, "Unexpected error building sink.")
+	require.NoError(t, sink.Close(), "Unexpected error closing sink.")
+	assert.Equal(t, "https://logs.us-east-1.amazonaws.com/", sink.endpoint, "Unexpected default endpoint.")
+}
+
+func TestNewSink(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AK")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "SK")
+	cw := newFakeCloudWatch()
+	srv := httptest.NewServer(cw)
+	defer srv.Close()
+
+	u, err := url.Parse("cloudwatch:?group=/my/app&stream=s&region=eu-west-1&create=true&retention=7&flush=1h&endpoint=" + url.QueryEscape(srv.URL))
+	require.NoError(t, err, "Failed to parse URL.")
+	sink, err := NewSink(u)
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	newTestLogger(sink).Info("hi")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, 7, cw.groups["/my/app"], "Expected retention to be applied.")
+	assert.Len(t, cw.events, 1, "Expected an event.")
+
+	u, err = url.Parse("cloudwatch:?group=g&stream=s&create=maybe")
+	require.NoError(t, err, "Failed to parse URL.")
+	_, err = NewSink(u)
+	assert.Error(t, err, "Expected invalid parameters to be rejected.")
+}
@@ -0,0 +1,482 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapcollector provides a sink that streams logs to a collector over
+// gRPC, so that internal platforms can build custom collectors against a
+// small, stable contract.
+//
+// The contract is the LogCollector service in collector.proto, in this
+// package's directory: the sink calls its client-streaming Stream method
+// and sends each entry as an Entry message, keeping the stream open across
+// batches. When the sink is closed, it ends the stream and checks that the
+// collector accepted every entry sent on it. If a stream fails, the sink
+// opens a new one and resends the failed batch; entries already sent on the
+// failed stream may or may not have reached the collector.
+//
+// If a Schema is configured, the sink declares it in the metadata of each
+// stream, so that collectors can decode the fields of entries from every
+// version of a service: zap-schema-name and zap-schema-version always, and
+// either zap-schema-id, if the schema is registered in a SchemaRegistry, or
+// zap-schema-definition-bin, holding the base64-encoded definition.
+//
+// Entries are buffered in a bounded queue while they wait to be sent, and
+// dropped once it's full. The sink speaks gRPC using only the standard
+// library, which supports HTTP/2 only over TLS, so the collector must
+// serve TLS.
+//
+// To use it in a zap.Config, register a scheme:
+//
+//	zap.RegisterSink("collector", zapcollector.NewSink)
+//	cfg.OutputPaths = []string{"stderr", "collector://logs.internal:9443?header=authorization:Bearer+token"}
+//
+// This package is experimental: its API may change in backwards
+// incompatible ways.
+package zapcollector
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultTimeout is the default of Config.Timeout.
+const DefaultTimeout = 10 * time.Second
+
+// _streamPath is the path of the LogCollector.Stream method.
+const _streamPath = "/zap.collector.v1.LogCollector/Stream"
+
+// _maxSummarySize bounds the response read from the collector.
+const _maxSummarySize = 1 << 10
+
+// gRPC status codes.
+var _codeNames = []string{
+	"OK", "Canceled", "Unknown", "InvalidArgument", "DeadlineExceeded",
+	"NotFound", "AlreadyExists", "PermissionDenied", "ResourceExhausted",
+	"FailedPrecondition", "Aborted", "OutOfRange", "Unimplemented",
+	"Internal", "Unavailable", "DataLoss", "Unauthenticated",
+}
+
+// _permanentCodes are the status codes that retrying a batch won't fix.
+var _permanentCodes = map[int]struct{}{
+	3:  {}, // InvalidArgument
+	7:  {}, // PermissionDenied
+	12: {}, // Unimplemented
+	16: {}, // Unauthenticated
+}
+
+var (
+	errStreamEnded = errors.New("collector ended the stream")
+	errSendTimeout = errors.New("timed out sending to collector")
+)
+
+// Config configures a Sink.
+type Config struct {
+	// URL is the collector's base URL, like "https://logs.internal:9443".
+	// Required.
+	URL string
+
+	// Headers are sent as gRPC metadata when opening each stream, as for
+	// authentication.
+	Headers map[string]string
+
+	// Schema, if set, is declared to the collector when opening each
+	// stream. Its ID is ignored, and its name must be printable ASCII.
+	Schema *zapcore.Schema
+
+	// SchemaRegistry, if set, registers Schema when the Sink is built, so
+	// that only the schema's ID, name, and version need to be sent.
+	SchemaRegistry zapcore.SchemaRegistry
+
+	// Timeout bounds sending each batch, and waiting for the collector's
+	// summary when closing the sink. Defaults to DefaultTimeout.
+	Timeout time.Duration
+
+	// EncoderConfig describes the JSON the sink receives. Defaults to
+	// zap.NewProductionEncoderConfig.
+	EncoderConfig *zapcore.EncoderConfig
+
+	// Batch configures batching, buffering, and retries.
+	Batch zapbatch.Config
+
+	// Client calls the collector. It must support HTTP/2. Defaults to a
+	// client using TLSConfig, or the system's roots if that's nil.
+	Client    *http.Client
+	TLSConfig *tls.Config
+}
+
+// Sink is a zap.Sink that streams entries to a collector.
+type Sink struct {
+	cfg       Config
+	streamURL string
+	metadata  http.Header // sent when opening each stream
+	dec       *zapcore.EntryDecoder
+	batcher   *zapbatch.Batcher
+
+	// Only accessed by the batcher's goroutine, and by Close after the
+	// batcher stops.
+	stream *stream
+}
+
+var _ zap.Sink = (*Sink)(nil)
+
+// New builds a Sink. It opens a stream in the background, when the first
+// entries are sent.
+func New(cfg Config) (*Sink, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("invalid collector URL %q: must be an https URL", cfg.URL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + _streamPath
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Transport: &http.Transport{
+			Proxy:             http.ProxyFromEnvironment,
+			TLSClientConfig:   cfg.TLSConfig,
+			ForceAttemptHTTP2: true,
+		}}
+	}
+	encCfg := zap.NewProductionEncoderConfig()
+	if cfg.EncoderConfig != nil {
+		encCfg = *cfg.EncoderConfig
+	}
+
+	metadata, err := schemaMetadata(cfg.Schema, cfg.SchemaRegistry)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Sink{
+		cfg:       cfg,
+		streamURL: u.String(),
+		metadata:  metadata,
+		dec:       zapcore.NewEntryDecoder(encCfg),
+	}
+	s.batcher = zapbatch.New(s.send, cfg.Batch)
+	return s, nil
+}
+
+// schemaMetadata builds the metadata declaring a schema, registering it
+// first if there's a registry.
+func schemaMetadata(schema *zapcore.Schema, reg zapcore.SchemaRegistry) (http.Header, error) {
+	if schema == nil {
+		return nil, nil
+	}
+	s := *schema
+	if s.Name == "" || strings.IndexFunc(s.Name, func(r rune) bool { return r < ' ' || r > '~' }) >= 0 {
+		return nil, fmt.Errorf("invalid log schema name %q: must be non-empty printable ASCII", s.Name)
+	}
+
+	h := make(http.Header)
+	h.Set("Zap-Schema-Name", s.Name)
+	h.Set("Zap-Schema-Version", strconv.FormatUint(uint64(s.Version), 10))
+	if reg != nil {
+		id, err := reg.Register(s)
+		if err != nil {
+			return nil, fmt.Errorf("can't register log schema %q: %w", s.Name, err)
+		}
+		h.Set("Zap-Schema-Id", strconv.FormatUint(uint64(id), 10))
+	} else if len(s.Definition) > 0 {
+		h.Set("Zap-Schema-Definition-Bin", base64.StdEncoding.EncodeToString(s.Definition))
+	}
+	return h, nil
+}
+
+// NewSink builds a Sink from a URL, for use with zap.RegisterSink. The URL
+//
+//	collector://logs.internal:9443/prefix?header=authorization:Bearer+token&timeout=5s
+//
+// streams to https://logs.internal:9443/prefix. The header parameter is a
+// comma-separated list of name:value pairs. Besides the parameters shown,
+// it accepts the batching parameters described by
+// zapbatch.ConfigFromParams.
+func NewSink(u *url.URL) (zap.Sink, error) {
+	params := zap.NewSinkParams(u)
+	cfg := Config{
+		Timeout: params.Duration("timeout", 0),
+		Batch:   zapbatch.ConfigFromParams(params),
+	}
+	headers := params.String("header", "")
+	if err := params.Err(); err != nil {
+		return nil, fmt.Errorf("invalid collector sink URL %v: %v", u.Redacted(), err)
+	}
+	if headers != "" {
+		cfg.Headers = make(map[string]string)
+		for _, h := range strings.Split(headers, ",") {
+			k, v, ok := strings.Cut(h, ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid collector sink URL %v: header %q must have the form name:value", u.Redacted(), k)
+			}
+			cfg.Headers[k] = v
+		}
+	}
+	cfg.URL = (&url.URL{Scheme: "https", Host: u.Host, Path: u.Path}).String()
+	return New(cfg)
+}
+
+// Write queues each line of p to be sent.
+func (s *Sink) Write(p []byte) (int, error) {
+	var err error
+	for _, line := range bytes.Split(p, []byte{'\n'}) {
+		if len(line) > 0 {
+			err = multierr.Append(err, s.batcher.Add(line))
+		}
+	}
+	return len(p), err
+}
+
+// Sync sends all queued entries to the collector, returning any errors
+// sending entries since the last Sync.
+func (s *Sink) Sync() error {
+	return s.batcher.Flush()
+}
+
+// Close sends all queued entries, ends the stream, and waits for the
+// collector to confirm it accepted them.
+func (s *Sink) Close() error {
+	err := s.batcher.Close()
+	if s.stream != nil {
+		err = multierr.Append(err, s.stream.finish(s.cfg.Timeout))
+		s.stream = nil
+	}
+	return err
+}
+
+func (s *Sink) send(batch [][]byte) error {
+	if s.stream == nil {
+		s.stream = s.open()
+	}
+
+	now := time.Now()
+	var buf []byte
+	for _, line := range batch {
+		e := s.entry(line, now)
+		buf = e.appendFrame(buf)
+	}
+	if err := s.stream.write(buf, len(batch), s.cfg.Timeout); err != nil {
+		// Start over on a new stream.
+		s.stream.abort()
+		s.stream = nil
+		return err
+	}
+	return nil
+}
+
+// entry decodes a line. Lines that can't be decoded are sent as info-level
+// messages, timestamped now.
+func (s *Sink) entry(line []byte, now time.Time) entry {
+	e := entry{TimeUnixNano: now.UnixNano(), Message: string(line)}
+	ent, fields, err := s.dec.DecodeJSON(line)
+	if err != nil {
+		return e
+	}
+
+	e.Level, e.Logger, e.Message, e.Stack = ent.Level, ent.LoggerName, ent.Message, ent.Stack
+	if !ent.Time.IsZero() {
+		e.TimeUnixNano = ent.Time.UnixNano()
+	}
+	if ent.Caller.Defined {
+		e.Caller = ent.Caller.String()
+	}
+	if len(fields) > 0 {
+		enc := zapcore.NewMapObjectEncoder()
+		for _, f := range fields {
+			f.AddTo(enc)
+		}
+		e.FieldsJSON, _ = json.Marshal(enc.Fields)
+	}
+	return e
+}
+
+// stream is a call to LogCollector.Stream. Its request body is a pipe that
+// the sink writes entries to; a goroutine makes the call, which lasts until
+// the sink ends the stream or it fails.
+type stream struct {
+	pw     *io.PipeWriter
+	cancel context.CancelFunc
+	sent   uint64
+
+	done     chan struct{}
+	accepted uint64 // set before done is closed
+	err      error  // set before done is closed
+}
+
+func (s *Sink) open() *stream {
+	ctx, cancel := context.WithCancel(context.Background())
+	pr, pw := io.Pipe()
+	st := &stream{pw: pw, cancel: cancel, done: make(chan struct{})}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.streamURL, pr)
+	if err != nil {
+		st.err = zapbatch.Permanent(err)
+		pr.CloseWithError(st.err)
+		close(st.done)
+		return st
+	}
+	req.Header.Set("Content-Type", "application/grpc+proto")
+	req.Header.Set("Te", "trailers")
+	for k, v := range s.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	for k, v := range s.metadata {
+		req.Header[k] = v
+	}
+
+	go func() {
+		defer close(st.done)
+		st.accepted, st.err = call(s.cfg.Client, req)
+		if st.err == nil {
+			// The collector ended the stream before we did.
+			pr.CloseWithError(errStreamEnded)
+			return
+		}
+		pr.CloseWithError(st.err)
+	}()
+	return st
+}
+
+// write sends frames holding n entries, failing if the stream doesn't
+// accept them within the timeout.
+func (st *stream) write(frames []byte, n int, timeout time.Duration) error {
+	var timedOut atomic.Bool
+	timer := time.AfterFunc(timeout, func() {
+		timedOut.Store(true)
+		st.cancel()
+	})
+	defer timer.Stop()
+	if _, err := st.pw.Write(frames); err != nil {
+		if timedOut.Load() {
+			return errSendTimeout
+		}
+		// The call ended, and either it or the transport closed the pipe.
+		<-st.done
+		if st.err != nil {
+			return st.err
+		}
+		return errStreamEnded
+	}
+	st.sent += uint64(n)
+	return nil
+}
+
+// finish ends the stream, waiting for the collector's summary.
+func (st *stream) finish(timeout time.Duration) error {
+	st.pw.Close()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-st.done:
+	case <-timer.C:
+		st.abort()
+		return errors.New("timed out waiting for collector to end stream")
+	}
+
+	if st.err != nil {
+		return st.err
+	}
+	if st.accepted < st.sent {
+		return fmt.Errorf("collector accepted %d of %d entries", st.accepted, st.sent)
+	}
+	return nil
+}
+
+// abort cancels the call.
+func (st *stream) abort() {
+	st.cancel()
+	<-st.done
+}
+
+// call makes a streaming call, returning the number of entries the
+// collector accepted.
+func call(client *http.Client, req *http.Request) (uint64, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if err := zapbatch.CheckResponse(resp); err != nil {
+		return 0, err
+	}
+	if resp.ProtoMajor != 2 {
+		return 0, zapbatch.Permanent(fmt.Errorf("collector %s doesn't speak HTTP/2", req.URL.Host))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, _maxSummarySize))
+	if err != nil {
+		return 0, err
+	}
+	if err := status(resp); err != nil {
+		return 0, err
+	}
+	if len(body) < 5 || int(binary.BigEndian.Uint32(body[1:])) != len(body)-5 {
+		return 0, fmt.Errorf("malformed response from collector: %w", errMalformed)
+	}
+	return parseSummary(body[5:])
+}
+
+// status converts a gRPC status, from the trailers or, if the collector
+// failed the call immediately, the headers, into an error.
+func status(resp *http.Response) error {
+	code, msg := resp.Trailer.Get("Grpc-Status"), resp.Trailer.Get("Grpc-Message")
+	if code == "" {
+		code, msg = resp.Header.Get("Grpc-Status"), resp.Header.Get("Grpc-Message")
+	}
+	if code == "" {
+		return errors.New("collector response is missing a gRPC status")
+	}
+	n, err := strconv.Atoi(code)
+	if err != nil {
+		return fmt.Errorf("collector returned invalid gRPC status %q", code)
+	}
+	if n == 0 {
+		return nil
+	}
+
+	name := code
+	if n > 0 && n < len(_codeNames) {
+		name = _codeNames[n]
+	}
+	if m, err := url.PathUnescape(msg); err == nil {
+		msg = m
+	}
+	err = fmt.Errorf("collector returned %s: %s", name, msg)
+	if _, ok := _permanentCodes[n]; ok {
+		err = zapbatch.Permanent(err)
+	}
+	return err
+}
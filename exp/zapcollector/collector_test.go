@@ -0,0 +1,431 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcollector
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeCollector implements LogCollector.Stream.
+type fakeCollector struct {
+	failFirst int // fail the first call with this status
+	reject    int // entries to leave out of each summary
+	block     bool
+
+	mu       sync.Mutex
+	calls    int
+	headers  []http.Header
+	protos   []int
+	entries  []entry
+	finished int
+}
+
+func newFakeCollector(t *testing.T, f *fakeCollector) *httptest.Server {
+	srv := httptest.NewUnstartedServer(f)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func (f *fakeCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/prefix"+_streamPath {
+		http.NotFound(w, r)
+		return
+	}
+	f.mu.Lock()
+	f.calls++
+	call := f.calls
+	f.headers = append(f.headers, r.Header)
+	f.protos = append(f.protos, r.ProtoMajor)
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/grpc")
+	if f.block {
+		<-r.Context().Done()
+		return
+	}
+	if call == 1 && f.failFirst != 0 {
+		w.Header().Set("Grpc-Status", fmt.Sprint(f.failFirst))
+		w.Header().Set("Grpc-Message", "try%20again")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var accepted uint64
+	for {
+		var header [5]byte
+		if _, err := io.ReadFull(r.Body, header[:]); err != nil {
+			break
+		}
+		msg := make([]byte, binary.BigEndian.Uint32(header[1:]))
+		if _, err := io.ReadFull(r.Body, msg); err != nil {
+			break
+		}
+		e, err := parseEntry(msg)
+		if err != nil {
+			w.Header().Set("Grpc-Status", "3")
+			w.Header().Set("Grpc-Message", err.Error())
+			return
+		}
+		f.mu.Lock()
+		f.entries = append(f.entries, e)
+		f.mu.Unlock()
+		accepted++
+	}
+	if accepted >= uint64(f.reject) {
+		accepted -= uint64(f.reject)
+	}
+
+	summary := appendKey(nil, 1, _wireVarint)
+	summary = binary.AppendUvarint(summary, accepted)
+	frame := append([]byte{0}, binary.BigEndian.AppendUint32(nil, uint32(len(summary)))...)
+	w.Write(append(frame, summary...))
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", "0")
+
+	f.mu.Lock()
+	f.finished++
+	f.mu.Unlock()
+}
+
+func (f *fakeCollector) Entries() []entry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]entry(nil), f.entries...)
+}
+
+// parseEntry decodes an Entry message.
+func parseEntry(b []byte) (entry, error) {
+	var e entry
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return e, errMalformed
+		}
+		b = b[n:]
+		switch field, wire := key>>3, key&7; {
+		case field == 1 && wire == _wireVarint:
+			v, n := binary.Uvarint(b)
+			e.TimeUnixNano, b = int64(v), b[n:]
+		case field == 2 && wire == _wireVarint:
+			v, n := binary.Varint(b)
+			e.Level, b = zapcore.Level(v), b[n:]
+		case field >= 3 && field <= 7 && wire == _wireBytes:
+			size, n := binary.Uvarint(b)
+			if n <= 0 || size > uint64(len(b)-n) {
+				return e, errMalformed
+			}
+			s := string(b[n : n+int(size)])
+			b = b[n+int(size):]
+			switch field {
+			case 3:
+				e.Logger = s
+			case 4:
+				e.Message = s
+			case 5:
+				e.Caller = s
+			case 6:
+				e.Stack = s
+			case 7:
+				e.FieldsJSON = []byte(s)
+			}
+		default:
+			return e, fmt.Errorf("unexpected field %d with wire type %d", field, wire)
+		}
+	}
+	return e, nil
+}
+
+func newTestSink(t *testing.T, srv *httptest.Server, cfg Config) *Sink {
+	cfg.URL = srv.URL + "/prefix/"
+	cfg.Client = srv.Client()
+	if cfg.Batch.FlushInterval == 0 {
+		cfg.Batch.FlushInterval = time.Hour
+	}
+	sink, err := New(cfg)
+	require.NoError(t, err, "Unexpected error building sink.")
+	return sink
+}
+
+func newTestLogger(sink zap.Sink) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	return zap.New(zapcore.NewCore(enc, sink, zap.DebugLevel), zap.AddCaller())
+}
+
+func TestSinkStreams(t *testing.T) {
+	collector := &fakeCollector{}
+	srv := newFakeCollector(t, collector)
+	sink := newTestSink(t, srv, Config{Headers: map[string]string{"Authorization": "Bearer token"}})
+
+	logger := newTestLogger(sink)
+	logger.Named("db").Warn("first", zap.Int("attempt", 2))
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+	logger.Info("second")
+	_, err := sink.Write([]byte("not json\n"))
+	require.NoError(t, err, "Unexpected error writing.")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+
+	assert.Eventually(t, func() bool { return len(collector.Entries()) == 3 }, time.Second, time.Millisecond,
+		"Expected entries to be streamed before the sink is closed.")
+	require.NoError(t, sink.Close(), "Unexpected error closing.")
+
+	collector.mu.Lock()
+	assert.Equal(t, 1, collector.calls, "Expected a single stream.")
+	assert.Equal(t, 1, collector.finished, "Expected Close to end the stream.")
+	assert.Equal(t, []int{2}, collector.protos, "Expected HTTP/2.")
+	h := collector.headers[0]
+	collector.mu.Unlock()
+	assert.Equal(t, "application/grpc+proto", h.Get("Content-Type"), "Unexpected content type.")
+	assert.Equal(t, "trailers", h.Get("Te"), "Expected the TE header gRPC requires.")
+	assert.Equal(t, "Bearer token", h.Get("Authorization"), "Expected the configured metadata.")
+
+	entries := collector.Entries()
+	assert.Equal(t, zapcore.WarnLevel, entries[0].Level, "Unexpected level.")
+	assert.Equal(t, "db", entries[0].Logger, "Unexpected logger.")
+	assert.Equal(t, "first", entries[0].Message, "Unexpected message.")
+	assert.Contains(t, entries[0].Caller, "zapcollector/collector_test.go:", "Expected the caller.")
+	assert.JSONEq(t, `{"attempt":2}`, string(entries[0].FieldsJSON), "Unexpected fields.")
+	assert.InDelta(t, time.Now().UnixNano(), entries[0].TimeUnixNano, float64(time.Minute), "Unexpected time.")
+
+	assert.Equal(t, zapcore.InfoLevel, entries[1].Level, "Unexpected level.")
+	assert.Empty(t, entries[1].FieldsJSON, "Expected no fields.")
+	assert.Equal(t, "not json", entries[2].Message, "Expected undecodable lines as messages.")
+}
+
+func TestSinkSchema(t *testing.T) {
+	schema := &zapcore.Schema{Name: "checkout", Version: 3, Definition: []byte(`{"user":"string"}`)}
+
+	tests := []struct {
+		desc     string
+		registry zapcore.SchemaRegistry
+		want     map[string]string
+	}{
+		{
+			desc: "inline",
+			want: map[string]string{
+				"Zap-Schema-Name":           "checkout",
+				"Zap-Schema-Version":        "3",
+				"Zap-Schema-Id":             "",
+				"Zap-Schema-Definition-Bin": "eyJ1c2VyIjoic3RyaW5nIn0=",
+			},
+		},
+		{
+			desc:     "registered",
+			registry: zapcore.NewSchemaRegistry(),
+			want: map[string]string{
+				"Zap-Schema-Name":           "checkout",
+				"Zap-Schema-Version":        "3",
+				"Zap-Schema-Id":             "1",
+				"Zap-Schema-Definition-Bin": "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			collector := &fakeCollector{}
+			srv := newFakeCollector(t, collector)
+			sink := newTestSink(t, srv, Config{Schema: schema, SchemaRegistry: tt.registry})
+
+			require.NoError(t, sink.send([][]byte{[]byte("hello")}), "Unexpected error sending.")
+			require.NoError(t, sink.Close(), "Unexpected error closing.")
+
+			collector.mu.Lock()
+			h := collector.headers[0]
+			collector.mu.Unlock()
+			for k, v := range tt.want {
+				assert.Equal(t, v, h.Get(k), "Unexpected %v metadata.", k)
+			}
+		})
+	}
+}
+
+func TestSinkSchemaErrors(t *testing.T) {
+	reg := zapcore.NewSchemaRegistry()
+	_, err := reg.Register(zapcore.Schema{Name: "checkout", Version: 1, Definition: []byte("old")})
+	require.NoError(t, err, "Unexpected error registering schema.")
+
+	tests := []struct {
+		desc    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			desc:    "empty name",
+			cfg:     Config{Schema: &zapcore.Schema{}},
+			wantErr: "invalid log schema name",
+		},
+		{
+			desc:    "non-ASCII name",
+			cfg:     Config{Schema: &zapcore.Schema{Name: "caf\u00e9"}},
+			wantErr: "invalid log schema name",
+		},
+		{
+			desc: "conflicting registration",
+			cfg: Config{
+				Schema:         &zapcore.Schema{Name: "checkout", Version: 1, Definition: []byte("new")},
+				SchemaRegistry: reg,
+			},
+			wantErr: "can't register log schema",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tt.cfg.URL = "https://logs.internal"
+			_, err := New(tt.cfg)
+			assert.ErrorContains(t, err, tt.wantErr, "Unexpected error building sink.")
+		})
+	}
+}
+
+func TestSinkReconnects(t *testing.T) {
+	collector := &fakeCollector{failFirst: 14}
+	srv := newFakeCollector(t, collector)
+	sink := newTestSink(t, srv, Config{})
+
+	// The first stream fails, which the sink may not notice until its next
+	// batch.
+	err := sink.send([][]byte{[]byte("first")})
+	if err == nil {
+		<-sink.stream.done
+		err = sink.send([][]byte{[]byte("second")})
+	}
+	assert.EqualError(t, err, "collector returned Unavailable: try again", "Expected the stream's status.")
+	assert.False(t, zapbatch.IsPermanent(err), "Expected unavailability to be retried.")
+
+	require.NoError(t, sink.send([][]byte{[]byte("third")}), "Expected a new stream.")
+	require.NoError(t, sink.Close(), "Unexpected error closing.")
+	entries := collector.Entries()
+	require.Len(t, entries, 1, "Expected only entries sent on the new stream.")
+	assert.Equal(t, "third", entries[0].Message, "Unexpected entry.")
+}
+
+func TestSinkErrors(t *testing.T) {
+	t.Run("permanent status", func(t *testing.T) {
+		collector := &fakeCollector{failFirst: 16}
+		srv := newFakeCollector(t, collector)
+		sink := newTestSink(t, srv, Config{})
+
+		newTestLogger(sink).Info("hello")
+		_ = sink.Sync()
+		err := sink.Close()
+		assert.ErrorContains(t, err, "collector returned Unauthenticated: try again", "Expected the stream's status.")
+		assert.True(t, zapbatch.IsPermanent(err), "Expected authentication errors not to be retried.")
+	})
+
+	t.Run("rejected entries", func(t *testing.T) {
+		collector := &fakeCollector{reject: 1}
+		srv := newFakeCollector(t, collector)
+		sink := newTestSink(t, srv, Config{})
+
+		logger := newTestLogger(sink)
+		logger.Info("one")
+		logger.Info("two")
+		require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+		assert.EqualError(t, sink.Close(), "collector accepted 1 of 2 entries", "Expected lost entries to be reported.")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		collector := &fakeCollector{block: true}
+		srv := newFakeCollector(t, collector)
+		sink := newTestSink(t, srv, Config{Timeout: 50 * time.Millisecond})
+		defer sink.Close()
+
+		big := bytes.Repeat([]byte("x"), 4<<20)
+		assert.Equal(t, errSendTimeout, sink.send([][]byte{big}), "Expected a timeout.")
+		assert.Nil(t, sink.stream, "Expected the stream to be abandoned.")
+	})
+
+	t.Run("invalid URL", func(t *testing.T) {
+		for _, bad := range []string{"", "http://collector:9443", "https://"} {
+			_, err := New(Config{URL: bad})
+			assert.ErrorContains(t, err, "must be an https URL", "Expected %q to be rejected.", bad)
+		}
+	})
+}
+
+func TestNewSink(t *testing.T) {
+	u, err := url.Parse("collector://logs.internal:9443/prefix?header=authorization:Bearer+token,x-tenant:acme&timeout=5s&flush=1h")
+	require.NoError(t, err, "Failed to parse URL.")
+	sink, err := NewSink(u)
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	s := sink.(*Sink)
+	assert.Equal(t, "https://logs.internal:9443/prefix"+_streamPath, s.streamURL, "Unexpected stream URL.")
+	assert.Equal(t, map[string]string{"authorization": "Bearer token", "x-tenant": "acme"}, s.cfg.Headers, "Unexpected headers.")
+	assert.Equal(t, 5*time.Second, s.cfg.Timeout, "Unexpected timeout.")
+
+	for _, bad := range []string{"collector://logs?header=nocolon", "collector://logs?timeout=soon"} {
+		u, err := url.Parse(bad)
+		require.NoError(t, err, "Failed to parse URL.")
+		_, err = NewSink(u)
+		assert.Error(t, err, "Expected %q to be rejected.", bad)
+	}
+}
+
+func TestEntryEncoding(t *testing.T) {
+	e := entry{
+		TimeUnixNano: 1700000000123456789,
+		Level:        zapcore.DebugLevel,
+		Logger:       "a.b",
+		Message:      strings.Repeat("m", 300),
+		Stack:        "goroutine 1",
+		FieldsJSON:   []byte(`{"k":"v"}`),
+	}
+	frame := e.appendFrame([]byte("prefix"))
+	require.True(t, bytes.HasPrefix(frame, []byte("prefix")), "Expected frames to be appended.")
+	frame = frame[len("prefix"):]
+	assert.Equal(t, byte(0), frame[0], "Expected an uncompressed message.")
+	assert.Equal(t, len(frame)-5, int(binary.BigEndian.Uint32(frame[1:])), "Unexpected message length.")
+
+	got, err := parseEntry(frame[5:])
+	require.NoError(t, err, "Unexpected error decoding entry.")
+	assert.Equal(t, e, got, "Expected the entry to round-trip.")
+
+	empty := (&entry{}).appendFrame(nil)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0}, empty, "Expected zero values to be omitted.")
+
+	accepted, err := parseSummary([]byte{0x08, 0x96, 0x01, 0x12, 0x01, 'x', 0x1d, 1, 2, 3, 4})
+	require.NoError(t, err, "Unexpected error decoding summary.")
+	assert.Equal(t, uint64(150), accepted, "Expected unknown fields to be skipped.")
+	for _, bad := range [][]byte{{0x08}, {0x12, 0x05, 'x'}, {0x19, 1}, {0x0b}} {
+		_, err := parseSummary(bad)
+		assert.Error(t, err, "Expected %x to be rejected.", bad)
+	}
+}
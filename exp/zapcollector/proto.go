@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcollector
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Protocol buffer wire types.
+const (
+	_wireVarint = 0
+	_wire64     = 1
+	_wireBytes  = 2
+	_wire32     = 5
+)
+
+var errMalformed = errors.New("malformed protocol buffer")
+
+// entry is the Entry message of collector.proto.
+type entry struct {
+	TimeUnixNano int64
+	Level        zapcore.Level
+	Logger       string
+	Message      string
+	Caller       string
+	Stack        string
+	FieldsJSON   []byte
+}
+
+// appendFrame appends e to b as a gRPC length-prefixed message.
+func (e *entry) appendFrame(b []byte) []byte {
+	b = append(b, 0, 0, 0, 0, 0) // uncompressed, then the length
+	start := len(b)
+	if e.TimeUnixNano != 0 {
+		b = appendKey(b, 1, _wireVarint)
+		b = binary.AppendUvarint(b, uint64(e.TimeUnixNano))
+	}
+	if e.Level != 0 {
+		b = appendKey(b, 2, _wireVarint)
+		b = binary.AppendVarint(b, int64(e.Level)) // zigzag, for sint32
+	}
+	b = appendString(b, 3, e.Logger)
+	b = appendString(b, 4, e.Message)
+	b = appendString(b, 5, e.Caller)
+	b = appendString(b, 6, e.Stack)
+	b = appendString(b, 7, string(e.FieldsJSON))
+	binary.BigEndian.PutUint32(b[start-4:], uint32(len(b)-start))
+	return b
+}
+
+func appendKey(b []byte, field int, wire byte) []byte {
+	return binary.AppendUvarint(b, uint64(field)<<3|uint64(wire))
+}
+
+// appendString appends a string or bytes field, unless it's empty.
+func appendString(b []byte, field int, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = appendKey(b, field, _wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// parseSummary decodes a StreamSummary message, returning the number of
+// entries accepted.
+func parseSummary(b []byte) (accepted uint64, err error) {
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return 0, errMalformed
+		}
+		b = b[n:]
+		field, wire := key>>3, key&7
+
+		switch wire {
+		case _wireVarint:
+			v, n := binary.Uvarint(b)
+			if n <= 0 {
+				return 0, errMalformed
+			}
+			b = b[n:]
+			if field == 1 {
+				accepted = v
+			}
+		case _wire64, _wire32:
+			size := 8
+			if wire == _wire32 {
+				size = 4
+			}
+			if len(b) < size {
+				return 0, errMalformed
+			}
+			b = b[size:]
+		case _wireBytes:
+			size, n := binary.Uvarint(b)
+			if n <= 0 || size > uint64(len(b)-n) {
+				return 0, errMalformed
+			}
+			b = b[n+int(size):]
+		default:
+			return 0, fmt.Errorf("unsupported protocol buffer wire type %d", wire)
+		}
+	}
+	return accepted, nil
+}
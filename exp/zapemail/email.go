@@ -0,0 +1,363 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapemail provides a core that emails high-severity entries, for
+// deployments where email is still the alerting channel of choice.
+//
+// Entries at or above the configured level (by default, ErrorLevel) are
+// collected into digests: one email every few minutes, listing all the
+// entries logged since the last. Entries at or above the immediate level (by
+// default, FatalLevel) are emailed right away, before Write returns, along
+// with any pending digest, so that they're sent before the process exits.
+//
+// Tee the core with your usual one:
+//
+//	mail, err := zapemail.New(zapemail.Config{
+//		Addr:     "smtp.example.com:587",
+//		Username: "alerts@example.com",
+//		Password: os.Getenv("SMTP_PASSWORD"),
+//		From:     "alerts@example.com",
+//		To:       []string{"oncall@example.com"},
+//	})
+//	logger = logger.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+//		return zapcore.NewTee(c, mail)
+//	}))
+//	defer mail.Close()
+//
+// This package is experimental: its API may change in backwards
+// incompatible ways.
+package zapemail
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+// Defaults for the zero values of Config's fields.
+const (
+	DefaultDigestInterval   = 5 * time.Minute
+	DefaultMaxDigestEntries = 100
+	DefaultSubjectPrefix    = "[zap]"
+	DefaultTimeout          = 30 * time.Second
+)
+
+// TLSMode selects how connections to the SMTP server are secured.
+type TLSMode int
+
+const (
+	// StartTLS upgrades the connection with STARTTLS, failing if the
+	// server doesn't support it. It's the usual mode for port 587.
+	StartTLS TLSMode = iota
+	// ImplicitTLS connects with TLS from the start, as on port 465.
+	ImplicitTLS
+	// NoTLS sends mail in the clear. Servers usually refuse
+	// authentication over unencrypted connections, except to localhost.
+	NoTLS
+)
+
+// Config configures a Core.
+type Config struct {
+	// Addr is the host and port of the SMTP server. Required.
+	Addr string
+
+	// TLS selects how the connection is secured, and TLSConfig configures
+	// it. TLSConfig defaults to verifying the server's certificate for
+	// Addr's host.
+	TLS       TLSMode
+	TLSConfig *tls.Config
+
+	// Username and Password authenticate with PLAIN authentication, if
+	// set.
+	Username string
+	Password string
+
+	// From and To address the emails. Required.
+	From string
+	To   []string
+
+	// SubjectPrefix starts every subject. Defaults to
+	// DefaultSubjectPrefix.
+	SubjectPrefix string
+
+	// Level is the lowest level emailed. Defaults to ErrorLevel.
+	Level zapcore.LevelEnabler
+
+	// Immediate selects the entries emailed immediately rather than in a
+	// digest. Defaults to FatalLevel.
+	Immediate zapcore.LevelEnabler
+
+	// DigestInterval is the longest an entry waits to be emailed in a
+	// digest, and MaxDigestEntries the most entries in a single digest.
+	// They default to DefaultDigestInterval and DefaultMaxDigestEntries.
+	DigestInterval   time.Duration
+	MaxDigestEntries int
+
+	// Encoder formats entries in emails. Defaults to a console encoder
+	// with ISO 8601 timestamps.
+	Encoder zapcore.Encoder
+
+	// Timeout bounds the time spent sending an email. Defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+}
+
+// Core is a zapcore.Core that emails entries. It must be closed to stop its
+// background sending.
+type Core struct {
+	zapcore.LevelEnabler
+
+	enc    zapcore.Encoder
+	mailer *mailer
+}
+
+var _ zapcore.Core = (*Core)(nil)
+
+type mailer struct {
+	cfg      Config
+	host     string
+	hostname string
+	batcher  *zapbatch.Batcher
+	now      func() time.Time
+}
+
+// New builds a Core and starts its background sending.
+func New(cfg Config) (*Core, error) {
+	host, _, err := net.SplitHostPort(cfg.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP address %q: %w", cfg.Addr, err)
+	}
+	if cfg.From == "" || len(cfg.To) == 0 {
+		return nil, errors.New("missing email sender or recipients")
+	}
+	if cfg.SubjectPrefix == "" {
+		cfg.SubjectPrefix = DefaultSubjectPrefix
+	}
+	if cfg.Level == nil {
+		cfg.Level = zapcore.ErrorLevel
+	}
+	if cfg.Immediate == nil {
+		cfg.Immediate = zapcore.FatalLevel
+	}
+	if cfg.DigestInterval <= 0 {
+		cfg.DigestInterval = DefaultDigestInterval
+	}
+	if cfg.MaxDigestEntries <= 0 {
+		cfg.MaxDigestEntries = DefaultMaxDigestEntries
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	enc := cfg.Encoder
+	if enc == nil {
+		encCfg := zap.NewProductionEncoderConfig()
+		encCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+		encCfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		enc = zapcore.RNewConsoleEncoder(encCfg)
+	}
+	hostname, _ := os.Hostname()
+
+	m := &mailer{
+		cfg:      cfg,
+		host:     host,
+		hostname: hostname,
+		now:      time.Now,
+	}
+	m.batcher = zapbatch.New(m.sendDigest, zapbatch.Config{
+		MaxItems:      cfg.MaxDigestEntries,
+		FlushInterval: cfg.DigestInterval,
+	})
+	return &Core{LevelEnabler: cfg.Level, enc: enc, mailer: m}, nil
+}
+
+// With adds structured context to the Core.
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.enc = c.enc.Clone()
+	for i := range fields {
+		fields[i].AddTo(clone.enc)
+	}
+	return &clone
+}
+
+// Check adds the Core to ce if the entry is severe enough to email.
+func (c *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write queues the entry for the next digest or, if it's to be emailed
+// immediately, emails it and any pending digest before returning.
+func (c *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	text := buf.Bytes()
+	defer buf.Free()
+
+	if !c.mailer.cfg.Immediate.Enabled(ent.Level) {
+		return c.mailer.batcher.Add(text)
+	}
+	subject := fmt.Sprintf("%s %s", ent.Level.CapitalString(), ent.Message)
+	if ent.LoggerName != "" {
+		subject = fmt.Sprintf("%s %s: %s", ent.Level.CapitalString(), ent.LoggerName, ent.Message)
+	}
+	err = c.mailer.send(subject, text)
+	if flushErr := c.mailer.batcher.Flush(); flushErr != nil && err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+// Sync emails any pending digest, returning any errors sending emails since
+// the last Sync.
+func (c *Core) Sync() error {
+	return c.mailer.batcher.Flush()
+}
+
+// Close emails any pending digest and stops the Core. It's shared by all
+// Cores derived from this one with With.
+func (c *Core) Close() error {
+	return c.mailer.batcher.Close()
+}
+
+func (m *mailer) sendDigest(entries [][]byte) error {
+	noun := "entries"
+	if len(entries) == 1 {
+		noun = "entry"
+	}
+	return m.send(fmt.Sprintf("%d %s", len(entries), noun), bytes.Join(entries, nil))
+}
+
+// send emails body, a plain text message. SMTP errors that retrying can't
+// fix are marked permanent.
+func (m *mailer) send(subject string, body []byte) error {
+	msg, err := m.message(subject, body)
+	if err != nil {
+		return zapbatch.Permanent(err)
+	}
+	err = m.deliver(msg)
+	var tpErr *textproto.Error
+	if errors.As(err, &tpErr) && tpErr.Code >= 500 {
+		return zapbatch.Permanent(err)
+	}
+	return err
+}
+
+func (m *mailer) message(subject string, body []byte) ([]byte, error) {
+	if m.hostname != "" {
+		subject += " on " + m.hostname
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", m.cfg.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(m.cfg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.cfg.SubjectPrefix+" "+subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", m.now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write(body); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (m *mailer) deliver(msg []byte) (err error) {
+	tlsConfig := m.cfg.TLSConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{ServerName: m.host, MinVersion: tls.VersionTLS12}
+	}
+
+	dialer := &net.Dialer{Timeout: m.cfg.Timeout}
+	var conn net.Conn
+	if m.cfg.TLS == ImplicitTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", m.cfg.Addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", m.cfg.Addr)
+	}
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(m.now().Add(m.cfg.Timeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if m.cfg.TLS == StartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("SMTP server %s doesn't support STARTTLS", m.cfg.Addr)
+		}
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+	if m.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(m.cfg.From); err != nil {
+		return err
+	}
+	for _, to := range m.cfg.To {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
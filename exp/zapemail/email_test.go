@@ -0,0 +1,288 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapemail
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+type receivedMail struct {
+	From    string
+	To      []string
+	Auth    string
+	TLS     bool
+	Subject string
+	Body    string
+}
+
+// fakeSMTP is a minimal SMTP server.
+type fakeSMTP struct {
+	ln       net.Listener
+	tls      *tls.Config
+	implicit bool
+	rejectTo string // recipient refused with a 550
+
+	mu    sync.Mutex
+	mails []receivedMail
+}
+
+func newFakeSMTP(t *testing.T, implicit bool) (*fakeSMTP, *tls.Config) {
+	srv := httptest.NewUnstartedServer(http.NotFoundHandler())
+	srv.StartTLS()
+	serverTLS := &tls.Config{Certificates: srv.TLS.Certificates}
+	clientTLS := srv.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+	clientTLS.ServerName = "127.0.0.1"
+	srv.Close()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "Failed to listen.")
+	if implicit {
+		ln = tls.NewListener(ln, serverTLS)
+	}
+	f := &fakeSMTP{ln: ln, tls: serverTLS, implicit: implicit}
+	t.Cleanup(func() { ln.Close() })
+	go f.serve()
+	return f, clientTLS
+}
+
+func (f *fakeSMTP) Addr() string { return f.ln.Addr().String() }
+
+func (f *fakeSMTP) Mails() []receivedMail {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]receivedMail(nil), f.mails...)
+}
+
+func (f *fakeSMTP) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeSMTP) handle(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	m := receivedMail{TLS: f.implicit}
+	_ = tp.PrintfLine("220 fake ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(cmd) {
+		case "EHLO":
+			_ = tp.PrintfLine("250-fake")
+			if !m.TLS {
+				_ = tp.PrintfLine("250-STARTTLS")
+			}
+			_ = tp.PrintfLine("250 AUTH PLAIN")
+		case "STARTTLS":
+			_ = tp.PrintfLine("220 ready")
+			tlsConn := tls.Server(conn, f.tls)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn, tp, m.TLS = tlsConn, textproto.NewConn(tlsConn), true
+		case "AUTH":
+			creds, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(arg, "PLAIN "))
+			m.Auth = string(creds)
+			_ = tp.PrintfLine("235 ok")
+		case "MAIL":
+			m.From = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			_ = tp.PrintfLine("250 ok")
+		case "RCPT":
+			to := strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			if to == f.rejectTo {
+				_ = tp.PrintfLine("550 no such user")
+				continue
+			}
+			m.To = append(m.To, to)
+			_ = tp.PrintfLine("250 ok")
+		case "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+			data, err := io.ReadAll(tp.DotReader())
+			if err != nil {
+				return
+			}
+			msg, err := mail.ReadMessage(strings.NewReader(string(data)))
+			if err != nil {
+				return
+			}
+			m.Subject, _ = new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+			body, _ := io.ReadAll(quotedprintable.NewReader(msg.Body))
+			m.Body = string(body)
+			f.mu.Lock()
+			f.mails = append(f.mails, m)
+			f.mu.Unlock()
+			_ = tp.PrintfLine("250 queued")
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+			return
+		default:
+			_ = tp.PrintfLine("502 unknown command")
+		}
+	}
+}
+
+func newTestCore(t *testing.T, cfg Config) *Core {
+	if cfg.From == "" {
+		cfg.From = "zap@example.com"
+		cfg.To = []string{"ops@example.com", "dev@example.com"}
+	}
+	core, err := New(cfg)
+	require.NoError(t, err, "Unexpected error building core.")
+	t.Cleanup(func() { _ = core.Close() })
+	core.mailer.hostname = "host1"
+	return core
+}
+
+func TestCoreDigest(t *testing.T) {
+	smtpd, clientTLS := newFakeSMTP(t, false)
+	core := newTestCore(t, Config{
+		Addr:           smtpd.Addr(),
+		TLSConfig:      clientTLS,
+		Username:       "user",
+		Password:       "pass",
+		DigestInterval: time.Hour,
+	})
+	logger := zap.New(core).Named("billing").With(zap.String("region", "eu"))
+
+	logger.Warn("not emailed")
+	logger.Error("charge failed", zap.Int("attempt", 1))
+	logger.Error("charge failed", zap.Int("attempt", 2))
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+
+	mails := smtpd.Mails()
+	require.Len(t, mails, 1, "Expected a single digest.")
+	m := mails[0]
+	assert.True(t, m.TLS, "Expected STARTTLS.")
+	assert.Equal(t, "\x00user\x00pass", m.Auth, "Expected PLAIN authentication.")
+	assert.Equal(t, "zap@example.com", m.From, "Unexpected sender.")
+	assert.Equal(t, []string{"ops@example.com", "dev@example.com"}, m.To, "Unexpected recipients.")
+	assert.Equal(t, "[zap] 2 entries on host1", m.Subject, "Unexpected subject.")
+
+	lines := strings.Split(strings.TrimSpace(m.Body), "\n")
+	require.Len(t, lines, 2, "Expected a line per entry.")
+	assert.Contains(t, lines[0], "ERROR\tbilling\tcharge failed\t{\"region\": \"eu\", \"attempt\": 1}", "Unexpected first entry.")
+	assert.Contains(t, lines[1], `"attempt": 2`, "Unexpected second entry.")
+}
+
+func TestCoreImmediate(t *testing.T) {
+	smtpd, clientTLS := newFakeSMTP(t, true)
+	core := newTestCore(t, Config{
+		Addr:           smtpd.Addr(),
+		TLS:            ImplicitTLS,
+		TLSConfig:      clientTLS,
+		SubjectPrefix:  "[prod]",
+		Immediate:      zapcore.DPanicLevel,
+		DigestInterval: time.Hour,
+	})
+	logger := zap.New(core, zap.Development())
+
+	logger.Error("pending")
+	require.Empty(t, smtpd.Mails(), "Expected errors to wait for the digest.")
+	assert.Panics(t, func() { logger.DPanic("invariant broken: ünïcode") }, "Expected development loggers to panic.")
+
+	mails := smtpd.Mails()
+	require.Len(t, mails, 2, "Expected the immediate email and the pending digest before Write returns.")
+	assert.True(t, mails[0].TLS, "Expected implicit TLS.")
+	assert.Equal(t, "[prod] DPANIC invariant broken: ünïcode on host1", mails[0].Subject, "Unexpected immediate subject.")
+	assert.Contains(t, mails[0].Body, "DPANIC\tinvariant broken: ünïcode", "Unexpected immediate body.")
+	assert.Equal(t, "[prod] 1 entry on host1", mails[1].Subject, "Unexpected digest subject.")
+}
+
+func TestCoreNoTLS(t *testing.T) {
+	smtpd, _ := newFakeSMTP(t, false)
+	core := newTestCore(t, Config{Addr: smtpd.Addr(), TLS: NoTLS, DigestInterval: time.Hour})
+	logger := zap.New(core, zap.WithFatalHook(zapcore.WriteThenPanic)).Named("db")
+
+	assert.Panics(t, func() { logger.Fatal("unreachable") }, "Expected the fatal hook to run.")
+	mails := smtpd.Mails()
+	require.Len(t, mails, 1, "Expected fatal entries to be emailed immediately.")
+	assert.False(t, mails[0].TLS, "Expected a plaintext connection.")
+	assert.Equal(t, "[zap] FATAL db: unreachable on host1", mails[0].Subject, "Unexpected subject.")
+}
+
+func TestCoreErrors(t *testing.T) {
+	smtpd, clientTLS := newFakeSMTP(t, false)
+	smtpd.rejectTo = "dev@example.com"
+	core := newTestCore(t, Config{Addr: smtpd.Addr(), TLSConfig: clientTLS, DigestInterval: time.Hour})
+
+	zap.New(core).Error("rejected")
+	err := core.Sync()
+	assert.ErrorContains(t, err, `550 "no such user"`, "Expected SMTP errors to be returned by Sync.")
+	assert.True(t, zapbatch.IsPermanent(err), "Expected 5xx errors to be permanent.")
+
+	plain, _ := newFakeSMTP(t, false)
+	core = newTestCore(t, Config{Addr: plain.Addr(), DigestInterval: time.Hour})
+	core.mailer.cfg.TLSConfig = &tls.Config{ServerName: "wrong.example.com"}
+	zap.New(core).Error("untrusted")
+	assert.Error(t, core.Sync(), "Expected certificate verification to fail.")
+
+	_, err = New(Config{Addr: "no-port", From: "a@b", To: []string{"c@d"}})
+	assert.ErrorContains(t, err, "invalid SMTP address", "Expected addresses without ports to be rejected.")
+	_, err = New(Config{Addr: "smtp:25"})
+	assert.EqualError(t, err, "missing email sender or recipients", "Expected addresses to be required.")
+}
+
+func TestMessage(t *testing.T) {
+	core := newTestCore(t, Config{Addr: "smtp:25"})
+	core.mailer.now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+	msg, err := core.mailer.message("1 entry", []byte("a line that is long enough to need wrapping when encoded as quoted-printable, which limits lines to 76 characters\n.leading dot\n"))
+	require.NoError(t, err, "Unexpected error building message.")
+
+	parsed, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(string(msg))))
+	require.NoError(t, err, "Expected a valid message.")
+	assert.Equal(t, "Wed, 01 May 2024 12:00:00 +0000", parsed.Header.Get("Date"), "Unexpected date.")
+	assert.Equal(t, "ops@example.com, dev@example.com", parsed.Header.Get("To"), "Unexpected recipients.")
+	for _, line := range strings.Split(string(msg), "\r\n") {
+		assert.LessOrEqual(t, len(line), 78, "Expected short lines.")
+	}
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	require.NoError(t, err, "Unexpected error decoding body.")
+	assert.Equal(t, "a line that is long enough to need wrapping when encoded as quoted-printable, which limits lines to 76 characters\r\n.leading dot\r\n", string(body), "Unexpected body.")
+}
@@ -0,0 +1,342 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zaphoneycomb provides a sink that sends logs to Honeycomb's
+// events API, so that each entry becomes a wide event.
+//
+// The sink accepts JSON-encoded entries, like those written by zap's
+// production configuration, and sends them to the batch endpoint with
+// compression. Every field of an entry becomes an attribute of its event,
+// and the entry's time becomes the event's time.
+//
+// Entries can carry a sample rate in a field (by default "sample_rate"):
+// an entry with a sample rate of 10 stands for 10 similar events. The rate
+// is sent to Honeycomb, which weights the event accordingly. By default the
+// sink assumes the application already sampled; with Config.Sample, the
+// sink does the sampling itself, so the application can pick a rate per
+// entry (for example, keeping every error but one in 100 successes).
+//
+// To use it in a zap.Config, register the "honeycomb" scheme:
+//
+//	zap.RegisterSink("honeycomb", zaphoneycomb.NewSink)
+//	cfg.OutputPaths = []string{"honeycomb://api.honeycomb.io/my-dataset?sample=true"}
+//
+// This package is experimental: its API may change in backwards
+// incompatible ways.
+package zaphoneycomb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+// Defaults for the zero values of Config's fields.
+const (
+	DefaultURL           = "https://api.honeycomb.io"
+	DefaultSampleRateKey = "sample_rate"
+)
+
+// Config configures a Sink.
+type Config struct {
+	// APIKey authenticates with Honeycomb. Defaults to the
+	// HONEYCOMB_API_KEY environment variable.
+	APIKey string
+
+	// Dataset names the dataset to send events to. Required.
+	Dataset string
+
+	// URL is the base URL of the API. Defaults to DefaultURL.
+	URL string
+
+	// SampleRateKey is the field holding an entry's sample rate. Defaults
+	// to DefaultSampleRateKey; set it to "-" to ignore sample rates.
+	SampleRateKey string
+
+	// Sample makes the sink keep entries with a sample rate of N with a
+	// probability of 1/N, rather than assuming the application sampled.
+	Sample bool
+
+	// DisableCompression sends request bodies uncompressed.
+	DisableCompression bool
+
+	// EncoderConfig describes the JSON the sink receives. Defaults to
+	// zap.NewProductionEncoderConfig.
+	EncoderConfig *zapcore.EncoderConfig
+
+	// Batch configures batching and retries.
+	Batch zapbatch.Config
+
+	// Client sends requests to Honeycomb. Defaults to zapbatch.DefaultClient.
+	Client *http.Client
+}
+
+// Sink is a zap.Sink that sends entries to Honeycomb as events.
+type Sink struct {
+	cfg      Config
+	batchURL string
+	timeKey  string
+	msgKey   string
+	dec      *zapcore.EntryDecoder
+	batcher  *zapbatch.Batcher
+
+	// Only accessed by the batcher's goroutine.
+	keep func(rate int) bool // whether to keep an entry with a sample rate
+	now  func() time.Time
+}
+
+var _ zap.Sink = (*Sink)(nil)
+
+// New builds a Sink and starts its background batching.
+func New(cfg Config) (*Sink, error) {
+	if cfg.APIKey == "" {
+		cfg.APIKey = os.Getenv("HONEYCOMB_API_KEY")
+	}
+	if cfg.APIKey == "" {
+		return nil, errors.New("missing Honeycomb API key")
+	}
+	if cfg.Dataset == "" {
+		return nil, errors.New("missing Honeycomb dataset")
+	}
+	if cfg.URL == "" {
+		cfg.URL = DefaultURL
+	}
+	if cfg.SampleRateKey == "" {
+		cfg.SampleRateKey = DefaultSampleRateKey
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid Honeycomb URL %q: must be an http or https URL", cfg.URL)
+	}
+	// Escape the dataset, which may contain slashes.
+	u.RawPath = strings.TrimSuffix(u.EscapedPath(), "/") + "/1/batch/" + url.PathEscape(cfg.Dataset)
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/1/batch/" + cfg.Dataset
+
+	encCfg := zap.NewProductionEncoderConfig()
+	if cfg.EncoderConfig != nil {
+		encCfg = *cfg.EncoderConfig
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	s := &Sink{
+		cfg:      cfg,
+		batchURL: u.String(),
+		timeKey:  encCfg.TimeKey,
+		msgKey:   encCfg.MessageKey,
+		dec:      zapcore.NewEntryDecoder(encCfg),
+		keep:     func(rate int) bool { return rng.Intn(rate) == 0 },
+		now:      time.Now,
+	}
+	if s.msgKey == "" {
+		s.msgKey = "msg"
+	}
+	s.batcher = zapbatch.New(s.send, cfg.Batch)
+	return s, nil
+}
+
+// NewSink builds a Sink from a URL, for use with zap.RegisterSink. The URL
+//
+//	honeycomb://:KEY@api.honeycomb.io/my-dataset?sample=true
+//
+// sends to the my-dataset dataset over HTTPS. The API key may instead be
+// given by the key parameter or the HONEYCOMB_API_KEY environment variable.
+// Besides the parameter shown, it accepts sample_rate_key, compress
+// (defaulting to true), tls (defaulting to true), and the batching
+// parameters described by zapbatch.ConfigFromParams.
+func NewSink(u *url.URL) (zap.Sink, error) {
+	params := zap.NewSinkParams(u)
+	cfg := Config{
+		APIKey:             params.String("key", ""),
+		Dataset:            strings.Trim(u.Path, "/"),
+		SampleRateKey:      params.String("sample_rate_key", ""),
+		Sample:             params.Bool("sample", false),
+		DisableCompression: !params.Bool("compress", true),
+		Batch:              zapbatch.ConfigFromParams(params),
+	}
+	scheme := "https"
+	if !params.Bool("tls", true) {
+		scheme = "http"
+	}
+	if err := params.Err(); err != nil {
+		return nil, fmt.Errorf("invalid Honeycomb sink URL %v: %v", u.Redacted(), err)
+	}
+
+	if key, ok := u.User.Password(); ok {
+		cfg.APIKey = key
+	}
+	cfg.URL = (&url.URL{Scheme: scheme, Host: u.Host}).String()
+	return New(cfg)
+}
+
+// Write queues each line of p to be sent to Honeycomb.
+func (s *Sink) Write(p []byte) (int, error) {
+	var err error
+	for _, line := range bytes.Split(p, []byte{'\n'}) {
+		if len(line) > 0 {
+			err = multierr.Append(err, s.batcher.Add(line))
+		}
+	}
+	return len(p), err
+}
+
+// Sync sends all queued entries, returning any errors sending entries since
+// the last Sync.
+func (s *Sink) Sync() error {
+	return s.batcher.Flush()
+}
+
+// Close sends all queued entries and stops the sink.
+func (s *Sink) Close() error {
+	return s.batcher.Close()
+}
+
+type event struct {
+	Time       string                     `json:"time"`
+	SampleRate int                        `json:"samplerate,omitempty"`
+	Data       map[string]json.RawMessage `json:"data"`
+}
+
+type eventStatus struct {
+	Status int    `json:"status"`
+	Error  string `json:"error"`
+}
+
+func (s *Sink) send(batch [][]byte) error {
+	now := s.now()
+	events := make([]event, 0, len(batch))
+	for _, line := range batch {
+		if ev, ok := s.event(line, now); ok {
+			events = append(events, ev)
+		}
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	body, err := s.encode(events)
+	if err != nil {
+		return zapbatch.Permanent(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, s.batchURL, bytes.NewReader(body))
+	if err != nil {
+		return zapbatch.Permanent(err)
+	}
+	req.Header.Set("X-Honeycomb-Team", s.cfg.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+	if !s.cfg.DisableCompression {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	client := s.cfg.Client
+	if client == nil {
+		client = zapbatch.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := zapbatch.CheckResponse(resp); err != nil {
+		return err
+	}
+
+	// The batch endpoint reports the status of each event. Rejected events
+	// won't be accepted on retry, so don't resend the batch.
+	var statuses []eventStatus
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(respBody, &statuses); err != nil {
+		return nil // not worth resending accepted events
+	}
+	var rejected int
+	var firstErr string
+	for _, st := range statuses {
+		if st.Status < 200 || st.Status >= 300 {
+			if rejected == 0 {
+				firstErr = fmt.Sprintf("%d %s", st.Status, st.Error)
+			}
+			rejected++
+		}
+	}
+	if rejected > 0 {
+		return zapbatch.Permanent(fmt.Errorf("%d of %d events rejected by Honeycomb, first: %s", rejected, len(events), firstErr))
+	}
+	return nil
+}
+
+func (s *Sink) encode(events []event) ([]byte, error) {
+	var buf bytes.Buffer
+	if s.cfg.DisableCompression {
+		err := json.NewEncoder(&buf).Encode(events)
+		return buf.Bytes(), err
+	}
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(events); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// event converts a line into an event, reporting false if it's sampled
+// out. Lines that aren't JSON objects are sent as a message attribute,
+// timestamped now.
+func (s *Sink) event(line []byte, now time.Time) (event, bool) {
+	ev := event{Time: now.UTC().Format(time.RFC3339Nano)}
+	if err := json.Unmarshal(line, &ev.Data); err != nil || ev.Data == nil {
+		msg, _ := json.Marshal(string(line))
+		ev.Data = map[string]json.RawMessage{s.msgKey: msg}
+		return ev, true
+	}
+
+	if ent, _, err := s.dec.DecodeJSON(line); err == nil && !ent.Time.IsZero() {
+		ev.Time = ent.Time.UTC().Format(time.RFC3339Nano)
+		delete(ev.Data, s.timeKey)
+	}
+	if raw, ok := ev.Data[s.cfg.SampleRateKey]; ok && s.cfg.SampleRateKey != "-" {
+		if rate, err := strconv.Atoi(string(raw)); err == nil && rate > 0 {
+			delete(ev.Data, s.cfg.SampleRateKey)
+			ev.SampleRate = rate
+			if s.cfg.Sample && rate > 1 && !s.keep(rate) {
+				return event{}, false
+			}
+		}
+	}
+	return ev, true
+}
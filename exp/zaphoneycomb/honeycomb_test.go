@@ -0,0 +1,285 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaphoneycomb
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeHoneycomb records events sent to the batch endpoint.
+type fakeHoneycomb struct {
+	mu      sync.Mutex
+	events  []map[string]interface{}
+	headers []http.Header
+	paths   []string
+	reject  bool
+}
+
+func (f *fakeHoneycomb) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = gz
+	}
+	var events []map[string]interface{}
+	if err := json.NewDecoder(body).Decode(&events); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, events...)
+	f.headers = append(f.headers, r.Header)
+	f.paths = append(f.paths, r.URL.EscapedPath())
+
+	statuses := make([]map[string]interface{}, len(events))
+	for i := range statuses {
+		statuses[i] = map[string]interface{}{"status": 202}
+	}
+	if f.reject {
+		statuses[0] = map[string]interface{}{"status": 400, "error": "bad event"}
+	}
+	_ = json.NewEncoder(w).Encode(statuses)
+}
+
+func (f *fakeHoneycomb) Events() []map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]map[string]interface{}(nil), f.events...)
+}
+
+func newTestLogger(sink zap.Sink) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	return zap.New(zapcore.NewCore(enc, sink, zap.DebugLevel))
+}
+
+func TestSinkSends(t *testing.T) {
+	tests := []struct {
+		desc     string
+		compress bool
+	}{
+		{desc: "compressed", compress: true},
+		{desc: "uncompressed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			hc := &fakeHoneycomb{}
+			srv := httptest.NewServer(hc)
+			defer srv.Close()
+
+			sink, err := New(Config{
+				APIKey:             "key",
+				Dataset:            "my/data",
+				URL:                srv.URL,
+				DisableCompression: !tt.compress,
+				Batch:              zapbatch.Config{FlushInterval: time.Hour},
+			})
+			require.NoError(t, err, "Unexpected error building sink.")
+			defer sink.Close()
+
+			newTestLogger(sink).Named("http").Info("request",
+				zap.String("path", "/users"),
+				zap.Int("status", 200),
+				zap.Int("sample_rate", 20),
+				zap.Object("user", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+					enc.AddString("id", "u1")
+					return nil
+				})),
+			)
+			_, err = sink.Write([]byte("plain text\n"))
+			require.NoError(t, err, "Unexpected error writing.")
+			require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+
+			require.Len(t, hc.headers, 1, "Expected a single batch.")
+			assert.Equal(t, "key", hc.headers[0].Get("X-Honeycomb-Team"), "Expected the API key header.")
+			assert.Equal(t, tt.compress, hc.headers[0].Get("Content-Encoding") == "gzip", "Unexpected compression.")
+			assert.Equal(t, "/1/batch/my%2Fdata", hc.paths[0], "Unexpected dataset path.")
+
+			events := hc.Events()
+			require.Len(t, events, 2, "Expected an event per line.")
+			assert.Equal(t, float64(20), events[0]["samplerate"], "Expected the sample rate to be sent.")
+			assert.Equal(t, map[string]interface{}{
+				"level":  "info",
+				"logger": "http",
+				"msg":    "request",
+				"path":   "/users",
+				"status": float64(200),
+				"user":   map[string]interface{}{"id": "u1"},
+			}, events[0]["data"], "Expected fields as attributes, without time or sample rate.")
+			ts, err := time.Parse(time.RFC3339Nano, events[0]["time"].(string))
+			require.NoError(t, err, "Expected an RFC 3339 event time.")
+			assert.WithinDuration(t, time.Now(), ts, time.Minute, "Unexpected event time.")
+
+			assert.Equal(t, map[string]interface{}{"msg": "plain text"}, events[1]["data"], "Expected non-JSON lines as messages.")
+			assert.NotContains(t, events[1], "samplerate", "Expected no sample rate by default.")
+		})
+	}
+}
+
+func TestEvent(t *testing.T) {
+	sink, err := New(Config{APIKey: "k", Dataset: "d", Sample: true})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	var rates []int
+	sink.keep = func(rate int) bool {
+		rates = append(rates, rate)
+		return rate < 100
+	}
+	now := time.Unix(0, 0)
+
+	tests := []struct {
+		desc     string
+		give     string
+		wantKeep bool
+		wantRate int
+		wantData map[string]json.RawMessage
+		wantTime string
+	}{
+		{
+			desc:     "kept",
+			give:     `{"ts":1.5,"msg":"a","sample_rate":10}`,
+			wantKeep: true,
+			wantRate: 10,
+			wantData: map[string]json.RawMessage{"msg": json.RawMessage(`"a"`)},
+			wantTime: "1970-01-01T00:00:01.5Z",
+		},
+		{
+			desc: "sampled out",
+			give: `{"msg":"b","sample_rate":100}`,
+		},
+		{
+			desc:     "rate of one",
+			give:     `{"msg":"c","sample_rate":1}`,
+			wantKeep: true,
+			wantRate: 1,
+			wantData: map[string]json.RawMessage{"msg": json.RawMessage(`"c"`)},
+			wantTime: "1970-01-01T00:00:00Z",
+		},
+		{
+			desc:     "invalid rate",
+			give:     `{"msg":"d","sample_rate":"high"}`,
+			wantKeep: true,
+			wantData: map[string]json.RawMessage{"msg": json.RawMessage(`"d"`), "sample_rate": json.RawMessage(`"high"`)},
+			wantTime: "1970-01-01T00:00:00Z",
+		},
+		{
+			desc:     "null",
+			give:     `null`,
+			wantKeep: true,
+			wantData: map[string]json.RawMessage{"msg": json.RawMessage(`"null"`)},
+			wantTime: "1970-01-01T00:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ev, ok := sink.event([]byte(tt.give), now)
+			require.Equal(t, tt.wantKeep, ok, "Unexpected sampling decision.")
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.wantRate, ev.SampleRate, "Unexpected sample rate.")
+			assert.Equal(t, tt.wantData, ev.Data, "Unexpected data.")
+			assert.Equal(t, tt.wantTime, ev.Time, "Unexpected time.")
+		})
+	}
+	assert.Equal(t, []int{10, 100}, rates, "Expected only rates above one to be sampled.")
+}
+
+func TestSinkErrors(t *testing.T) {
+	hc := &fakeHoneycomb{reject: true}
+	srv := httptest.NewServer(hc)
+	defer srv.Close()
+
+	sink, err := New(Config{APIKey: "k", Dataset: "d", URL: srv.URL, Batch: zapbatch.Config{FlushInterval: time.Hour}})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	newTestLogger(sink).Info("one")
+	newTestLogger(sink).Info("two")
+	err = sink.Sync()
+	assert.EqualError(t, err, "1 of 2 events rejected by Honeycomb, first: 400 bad event", "Expected rejected events to be reported.")
+	assert.Len(t, hc.Events(), 2, "Expected rejected events not to be retried.")
+
+	t.Setenv("HONEYCOMB_API_KEY", "")
+	_, err = New(Config{Dataset: "d"})
+	assert.EqualError(t, err, "missing Honeycomb API key", "Expected an API key to be required.")
+	_, err = New(Config{APIKey: "k"})
+	assert.EqualError(t, err, "missing Honeycomb dataset", "Expected a dataset to be required.")
+	_, err = New(Config{APIKey: "k", Dataset: "d", URL: "ftp://honeycomb"})
+	assert.ErrorContains(t, err, "must be an http or https URL", "Expected non-HTTP URLs to be rejected.")
+}
+
+func TestNewSink(t *testing.T) {
+	hc := &fakeHoneycomb{}
+	srv := httptest.NewServer(hc)
+	defer srv.Close()
+
+	u, err := url.Parse("honeycomb://:secret@" + srv.Listener.Addr().String() + "/logs?tls=false&compress=false&sample_rate_key=rate&flush=1h")
+	require.NoError(t, err, "Failed to parse URL.")
+	sink, err := NewSink(u)
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	newTestLogger(sink).Info("hi", zap.Int("rate", 5))
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+
+	events := hc.Events()
+	require.Len(t, events, 1, "Expected one event.")
+	assert.Equal(t, "secret", hc.headers[0].Get("X-Honeycomb-Team"), "Expected the key from the URL.")
+	assert.Empty(t, hc.headers[0].Get("Content-Encoding"), "Expected compression to be disabled.")
+	assert.Equal(t, "/1/batch/logs", hc.paths[0], "Unexpected dataset.")
+	assert.Equal(t, float64(5), events[0]["samplerate"], "Expected the custom sample rate key.")
+
+	t.Setenv("HONEYCOMB_API_KEY", "env-key")
+	u, err = url.Parse("honeycomb://api.honeycomb.io/logs")
+	require.NoError(t, err, "Failed to parse URL.")
+	sink, err = NewSink(u)
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+	assert.Equal(t, "https://api.honeycomb.io/1/batch/logs", sink.(*Sink).batchURL, "Expected HTTPS by default.")
+
+	u, err = url.Parse("honeycomb://api.honeycomb.io/logs?sample=maybe")
+	require.NoError(t, err, "Failed to parse URL.")
+	_, err = NewSink(u)
+	assert.Error(t, err, "Expected invalid parameters to be rejected.")
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapk8s provides zap fields that identify Kubernetes objects, for
+// operator authors using zap directly. The fields use the namespace and name
+// keys that controller-runtime adds to its reconciler loggers, so logs from
+// both look the same.
+//
+// The package doesn't import the Kubernetes libraries: Object works with any
+// type that has the accessors of metav1.Object, and NamespacedName with any
+// struct shaped like types.NamespacedName.
+//
+// This package is experimental: its API may change in backwards incompatible
+// ways.
+package zapk8s // import "go.uber.org/zap/exp/zapk8s"
+
+import (
+	"reflect"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// A NamedObject is a Kubernetes object. All API types satisfy it through
+// their embedded ObjectMeta.
+type NamedObject interface {
+	GetNamespace() string
+	GetName() string
+}
+
+// Object constructs a field that adds the kind, namespace, name, and uid
+// of a Kubernetes object to the logging context. The namespace is omitted
+// for cluster-scoped objects.
+//
+// The kind comes from the object's TypeMeta or, for unstructured objects,
+// its GetKind method. Typed objects read from the API server often have an
+// empty TypeMeta, so the Go type name is used in that case. The uid comes
+// from the object's ObjectMeta and is omitted if it isn't set.
+func Object(obj NamedObject) zap.Field {
+	if obj == nil || isNilPointer(obj) {
+		return zap.Skip()
+	}
+	return zap.Inline(objectRef{
+		kind:      kindOf(obj),
+		namespace: obj.GetNamespace(),
+		name:      obj.GetName(),
+		uid:       stringField(obj, "UID"),
+	})
+}
+
+// NamespacedName constructs a field that adds the namespace and name of a
+// types.NamespacedName, such as a reconcile.Request, to the logging context.
+func NamespacedName[N ~struct {
+	Namespace string
+	Name      string
+}](nn N) zap.Field {
+	v := struct {
+		Namespace string
+		Name      string
+	}(nn)
+	return zap.Inline(objectRef{namespace: v.Namespace, name: v.Name})
+}
+
+type objectRef struct {
+	kind      string
+	namespace string
+	name      string
+	uid       string
+}
+
+func (r objectRef) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if r.kind != "" {
+		enc.AddString("kind", r.kind)
+	}
+	if r.namespace != "" {
+		enc.AddString("namespace", r.namespace)
+	}
+	enc.AddString("name", r.name)
+	if r.uid != "" {
+		enc.AddString("uid", r.uid)
+	}
+	return nil
+}
+
+func kindOf(obj NamedObject) string {
+	if kind := stringField(obj, "Kind"); kind != "" {
+		return kind
+	}
+	if k, ok := obj.(interface{ GetKind() string }); ok {
+		if kind := k.GetKind(); kind != "" {
+			return kind
+		}
+	}
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// stringField returns the named string-kinded field of obj's underlying
+// struct, including fields promoted from embedded structs like TypeMeta
+// and ObjectMeta. It returns "" if there's no such field.
+func stringField(obj interface{}, name string) string {
+	v := reflect.Indirect(reflect.ValueOf(obj))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	f := v.FieldByName(name)
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
+}
+
+func isNilPointer(obj interface{}) bool {
+	v := reflect.ValueOf(obj)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
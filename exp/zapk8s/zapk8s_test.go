@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapk8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+// Minimal stand-ins for the Kubernetes API machinery types.
+type (
+	UID string
+
+	TypeMeta struct {
+		Kind       string
+		APIVersion string
+	}
+
+	ObjectMeta struct {
+		Name      string
+		Namespace string
+		UID       UID
+	}
+
+	Pod struct {
+		TypeMeta
+		ObjectMeta
+	}
+
+	Unstructured struct {
+		Object map[string]interface{}
+	}
+
+	namespacedName struct {
+		Namespace string
+		Name      string
+	}
+)
+
+func (m *ObjectMeta) GetName() string      { return m.Name }
+func (m *ObjectMeta) GetNamespace() string { return m.Namespace }
+func (m *ObjectMeta) GetUID() UID          { return m.UID }
+
+func (u *Unstructured) meta() map[string]interface{} {
+	m, _ := u.Object["metadata"].(map[string]interface{})
+	return m
+}
+
+func (u *Unstructured) GetKind() string      { s, _ := u.Object["kind"].(string); return s }
+func (u *Unstructured) GetName() string      { s, _ := u.meta()["name"].(string); return s }
+func (u *Unstructured) GetNamespace() string { s, _ := u.meta()["namespace"].(string); return s }
+
+func TestObject(t *testing.T) {
+	var nilPod *Pod
+
+	tests := []struct {
+		desc string
+		give NamedObject
+		want map[string]interface{}
+	}{
+		{
+			desc: "typed",
+			give: &Pod{
+				TypeMeta:   TypeMeta{Kind: "Pod", APIVersion: "v1"},
+				ObjectMeta: ObjectMeta{Namespace: "default", Name: "web-0", UID: "1234"},
+			},
+			want: map[string]interface{}{"kind": "Pod", "namespace": "default", "name": "web-0", "uid": "1234"},
+		},
+		{
+			desc: "empty TypeMeta",
+			give: &Pod{ObjectMeta: ObjectMeta{Namespace: "default", Name: "web-0"}},
+			want: map[string]interface{}{"kind": "Pod", "namespace": "default", "name": "web-0"},
+		},
+		{
+			desc: "cluster-scoped",
+			give: &Pod{TypeMeta: TypeMeta{Kind: "Node"}, ObjectMeta: ObjectMeta{Name: "node-1", UID: "5678"}},
+			want: map[string]interface{}{"kind": "Node", "name": "node-1", "uid": "5678"},
+		},
+		{
+			desc: "unstructured",
+			give: &Unstructured{Object: map[string]interface{}{
+				"kind":     "Widget",
+				"metadata": map[string]interface{}{"namespace": "ns", "name": "w"},
+			}},
+			want: map[string]interface{}{"kind": "Widget", "namespace": "ns", "name": "w"},
+		},
+		{
+			desc: "nil",
+			give: nilPod,
+			want: map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := zapcore.NewMapObjectEncoder()
+			Object(tt.give).AddTo(enc)
+			assert.Equal(t, tt.want, enc.Fields, "Unexpected object fields.")
+		})
+	}
+}
+
+func TestNamespacedName(t *testing.T) {
+	enc := zapcore.NewMapObjectEncoder()
+	NamespacedName(namespacedName{Namespace: "default", Name: "web-0"}).AddTo(enc)
+	assert.Equal(t, map[string]interface{}{"namespace": "default", "name": "web-0"}, enc.Fields, "Unexpected namespaced name fields.")
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaploki
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeLoki records the push requests it receives.
+type fakeLoki struct {
+	mu       sync.Mutex
+	requests []pushRequest
+	headers  []http.Header
+	status   int
+}
+
+func (f *fakeLoki) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/prefix/loki/api/v1/push" {
+		http.NotFound(w, r)
+		return
+	}
+	var req pushRequest
+	body, _ := io.ReadAll(r.Body)
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	f.headers = append(f.headers, r.Header)
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeLoki) Requests() []pushRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]pushRequest(nil), f.requests...)
+}
+
+func newTestLogger(t testing.TB, sink zap.Sink) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	return zap.New(zapcore.NewCore(enc, sink, zap.DebugLevel))
+}
+
+func TestSinkPushes(t *testing.T) {
+	loki := &fakeLoki{}
+	srv := httptest.NewServer(loki)
+	defer srv.Close()
+
+	sink, err := New(Config{
+		URL:         srv.URL + "/prefix/",
+		TenantID:    "acme",
+		Labels:      map[string]string{"app": "api", "bad-name": "x"},
+		LabelFields: []string{"region", "missing"},
+		Batch:       zapbatch.Config{FlushInterval: time.Hour},
+	})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	logger := newTestLogger(t, sink)
+	logger.Named("db").Info("first", zap.String("region", "us"))
+	logger.Named("db").Info("second", zap.String("region", "us"))
+	logger.Warn("third")
+	_, err = sink.Write([]byte("not json\n"))
+	require.NoError(t, err, "Unexpected error writing.")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+
+	reqs := loki.Requests()
+	require.Len(t, reqs, 1, "Expected a single batched push.")
+	assert.Equal(t, "acme", loki.headers[0].Get("X-Scope-OrgID"), "Expected tenant header.")
+
+	streams := reqs[0].Streams
+	require.Len(t, streams, 3, "Expected entries to be grouped by labels.")
+	assert.Equal(t, map[string]string{
+		"app": "api", "bad_name": "x", "level": "info", "logger": "db", "region": "us",
+	}, streams[0].Labels, "Unexpected labels for the first stream.")
+	require.Len(t, streams[0].Values, 2, "Expected two entries in the first stream.")
+	assert.Contains(t, streams[0].Values[0][1], `"msg":"first"`, "Expected the original line.")
+	assert.Contains(t, streams[0].Values[1][1], `"msg":"second"`, "Expected the original line.")
+
+	assert.Equal(t, map[string]string{"app": "api", "bad_name": "x", "level": "warn"}, streams[1].Labels, "Unexpected labels for the second stream.")
+	assert.Equal(t, map[string]string{"app": "api", "bad_name": "x"}, streams[2].Labels, "Expected undecodable lines to get only static labels.")
+	assert.Equal(t, "not json", streams[2].Values[0][1], "Unexpected undecodable line.")
+}
+
+func TestBuildRequestSortsStreams(t *testing.T) {
+	sink, err := New(Config{URL: "http://loki", LevelLabel: "-", NameLabel: "-"})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	req := sink.buildRequest([][]byte{
+		[]byte(`{"level":"info","ts":2,"logger":"a","msg":"later"}`),
+		[]byte(`{"level":"error","ts":1,"logger":"b","msg":"earlier"}`),
+	}, time.Now())
+	require.Len(t, req.Streams, 1, "Expected omitted labels to merge streams.")
+	assert.Equal(t, [][2]string{
+		{"1000000000", `{"level":"error","ts":1,"logger":"b","msg":"earlier"}`},
+		{"2000000000", `{"level":"info","ts":2,"logger":"a","msg":"later"}`},
+	}, req.Streams[0].Values, "Expected entries to be sorted by time.")
+}
+
+func TestSinkErrors(t *testing.T) {
+	loki := &fakeLoki{status: http.StatusBadRequest}
+	srv := httptest.NewServer(loki)
+	defer srv.Close()
+
+	sink, err := New(Config{URL: srv.URL + "/prefix", Batch: zapbatch.Config{FlushInterval: time.Hour}})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	newTestLogger(t, sink).Info("rejected")
+	assert.ErrorContains(t, sink.Sync(), "400 Bad Request", "Expected push errors to be returned by Sync.")
+	assert.Len(t, loki.Requests(), 1, "Expected client errors not to be retried.")
+
+	_, err = New(Config{URL: "ftp://loki"})
+	assert.ErrorContains(t, err, "scheme must be http or https", "Expected non-HTTP URLs to be rejected.")
+}
+
+func TestNewSink(t *testing.T) {
+	loki := &fakeLoki{}
+	srv := httptest.NewServer(loki)
+	defer srv.Close()
+
+	u, err := url.Parse("loki://" + srv.Listener.Addr().String() + "/prefix?tenant=t1&labels=app=api&label_fields=k&level_label=severity&flush=1h")
+	require.NoError(t, err, "Failed to parse URL.")
+	sink, err := NewSink(u)
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	newTestLogger(t, sink).Info("hi", zap.Int("k", 1))
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+
+	reqs := loki.Requests()
+	require.Len(t, reqs, 1, "Expected one push.")
+	assert.Equal(t, map[string]string{"app": "api", "severity": "info", "k": "1"}, reqs[0].Streams[0].Labels, "Unexpected labels.")
+
+	for _, bad := range []string{"loki://host?labels=novalue", "loki://host?flush=soon"} {
+		u, err := url.Parse(bad)
+		require.NoError(t, err, "Failed to parse URL.")
+		_, err = NewSink(u)
+		assert.Error(t, err, "Expected %q to be rejected.", bad)
+	}
+}
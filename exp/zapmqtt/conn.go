@@ -0,0 +1,353 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapmqtt
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sync"
+	"time"
+)
+
+// MQTT 3.1.1 control packet types.
+const (
+	_connect    = 1
+	_connack    = 2
+	_publish    = 3
+	_puback     = 4
+	_pingreq    = 12
+	_pingresp   = 13
+	_disconnect = 14
+)
+
+var _connackErrors = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "client identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+var (
+	errConnClosed = errors.New("MQTT connection closed")
+	errNoIDs      = errors.New("too many unacknowledged MQTT messages")
+)
+
+// conn is a connection to an MQTT broker. Publishing is safe for concurrent
+// use; a background goroutine reads acknowledgements and another keeps the
+// connection alive.
+type conn struct {
+	nc net.Conn
+
+	br *bufio.Reader // only used by the handshake, then readLoop
+
+	writeMu sync.Mutex
+	bw      *bufio.Writer
+
+	mu     sync.Mutex
+	nextID uint16
+	acks   map[uint16]chan struct{}
+	err    error
+
+	done chan struct{}
+}
+
+// dial connects to the broker and completes the MQTT handshake.
+func dial(cfg *Config) (*conn, error) {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	var (
+		nc  net.Conn
+		err error
+	)
+	if cfg.TLSConfig != nil {
+		nc, err = tls.DialWithDialer(dialer, "tcp", cfg.Addr, cfg.TLSConfig)
+	} else {
+		nc, err = dialer.Dial("tcp", cfg.Addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &conn{
+		nc:   nc,
+		br:   bufio.NewReader(nc),
+		bw:   bufio.NewWriter(nc),
+		acks: make(map[uint16]chan struct{}),
+		done: make(chan struct{}),
+	}
+	if err := c.handshake(cfg); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	go c.readLoop()
+	go c.pingLoop(cfg.KeepAlive)
+	return c, nil
+}
+
+func (c *conn) handshake(cfg *Config) error {
+	if err := c.nc.SetDeadline(time.Now().Add(cfg.Timeout)); err != nil {
+		return err
+	}
+
+	var flags byte = 0x02 // clean session
+	var payload []byte
+	payload = appendString(payload, cfg.ClientID)
+	if w := cfg.Will; w != nil {
+		flags |= 0x04 | w.QoS<<3
+		if w.Retain {
+			flags |= 0x20
+		}
+		payload = appendString(payload, w.Topic)
+		payload = appendString(payload, string(w.Payload))
+	}
+	if cfg.Username != "" {
+		flags |= 0x80
+		payload = appendString(payload, cfg.Username)
+	}
+	if cfg.Password != "" {
+		flags |= 0x40
+		payload = appendString(payload, cfg.Password)
+	}
+
+	var body []byte
+	body = appendString(body, "MQTT")
+	body = append(body, 4, flags) // protocol level 4 is MQTT 3.1.1
+	body = binary.BigEndian.AppendUint16(body, uint16(cfg.KeepAlive/time.Second))
+	body = append(body, payload...)
+	if err := c.writePacket(_connect<<4, body); err != nil {
+		return err
+	}
+
+	typ, resp, err := readPacket(c.br)
+	if err != nil {
+		return fmt.Errorf("reading MQTT CONNACK: %w", err)
+	}
+	if typ>>4 != _connack || len(resp) != 2 {
+		return fmt.Errorf("unexpected MQTT packet type %d during handshake", typ>>4)
+	}
+	if rc := resp[1]; rc != 0 {
+		reason, ok := _connackErrors[rc]
+		if !ok {
+			reason = fmt.Sprintf("return code %d", rc)
+		}
+		return fmt.Errorf("MQTT broker refused connection: %s", reason)
+	}
+	return c.nc.SetDeadline(time.Time{})
+}
+
+// publish sends a message. For QoS 1, the returned channel is closed when
+// the broker acknowledges it; for QoS 0 it's nil.
+func (c *conn) publish(topic string, payload []byte, qos byte, retain bool) (<-chan struct{}, error) {
+	header := byte(_publish<<4) | qos<<1
+	if retain {
+		header |= 0x01
+	}
+	body := appendString(make([]byte, 0, 2+len(topic)+2+len(payload)), topic)
+
+	var ack chan struct{}
+	if qos > 0 {
+		c.mu.Lock()
+		if c.err != nil {
+			c.mu.Unlock()
+			return nil, c.err
+		}
+		if len(c.acks) >= math.MaxUint16 {
+			c.mu.Unlock()
+			return nil, errNoIDs
+		}
+		// Skip zero, which isn't a valid packet ID, and IDs of messages
+		// that are still waiting for acknowledgement.
+		for {
+			c.nextID++
+			if _, inFlight := c.acks[c.nextID]; c.nextID != 0 && !inFlight {
+				break
+			}
+		}
+		id := c.nextID
+		ack = make(chan struct{})
+		c.acks[id] = ack
+		c.mu.Unlock()
+		body = binary.BigEndian.AppendUint16(body, id)
+	}
+	body = append(body, payload...)
+	return ack, c.writePacket(header, body)
+}
+
+// flush writes buffered packets to the network.
+func (c *conn) flush() error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := c.bw.Flush(); err != nil {
+		c.fail(err)
+		return err
+	}
+	return nil
+}
+
+func (c *conn) writePacket(header byte, body []byte) error {
+	if len(body) > _maxRemainingLength {
+		return fmt.Errorf("MQTT packet of %d bytes is too large", len(body))
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	var fixed [5]byte
+	fixed[0] = header
+	n := 1 + putRemainingLength(fixed[1:], len(body))
+	if _, err := c.bw.Write(fixed[:n]); err != nil {
+		c.fail(err)
+		return err
+	}
+	if _, err := c.bw.Write(body); err != nil {
+		c.fail(err)
+		return err
+	}
+	if header>>4 != _publish {
+		// Publishes are flushed in batches; everything else goes right away.
+		if err := c.bw.Flush(); err != nil {
+			c.fail(err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *conn) readLoop() {
+	for {
+		typ, body, err := readPacket(c.br)
+		if err != nil {
+			c.fail(err)
+			return
+		}
+		if typ>>4 == _puback && len(body) == 2 {
+			id := binary.BigEndian.Uint16(body)
+			c.mu.Lock()
+			if ack, ok := c.acks[id]; ok {
+				close(ack)
+				delete(c.acks, id)
+			}
+			c.mu.Unlock()
+		}
+		// PINGRESPs need no handling: any traffic keeps the read going.
+	}
+}
+
+func (c *conn) pingLoop(keepAlive time.Duration) {
+	if keepAlive <= 0 {
+		return
+	}
+	ticker := time.NewTicker(keepAlive / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if c.writePacket(_pingreq<<4, nil) != nil {
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// fail closes the connection after an error. The first error is kept.
+func (c *conn) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	close(c.done)
+	c.nc.Close()
+}
+
+// Err returns the error that closed the connection, if any.
+func (c *conn) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// close disconnects gracefully, so that the broker doesn't publish the
+// will message.
+func (c *conn) close() error {
+	err := c.writePacket(_disconnect<<4, nil)
+	c.fail(errConnClosed)
+	return err
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+// putRemainingLength encodes n in MQTT's variable-length format, returning
+// the number of bytes used.
+func putRemainingLength(b []byte, n int) int {
+	i := 0
+	for {
+		digit := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			digit |= 0x80
+		}
+		b[i] = digit
+		i++
+		if n == 0 {
+			return i
+		}
+	}
+}
+
+// _maxRemainingLength is the largest length MQTT can encode.
+const _maxRemainingLength = 268435455
+
+func readPacket(r *bufio.Reader) (header byte, body []byte, err error) {
+	if header, err = r.ReadByte(); err != nil {
+		return 0, nil, err
+	}
+	n, mult := 0, 1
+	for i := 0; ; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		n += int(b&0x7f) * mult
+		if b&0x80 == 0 {
+			break
+		}
+		if i == 3 {
+			return 0, nil, errors.New("malformed MQTT remaining length")
+		}
+		mult *= 128
+	}
+	body = make([]byte, n)
+	_, err = io.ReadFull(r, body)
+	return header, body, err
+}
@@ -0,0 +1,366 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapmqtt
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"net"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+type connectPacket struct {
+	ClientID  string
+	Username  string
+	Password  string
+	KeepAlive uint16
+	Will      *Will
+}
+
+type publishPacket struct {
+	Topic   string
+	Payload string
+	QoS     byte
+	Retain  bool
+}
+
+// fakeBroker is a minimal MQTT broker.
+type fakeBroker struct {
+	ln         net.Listener
+	returnCode byte
+	noAcks     bool
+
+	mu          sync.Mutex
+	connects    []connectPacket
+	publishes   []publishPacket
+	pings       int
+	disconnects int
+	conns       []net.Conn
+}
+
+func newFakeBroker(t *testing.T) *fakeBroker {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "Failed to listen.")
+	b := &fakeBroker{ln: ln}
+	t.Cleanup(func() {
+		ln.Close()
+		b.dropAll()
+	})
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			b.mu.Lock()
+			b.conns = append(b.conns, c)
+			b.mu.Unlock()
+			go b.handle(c)
+		}
+	}()
+	return b
+}
+
+func (b *fakeBroker) Addr() string { return b.ln.Addr().String() }
+
+// dropAll closes every client connection, as if the broker restarted.
+func (b *fakeBroker) dropAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, c := range b.conns {
+		c.Close()
+	}
+	b.conns = nil
+}
+
+func (b *fakeBroker) Publishes() []publishPacket {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]publishPacket(nil), b.publishes...)
+}
+
+func (b *fakeBroker) Connects() []connectPacket {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]connectPacket(nil), b.connects...)
+}
+
+func readString(b []byte) (string, []byte) {
+	n := binary.BigEndian.Uint16(b)
+	return string(b[2 : 2+n]), b[2+n:]
+}
+
+func (b *fakeBroker) handle(c net.Conn) {
+	defer c.Close()
+	r := bufio.NewReader(c)
+	for {
+		header, body, err := readPacket(r)
+		if err != nil {
+			return
+		}
+		b.mu.Lock()
+		switch header >> 4 {
+		case _connect:
+			var cp connectPacket
+			_, rest := readString(body) // protocol name
+			flags := rest[1]
+			cp.KeepAlive = binary.BigEndian.Uint16(rest[2:])
+			cp.ClientID, rest = readString(rest[4:])
+			if flags&0x04 != 0 {
+				cp.Will = &Will{QoS: flags >> 3 & 0x03, Retain: flags&0x20 != 0}
+				var payload string
+				cp.Will.Topic, rest = readString(rest)
+				payload, rest = readString(rest)
+				cp.Will.Payload = []byte(payload)
+			}
+			if flags&0x80 != 0 {
+				cp.Username, rest = readString(rest)
+			}
+			if flags&0x40 != 0 {
+				cp.Password, _ = readString(rest)
+			}
+			b.connects = append(b.connects, cp)
+			_, _ = c.Write([]byte{_connack << 4, 2, 0, b.returnCode})
+		case _publish:
+			pp := publishPacket{QoS: header >> 1 & 0x03, Retain: header&0x01 != 0}
+			var rest []byte
+			pp.Topic, rest = readString(body)
+			if pp.QoS > 0 {
+				id := rest[:2]
+				rest = rest[2:]
+				if !b.noAcks {
+					_, _ = c.Write(append([]byte{_puback << 4, 2}, id...))
+				}
+			}
+			pp.Payload = string(rest)
+			b.publishes = append(b.publishes, pp)
+		case _pingreq:
+			b.pings++
+			_, _ = c.Write([]byte{_pingresp << 4, 0})
+		case _disconnect:
+			b.disconnects++
+		}
+		b.mu.Unlock()
+	}
+}
+
+func newTestLogger(sink zap.Sink) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	return zap.New(zapcore.NewCore(enc, sink, zap.DebugLevel))
+}
+
+func TestSinkPublishes(t *testing.T) {
+	broker := newFakeBroker(t)
+	sink, err := New(Config{
+		Addr:     broker.Addr(),
+		ClientID: "agent-7",
+		Username: "user",
+		Password: "pass",
+		Topic:    "fleet/agent-7/{logger}/{level}",
+		QoS:      1,
+		Retain:   true,
+		Will:     &Will{Topic: "fleet/agent-7/status", Payload: []byte("offline"), QoS: 1, Retain: true},
+		Batch:    zapbatch.Config{FlushInterval: time.Hour},
+	})
+	require.NoError(t, err, "Unexpected error building sink.")
+
+	logger := newTestLogger(sink)
+	logger.Named("gps").Warn("no fix")
+	logger.Info("booted")
+	logger.Named("a+b#c").Error("odd name")
+	_, err = sink.Write([]byte("plain\n"))
+	require.NoError(t, err, "Unexpected error writing.")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+
+	assert.Equal(t, []connectPacket{{
+		ClientID:  "agent-7",
+		Username:  "user",
+		Password:  "pass",
+		KeepAlive: 30,
+		Will:      &Will{Topic: "fleet/agent-7/status", Payload: []byte("offline"), QoS: 1, Retain: true},
+	}}, broker.Connects(), "Unexpected CONNECT.")
+
+	pubs := broker.Publishes()
+	require.Len(t, pubs, 4, "Expected a publish per entry.")
+	assert.Equal(t, "fleet/agent-7/gps/warn", pubs[0].Topic, "Unexpected topic.")
+	assert.Contains(t, pubs[0].Payload, `"msg":"no fix"`, "Expected the entry as the payload.")
+	assert.Equal(t, byte(1), pubs[0].QoS, "Unexpected QoS.")
+	assert.True(t, pubs[0].Retain, "Expected retained messages.")
+	assert.Equal(t, "fleet/agent-7/root/info", pubs[1].Topic, "Expected unnamed loggers to use root.")
+	assert.Equal(t, "fleet/agent-7/a_b_c/error", pubs[2].Topic, "Expected wildcards to be stripped.")
+	assert.Equal(t, "fleet/agent-7/root/unknown", pubs[3].Topic, "Unexpected topic for undecodable lines.")
+
+	require.NoError(t, sink.Close(), "Unexpected error closing.")
+	assert.Eventually(t, func() bool {
+		broker.mu.Lock()
+		defer broker.mu.Unlock()
+		return broker.disconnects == 1
+	}, time.Second, 10*time.Millisecond, "Expected a graceful DISCONNECT.")
+}
+
+func TestSinkReconnects(t *testing.T) {
+	broker := newFakeBroker(t)
+	sink, err := New(Config{Addr: broker.Addr(), QoS: 1, Batch: zapbatch.Config{FlushInterval: time.Hour, MinBackoff: time.Millisecond}})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	logger := newTestLogger(sink)
+	logger.Info("one")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+
+	broker.dropAll()
+	require.Eventually(t, func() bool { return sink.conn.Err() != nil }, time.Second, time.Millisecond, "Expected the connection to fail.")
+	logger.Info("two")
+	require.NoError(t, sink.Sync(), "Expected the sink to reconnect.")
+
+	assert.Len(t, broker.Connects(), 2, "Expected a second connection.")
+	assert.Len(t, broker.Publishes(), 2, "Expected both entries to be published.")
+}
+
+func TestSinkKeepAlive(t *testing.T) {
+	broker := newFakeBroker(t)
+	sink, err := New(Config{Addr: broker.Addr(), KeepAlive: 100 * time.Millisecond})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	newTestLogger(sink).Info("hello")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+	assert.Eventually(t, func() bool {
+		broker.mu.Lock()
+		defer broker.mu.Unlock()
+		return broker.pings >= 2
+	}, time.Second, 10*time.Millisecond, "Expected periodic pings.")
+}
+
+func TestSinkErrors(t *testing.T) {
+	t.Run("refused", func(t *testing.T) {
+		broker := newFakeBroker(t)
+		broker.returnCode = 5
+		sink, err := New(Config{Addr: broker.Addr(), Batch: zapbatch.Config{FlushInterval: time.Hour, MaxRetries: -1}})
+		require.NoError(t, err, "Unexpected error building sink.")
+		defer sink.Close()
+
+		newTestLogger(sink).Info("hello")
+		assert.ErrorContains(t, sink.Sync(), "MQTT broker refused connection: not authorized", "Expected refused connections to fail.")
+	})
+
+	t.Run("no acknowledgement", func(t *testing.T) {
+		broker := newFakeBroker(t)
+		broker.noAcks = true
+		sink, err := New(Config{Addr: broker.Addr(), QoS: 1, Timeout: 50 * time.Millisecond, Batch: zapbatch.Config{FlushInterval: time.Hour, MaxRetries: -1}})
+		require.NoError(t, err, "Unexpected error building sink.")
+		defer sink.Close()
+
+		newTestLogger(sink).Info("hello")
+		assert.ErrorContains(t, sink.Sync(), "timed out waiting for MQTT acknowledgement", "Expected unacknowledged publishes to fail.")
+	})
+
+	tests := []struct {
+		desc string
+		give Config
+		want string
+	}{
+		{"address", Config{Addr: "broker"}, "invalid MQTT broker address"},
+		{"QoS", Config{Addr: "broker:1883", QoS: 2}, "MQTT QoS must be 0 or 1"},
+		{"topic", Config{Addr: "broker:1883", Topic: "logs/#"}, "invalid MQTT topic template"},
+		{"will topic", Config{Addr: "broker:1883", Will: &Will{}}, "invalid MQTT will topic"},
+		{"password", Config{Addr: "broker:1883", Password: "p"}, "MQTT password requires a username"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := New(tt.give)
+			assert.ErrorContains(t, err, tt.want, "Unexpected error.")
+		})
+	}
+}
+
+func TestPacketIDsSkipInFlight(t *testing.T) {
+	pending := make(chan struct{})
+	c := &conn{
+		bw:     bufio.NewWriter(io.Discard),
+		nextID: math.MaxUint16 - 1,
+		acks:   map[uint16]chan struct{}{math.MaxUint16: pending, 1: pending, 3: pending},
+	}
+
+	ack, err := c.publish("t", nil, 1, false)
+	require.NoError(t, err, "Unexpected error publishing.")
+	assert.Equal(t, uint16(2), c.nextID, "Expected zero and in-flight IDs to be skipped.")
+	assert.Equal(t, (<-chan struct{})(c.acks[2]), ack, "Expected a new acknowledgement channel.")
+	for _, id := range []uint16{math.MaxUint16, 1, 3} {
+		assert.Equal(t, pending, c.acks[id], "Expected in-flight ID %d to be kept.", id)
+	}
+}
+
+func TestRemainingLength(t *testing.T) {
+	for _, n := range []int{0, 127, 128, 16383, 16384, 2097151, 2097152} {
+		var b [4]byte
+		size := putRemainingLength(b[:], n)
+		body := make([]byte, n)
+		_, got, err := readPacket(bufio.NewReader(io.MultiReader(bytes.NewReader(append([]byte{0}, b[:size]...)), bytes.NewReader(body))))
+		require.NoError(t, err, "Unexpected error reading length %d.", n)
+		assert.Len(t, got, n, "Unexpected length.")
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	broker := newFakeBroker(t)
+	u, err := url.Parse("mqtt://u:p@" + broker.Addr() + "?client_id=c1&topic=l/{level}&qos=1&will_topic=s&will_payload=gone&will_retain=true&flush=1h")
+	require.NoError(t, err, "Failed to parse URL.")
+	sink, err := NewSink(u)
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	newTestLogger(sink).Info("hi")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, []connectPacket{{
+		ClientID:  "c1",
+		Username:  "u",
+		Password:  "p",
+		KeepAlive: 30,
+		Will:      &Will{Topic: "s", Payload: []byte("gone"), Retain: true},
+	}}, broker.Connects(), "Unexpected CONNECT.")
+	assert.Equal(t, "l/info", broker.Publishes()[0].Topic, "Unexpected topic.")
+
+	u, err = url.Parse("mqtt://broker?tls=true")
+	require.NoError(t, err, "Failed to parse URL.")
+	tlsSink, err := NewSink(u)
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer tlsSink.Close()
+	assert.Equal(t, "broker:8883", tlsSink.(*Sink).cfg.Addr, "Expected the default TLS port.")
+	assert.Equal(t, "broker", tlsSink.(*Sink).cfg.TLSConfig.ServerName, "Unexpected TLS server name.")
+
+	u, err = url.Parse("mqtt://broker?qos=high")
+	require.NoError(t, err, "Failed to parse URL.")
+	_, err = NewSink(u)
+	assert.Error(t, err, "Expected invalid parameters to be rejected.")
+}
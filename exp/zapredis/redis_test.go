@@ -0,0 +1,392 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapredis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeRedis is a minimal Redis server that records the commands it
+// receives.
+type fakeRedis struct {
+	ln       net.Listener
+	password string
+
+	mu       sync.Mutex
+	commands [][]string
+	reads    int // the number of reads with pipelined commands
+	nextID   int
+	conns    []net.Conn
+}
+
+// newFakeRedis starts a server, which requires a password if one is given.
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "Failed to listen.")
+	f := &fakeRedis{ln: ln, password: password}
+	t.Cleanup(func() {
+		ln.Close()
+		f.dropAll()
+	})
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			f.mu.Lock()
+			f.conns = append(f.conns, c)
+			f.mu.Unlock()
+			go f.handle(c)
+		}
+	}()
+	return f
+}
+
+func (f *fakeRedis) Addr() string { return f.ln.Addr().String() }
+
+// dropAll closes every client connection, as if the server restarted.
+func (f *fakeRedis) dropAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, c := range f.conns {
+		c.Close()
+	}
+	f.conns = nil
+}
+
+func (f *fakeRedis) Commands() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([][]string(nil), f.commands...)
+}
+
+// Added returns the XADD commands received.
+func (f *fakeRedis) Added() [][]string {
+	var added [][]string
+	for _, cmd := range f.Commands() {
+		if cmd[0] == "XADD" {
+			added = append(added, cmd)
+		}
+	}
+	return added
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	cmd := make([]string, n)
+	for i := range cmd {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		cmd[i] = string(buf[:size])
+	}
+	return cmd, nil
+}
+
+func (f *fakeRedis) handle(c net.Conn) {
+	defer c.Close()
+	r := bufio.NewReader(c)
+	w := bufio.NewWriter(c)
+	authed := f.password == ""
+	for {
+		cmd, err := readCommand(r)
+		if err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		f.commands = append(f.commands, cmd)
+		switch {
+		case cmd[0] == "AUTH" && cmd[len(cmd)-1] == f.password:
+			authed = true
+			w.WriteString("+OK\r\n")
+		case cmd[0] == "AUTH":
+			w.WriteString("-WRONGPASS invalid username-password pair\r\n")
+		case !authed:
+			w.WriteString("-NOAUTH Authentication required.\r\n")
+		case cmd[0] == "SELECT":
+			w.WriteString("+OK\r\n")
+		case cmd[0] == "XADD" && cmd[1] == "not-a-stream":
+			w.WriteString("-WRONGTYPE Operation against a key holding the wrong kind of value\r\n")
+		case cmd[0] == "XADD":
+			f.nextID++
+			id := fmt.Sprintf("1700000000000-%d", f.nextID)
+			fmt.Fprintf(w, "$%d\r\n%s\r\n", len(id), id)
+		default:
+			w.WriteString("-ERR unknown command\r\n")
+		}
+		if r.Buffered() == 0 {
+			f.reads++
+			w.Flush()
+		}
+		f.mu.Unlock()
+	}
+}
+
+func newTestLogger(sink zap.Sink) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	return zap.New(zapcore.NewCore(enc, sink, zap.DebugLevel))
+}
+
+func TestSinkAdds(t *testing.T) {
+	redis := newFakeRedis(t, "secret")
+
+	sink, err := New(Config{
+		Addr:     redis.Addr(),
+		Password: "secret",
+		DB:       2,
+		Stream:   "logs:api",
+		MaxLen:   1000,
+		Batch:    zapbatch.Config{FlushInterval: time.Hour},
+	})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	logger := newTestLogger(sink)
+	logger.Named("db").Info("first")
+	logger.Warn("second")
+	_, err = sink.Write([]byte("not json\n"))
+	require.NoError(t, err, "Unexpected error writing.")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+
+	cmds := redis.Commands()
+	require.Len(t, cmds, 5, "Expected AUTH, SELECT, and an XADD per entry.")
+	assert.Equal(t, []string{"AUTH", "secret"}, cmds[0], "Unexpected AUTH.")
+	assert.Equal(t, []string{"SELECT", "2"}, cmds[1], "Unexpected SELECT.")
+
+	prefix := []string{"XADD", "logs:api", "MAXLEN", "~", "1000", "*"}
+	assert.Equal(t, append(prefix, "level", "info", "logger", "db", "entry"), cmds[2][:len(prefix)+5], "Unexpected first XADD.")
+	assert.Contains(t, cmds[2][len(cmds[2])-1], `"msg":"first"`, "Expected the original line.")
+	assert.Equal(t, append(prefix, "level", "warn", "entry"), cmds[3][:len(prefix)+3], "Expected no logger field for unnamed loggers.")
+	assert.Equal(t, append(prefix, "entry", "not json"), cmds[4], "Expected undecodable lines to be added as is.")
+
+	redis.mu.Lock()
+	reads := redis.reads
+	redis.mu.Unlock()
+	assert.Equal(t, 2, reads, "Expected the handshake and the batch to each take a single round trip.")
+}
+
+func TestSinkExactMaxLen(t *testing.T) {
+	redis := newFakeRedis(t, "")
+	sink, err := New(Config{
+		Addr:        redis.Addr(),
+		MaxLen:      10,
+		ExactMaxLen: true,
+		Batch:       zapbatch.Config{FlushInterval: time.Hour},
+	})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	newTestLogger(sink).Info("hello")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+	cmds := redis.Commands()
+	require.Len(t, cmds, 1, "Expected no handshake without a password or database.")
+	assert.Equal(t, []string{"XADD", "logs", "MAXLEN", "=", "10", "*"}, cmds[0][:6], "Expected exact trimming.")
+}
+
+func TestSinkReconnects(t *testing.T) {
+	redis := newFakeRedis(t, "")
+	sink, err := New(Config{
+		Addr:  redis.Addr(),
+		Batch: zapbatch.Config{FlushInterval: time.Hour, MinBackoff: time.Millisecond},
+	})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	logger := newTestLogger(sink)
+	logger.Info("first")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+
+	redis.dropAll()
+	logger.Info("second")
+	require.NoError(t, sink.Sync(), "Expected the sink to reconnect and retry.")
+
+	added := redis.Added()
+	require.Len(t, added, 2, "Expected both entries to be added.")
+	assert.Contains(t, added[1][len(added[1])-1], `"msg":"second"`, "Unexpected second entry.")
+}
+
+func TestSinkErrors(t *testing.T) {
+	t.Run("rejected", func(t *testing.T) {
+		redis := newFakeRedis(t, "")
+		sink, err := New(Config{
+			Addr:   redis.Addr(),
+			Stream: "not-a-stream",
+			Batch:  zapbatch.Config{FlushInterval: time.Hour},
+		})
+		require.NoError(t, err, "Unexpected error building sink.")
+		defer sink.Close()
+
+		newTestLogger(sink).Info("hello")
+		newTestLogger(sink).Info("world")
+		err = sink.Sync()
+		assert.ErrorContains(t, err, "2 of 2 entries rejected by Redis, first: WRONGTYPE", "Expected the error reply.")
+		assert.Len(t, redis.Added(), 2, "Expected rejected entries not to be retried.")
+	})
+
+	t.Run("bad password", func(t *testing.T) {
+		redis := newFakeRedis(t, "secret")
+		sink, err := New(Config{
+			Addr:     redis.Addr(),
+			Username: "app",
+			Password: "wrong",
+			Batch:    zapbatch.Config{FlushInterval: time.Hour},
+		})
+		require.NoError(t, err, "Unexpected error building sink.")
+		defer sink.Close()
+
+		newTestLogger(sink).Info("hello")
+		err = sink.Sync()
+		assert.ErrorContains(t, err, "AUTH failed: WRONGPASS", "Expected an authentication error.")
+		assert.Equal(t, [][]string{{"AUTH", "app", "wrong"}}, redis.Commands(), "Expected authentication errors not to be retried.")
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err, "Failed to listen.")
+		addr := ln.Addr().String()
+		ln.Close()
+
+		sink, err := New(Config{
+			Addr:  addr,
+			Batch: zapbatch.Config{FlushInterval: time.Hour, MaxRetries: -1},
+		})
+		require.NoError(t, err, "Unexpected error building sink.")
+		defer sink.Close()
+
+		newTestLogger(sink).Info("hello")
+		assert.ErrorContains(t, sink.Sync(), "can't connect to Redis", "Expected a connection error.")
+	})
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    Config
+		wantErr string
+	}{
+		{"missing address", Config{}, "invalid Redis address"},
+		{"negative database", Config{Addr: "redis:6379", DB: -1}, "invalid Redis database"},
+		{"negative max length", Config{Addr: "redis:6379", MaxLen: -1}, "must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := New(tt.give)
+			assert.ErrorContains(t, err, tt.wantErr, "Unexpected error.")
+		})
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	redis := newFakeRedis(t, "secret")
+
+	u, err := url.Parse("redis://app:secret@" + redis.Addr() + "/3?stream=svc&maxlen=50&exact_maxlen=true&timeout=5s&flush=1h")
+	require.NoError(t, err, "Failed to parse URL.")
+	sink, err := NewSink(u)
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	newTestLogger(sink).Info("hello")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+	cmds := redis.Commands()
+	require.Len(t, cmds, 3, "Expected AUTH, SELECT, and XADD.")
+	assert.Equal(t, []string{"AUTH", "app", "secret"}, cmds[0], "Unexpected AUTH.")
+	assert.Equal(t, []string{"SELECT", "3"}, cmds[1], "Unexpected SELECT.")
+	assert.Equal(t, []string{"XADD", "svc", "MAXLEN", "=", "50", "*"}, cmds[2][:6], "Unexpected XADD.")
+
+	tests := []struct {
+		desc string
+		give string
+		want func(*testing.T, *Sink)
+	}{
+		{
+			desc: "defaults",
+			give: "redis://redis",
+			want: func(t *testing.T, s *Sink) {
+				assert.Equal(t, "redis:6379", s.cfg.Addr, "Expected the default port.")
+				assert.Nil(t, s.cfg.TLSConfig, "Expected no TLS.")
+				assert.Equal(t, DefaultStream, s.cfg.Stream, "Expected the default stream.")
+			},
+		},
+		{
+			desc: "TLS",
+			give: "rediss://:pw@redis:6380",
+			want: func(t *testing.T, s *Sink) {
+				require.NotNil(t, s.cfg.TLSConfig, "Expected TLS.")
+				assert.Equal(t, "redis", s.cfg.TLSConfig.ServerName, "Unexpected TLS server name.")
+				assert.Empty(t, s.cfg.Username, "Expected password-only authentication.")
+				assert.Equal(t, "pw", s.cfg.Password, "Unexpected password.")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			u, err := url.Parse(tt.give)
+			require.NoError(t, err, "Failed to parse URL.")
+			sink, err := NewSink(u)
+			require.NoError(t, err, "Unexpected error building sink.")
+			defer sink.Close()
+			tt.want(t, sink.(*Sink))
+		})
+	}
+
+	for _, bad := range []string{"redis://redis/db", "redis://redis?maxlen=lots", "redis://:secret@redis?stream=a&unknown=1"} {
+		u, err := url.Parse(bad)
+		require.NoError(t, err, "Failed to parse URL.")
+		_, err = NewSink(u)
+		if assert.Error(t, err, "Expected %q to be rejected.", bad) {
+			assert.NotContains(t, err.Error(), "secret", "Expected the password to be redacted.")
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapredis
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// _maxBulkLen bounds the bulk strings read from the server, to avoid
+// allocating absurd amounts of memory for a corrupt reply.
+const _maxBulkLen = 512 << 20
+
+// Error is an error reply from Redis, like "WRONGTYPE Operation against a key
+// holding the wrong kind of value".
+type Error string
+
+func (e Error) Error() string { return string(e) }
+
+// conn is a connection speaking RESP, Redis's protocol. It's not safe for
+// concurrent use.
+type conn struct {
+	nc      net.Conn
+	br      *bufio.Reader
+	bw      *bufio.Writer
+	timeout time.Duration
+}
+
+// dial connects to Redis, authenticating and selecting the database if
+// configured.
+func dial(cfg *Config) (*conn, error) {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	var (
+		nc  net.Conn
+		err error
+	)
+	if cfg.TLSConfig != nil {
+		nc, err = tls.DialWithDialer(dialer, "tcp", cfg.Addr, cfg.TLSConfig)
+	} else {
+		nc, err = dialer.Dial("tcp", cfg.Addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &conn{
+		nc:      nc,
+		br:      bufio.NewReader(nc),
+		bw:      bufio.NewWriter(nc),
+		timeout: cfg.Timeout,
+	}
+	var cmds [][]string
+	switch {
+	case cfg.Username != "":
+		cmds = append(cmds, []string{"AUTH", cfg.Username, cfg.Password})
+	case cfg.Password != "":
+		cmds = append(cmds, []string{"AUTH", cfg.Password})
+	}
+	if cfg.DB != 0 {
+		cmds = append(cmds, []string{"SELECT", strconv.Itoa(cfg.DB)})
+	}
+	for _, cmd := range cmds {
+		c.write(cmd...)
+	}
+	replies, err := c.roundTrip(len(cmds))
+	if err == nil {
+		for i, r := range replies {
+			if e, ok := r.(Error); ok {
+				err = fmt.Errorf("%s failed: %w", cmds[i][0], e)
+				break
+			}
+		}
+	}
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// write buffers a command. Commands are sent by roundTrip.
+func (c *conn) write(args ...string) {
+	c.bw.WriteByte('*')
+	c.bw.WriteString(strconv.Itoa(len(args)))
+	c.bw.WriteString("\r\n")
+	for _, arg := range args {
+		c.bw.WriteByte('$')
+		c.bw.WriteString(strconv.Itoa(len(arg)))
+		c.bw.WriteString("\r\n")
+		c.bw.WriteString(arg)
+		c.bw.WriteString("\r\n")
+	}
+}
+
+// roundTrip sends the buffered commands and reads n replies, pipelining the
+// commands in a single round trip. Error replies are returned as Errors
+// among the replies; other errors mean the connection is unusable.
+func (c *conn) roundTrip(n int) ([]interface{}, error) {
+	if n == 0 {
+		return nil, nil
+	}
+	if err := c.nc.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return nil, err
+	}
+	if err := c.bw.Flush(); err != nil {
+		return nil, err
+	}
+	replies := make([]interface{}, n)
+	for i := range replies {
+		r, err := c.readReply()
+		if err != nil {
+			return nil, err
+		}
+		replies[i] = r
+	}
+	return replies, nil
+}
+
+// readReply reads a reply: a string for simple and bulk strings, an int64,
+// an Error, a []interface{} for arrays, or nil.
+func (c *conn) readReply() (interface{}, error) {
+	line, err := c.br.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("malformed Redis reply %q", line)
+	}
+	kind, rest := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return rest, nil
+	case '-':
+		return Error(rest), nil
+	case ':':
+		return strconv.ParseInt(rest, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(rest)
+		if err != nil || n > _maxBulkLen {
+			return nil, fmt.Errorf("malformed Redis bulk string length %q", rest)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(c.br, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(rest)
+		if err != nil {
+			return nil, fmt.Errorf("malformed Redis array length %q", rest)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		arr := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			r, err := c.readReply()
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, r)
+		}
+		return arr, nil
+	default:
+		return nil, fmt.Errorf("unknown Redis reply type %q", kind)
+	}
+}
+
+func (c *conn) close() error {
+	err := c.nc.Close()
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapsplunk
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeHEC records the events it receives.
+type fakeHEC struct {
+	mu      sync.Mutex
+	events  []map[string]interface{}
+	headers []http.Header
+	status  int
+}
+
+func (f *fakeHEC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/services/collector/event" {
+		http.NotFound(w, r)
+		return
+	}
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = gz
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.headers = append(f.headers, r.Header)
+	dec := json.NewDecoder(bufio.NewReader(body))
+	for dec.More() {
+		var ev map[string]interface{}
+		if err := dec.Decode(&ev); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.events = append(f.events, ev)
+	}
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+	}
+	_, _ = io.WriteString(w, `{"text":"Success","code":0}`)
+}
+
+func (f *fakeHEC) Events() []map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]map[string]interface{}(nil), f.events...)
+}
+
+func newTestLogger(sink zap.Sink) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	return zap.New(zapcore.NewCore(enc, sink, zap.DebugLevel))
+}
+
+func TestSinkSends(t *testing.T) {
+	tests := []struct {
+		desc string
+		gzip bool
+	}{
+		{desc: "plain"},
+		{desc: "gzip", gzip: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			hec := &fakeHEC{}
+			srv := httptest.NewServer(hec)
+			defer srv.Close()
+
+			sink, err := New(Config{
+				URL:           srv.URL,
+				Token:         "secret",
+				Index:         "main",
+				SourceType:    "_json",
+				IndexedFields: []string{"region"},
+				Gzip:          tt.gzip,
+				Batch:         zapbatch.Config{FlushInterval: time.Hour},
+			})
+			require.NoError(t, err, "Unexpected error building sink.")
+			defer sink.Close()
+
+			newTestLogger(sink).Info("hello", zap.String("region", "us"), zap.Int("n", 1))
+			_, err = sink.Write([]byte("not json\n"))
+			require.NoError(t, err, "Unexpected error writing.")
+			require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+
+			require.Len(t, hec.headers, 1, "Expected a single batched request.")
+			assert.Equal(t, "Splunk secret", hec.headers[0].Get("Authorization"), "Expected token auth.")
+
+			events := hec.Events()
+			require.Len(t, events, 2, "Expected an event per line.")
+			assert.Equal(t, "main", events[0]["index"], "Unexpected index.")
+			assert.Equal(t, "_json", events[0]["sourcetype"], "Unexpected sourcetype.")
+			assert.NotContains(t, events[0], "source", "Expected empty metadata to be omitted.")
+			assert.Equal(t, map[string]interface{}{"region": "us"}, events[0]["fields"], "Unexpected indexed fields.")
+
+			payload, ok := events[0]["event"].(map[string]interface{})
+			require.True(t, ok, "Expected the entry as an object payload.")
+			assert.Equal(t, "hello", payload["msg"], "Unexpected message.")
+			assert.Equal(t, float64(1), payload["n"], "Expected fields in the payload.")
+			assert.InDelta(t, payload["ts"], events[0]["time"], 0.001, "Expected the entry time as the event time.")
+
+			assert.Equal(t, "not json", events[1]["event"], "Expected undecodable lines as string payloads.")
+			assert.NotContains(t, events[1], "fields", "Expected no indexed fields for undecodable lines.")
+		})
+	}
+}
+
+func TestEventTime(t *testing.T) {
+	sink, err := New(Config{URL: "http://splunk", Token: "t"})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	ev := sink.event([]byte(`{"level":"info","ts":1700000000.123456,"msg":"hi"}`), time.Now())
+	assert.Equal(t, "1700000000.123", string(ev.Time), "Unexpected event time.")
+
+	ev = sink.event([]byte(`oops`), time.Unix(5, 7e6))
+	assert.Equal(t, "5.007", string(ev.Time), "Expected undecodable lines to be timestamped now.")
+}
+
+func TestSinkErrors(t *testing.T) {
+	hec := &fakeHEC{status: http.StatusForbidden}
+	srv := httptest.NewServer(hec)
+	defer srv.Close()
+
+	sink, err := New(Config{URL: srv.URL, Token: "bad", Batch: zapbatch.Config{FlushInterval: time.Hour}})
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	newTestLogger(sink).Info("rejected")
+	assert.ErrorContains(t, sink.Sync(), "403 Forbidden", "Expected send errors to be returned by Sync.")
+	assert.Len(t, hec.Events(), 1, "Expected client errors not to be retried.")
+
+	_, err = New(Config{URL: "ftp://splunk", Token: "t"})
+	assert.ErrorContains(t, err, "scheme must be http or https", "Expected non-HTTP URLs to be rejected.")
+	_, err = New(Config{URL: "http://splunk"})
+	assert.ErrorContains(t, err, "missing Splunk HEC token", "Expected a token to be required.")
+}
+
+func TestNewSink(t *testing.T) {
+	hec := &fakeHEC{}
+	srv := httptest.NewServer(hec)
+	defer srv.Close()
+
+	u, err := url.Parse("splunk://:tok@" + srv.Listener.Addr().String() + "?index=app&source=api&host=h1&gzip=true&indexed_fields=k&flush=1h")
+	require.NoError(t, err, "Failed to parse URL.")
+	sink, err := NewSink(u)
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	newTestLogger(sink).Info("hi", zap.Int("k", 1))
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+
+	events := hec.Events()
+	require.Len(t, events, 1, "Expected one event.")
+	assert.Equal(t, "Splunk tok", hec.headers[0].Get("Authorization"), "Expected the token from the URL.")
+	assert.Equal(t, "gzip", hec.headers[0].Get("Content-Encoding"), "Expected a compressed body.")
+	assert.Equal(t, "app", events[0]["index"], "Unexpected index.")
+	assert.Equal(t, "api", events[0]["source"], "Unexpected source.")
+	assert.Equal(t, "h1", events[0]["host"], "Unexpected host.")
+	assert.Equal(t, map[string]interface{}{"k": float64(1)}, events[0]["fields"], "Unexpected indexed fields.")
+
+	for _, bad := range []string{"splunk://host", "splunk://host?token=t&gzip=maybe"} {
+		u, err := url.Parse(bad)
+		require.NoError(t, err, "Failed to parse URL.")
+		_, err = NewSink(u)
+		assert.Error(t, err, "Expected %q to be rejected.", bad)
+	}
+}
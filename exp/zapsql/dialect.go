@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapsql
+
+import (
+	"fmt"
+	"time"
+)
+
+// Dialect identifies the kind of database a Sink writes to.
+type Dialect string
+
+// Supported dialects.
+const (
+	// SQLite stores timestamps as Unix nanoseconds and fields as JSON
+	// text.
+	SQLite Dialect = "sqlite"
+
+	// Postgres stores timestamps as TIMESTAMPTZ and fields as JSONB, and
+	// loads batches with COPY when the driver supports it.
+	Postgres Dialect = "postgres"
+)
+
+var _dialects = map[Dialect]dialect{
+	SQLite:   sqliteDialect{},
+	Postgres: postgresDialect{},
+}
+
+// dialect holds the statements for a kind of database.
+type dialect interface {
+	// schema returns the statements creating the table and its indexes.
+	schema(table string) []string
+	// timestamp converts a time into the argument for the ts column.
+	timestamp(t time.Time) interface{}
+	// insert returns a statement inserting a row, taking the columns ts,
+	// level, logger, message, caller, fields, and entry as arguments.
+	insert(table string) string
+	// pruneAge deletes entries older than the timestamp argument.
+	pruneAge(table string) string
+	// pruneRows deletes all but the newest entries, taking their number as
+	// an argument.
+	pruneRows(table string) string
+}
+
+// A copier is a dialect that can load batches with COPY. Drivers supporting
+// COPY, like github.com/lib/pq, do so with a prepared "COPY ... FROM STDIN"
+// statement that's executed once per row and then once without arguments to
+// finish.
+type copier interface {
+	copyIn(table string) string
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) schema(table string) []string {
+	return []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	ts INTEGER NOT NULL,
+	level INTEGER NOT NULL,
+	logger TEXT NOT NULL,
+	message TEXT NOT NULL,
+	caller TEXT NOT NULL,
+	fields TEXT NOT NULL,
+	entry TEXT NOT NULL
+)`, table),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %[1]s_ts ON %[1]s (ts)`, table),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %[1]s_level_ts ON %[1]s (level, ts)`, table),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %[1]s_logger_ts ON %[1]s (logger, ts)`, table),
+	}
+}
+
+func (sqliteDialect) timestamp(t time.Time) interface{} {
+	return t.UnixNano()
+}
+
+func (sqliteDialect) insert(table string) string {
+	return fmt.Sprintf(`INSERT INTO %s (ts, level, logger, message, caller, fields, entry) VALUES (?, ?, ?, ?, ?, ?, ?)`, table)
+}
+
+func (sqliteDialect) pruneAge(table string) string {
+	return fmt.Sprintf(`DELETE FROM %s WHERE ts < ?`, table)
+}
+
+func (sqliteDialect) pruneRows(table string) string {
+	return fmt.Sprintf(`DELETE FROM %[1]s WHERE id <= (SELECT MAX(id) FROM %[1]s) - ?`, table)
+}
+
+type postgresDialect struct{}
+
+var _ copier = postgresDialect{}
+
+func (postgresDialect) schema(table string) []string {
+	return []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id BIGSERIAL PRIMARY KEY,
+	ts TIMESTAMPTZ NOT NULL,
+	level SMALLINT NOT NULL,
+	logger TEXT NOT NULL,
+	message TEXT NOT NULL,
+	caller TEXT NOT NULL,
+	fields JSONB NOT NULL,
+	entry TEXT NOT NULL
+)`, table),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %[1]s_ts ON %[1]s (ts)`, table),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %[1]s_level_ts ON %[1]s (level, ts)`, table),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %[1]s_logger_ts ON %[1]s (logger, ts)`, table),
+	}
+}
+
+func (postgresDialect) timestamp(t time.Time) interface{} {
+	return t.UTC()
+}
+
+func (postgresDialect) insert(table string) string {
+	return fmt.Sprintf(`INSERT INTO %s (ts, level, logger, message, caller, fields, entry) VALUES ($1, $2, $3, $4, $5, $6, $7)`, table)
+}
+
+func (postgresDialect) copyIn(table string) string {
+	return fmt.Sprintf(`COPY %s (ts, level, logger, message, caller, fields, entry) FROM STDIN`, table)
+}
+
+func (postgresDialect) pruneAge(table string) string {
+	return fmt.Sprintf(`DELETE FROM %s WHERE ts < $1`, table)
+}
+
+func (postgresDialect) pruneRows(table string) string {
+	return fmt.Sprintf(`DELETE FROM %[1]s WHERE id <= (SELECT MAX(id) FROM %[1]s) - $1`, table)
+}
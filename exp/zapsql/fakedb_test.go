@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+)
+
+// fakeDB is a database/sql driver that records the statements executed.
+type fakeDB struct {
+	mu       sync.Mutex
+	execs    []fakeExec
+	commits  int
+	rollback int
+	failOn   string // fail statements containing this
+	noPrep   string // fail to prepare statements containing this
+	closed   bool
+}
+
+type fakeExec struct {
+	Query string
+	Args  []driver.Value
+}
+
+var (
+	_ driver.Connector = (*fakeDB)(nil)
+	_ driver.Driver    = (*fakeDB)(nil)
+)
+
+// _fakeDBs are the databases opened by DSN with the registered driver.
+var (
+	_fakeDBsMu sync.Mutex
+	_fakeDBs   = make(map[string]*fakeDB)
+)
+
+func init() {
+	sql.Register("zapsqltest", &fakeDB{})
+}
+
+func (d *fakeDB) Open(dsn string) (driver.Conn, error) {
+	_fakeDBsMu.Lock()
+	defer _fakeDBsMu.Unlock()
+	db, ok := _fakeDBs[dsn]
+	if !ok {
+		db = &fakeDB{}
+		_fakeDBs[dsn] = db
+	}
+	return &fakeConn{db}, nil
+}
+
+func (d *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{d}, nil }
+func (d *fakeDB) Driver() driver.Driver                        { return d }
+
+func (d *fakeDB) Execs() []fakeExec {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]fakeExec(nil), d.execs...)
+}
+
+// Queries returns the executed statements, with whitespace collapsed.
+func (d *fakeDB) Queries() []string {
+	var qs []string
+	for _, e := range d.Execs() {
+		qs = append(qs, strings.Join(strings.Fields(e.Query), " "))
+	}
+	return qs
+}
+
+type fakeConn struct{ db *fakeDB }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	if c.db.noPrep != "" && strings.Contains(query, c.db.noPrep) {
+		return nil, errors.New("unsupported statement")
+	}
+	return &fakeStmt{db: c.db, query: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.closed = true
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) { return &fakeTx{c.db}, nil }
+
+type fakeTx struct{ db *fakeDB }
+
+func (t *fakeTx) Commit() error {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+	t.db.commits++
+	return nil
+}
+
+func (t *fakeTx) Rollback() error {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+	t.db.rollback++
+	return nil
+}
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if s.db.failOn != "" && strings.Contains(s.query, s.db.failOn) {
+		return nil, errors.New("disk I/O error")
+	}
+	s.db.execs = append(s.db.execs, fakeExec{Query: s.query, Args: args})
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) { return nil, io.EOF }
@@ -0,0 +1,420 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapsql provides a sink that writes logs into a SQL database, giving
+// small applications queryable local logs without external infrastructure.
+//
+// The sink accepts JSON-encoded entries, like those written by zap's
+// production configuration, and inserts them in batches into a table with
+// indexed time, level, and logger name columns:
+//
+//	CREATE TABLE logs (
+//		id      INTEGER PRIMARY KEY AUTOINCREMENT,
+//		ts      INTEGER NOT NULL, -- Unix nanoseconds
+//		level   INTEGER NOT NULL, -- zapcore.Level: -1 debug, 0 info, 1 warn, 2 error...
+//		logger  TEXT NOT NULL,
+//		message TEXT NOT NULL,
+//		caller  TEXT NOT NULL,
+//		fields  TEXT NOT NULL,    -- JSON object
+//		entry   TEXT NOT NULL     -- the line as received
+//	)
+//
+// so that, for example, recent errors are found with
+//
+//	SELECT ts, logger, message FROM logs WHERE level >= 2 ORDER BY ts DESC LIMIT 20
+//
+// The table and indexes are created if they don't exist, and old entries
+// are pruned to enforce the configured retention.
+//
+// The sink works with any database/sql driver for SQLite, such as
+// modernc.org/sqlite (pure Go) or github.com/mattn/go-sqlite3; import the
+// driver and open the database yourself, or register the "sqlite" scheme:
+//
+//	zap.RegisterSink("sqlite", zapsql.NewSink)
+//	cfg.OutputPaths = []string{"stderr", "sqlite:///var/lib/app/logs.db?driver=sqlite&max_age=168h"}
+//
+// With the Postgres dialect, logs can live next to an application's data in
+// PostgreSQL. The ts column is a TIMESTAMPTZ and fields is JSONB, so fields
+// can be queried directly:
+//
+//	SELECT ts, message FROM logs WHERE fields->>'user' = 'alice' ORDER BY ts DESC
+//
+// (Add a GIN index on fields if such queries are common.) Batches are loaded
+// with COPY when the driver supports it, as github.com/lib/pq does, falling
+// back to INSERT statements otherwise. Register the "postgres" scheme to
+// configure it by URL:
+//
+//	zap.RegisterSink("postgres", zapsql.NewSink)
+//	cfg.OutputPaths = []string{"stderr", "postgres://app:secret@db:5432/app?sslmode=disable&max_age=720h"}
+//
+// This package is experimental: its API may change in backwards
+// incompatible ways.
+package zapsql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+// Defaults for the zero values of Config's fields.
+const (
+	DefaultTable         = "logs"
+	DefaultPruneInterval = time.Minute
+)
+
+var _identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Config configures a Sink.
+type Config struct {
+	// DB is the database to write to. Required.
+	DB *sql.DB
+
+	// Dialect is the kind of database DB is. Defaults to SQLite.
+	Dialect Dialect
+
+	// Table names the table entries are written to. Defaults to
+	// DefaultTable.
+	Table string
+
+	// MaxAge and MaxRows bound the entries kept: older entries, and all
+	// but the newest MaxRows, are deleted. Zero values keep everything.
+	MaxAge  time.Duration
+	MaxRows int64
+
+	// PruneInterval is how often old entries are pruned, which happens
+	// after writing a batch. Defaults to DefaultPruneInterval.
+	PruneInterval time.Duration
+
+	// EncoderConfig describes the JSON the sink receives. Defaults to
+	// zap.NewProductionEncoderConfig.
+	EncoderConfig *zapcore.EncoderConfig
+
+	// Batch configures batching and retries.
+	Batch zapbatch.Config
+}
+
+// Sink is a zap.Sink that writes entries into a SQL database.
+type Sink struct {
+	cfg     Config
+	dialect dialect
+	dec     *zapcore.EntryDecoder
+	batcher *zapbatch.Batcher
+	closeDB bool // whether the sink opened the database
+
+	// Only accessed by the batcher's goroutine.
+	lastPrune time.Time
+	noCopy    bool // whether the driver can't COPY
+	now       func() time.Time
+}
+
+var _ zap.Sink = (*Sink)(nil)
+
+// New creates the table if needed and starts the Sink's background
+// batching.
+func New(cfg Config) (*Sink, error) {
+	if cfg.DB == nil {
+		return nil, errors.New("missing database")
+	}
+	if cfg.Dialect == "" {
+		cfg.Dialect = SQLite
+	}
+	d, ok := _dialects[cfg.Dialect]
+	if !ok {
+		return nil, fmt.Errorf("unknown SQL dialect %q", cfg.Dialect)
+	}
+	if cfg.Table == "" {
+		cfg.Table = DefaultTable
+	}
+	if !_identifier.MatchString(cfg.Table) {
+		return nil, fmt.Errorf("invalid table name %q", cfg.Table)
+	}
+	if cfg.MaxAge < 0 || cfg.MaxRows < 0 {
+		return nil, errors.New("retention limits must not be negative")
+	}
+	if cfg.PruneInterval <= 0 {
+		cfg.PruneInterval = DefaultPruneInterval
+	}
+	encCfg := zap.NewProductionEncoderConfig()
+	if cfg.EncoderConfig != nil {
+		encCfg = *cfg.EncoderConfig
+	}
+
+	s := &Sink{
+		cfg:     cfg,
+		dialect: d,
+		dec:     zapcore.NewEntryDecoder(encCfg),
+		now:     time.Now,
+	}
+	for _, stmt := range s.dialect.schema(cfg.Table) {
+		if _, err := cfg.DB.Exec(stmt); err != nil {
+			return nil, fmt.Errorf("can't create log table: %w", err)
+		}
+	}
+	s.batcher = zapbatch.New(s.send, cfg.Batch)
+	return s, nil
+}
+
+// _sinkParams are the query parameters read by NewSink, including those
+// read by zapbatch.ConfigFromParams. The rest of a postgres URL's parameters
+// are left for the driver.
+var _sinkParams = []string{
+	"driver", "table", "max_age", "max_rows", "prune_interval",
+	"batch_items", "batch_size", "flush", "queue", "retries",
+}
+
+// NewSink opens a database and builds a Sink from a URL, for use with
+// zap.RegisterSink. The URL
+//
+//	sqlite:///var/lib/app/logs.db?driver=sqlite&table=logs&max_age=168h&max_rows=1000000
+//
+// opens /var/lib/app/logs.db with the "sqlite" database/sql driver, which
+// must be registered by importing it. Besides the parameters shown, it
+// accepts prune_interval and the batching parameters described by
+// zapbatch.ConfigFromParams. Closing the sink closes the database.
+//
+// URLs with the postgres or postgresql scheme, like
+//
+//	postgres://app:secret@db:5432/app?sslmode=disable&table=logs&max_age=720h
+//
+// use the Postgres dialect and the "postgres" driver by default. They're
+// passed to the driver as connection strings, without the parameters above.
+func NewSink(u *url.URL) (zap.Sink, error) {
+	var (
+		dialect = SQLite
+		dsn     string
+		query   = u.Query()
+		sinkURL = *u
+	)
+	if u.Scheme == "postgres" || u.Scheme == "postgresql" {
+		dialect = Postgres
+		sinkQuery := make(url.Values)
+		for _, name := range _sinkParams {
+			if vs, ok := query[name]; ok {
+				sinkQuery[name] = vs
+				delete(query, name)
+			}
+		}
+		sinkURL.RawQuery = sinkQuery.Encode()
+		dsnURL := *u
+		dsnURL.RawQuery = query.Encode()
+		dsn = dsnURL.String()
+	}
+
+	params := zap.NewSinkParams(&sinkURL)
+	driver := params.String("driver", string(dialect))
+	cfg := Config{
+		Dialect:       dialect,
+		Table:         params.String("table", ""),
+		MaxAge:        params.Duration("max_age", 0),
+		MaxRows:       int64(params.Int("max_rows", 0)),
+		PruneInterval: params.Duration("prune_interval", 0),
+		Batch:         zapbatch.ConfigFromParams(params),
+	}
+	if err := params.Err(); err != nil {
+		return nil, fmt.Errorf("invalid SQL sink URL %v: %v", u.Redacted(), err)
+	}
+	if dialect == SQLite {
+		dsn = u.Path
+		if u.Host != "" {
+			dsn = u.Host + dsn // sqlite://relative/path.db
+		}
+		if dsn == "" {
+			return nil, fmt.Errorf("invalid SQL sink URL %v: missing database path", u.Redacted())
+		}
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	cfg.DB = db
+	s, err := New(cfg)
+	if err != nil {
+		return nil, multierr.Append(err, db.Close())
+	}
+	s.closeDB = true
+	return s, nil
+}
+
+// Write queues each line of p to be written to the database.
+func (s *Sink) Write(p []byte) (int, error) {
+	var err error
+	for _, line := range bytes.Split(p, []byte{'\n'}) {
+		if len(line) > 0 {
+			err = multierr.Append(err, s.batcher.Add(line))
+		}
+	}
+	return len(p), err
+}
+
+// Sync writes all queued entries, returning any errors writing entries
+// since the last Sync.
+func (s *Sink) Sync() error {
+	return s.batcher.Flush()
+}
+
+// Close writes all queued entries and stops the sink. If the sink opened
+// the database, it's closed too.
+func (s *Sink) Close() error {
+	err := s.batcher.Close()
+	if s.closeDB {
+		err = multierr.Append(err, s.cfg.DB.Close())
+	}
+	return err
+}
+
+// row is a decoded entry.
+type row struct {
+	Time    time.Time
+	Level   zapcore.Level
+	Logger  string
+	Message string
+	Caller  string
+	Fields  string
+	Entry   string
+}
+
+func (s *Sink) send(batch [][]byte) error {
+	now := s.now()
+	rows := make([]row, len(batch))
+	for i, line := range batch {
+		rows[i] = s.row(line, now)
+	}
+	if err := s.insert(rows); err != nil {
+		return err
+	}
+
+	if (s.cfg.MaxAge > 0 || s.cfg.MaxRows > 0) && now.Sub(s.lastPrune) >= s.cfg.PruneInterval {
+		s.lastPrune = now
+		return s.prune(now)
+	}
+	return nil
+}
+
+// insert writes rows in a transaction, with COPY if the dialect supports
+// it. If COPY fails, the rows are inserted with INSERT statements instead;
+// if the driver can't prepare COPY statements at all, later batches don't
+// try it.
+func (s *Sink) insert(rows []row) error {
+	if c, ok := s.dialect.(copier); ok && !s.noCopy {
+		prepared, err := s.exec(c.copyIn(s.cfg.Table), rows, true /* finish */)
+		if err == nil {
+			return nil
+		}
+		s.noCopy = !prepared
+	}
+	_, err := s.exec(s.dialect.insert(s.cfg.Table), rows, false /* finish */)
+	return err
+}
+
+// exec executes query once per row in a transaction, then once without
+// arguments if finish is set. It reports whether query was prepared.
+func (s *Sink) exec(query string, rows []row, finish bool) (prepared bool, err error) {
+	ctx := context.Background()
+	tx, err := s.cfg.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil {
+			err = multierr.Append(err, tx.Rollback())
+		}
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+	for _, r := range rows {
+		if _, err := stmt.ExecContext(ctx, s.dialect.timestamp(r.Time), int64(r.Level), r.Logger, r.Message, r.Caller, r.Fields, r.Entry); err != nil {
+			return true, err
+		}
+	}
+	if finish {
+		if _, err := stmt.ExecContext(ctx); err != nil {
+			return true, err
+		}
+	}
+	return true, tx.Commit()
+}
+
+// prune deletes entries beyond the retention limits.
+func (s *Sink) prune(now time.Time) error {
+	var err error
+	if s.cfg.MaxAge > 0 {
+		_, e := s.cfg.DB.Exec(s.dialect.pruneAge(s.cfg.Table), s.dialect.timestamp(now.Add(-s.cfg.MaxAge)))
+		err = multierr.Append(err, e)
+	}
+	if s.cfg.MaxRows > 0 {
+		_, e := s.cfg.DB.Exec(s.dialect.pruneRows(s.cfg.Table), s.cfg.MaxRows)
+		err = multierr.Append(err, e)
+	}
+	if err != nil {
+		return fmt.Errorf("can't prune old entries: %w", err)
+	}
+	return nil
+}
+
+// row decodes a line. Lines that can't be decoded are stored as info-level
+// messages, timestamped now.
+func (s *Sink) row(line []byte, now time.Time) row {
+	r := row{
+		Time:    now,
+		Level:   zapcore.InfoLevel,
+		Message: string(line),
+		Fields:  "{}",
+		Entry:   string(line),
+	}
+	ent, fields, err := s.dec.DecodeJSON(line)
+	if err != nil {
+		return r
+	}
+
+	r.Level, r.Logger, r.Message = ent.Level, ent.LoggerName, ent.Message
+	if !ent.Time.IsZero() {
+		r.Time = ent.Time
+	}
+	if ent.Caller.Defined {
+		r.Caller = ent.Caller.String()
+	}
+	if len(fields) > 0 {
+		enc := zapcore.NewMapObjectEncoder()
+		for _, f := range fields {
+			f.AddTo(enc)
+		}
+		if b, err := json.Marshal(enc.Fields); err == nil {
+			r.Fields = string(b)
+		}
+	}
+	return r
+}
@@ -0,0 +1,281 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapsql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/exp/zapbatch"
+	"go.uber.org/zap/zapcore"
+)
+
+func newTestSink(t *testing.T, cfg Config) (*Sink, *fakeDB) {
+	fake := &fakeDB{}
+	cfg.DB = sql.OpenDB(fake)
+	if cfg.Batch.FlushInterval == 0 {
+		cfg.Batch.FlushInterval = time.Hour
+	}
+	sink, err := New(cfg)
+	require.NoError(t, err, "Unexpected error building sink.")
+	t.Cleanup(func() { _ = sink.Close() })
+	return sink, fake
+}
+
+func newTestLogger(sink zap.Sink) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	return zap.New(zapcore.NewCore(enc, sink, zap.DebugLevel), zap.AddCaller())
+}
+
+func TestSinkInserts(t *testing.T) {
+	sink, fake := newTestSink(t, Config{Table: "app_logs"})
+	assert.Equal(t, []string{
+		"CREATE TABLE IF NOT EXISTS app_logs ( id INTEGER PRIMARY KEY AUTOINCREMENT, ts INTEGER NOT NULL, level INTEGER NOT NULL, " +
+			"logger TEXT NOT NULL, message TEXT NOT NULL, caller TEXT NOT NULL, fields TEXT NOT NULL, entry TEXT NOT NULL )",
+		"CREATE INDEX IF NOT EXISTS app_logs_ts ON app_logs (ts)",
+		"CREATE INDEX IF NOT EXISTS app_logs_level_ts ON app_logs (level, ts)",
+		"CREATE INDEX IF NOT EXISTS app_logs_logger_ts ON app_logs (logger, ts)",
+	}, fake.Queries(), "Unexpected schema.")
+
+	_, err := sink.Write([]byte(`{"level":"warn","ts":1.5,"logger":"db","caller":"db/conn.go:12","msg":"slow","ms":250,"tags":["a"]}` + "\n"))
+	require.NoError(t, err, "Unexpected error writing.")
+	newTestLogger(sink).Error("failed")
+	_, err = sink.Write([]byte("plain text\n"))
+	require.NoError(t, err, "Unexpected error writing.")
+	require.NoError(t, sink.Sync(), "Unexpected error syncing.")
+
+	execs := fake.Execs()[4:]
+	require.Len(t, execs, 3, "Expected a row per entry.")
+	assert.Equal(t, "INSERT INTO app_logs (ts, level, logger, message, caller, fields, entry) VALUES (?, ?, ?, ?, ?, ?, ?)", execs[0].Query, "Unexpected insert.")
+	assert.Equal(t, []driver.Value{
+		int64(1500000000), int64(zapcore.WarnLevel), "db", "slow", "db/conn.go:12", `{"ms":250,"tags":["a"]}`,
+		`{"level":"warn","ts":1.5,"logger":"db","caller":"db/conn.go:12","msg":"slow","ms":250,"tags":["a"]}`,
+	}, execs[0].Args, "Unexpected decoded row.")
+
+	assert.Equal(t, int64(zapcore.ErrorLevel), execs[1].Args[1], "Unexpected level.")
+	assert.Equal(t, "failed", execs[1].Args[3], "Unexpected message.")
+	assert.Contains(t, execs[1].Args[4], "zapsql/sql_test.go:", "Expected the caller.")
+	assert.Equal(t, "{}", execs[1].Args[5], "Expected empty fields.")
+
+	assert.Equal(t, []driver.Value{int64(zapcore.InfoLevel), "", "plain text", "", "{}", "plain text"}, execs[2].Args[1:], "Expected undecodable lines as info messages.")
+	assert.Equal(t, 1, fake.commits, "Expected a single transaction.")
+}
+
+func TestSinkPrunes(t *testing.T) {
+	sink, fake := newTestSink(t, Config{MaxAge: time.Hour, MaxRows: 1000, PruneInterval: time.Minute})
+	now := time.Unix(10000, 0)
+	sink.now = func() time.Time { return now }
+
+	write := func() {
+		require.NoError(t, sink.send([][]byte{[]byte("x")}), "Unexpected error sending.")
+	}
+	prunes := func() (n int) {
+		for _, q := range fake.Queries() {
+			if q[:6] == "DELETE" {
+				n++
+			}
+		}
+		return n
+	}
+
+	write()
+	execs := fake.Execs()
+	require.Equal(t, 2, prunes(), "Expected both limits to be enforced.")
+	assert.Equal(t, fakeExec{Query: "DELETE FROM logs WHERE ts < ?", Args: []driver.Value{int64(6400000000000)}}, execs[len(execs)-2], "Unexpected age pruning.")
+	assert.Equal(t, fakeExec{Query: "DELETE FROM logs WHERE id <= (SELECT MAX(id) FROM logs) - ?", Args: []driver.Value{int64(1000)}}, execs[len(execs)-1], "Unexpected row pruning.")
+
+	now = now.Add(30 * time.Second)
+	write()
+	assert.Equal(t, 2, prunes(), "Expected no pruning within the interval.")
+	now = now.Add(30 * time.Second)
+	write()
+	assert.Equal(t, 4, prunes(), "Expected pruning after the interval.")
+}
+
+func TestSinkNoRetention(t *testing.T) {
+	sink, fake := newTestSink(t, Config{})
+	require.NoError(t, sink.send([][]byte{[]byte("x")}), "Unexpected error sending.")
+	for _, q := range fake.Queries() {
+		assert.NotContains(t, q, "DELETE", "Expected no pruning without retention limits.")
+	}
+}
+
+func TestSinkErrors(t *testing.T) {
+	sink, fake := newTestSink(t, Config{MaxRows: 10, Batch: zapbatch.Config{MaxRetries: -1}})
+	fake.failOn = "INSERT"
+	assert.ErrorContains(t, sink.send([][]byte{[]byte("x")}), "disk I/O error", "Expected insert errors.")
+	assert.Equal(t, 1, fake.rollback, "Expected the transaction to be rolled back.")
+
+	fake.failOn = "DELETE"
+	assert.EqualError(t, sink.send([][]byte{[]byte("x")}), "can't prune old entries: disk I/O error", "Expected prune errors.")
+
+	fake = &fakeDB{failOn: "CREATE"}
+	_, err := New(Config{DB: sql.OpenDB(fake)})
+	assert.ErrorContains(t, err, "can't create log table", "Expected schema errors.")
+
+	tests := []struct {
+		desc string
+		give Config
+		want string
+	}{
+		{"no database", Config{}, "missing database"},
+		{"table", Config{DB: sql.OpenDB(&fakeDB{}), Table: "logs; DROP TABLE users"}, "invalid table name"},
+		{"retention", Config{DB: sql.OpenDB(&fakeDB{}), MaxRows: -1}, "retention limits must not be negative"},
+		{"dialect", Config{DB: sql.OpenDB(&fakeDB{}), Dialect: "oracle"}, `unknown SQL dialect "oracle"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := New(tt.give)
+			assert.ErrorContains(t, err, tt.want, "Unexpected error.")
+		})
+	}
+}
+
+func TestPostgresCopies(t *testing.T) {
+	sink, fake := newTestSink(t, Config{Dialect: Postgres, MaxAge: time.Hour})
+	assert.Equal(t, []string{
+		"CREATE TABLE IF NOT EXISTS logs ( id BIGSERIAL PRIMARY KEY, ts TIMESTAMPTZ NOT NULL, level SMALLINT NOT NULL, " +
+			"logger TEXT NOT NULL, message TEXT NOT NULL, caller TEXT NOT NULL, fields JSONB NOT NULL, entry TEXT NOT NULL )",
+		"CREATE INDEX IF NOT EXISTS logs_ts ON logs (ts)",
+		"CREATE INDEX IF NOT EXISTS logs_level_ts ON logs (level, ts)",
+		"CREATE INDEX IF NOT EXISTS logs_logger_ts ON logs (logger, ts)",
+	}, fake.Queries(), "Unexpected schema.")
+	now := time.Unix(10000, 0)
+	sink.now = func() time.Time { return now }
+
+	require.NoError(t, sink.send([][]byte{
+		[]byte(`{"level":"error","ts":1.5,"msg":"failed","user":"alice"}`),
+		[]byte("plain text"),
+	}), "Unexpected error sending.")
+
+	copyIn := "COPY logs (ts, level, logger, message, caller, fields, entry) FROM STDIN"
+	assert.Equal(t, []fakeExec{
+		{Query: copyIn, Args: []driver.Value{
+			time.Unix(1, 500000000).UTC(), int64(zapcore.ErrorLevel), "", "failed", "", `{"user":"alice"}`,
+			`{"level":"error","ts":1.5,"msg":"failed","user":"alice"}`,
+		}},
+		{Query: copyIn, Args: []driver.Value{now.UTC(), int64(zapcore.InfoLevel), "", "plain text", "", "{}", "plain text"}},
+		{Query: copyIn, Args: []driver.Value{}},
+		{Query: "DELETE FROM logs WHERE ts < $1", Args: []driver.Value{now.Add(-time.Hour).UTC()}},
+	}, fake.Execs()[4:], "Expected rows to be copied, then pruned.")
+	assert.Equal(t, 1, fake.commits, "Expected a single transaction.")
+}
+
+func TestPostgresFallsBack(t *testing.T) {
+	insert := "INSERT INTO logs (ts, level, logger, message, caller, fields, entry) VALUES ($1, $2, $3, $4, $5, $6, $7)"
+	countQueries := func(fake *fakeDB, prefix string) (n int) {
+		for _, q := range fake.Queries() {
+			if strings.HasPrefix(q, prefix) {
+				n++
+			}
+		}
+		return n
+	}
+
+	t.Run("copy failure", func(t *testing.T) {
+		sink, fake := newTestSink(t, Config{Dialect: Postgres})
+		fake.failOn = "COPY"
+		require.NoError(t, sink.send([][]byte{[]byte("x")}), "Expected the batch to be inserted.")
+		assert.Equal(t, 1, fake.rollback, "Expected the COPY transaction to be rolled back.")
+		assert.Equal(t, 1, countQueries(fake, "INSERT"), "Expected a fallback insert.")
+		assert.Equal(t, insert, fake.Execs()[4].Query, "Unexpected insert.")
+
+		fake.failOn = ""
+		require.NoError(t, sink.send([][]byte{[]byte("y")}), "Unexpected error sending.")
+		assert.Equal(t, 2, countQueries(fake, "COPY"), "Expected COPY to be retried for later batches.")
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		sink, fake := newTestSink(t, Config{Dialect: Postgres})
+		fake.noPrep = "COPY"
+		require.NoError(t, sink.send([][]byte{[]byte("x")}), "Expected the batch to be inserted.")
+		require.NoError(t, sink.send([][]byte{[]byte("y")}), "Expected the batch to be inserted.")
+		assert.Equal(t, 2, countQueries(fake, "INSERT"), "Expected inserts.")
+
+		fake.noPrep = ""
+		require.NoError(t, sink.send([][]byte{[]byte("z")}), "Unexpected error sending.")
+		assert.Equal(t, 0, countQueries(fake, "COPY"), "Expected COPY not to be retried once unsupported.")
+	})
+
+	t.Run("both fail", func(t *testing.T) {
+		sink, fake := newTestSink(t, Config{Dialect: Postgres})
+		fake.failOn = "logs"
+		assert.ErrorContains(t, sink.send([][]byte{[]byte("x")}), "disk I/O error", "Expected insert errors.")
+		assert.Equal(t, 2, fake.rollback, "Expected both transactions to be rolled back.")
+	})
+}
+
+func TestNewSink(t *testing.T) {
+	u, err := url.Parse("sqlite:///var/lib/app/logs.db?driver=zapsqltest&table=t1&max_rows=5&flush=1h")
+	require.NoError(t, err, "Failed to parse URL.")
+	sink, err := NewSink(u)
+	require.NoError(t, err, "Unexpected error building sink.")
+
+	newTestLogger(sink).Info("hi")
+	require.NoError(t, sink.Close(), "Unexpected error closing.")
+
+	_fakeDBsMu.Lock()
+	fake := _fakeDBs["/var/lib/app/logs.db"]
+	_fakeDBsMu.Unlock()
+	require.NotNil(t, fake, "Expected the database to be opened by path.")
+	assert.Contains(t, fake.Queries(), "DELETE FROM t1 WHERE id <= (SELECT MAX(id) FROM t1) - ?", "Expected the configured table and retention.")
+	assert.True(t, fake.closed, "Expected Close to close the database.")
+
+	for _, bad := range []string{
+		"sqlite://?driver=zapsqltest",
+		"sqlite:///x.db?driver=nope",
+		"sqlite:///x.db?max_age=old",
+		"sqlite:///x.db?sslmode=disable",
+		"postgres://db/app?driver=zapsqltest&max_rows=many",
+	} {
+		u, err := url.Parse(bad)
+		require.NoError(t, err, "Failed to parse URL.")
+		_, err = NewSink(u)
+		assert.Error(t, err, "Expected %q to be rejected.", bad)
+	}
+}
+
+func TestNewSinkPostgres(t *testing.T) {
+	dsn := "postgres://app:secret@db:5432/app?application_name=api&sslmode=disable"
+	u, err := url.Parse("postgres://app:secret@db:5432/app?sslmode=disable&driver=zapsqltest&table=t2&max_age=1h&flush=1h&application_name=api")
+	require.NoError(t, err, "Failed to parse URL.")
+	sink, err := NewSink(u)
+	require.NoError(t, err, "Unexpected error building sink.")
+
+	newTestLogger(sink).Info("hi")
+	require.NoError(t, sink.Close(), "Unexpected error closing.")
+
+	_fakeDBsMu.Lock()
+	fake := _fakeDBs[dsn]
+	_fakeDBsMu.Unlock()
+	require.NotNil(t, fake, "Expected the driver's parameters to be passed through.")
+	queries := fake.Queries()
+	assert.Contains(t, queries, "COPY t2 (ts, level, logger, message, caller, fields, entry) FROM STDIN", "Expected rows to be copied.")
+	assert.Contains(t, queries, "DELETE FROM t2 WHERE ts < $1", "Expected the configured table and retention.")
+	assert.True(t, fake.closed, "Expected Close to close the database.")
+}
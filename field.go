@@ -21,8 +21,12 @@
 package zap
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"math"
+	"reflect"
+	"sort"
 	"time"
 
 	"go.uber.org/zap/internal/stacktrace"
@@ -84,6 +88,15 @@ func ByteString(key string, val []byte) Field {
 	return Field{Key: key, Type: zapcore.ByteStringType, Interface: val}
 }
 
+// RawJSON constructs a field that embeds already-encoded JSON, such as a
+// payload received from another service, without decoding and re-encoding
+// it. The value must be a single valid JSON value; if it isn't, nothing is
+// added under the key and the error is logged under the key with an "Error"
+// suffix. Multi-line JSON is compacted by the JSON encoder.
+func RawJSON(key string, val []byte) Field {
+	return Field{Key: key, Type: zapcore.RawJSONType, Interface: val}
+}
+
 // Complex128 constructs a field that carries a complex number. Unlike most
 // numeric fields, this costs an allocation (to convert the complex128 to
 // interface{}).
@@ -342,6 +355,15 @@ func Stringer(key string, val fmt.Stringer) Field {
 	return Field{Key: key, Type: zapcore.StringerType, Interface: val}
 }
 
+// Text constructs a field with the given key and the output of the value's
+// MarshalText method, which is called lazily. It's a fast path for enum-like
+// types that implement encoding.TextMarshaler, avoiding the reflection used
+// by Any and the fmt formatting often behind String methods. If MarshalText
+// fails, the error is logged under the key with an "Error" suffix.
+func Text(key string, val encoding.TextMarshaler) Field {
+	return Field{Key: key, Type: zapcore.TextMarshalerType, Interface: val}
+}
+
 // Time constructs a Field with the given key and value. The encoder
 // controls how the time is serialized.
 func Time(key string, val time.Time) Field {
@@ -360,6 +382,30 @@ func Timep(key string, val *time.Time) Field {
 	return Time(key, *val)
 }
 
+// TimeLayout constructs a field that formats a time with the given layout,
+// regardless of the encoder's time encoder. It's useful for values like
+// business dates, which read best as "2006-01-02". Formatting is deferred
+// until the field is encoded.
+func TimeLayout(key string, val time.Time, layout string) Field {
+	return Stringer(key, timeLayout{t: val, layout: layout})
+}
+
+type timeLayout struct {
+	t      time.Time
+	layout string
+}
+
+func (tl timeLayout) String() string {
+	return tl.t.Format(tl.layout)
+}
+
+// TimeIn constructs a field that carries a time converted to the given
+// location, instead of the location it was created in. The encoder still
+// controls how the time is serialized.
+func TimeIn(key string, val time.Time, loc *time.Location) Field {
+	return Time(key, val.In(loc))
+}
+
 // Stack constructs a field that stores a stacktrace of the current goroutine
 // under provided key. Keep in mind that taking a stacktrace is eager and
 // expensive (relatively speaking); this function both makes an allocation and
@@ -438,6 +484,28 @@ func DictObject(val ...Field) zapcore.ObjectMarshaler {
 	return dictObject(val)
 }
 
+// SortedMap constructs a field that carries a map, with its entries encoded
+// in sorted key order. Unlike passing a map to Any, which encodes entries in
+// Go's randomized iteration order, the output is stable, which keeps diffs
+// and golden tests readable. Values are encoded as if passed to Any.
+func SortedMap[T any](key string, val map[string]T) Field {
+	return Object(key, sortedMap[T](val))
+}
+
+type sortedMap[T any] map[string]T
+
+func (m sortedMap[T]) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		Any(k, m[k]).AddTo(enc)
+	}
+	return nil
+}
+
 // We discovered an issue where zap.Any can cause a performance degradation
 // when used in new goroutines.
 //
@@ -484,6 +552,20 @@ func (f anyFieldC[T]) Any(key string, val any) Field {
 // Since byte/uint8 and rune/int32 are aliases, Any can't differentiate between
 // them. To minimize surprises, []byte values are treated as binary blobs, byte
 // values are treated as uint8, and runes are always treated as integers.
+//
+// Values of other types are represented using the first of these methods
+// they implement, in order: MarshalLogObject, MarshalLogArray, Error,
+// String, MarshalJSON (whose output is embedded as is), MarshalText, and
+// MarshalBinary. Panics in these methods are recovered and logged under the
+// key with an "Error" suffix. Only values that implement none of them fall
+// back to reflection.
+//
+// Any doesn't allocate for errors, strings, numbers, times, durations, and
+// pointers to them, or for marshalers and Stringers, beyond what the caller
+// allocates to convert the value to an interface. The exceptions are
+// pointers to complex numbers and slices other than []byte, which allocate
+// once more, since the Field must hold a value of a different type than the
+// one the caller boxed.
 func Any(key string, value interface{}) Field {
 	var c interface{ Any(string, any) Field }
 
@@ -501,13 +583,15 @@ func Any(key string, value interface{}) Field {
 	case []bool:
 		c = anyFieldC[[]bool](Bools)
 	case complex128:
-		c = anyFieldC[complex128](Complex128)
+		// Like []byte, reuse the interface value; Complex128 would box the
+		// number again.
+		return Field{Key: key, Type: zapcore.Complex128Type, Interface: value}
 	case *complex128:
 		c = anyFieldC[*complex128](Complex128p)
 	case []complex128:
 		c = anyFieldC[[]complex128](Complex128s)
 	case complex64:
-		c = anyFieldC[complex64](Complex64)
+		return Field{Key: key, Type: zapcore.Complex64Type, Interface: value}
 	case *complex64:
 		c = anyFieldC[*complex64](Complex64p)
 	case []complex64:
@@ -589,7 +673,9 @@ func Any(key string, value interface{}) Field {
 	case *uint8:
 		c = anyFieldC[*uint8](Uint8p)
 	case []byte:
-		c = anyFieldC[[]byte](Binary)
+		// Reuse the interface value rather than converting the slice to an
+		// interface again.
+		return Field{Key: key, Type: zapcore.BinaryType, Interface: value}
 	case uintptr:
 		c = anyFieldC[uintptr](Uintptr)
 	case *uintptr:
@@ -614,9 +700,151 @@ func Any(key string, value interface{}) Field {
 		c = anyFieldC[[]error](Errors)
 	case fmt.Stringer:
 		c = anyFieldC[fmt.Stringer](Stringer)
+	case json.Marshaler:
+		// Like []byte, reuse the interface value.
+		return Field{Key: key, Type: zapcore.JSONMarshalerType, Interface: value}
+	case encoding.TextMarshaler:
+		return Field{Key: key, Type: zapcore.TextMarshalerType, Interface: value}
+	case encoding.BinaryMarshaler:
+		return Field{Key: key, Type: zapcore.BinaryMarshalerType, Interface: value}
 	default:
+		if f, ok := kindField(key, value); ok {
+			return f
+		}
 		c = anyFieldC[any](Reflect)
 	}
 
 	return c.Any(key, value)
 }
+
+// kindField builds a field for values of user-defined types whose underlying
+// type is a bool, number, or string, like
+//
+//	type UserID int64
+//
+// so that Any needn't fall back to Reflect for them. Types that customize
+// their JSON representation are left to Reflect.
+func kindField(key string, value interface{}) (Field, bool) {
+	switch value.(type) {
+	case nil, json.Marshaler, encoding.TextMarshaler:
+		return Field{}, false
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Bool:
+		return Bool(key, v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Int64(key, v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Uint64(key, v.Uint()), true
+	case reflect.Uintptr:
+		return Uintptr(key, uintptr(v.Uint())), true
+	case reflect.Float32:
+		return Float32(key, float32(v.Float())), true
+	case reflect.Float64:
+		return Float64(key, v.Float()), true
+	case reflect.Complex64:
+		return Complex64(key, complex64(v.Complex())), true
+	case reflect.Complex128:
+		return Complex128(key, v.Complex()), true
+	case reflect.String:
+		return String(key, v.String()), true
+	default:
+		return Field{}, false
+	}
+}
+
+// Typed is a generic counterpart to Any. It chooses the same representation
+// for a value as Any, but since it knows the value's static type, it doesn't
+// need to convert values to interfaces to inspect them: for numbers, strings,
+// times, durations, and pointers to them, it allocates no more than the
+// exact constructor would.
+//
+// Prefer the exact constructors, like Int or String, where the type is known;
+// Typed is meant for generic code and for call sites that would otherwise use
+// Any with a concrete type.
+func Typed[T any](key string, value T) Field {
+	switch v := any(value).(type) {
+	case bool:
+		return Bool(key, v)
+	case *bool:
+		return Boolp(key, v)
+	case complex128:
+		return Complex128(key, v)
+	case *complex128:
+		return Complex128p(key, v)
+	case complex64:
+		return Complex64(key, v)
+	case *complex64:
+		return Complex64p(key, v)
+	case float64:
+		return Float64(key, v)
+	case *float64:
+		return Float64p(key, v)
+	case float32:
+		return Float32(key, v)
+	case *float32:
+		return Float32p(key, v)
+	case int:
+		return Int(key, v)
+	case *int:
+		return Intp(key, v)
+	case int64:
+		return Int64(key, v)
+	case *int64:
+		return Int64p(key, v)
+	case int32:
+		return Int32(key, v)
+	case *int32:
+		return Int32p(key, v)
+	case int16:
+		return Int16(key, v)
+	case *int16:
+		return Int16p(key, v)
+	case int8:
+		return Int8(key, v)
+	case *int8:
+		return Int8p(key, v)
+	case string:
+		return String(key, v)
+	case *string:
+		return Stringp(key, v)
+	case uint:
+		return Uint(key, v)
+	case *uint:
+		return Uintp(key, v)
+	case uint64:
+		return Uint64(key, v)
+	case *uint64:
+		return Uint64p(key, v)
+	case uint32:
+		return Uint32(key, v)
+	case *uint32:
+		return Uint32p(key, v)
+	case uint16:
+		return Uint16(key, v)
+	case *uint16:
+		return Uint16p(key, v)
+	case uint8:
+		return Uint8(key, v)
+	case *uint8:
+		return Uint8p(key, v)
+	case uintptr:
+		return Uintptr(key, v)
+	case *uintptr:
+		return Uintptrp(key, v)
+	case time.Time:
+		return Time(key, v)
+	case *time.Time:
+		return Timep(key, v)
+	case time.Duration:
+		return Duration(key, v)
+	case *time.Duration:
+		return Durationp(key, v)
+	}
+	// The switch above only matches concrete types so that the conversion to
+	// an interface it inspects doesn't escape; everything else goes through
+	// Any.
+	return Any(key, value)
+}
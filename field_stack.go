@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// AppendFields returns a copy of ctx carrying the given fields, and a
+// function that removes them again. Entries logged with the context include
+// the fields, but only when they're logged through Logger.Ctx or
+// SugaredLogger.Ctx: plain calls like logger.Info don't see the context, so
+// they don't get the fields. It's intended for middleware that wants to
+// annotate everything logged while handling a request without threading a
+// child logger through every layer:
+//
+//	ctx, undo := zap.AppendFields(r.Context(), zap.String("request_id", id))
+//	defer undo()
+//	next.ServeHTTP(w, r.WithContext(ctx))
+//
+//	// Further down:
+//	logger.Ctx(ctx).Info("handled request")
+//
+// Since the fields are carried by the context rather than the logger,
+// concurrent requests logging through the same logger never see each
+// other's fields. To add fields to every entry a logger writes, whether or
+// not it's given a context, use Logger.AppendFields.
+//
+// The fields are written after those added with With and before those
+// passed at the log site, and they're only encoded for entries that are
+// written. Calling the returned function more than once has no further
+// effect. See zapcore.ContextWithFields for details.
+func AppendFields(ctx context.Context, fields ...Field) (context.Context, func()) {
+	return zapcore.ContextWithFields(ctx, fields...)
+}
+
+// fieldStack holds the fields added with Logger.AppendFields. It's shared by
+// a Logger and every Logger derived from it.
+type fieldStack struct {
+	mu     sync.Mutex // serializes changes
+	frames []*fieldStackFrame
+
+	// Snapshot of frames, rebuilt on every change so that logging doesn't
+	// need the lock. Nil when there are no fields.
+	state atomic.Pointer[fieldStackState]
+}
+
+type fieldStackFrame struct {
+	fields []Field
+}
+
+type fieldStackState struct {
+	fields []Field         // every frame's fields, oldest first
+	ctx    context.Context // a background context carrying fields
+}
+
+// Push adds fields to the stack, returning a function that removes them
+// again. The returned function is idempotent and may be called out of order.
+func (s *fieldStack) Push(fields []Field) (undo func()) {
+	frame := &fieldStackFrame{fields: append([]Field(nil), fields...)}
+
+	s.mu.Lock()
+	s.frames = append(s.frames, frame)
+	s.rebuild()
+	s.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { s.remove(frame) })
+	}
+}
+
+func (s *fieldStack) remove(frame *fieldStackFrame) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.frames {
+		if f == frame {
+			s.frames = append(s.frames[:i], s.frames[i+1:]...)
+			break
+		}
+	}
+	s.rebuild()
+}
+
+// rebuild must be called with s.mu held.
+func (s *fieldStack) rebuild() {
+	if len(s.frames) == 0 {
+		s.state.Store(nil)
+		return
+	}
+	var fields []Field
+	for _, f := range s.frames {
+		fields = append(fields, f.fields...)
+	}
+	ctx, _ := zapcore.ContextWithFields(context.Background(), fields...)
+	s.state.Store(&fieldStackState{fields: fields, ctx: ctx})
+}
+
+// Context returns the context to give entries logged with ctx, which may be
+// nil, so that they're written with the fields on the stack. The fields are
+// only added to the entry when it's written.
+func (s *fieldStack) Context(ctx context.Context) context.Context {
+	if s == nil {
+		return ctx
+	}
+	st := s.state.Load()
+	if st == nil {
+		return ctx
+	}
+	if ctx == nil {
+		return st.ctx
+	}
+	ctx, _ = zapcore.ContextWithFields(ctx, st.fields...)
+	return ctx
+}
@@ -21,6 +21,7 @@
 package zap
 
 import (
+	"errors"
 	"math"
 	"net"
 	"regexp"
@@ -29,6 +30,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/internal/stacktrace"
 	"go.uber.org/zap/zapcore"
 )
@@ -40,6 +42,21 @@ func (n username) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	return nil
 }
 
+type (
+	enabled     bool
+	userID      int64
+	port        uint16
+	ratio       float32
+	color       string
+	jsonColor   string
+	textColor   string
+	binaryColor string
+)
+
+func (c jsonColor) MarshalJSON() ([]byte, error)     { return []byte(`"#` + c + `"`), nil }
+func (c textColor) MarshalText() ([]byte, error)     { return []byte("#" + c), nil }
+func (c binaryColor) MarshalBinary() ([]byte, error) { return []byte(c), nil }
+
 func assertCanBeReused(t testing.TB, field Field) {
 	var wg sync.WaitGroup
 
@@ -100,6 +117,7 @@ func TestFieldConstructors(t *testing.T) {
 		{"Bool", Field{Key: "k", Type: zapcore.BoolType, Integer: 1}, Bool("k", true)},
 		{"Bool", Field{Key: "k", Type: zapcore.BoolType, Integer: 0}, Bool("k", false)},
 		{"ByteString", Field{Key: "k", Type: zapcore.ByteStringType, Interface: []byte("ab12")}, ByteString("k", []byte("ab12"))},
+		{"RawJSON", Field{Key: "k", Type: zapcore.RawJSONType, Interface: []byte(`{"a":1}`)}, RawJSON("k", []byte(`{"a":1}`))},
 		{"Complex128", Field{Key: "k", Type: zapcore.Complex128Type, Interface: 1 + 2i}, Complex128("k", 1+2i)},
 		{"Complex64", Field{Key: "k", Type: zapcore.Complex64Type, Interface: complex64(1 + 2i)}, Complex64("k", 1+2i)},
 		{"Duration", Field{Key: "k", Type: zapcore.DurationType, Integer: 1}, Duration("k", 1)},
@@ -115,6 +133,8 @@ func TestFieldConstructors(t *testing.T) {
 		{"Time", Field{Key: "k", Type: zapcore.TimeType, Integer: math.MaxInt64, Interface: time.UTC}, Time("k", time.Unix(0, math.MaxInt64).In(time.UTC))},
 		{"Time", Field{Key: "k", Type: zapcore.TimeFullType, Interface: time.Time{}}, Time("k", time.Time{})},
 		{"Time", Field{Key: "k", Type: zapcore.TimeFullType, Interface: time.Unix(math.MaxInt64, 0)}, Time("k", time.Unix(math.MaxInt64, 0))},
+		{"TimeLayout", Field{Key: "k", Type: zapcore.StringerType, Interface: timeLayout{t: time.Unix(0, 0), layout: "2006-01-02"}}, TimeLayout("k", time.Unix(0, 0), "2006-01-02")},
+		{"TimeIn", Field{Key: "k", Type: zapcore.TimeType, Integer: 0, Interface: time.UTC}, TimeIn("k", time.Unix(0, 0), time.UTC)},
 		{"Uint", Field{Key: "k", Type: zapcore.Uint64Type, Integer: 1}, Uint("k", 1)},
 		{"Uint64", Field{Key: "k", Type: zapcore.Uint64Type, Integer: 1}, Uint64("k", 1)},
 		{"Uint32", Field{Key: "k", Type: zapcore.Uint32Type, Integer: 1}, Uint32("k", 1)},
@@ -124,6 +144,7 @@ func TestFieldConstructors(t *testing.T) {
 		{"Reflect", Field{Key: "k", Type: zapcore.ReflectType, Interface: ints}, Reflect("k", ints)},
 		{"Reflect", Field{Key: "k", Type: zapcore.ReflectType}, Reflect("k", nil)},
 		{"Stringer", Field{Key: "k", Type: zapcore.StringerType, Interface: addr}, Stringer("k", addr)},
+		{"Text", Field{Key: "k", Type: zapcore.TextMarshalerType, Interface: addr}, Text("k", addr)},
 		{"Object", Field{Key: "k", Type: zapcore.ObjectMarshalerType, Interface: name}, Object("k", name)},
 		{"Inline", Field{Type: zapcore.InlineMarshalerType, Interface: name}, Inline(name)},
 		{"Any:ObjectMarshaler", Any("k", name), Object("k", name)},
@@ -252,6 +273,15 @@ func TestFieldConstructors(t *testing.T) {
 		{"Any:PtrUintptr", Any("k", (*uintptr)(nil)), nilField("k")},
 		{"Any:PtrUintptr", Any("k", &uintptrVal), Uintptr("k", uintptrVal)},
 		{"Any:ErrorNil", Any("k", nilErr), nilField("k")},
+		{"Any:NamedBool", Any("k", enabled(true)), Bool("k", true)},
+		{"Any:NamedInt", Any("k", userID(42)), Int64("k", 42)},
+		{"Any:NamedUint", Any("k", port(8080)), Uint64("k", 8080)},
+		{"Any:NamedFloat32", Any("k", ratio(0.5)), Float32("k", 0.5)},
+		{"Any:NamedString", Any("k", color("red")), String("k", "red")},
+		{"Any:NamedJSONMarshaler", Any("k", jsonColor("red")), Field{Key: "k", Type: zapcore.JSONMarshalerType, Interface: jsonColor("red")}},
+		{"Any:NamedTextMarshaler", Any("k", textColor("red")), Text("k", textColor("red"))},
+		{"Any:BinaryMarshaler", Any("k", binaryColor("red")), Field{Key: "k", Type: zapcore.BinaryMarshalerType, Interface: binaryColor("red")}},
+		{"Any:Map", Any("k", map[string]int{"a": 1}), Reflect("k", map[string]int{"a": 1})},
 		{"Namespace", Namespace("k"), Field{Key: "k", Type: zapcore.NamespaceType}},
 	}
 
@@ -265,6 +295,177 @@ func TestFieldConstructors(t *testing.T) {
 	}
 }
 
+// _allocField keeps the compiler from optimizing away fields built in
+// allocation tests.
+var _allocField Field
+
+func TestFieldConstructorsDontAllocate(t *testing.T) {
+	var (
+		err   error = errors.New("fail")
+		str         = "foo"
+		num         = 1 << 20 // too large for Go's preallocated small-integer interfaces
+		when        = time.Unix(0, 0).In(time.FixedZone("custom", 3600))
+		dur         = time.Minute
+		bytes       = []byte("foo")
+		obj         = &emptyObject{}
+	)
+	// Values passed to Any are converted to interfaces up front, since that
+	// allocation belongs to the caller.
+	var (
+		anyErr    interface{} = err
+		anyStr    interface{} = str
+		anyNum    interface{} = num
+		anyPtr    interface{} = &num
+		anyTime   interface{} = when
+		anyDur    interface{} = dur
+		anyBytes  interface{} = bytes
+		anyC128   interface{} = complex128(1 + 2i)
+		anyC64    interface{} = complex64(1 + 2i)
+		anyObj    interface{} = obj
+		anyString interface{} = net.ParseIP("1.2.3.4")
+	)
+
+	tests := []struct {
+		desc  string
+		build func() Field
+	}{
+		{"Error", func() Field { return Error(err) }},
+		{"NamedError", func() Field { return NamedError("k", err) }},
+		{"ErrorVerbose", func() Field { return ErrorVerbose("k", err, "kVerbose", 10) }},
+		{"String", func() Field { return String("k", str) }},
+		{"Int", func() Field { return Int("k", num) }},
+		{"Intp", func() Field { return Intp("k", &num) }},
+		{"Time", func() Field { return Time("k", when) }},
+		{"Duration", func() Field { return Duration("k", dur) }},
+		{"Object", func() Field { return Object("k", obj) }},
+		{"Any error", func() Field { return Any("k", anyErr) }},
+		{"Any string", func() Field { return Any("k", anyStr) }},
+		{"Any int", func() Field { return Any("k", anyNum) }},
+		{"Any pointer", func() Field { return Any("k", anyPtr) }},
+		{"Any time", func() Field { return Any("k", anyTime) }},
+		{"Any duration", func() Field { return Any("k", anyDur) }},
+		{"Any bytes", func() Field { return Any("k", anyBytes) }},
+		{"Any complex128", func() Field { return Any("k", anyC128) }},
+		{"Any complex64", func() Field { return Any("k", anyC64) }},
+		{"Any marshaler", func() Field { return Any("k", anyObj) }},
+		{"Any Stringer", func() Field { return Any("k", anyString) }},
+		{"Typed error", func() Field { return Typed("k", err) }},
+		{"Typed int", func() Field { return Typed("k", num) }},
+		{"Typed time", func() Field { return Typed("k", when) }},
+		{"Typed duration", func() Field { return Typed("k", dur) }},
+		{"Typed marshaler", func() Field { return Typed("k", obj) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(100, func() {
+				_allocField = tt.build()
+			})
+			assert.Zero(t, allocs, "Expected building the field not to allocate.")
+		})
+	}
+}
+
+func TestTyped(t *testing.T) {
+	var (
+		num  = 42
+		str  = "foo"
+		when = time.Unix(0, 1000)
+		dur  = time.Second
+		err  = errors.New("fail")
+	)
+	tests := []struct {
+		desc string
+		give Field
+		want Field
+	}{
+		{"bool", Typed("k", true), Bool("k", true)},
+		{"int", Typed("k", num), Int("k", num)},
+		{"int pointer", Typed("k", &num), Intp("k", &num)},
+		{"nil int pointer", Typed("k", (*int)(nil)), nilField("k")},
+		{"int8", Typed("k", int8(-1)), Int8("k", -1)},
+		{"uint16", Typed("k", uint16(1)), Uint16("k", 1)},
+		{"float32", Typed("k", float32(0.5)), Float32("k", 0.5)},
+		{"complex64", Typed("k", complex64(1+2i)), Complex64("k", 1+2i)},
+		{"string", Typed("k", str), String("k", str)},
+		{"string pointer", Typed("k", &str), Stringp("k", &str)},
+		{"time", Typed("k", when), Time("k", when)},
+		{"duration", Typed("k", dur), Duration("k", dur)},
+		{"bytes", Typed("k", []byte("foo")), Binary("k", []byte("foo"))},
+		{"strings", Typed("k", []string{"a"}), Strings("k", []string{"a"})},
+		{"error", Typed("k", err), NamedError("k", err)},
+		{"nil error", Typed[error]("k", nil), nilField("k")},
+		{"marshaler", Typed("k", username("phil")), Object("k", username("phil"))},
+		{"named type", Typed("k", userID(42)), Int64("k", 42)},
+		{"interface", Typed[interface{}]("k", 42), Int("k", 42)},
+		{"map", Typed("k", map[string]int{"a": 1}), Reflect("k", map[string]int{"a": 1})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.give, "Unexpected field.")
+		})
+	}
+}
+
+func TestTypedPointers(t *testing.T) {
+	var (
+		b    = true
+		c128 = complex128(1 + 2i)
+		c64  = complex64(1 + 2i)
+		f64  = 1.5
+		f32  = float32(1.5)
+		i    = -1
+		i64  = int64(-1)
+		i32  = int32(-1)
+		i16  = int16(-1)
+		i8   = int8(-1)
+		s    = "foo"
+		u    = uint(1)
+		u64  = uint64(1)
+		u32  = uint32(1)
+		u16  = uint16(1)
+		u8   = uint8(1)
+		uptr = uintptr(1)
+		when = time.Unix(0, 1000)
+		dur  = time.Second
+	)
+	tests := []struct {
+		desc  string
+		typed func() Field
+		exact func() Field
+	}{
+		{"bool", func() Field { return Typed("k", &b) }, func() Field { return Boolp("k", &b) }},
+		{"complex128", func() Field { return Typed("k", &c128) }, func() Field { return Complex128p("k", &c128) }},
+		{"complex64", func() Field { return Typed("k", &c64) }, func() Field { return Complex64p("k", &c64) }},
+		{"float64", func() Field { return Typed("k", &f64) }, func() Field { return Float64p("k", &f64) }},
+		{"float32", func() Field { return Typed("k", &f32) }, func() Field { return Float32p("k", &f32) }},
+		{"int", func() Field { return Typed("k", &i) }, func() Field { return Intp("k", &i) }},
+		{"int64", func() Field { return Typed("k", &i64) }, func() Field { return Int64p("k", &i64) }},
+		{"int32", func() Field { return Typed("k", &i32) }, func() Field { return Int32p("k", &i32) }},
+		{"int16", func() Field { return Typed("k", &i16) }, func() Field { return Int16p("k", &i16) }},
+		{"int8", func() Field { return Typed("k", &i8) }, func() Field { return Int8p("k", &i8) }},
+		{"string", func() Field { return Typed("k", &s) }, func() Field { return Stringp("k", &s) }},
+		{"uint", func() Field { return Typed("k", &u) }, func() Field { return Uintp("k", &u) }},
+		{"uint64", func() Field { return Typed("k", &u64) }, func() Field { return Uint64p("k", &u64) }},
+		{"uint32", func() Field { return Typed("k", &u32) }, func() Field { return Uint32p("k", &u32) }},
+		{"uint16", func() Field { return Typed("k", &u16) }, func() Field { return Uint16p("k", &u16) }},
+		{"uint8", func() Field { return Typed("k", &u8) }, func() Field { return Uint8p("k", &u8) }},
+		{"uintptr", func() Field { return Typed("k", &uptr) }, func() Field { return Uintptrp("k", &uptr) }},
+		{"time", func() Field { return Typed("k", &when) }, func() Field { return Timep("k", &when) }},
+		{"duration", func() Field { return Typed("k", &dur) }, func() Field { return Durationp("k", &dur) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.exact(), tt.typed(), "Unexpected field.")
+			want := testing.AllocsPerRun(100, func() { _allocField = tt.exact() })
+			got := testing.AllocsPerRun(100, func() { _allocField = tt.typed() })
+			assert.Equal(t, want, got, "Expected Typed to allocate no more than the exact constructor.")
+		})
+	}
+}
+
 func TestStackField(t *testing.T) {
 	f := Stack("stacktrace")
 	assert.Equal(t, "stacktrace", f.Key, "Unexpected field key.")
@@ -356,3 +557,58 @@ func TestDictObject(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeLayoutAndZone(t *testing.T) {
+	loc := time.FixedZone("UTC+9", 9*60*60)
+	ts := time.Date(2026, 3, 31, 20, 0, 0, 0, time.UTC)
+
+	enc := zapcore.NewMapObjectEncoder()
+	TimeLayout("date", ts, "2006-01-02").AddTo(enc)
+	TimeIn("local", ts, loc).AddTo(enc)
+	assert.Equal(t, "2026-03-31", enc.Fields["date"], "Unexpected formatted time.")
+	assert.Equal(t, ts.In(loc), enc.Fields["local"], "Unexpected time location.")
+}
+
+func TestSortedMap(t *testing.T) {
+	tests := []struct {
+		desc string
+		give Field
+		want string
+	}{
+		{
+			desc: "strings",
+			give: SortedMap("m", map[string]string{"c": "3", "a": "1", "b": "2"}),
+			want: `{"m":{"a":"1","b":"2","c":"3"}}`,
+		},
+		{
+			desc: "mixed values",
+			give: SortedMap("m", map[string]interface{}{"z": 1.5, "y": true, "x": []int{1, 2}}),
+			want: `{"m":{"x":[1,2],"y":true,"z":1.5}}`,
+		},
+		{
+			desc: "nil",
+			give: SortedMap[int]("m", nil),
+			want: `{"m":{}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{})
+			buf, err := enc.EncodeEntry(zapcore.Entry{}, []Field{tt.give})
+			require.NoError(t, err, "Unexpected error encoding.")
+			assert.Equal(t, tt.want+"\n", buf.String(), "Unexpected JSON.")
+		})
+	}
+}
+
+func TestAnyMarshalersJSON(t *testing.T) {
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{})
+	buf, err := enc.EncodeEntry(zapcore.Entry{}, []Field{
+		Any("json", jsonColor("red")),
+		Any("text", textColor("red")),
+		Any("binary", binaryColor("red")),
+	})
+	require.NoError(t, err, "Unexpected error encoding.")
+	assert.Equal(t, `{"json":"#red","text":"#red","binary":"cmVk"}`+"\n", buf.String(), "Unexpected JSON.")
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// fileSink is a Sink backed by a file that can be reopened at its original
+// path. Reopening lets external tools like logrotate move the file aside and
+// have zap start writing to a fresh one.
+type fileSink struct {
+	mu     sync.Mutex
+	f      *os.File
+	closed bool
+	path   string
+	flag   int
+	mode   os.FileMode
+	sr     *sinkRegistry
+}
+
+func (sr *sinkRegistry) newFileSink(path string, flag int, mode os.FileMode) (*fileSink, error) {
+	f, err := sr.openFile(path, flag, mode)
+	if err != nil {
+		return nil, err
+	}
+	s := &fileSink{
+		f:    f,
+		path: path,
+		// Reopened files must never be truncated or overwritten: if the file
+		// hasn't been rotated away, we'd lose everything logged so far.
+		flag: flag&^os.O_TRUNC | os.O_APPEND,
+		mode: mode,
+		sr:   sr,
+	}
+	sr.mu.Lock()
+	sr.files[s] = struct{}{}
+	sr.mu.Unlock()
+	return s, nil
+}
+
+func (s *fileSink) Write(bs []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Write(bs)
+}
+
+func (s *fileSink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Sync()
+}
+
+func (s *fileSink) Close() error {
+	s.sr.mu.Lock()
+	delete(s.sr.files, s)
+	s.sr.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return s.f.Close()
+}
+
+// Reopen closes the underlying file and opens its path again. If the path
+// can't be opened, the sink keeps writing to the old file. Reopen fails once
+// the sink is closed.
+func (s *fileSink) Reopen() error {
+	// Hold the lock throughout so that a concurrent Close can't leave us
+	// installing a file that nothing will close.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return fmt.Errorf("reopen %q: %w", s.path, os.ErrClosed)
+	}
+
+	f, err := s.sr.openFile(s.path, s.flag, s.mode)
+	if err != nil {
+		return fmt.Errorf("reopen %q: %w", s.path, err)
+	}
+	old := s.f
+	s.f = f
+
+	// Sync errors are common for special files (e.g., /dev/stdout), so only
+	// report failures to close.
+	_ = old.Sync()
+	return old.Close()
+}
+
+func (sr *sinkRegistry) reopenFiles() error {
+	sr.mu.Lock()
+	files := make([]*fileSink, 0, len(sr.files))
+	for f := range sr.files {
+		files = append(files, f)
+	}
+	sr.mu.Unlock()
+
+	var err error
+	for _, f := range files {
+		err = multierr.Append(err, f.Reopen())
+	}
+	return err
+}
+
+func (sr *sinkRegistry) reopenOn(sigs <-chan os.Signal, errOut io.Writer) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-sigs:
+				if err := sr.reopenFiles(); err != nil {
+					fmt.Fprintf(errOut, "%v reopen error: %v\n", time.Now().UTC(), err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+// ReopenFiles closes and reopens every file opened by Open (and therefore by
+// Config.Build) that hasn't been closed yet. Files are reopened at their
+// original paths, without truncation. Call it after an external tool like
+// logrotate has moved log files aside.
+//
+// Files that can't be reopened keep writing to their previous location, and
+// the errors are returned together.
+func ReopenFiles() error {
+	return _sinkRegistry.reopenFiles()
+}
+
+// ReopenOnSignal calls ReopenFiles every time the process receives one of
+// the given signals, reporting any errors to standard error. If no signals
+// are given, it listens for SIGHUP, which is what logrotate's postrotate
+// scripts conventionally send. It returns a function that stops listening.
+func ReopenOnSignal(sigs ...os.Signal) (stop func()) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{syscall.SIGHUP}
+	}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	stopReopen := _sinkRegistry.reopenOn(ch, os.Stderr)
+	return func() {
+		signal.Stop(ch)
+		stopReopen()
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSinkReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "log.txt")
+	rotated := filepath.Join(dir, "log.txt.1")
+
+	sr := newSinkRegistry()
+	sink, err := sr.newSink(path)
+	require.NoError(t, err, "Unexpected error opening sink.")
+	defer func() { assert.NoError(t, sink.Close()) }()
+
+	_, err = sink.Write([]byte("foo\n"))
+	require.NoError(t, err, "Unexpected error writing.")
+	require.NoError(t, os.Rename(path, rotated), "Unexpected error rotating log file.")
+	require.NoError(t, sr.reopenFiles(), "Unexpected error reopening files.")
+	_, err = sink.Write([]byte("bar\n"))
+	require.NoError(t, err, "Unexpected error writing.")
+
+	contents, err := os.ReadFile(rotated)
+	require.NoError(t, err, "Unexpected error reading rotated file.")
+	assert.Equal(t, "foo\n", string(contents), "Unexpected contents in rotated file.")
+	contents, err = os.ReadFile(path)
+	require.NoError(t, err, "Unexpected error reading new file.")
+	assert.Equal(t, "bar\n", string(contents), "Unexpected contents in new file.")
+}
+
+func TestFileSinkReopenDoesNotTruncate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.txt")
+	sr := newSinkRegistry()
+	sink, err := sr.newSink("file://" + filepath.ToSlash(path) + "?append=false")
+	require.NoError(t, err, "Unexpected error opening sink.")
+	defer func() { assert.NoError(t, sink.Close()) }()
+
+	_, err = sink.Write([]byte("foo\n"))
+	require.NoError(t, err, "Unexpected error writing.")
+	require.NoError(t, sr.reopenFiles(), "Unexpected error reopening files.")
+	_, err = sink.Write([]byte("bar\n"))
+	require.NoError(t, err, "Unexpected error writing.")
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err, "Unexpected error reading log file.")
+	assert.Equal(t, "foo\nbar\n", string(contents), "Expected reopen to preserve existing contents.")
+}
+
+func TestFileSinkReopenErrors(t *testing.T) {
+	sr := newSinkRegistry()
+	sink, err := sr.newSink(filepath.Join(t.TempDir(), "log.txt"))
+	require.NoError(t, err, "Unexpected error opening sink.")
+	defer func() { assert.NoError(t, sink.Close()) }()
+
+	sr.openFile = func(string, int, os.FileMode) (*os.File, error) {
+		return nil, errors.New("fail")
+	}
+	assert.ErrorContains(t, sr.reopenFiles(), "fail", "Expected reopen error.")
+	_, err = sink.Write([]byte("foo\n"))
+	assert.NoError(t, err, "Expected sink to keep using the old file.")
+}
+
+func TestFileSinkReopenAfterClose(t *testing.T) {
+	sr := newSinkRegistry()
+	fs, err := sr.newFileSink(filepath.Join(t.TempDir(), "log.txt"), os.O_WRONLY|os.O_CREATE, 0o666)
+	require.NoError(t, err, "Unexpected error opening sink.")
+	require.NoError(t, fs.Close(), "Unexpected error closing sink.")
+
+	sr.openFile = func(string, int, os.FileMode) (*os.File, error) {
+		t.Fatal("Reopen shouldn't open files after Close.")
+		return nil, nil
+	}
+	assert.ErrorIs(t, fs.Reopen(), os.ErrClosed, "Expected Reopen to fail after Close.")
+}
+
+func TestFileSinkReopenRacesClose(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		opened []*os.File
+	)
+	sr := newSinkRegistry()
+	open := sr.openFile
+	sr.openFile = func(name string, flag int, mode os.FileMode) (*os.File, error) {
+		f, err := open(name, flag, mode)
+		if err == nil {
+			mu.Lock()
+			opened = append(opened, f)
+			mu.Unlock()
+		}
+		return f, err
+	}
+
+	path := filepath.Join(t.TempDir(), "log.txt")
+	for i := 0; i < 50; i++ {
+		fs, err := sr.newFileSink(path, os.O_WRONLY|os.O_CREATE, 0o666)
+		require.NoError(t, err, "Unexpected error opening sink.")
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = fs.Reopen()
+		}()
+		go func() {
+			defer wg.Done()
+			_ = fs.Close()
+		}()
+		wg.Wait()
+	}
+
+	for _, f := range opened {
+		assert.ErrorIs(t, f.Close(), os.ErrClosed, "Expected every opened file to be closed.")
+	}
+}
+
+func TestFileSinkCloseUnregisters(t *testing.T) {
+	sr := newSinkRegistry()
+	sink, err := sr.newSink(filepath.Join(t.TempDir(), "log.txt"))
+	require.NoError(t, err, "Unexpected error opening sink.")
+	assert.Len(t, sr.files, 1, "Expected open file to be tracked.")
+	require.NoError(t, sink.Close(), "Unexpected error closing sink.")
+	assert.Empty(t, sr.files, "Expected closed file to be forgotten.")
+	assert.NoError(t, sr.reopenFiles(), "Unexpected error reopening no files.")
+}
+
+func TestReopenOnSignal(t *testing.T) {
+	sr := newSinkRegistry()
+	_, err := sr.newSink(filepath.Join(t.TempDir(), "log.txt"))
+	require.NoError(t, err, "Unexpected error opening sink.")
+
+	reopened := make(chan struct{}, 1)
+	sr.openFile = func(string, int, os.FileMode) (*os.File, error) {
+		reopened <- struct{}{}
+		return nil, errors.New("fail")
+	}
+
+	var errOut bytes.Buffer
+	sigs := make(chan os.Signal)
+	stop := sr.reopenOn(sigs, &errOut)
+	sigs <- os.Interrupt
+	select {
+	case <-reopened:
+	case <-time.After(time.Second):
+		t.Fatal("Expected signal to reopen files.")
+	}
+	stop()
+	stop() // idempotent
+	assert.Contains(t, errOut.String(), "reopen error", "Expected reopen errors to be reported.")
+}
+
+func TestReopenOnSignalStop(t *testing.T) {
+	stop := ReopenOnSignal()
+	stop()
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"math"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// GeoOption configures the fields built by LatLon.
+type GeoOption interface {
+	apply(*geoOptions)
+}
+
+type geoOptions struct {
+	precision int
+}
+
+type geoOptionFunc func(*geoOptions)
+
+func (f geoOptionFunc) apply(o *geoOptions) {
+	f(o)
+}
+
+// GeoPrecision truncates coordinates to the given number of decimal places,
+// so that logs don't reveal precise locations. Two decimal places identify
+// an area about a kilometer across; four, about ten meters. By default,
+// coordinates are logged at full precision.
+func GeoPrecision(decimals int) GeoOption {
+	return geoOptionFunc(func(o *geoOptions) {
+		o.precision = decimals
+	})
+}
+
+// LatLon constructs a field that logs a location as a GeoJSON Point, which
+// log stores and mapping tools can index directly:
+//
+//	{"type": "Point", "coordinates": [lon, lat]}
+//
+// Note that GeoJSON puts the longitude first.
+func LatLon(key string, lat, lon float64, opts ...GeoOption) Field {
+	o := geoOptions{precision: -1}
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	if o.precision >= 0 {
+		scale := math.Pow10(o.precision)
+		lat = math.Trunc(lat*scale) / scale
+		lon = math.Trunc(lon*scale) / scale
+	}
+	return Object(key, geoPoint{lat: lat, lon: lon})
+}
+
+type geoPoint struct {
+	lat, lon float64
+}
+
+func (p geoPoint) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("type", "Point")
+	return enc.AddArray("coordinates", zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+		arr.AppendFloat64(p.lon)
+		arr.AppendFloat64(p.lat)
+		return nil
+	}))
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLatLon(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []GeoOption
+		wantLat float64
+		wantLon float64
+	}{
+		{"full precision", nil, 37.774929, -122.419416},
+		{"two decimals", []GeoOption{GeoPrecision(2)}, 37.77, -122.41},
+		{"zero decimals", []GeoOption{GeoPrecision(0)}, 37, -122},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := zapcore.NewMapObjectEncoder()
+			LatLon("loc", 37.774929, -122.419416, tt.opts...).AddTo(enc)
+			assert.Equal(t, map[string]interface{}{
+				"type":        "Point",
+				"coordinates": []interface{}{tt.wantLon, tt.wantLat},
+			}, enc.Fields["loc"], "Unexpected GeoJSON point.")
+		})
+	}
+}
+
+func TestLatLonJSON(t *testing.T) {
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{})
+	buf, err := enc.EncodeEntry(zapcore.Entry{}, []Field{LatLon("loc", 51.5007, -0.1246, GeoPrecision(3))})
+	assert.NoError(t, err, "Unexpected error encoding.")
+	assert.Equal(t, `{"loc":{"type":"Point","coordinates":[-0.124,51.5]}}`+"\n", buf.String(), "Unexpected JSON.")
+}
@@ -23,9 +23,12 @@ package zap
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 
 	"go.uber.org/zap/zapcore"
 )
@@ -37,39 +40,148 @@ const (
 		"https://github.com/uber-go/zap/issues/new and reference this error: %v"
 )
 
+// globals holds the global Logger and SugaredLogger. It's replaced as a
+// whole, so L and S don't need to lock.
+type globals struct {
+	l *Logger
+	s *SugaredLogger
+	// set records whether the globals were set with ReplaceGlobals or
+	// SetGlobalOnce, rather than being the defaults.
+	set bool
+}
+
 var (
-	_globalMu sync.RWMutex
-	_globalL  = NewNop()
-	_globalS  = _globalL.Sugar()
+	_globalMu sync.Mutex // serializes writers
+	_globals  atomic.Pointer[globals]
+	_guard    atomic.Int32 // GlobalGuard
+	_warned   atomic.Bool
+
+	_globalsErrOutput io.Writer = os.Stderr // for tests
 )
 
+func init() {
+	l := NewDefault()
+	_globals.Store(&globals{l: l, s: l.Sugar()})
+}
+
+// _defaultLevelEnv names the environment variable that sets the level of the
+// Logger built by NewDefault.
+const _defaultLevelEnv = "ZAP_DEFAULT_LEVEL"
+
+// NewDefault builds the Logger that L and S return until the global loggers
+// are set with ReplaceGlobals or SetGlobalOnce. It writes InfoLevel and
+// above logs to standard error in a minimal, human-readable format, so that
+// errors logged early in a program's startup aren't silently lost.
+//
+// The level may be changed by setting the ZAP_DEFAULT_LEVEL environment
+// variable to a level name (e.g., "debug" or "error"). Setting it to "off"
+// makes NewDefault return a no-op Logger, which was the default global
+// Logger in earlier versions of zap. Unrecognized values are ignored.
+func NewDefault() *Logger {
+	lvl := InfoLevel
+	if text, ok := os.LookupEnv(_defaultLevelEnv); ok {
+		if strings.EqualFold(text, "off") {
+			return NewNop()
+		}
+		if l, err := zapcore.ParseLevel(text); err == nil {
+			lvl = l
+		}
+	}
+
+	encCfg := zapcore.EncoderConfig{
+		TimeKey:        "T",
+		LevelKey:       "L",
+		NameKey:        "N",
+		MessageKey:     "M",
+		EventKey:       "E",
+		StacktraceKey:  "S",
+		EncodeLevel:    zapcore.CapitalLevelEncoder,
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+	}
+	errOut := zapcore.Lock(os.Stderr)
+	core := zapcore.NewCore(zapcore.RNewConsoleEncoder(encCfg), errOut, lvl)
+	return New(core, ErrorOutput(errOut))
+}
+
+// GlobalGuard controls what L and S do if they're called before the global
+// loggers have been set with ReplaceGlobals or SetGlobalOnce. Using the
+// global loggers too early usually means that logs are silently lost.
+type GlobalGuard int32
+
+const (
+	// GlobalGuardNone returns the default global loggers without complaint.
+	// This is the default.
+	GlobalGuardNone GlobalGuard = iota
+	// GlobalGuardWarn prints a warning to standard error the first time the
+	// default global loggers are used.
+	GlobalGuardWarn
+	// GlobalGuardPanic panics if the default global loggers are used.
+	GlobalGuardPanic
+)
+
+// SetGlobalGuard sets what L and S do if they're called before the global
+// loggers have been set. It's safe for concurrent use, but is intended to be
+// called early in main.
+func SetGlobalGuard(g GlobalGuard) {
+	_guard.Store(int32(g))
+}
+
+func loadGlobals() *globals {
+	g := _globals.Load()
+	if !g.set {
+		switch GlobalGuard(_guard.Load()) {
+		case GlobalGuardWarn:
+			if _warned.CompareAndSwap(false, true) {
+				fmt.Fprintln(_globalsErrOutput, "zap: global logger used before being set with ReplaceGlobals or SetGlobalOnce")
+			}
+		case GlobalGuardPanic:
+			panic("zap: global logger used before being set with ReplaceGlobals or SetGlobalOnce")
+		}
+	}
+	return g
+}
+
 // L returns the global Logger, which can be reconfigured with ReplaceGlobals.
 // It's safe for concurrent use.
 func L() *Logger {
-	_globalMu.RLock()
-	l := _globalL
-	_globalMu.RUnlock()
-	return l
+	return loadGlobals().l
 }
 
 // S returns the global SugaredLogger, which can be reconfigured with
 // ReplaceGlobals. It's safe for concurrent use.
 func S() *SugaredLogger {
-	_globalMu.RLock()
-	s := _globalS
-	_globalMu.RUnlock()
-	return s
+	return loadGlobals().s
 }
 
 // ReplaceGlobals replaces the global Logger and SugaredLogger, and returns a
 // function to restore the original values. It's safe for concurrent use.
 func ReplaceGlobals(logger *Logger) func() {
 	_globalMu.Lock()
-	prev := _globalL
-	_globalL = logger
-	_globalS = logger.Sugar()
+	prev := _globals.Swap(&globals{l: logger, s: logger.Sugar(), set: true})
 	_globalMu.Unlock()
-	return func() { ReplaceGlobals(prev) }
+	return func() {
+		_globalMu.Lock()
+		_globals.Store(prev)
+		_globalMu.Unlock()
+	}
+}
+
+// SetGlobalOnce replaces the global Logger and SugaredLogger, unless they've
+// already been set with ReplaceGlobals or SetGlobalOnce. It reports whether
+// the globals were replaced. It's safe for concurrent use.
+//
+// SetGlobalOnce is useful in libraries and test helpers that want to
+// install a global logger without clobbering one configured by main.
+func SetGlobalOnce(logger *Logger) bool {
+	_globalMu.Lock()
+	defer _globalMu.Unlock()
+
+	if _globals.Load().set {
+		return false
+	}
+	_globals.Store(&globals{l: logger, s: logger.Sugar(), set: true})
+	return true
 }
 
 // NewStdLog returns a *log.Logger which writes to the supplied zap Logger at
@@ -140,6 +252,8 @@ func redirectStdLogAt(l *Logger, level zapcore.Level) (func(), error) {
 
 func levelToFunc(logger *Logger, lvl zapcore.Level) (func(string, ...Field), error) {
 	switch lvl {
+	case TraceLevel:
+		return logger.Trace, nil
 	case DebugLevel:
 		return logger.Debug, nil
 	case InfoLevel:
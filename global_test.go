@@ -21,7 +21,11 @@
 package zap
 
 import (
+	"bytes"
+	"io"
 	"log"
+	"os"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -38,13 +42,14 @@ import (
 )
 
 func TestReplaceGlobals(t *testing.T) {
+	withUnsetGlobals(t)
 	initialL := *L()
 	initialS := *S()
 
 	withLogger(t, DebugLevel, nil, func(l *Logger, logs *observer.ObservedLogs) {
 		L().Info("no-op")
 		S().Info("no-op")
-		assert.Equal(t, 0, logs.Len(), "Expected initial logs to go to default no-op global.")
+		assert.Equal(t, 0, logs.Len(), "Expected initial logs to go to the default global.")
 
 		defer ReplaceGlobals(l)()
 
@@ -67,6 +72,7 @@ func TestReplaceGlobals(t *testing.T) {
 }
 
 func TestGlobalsConcurrentUse(t *testing.T) {
+	withUnsetGlobals(t)
 	var (
 		stop atomic.Bool
 		wg   sync.WaitGroup
@@ -97,6 +103,108 @@ func TestGlobalsConcurrentUse(t *testing.T) {
 	wg.Wait()
 }
 
+// withUnsetGlobals runs a test with default global loggers that haven't been
+// set, restoring the previous globals and guard afterwards.
+func withUnsetGlobals(t testing.TB) {
+	l := NewNop()
+	prev := _globals.Swap(&globals{l: l, s: l.Sugar()})
+	prevGuard := _guard.Load()
+	t.Cleanup(func() {
+		_globals.Store(prev)
+		_guard.Store(prevGuard)
+	})
+}
+
+func TestSetGlobalOnce(t *testing.T) {
+	withUnsetGlobals(t)
+
+	first, second := NewNop(), NewNop()
+	assert.True(t, SetGlobalOnce(first), "Expected first SetGlobalOnce to succeed.")
+	assert.False(t, SetGlobalOnce(second), "Expected second SetGlobalOnce to fail.")
+	assert.Same(t, first, L(), "Unexpected global logger.")
+
+	restore := ReplaceGlobals(second)
+	assert.Same(t, second, L(), "Expected ReplaceGlobals to override SetGlobalOnce.")
+	restore()
+	assert.Same(t, first, L(), "Expected restore to bring back the previous logger.")
+}
+
+func TestSetGlobalOnceAfterReplaceGlobals(t *testing.T) {
+	withUnsetGlobals(t)
+
+	logger := NewNop()
+	ReplaceGlobals(logger)
+	assert.False(t, SetGlobalOnce(NewNop()), "Expected SetGlobalOnce to respect ReplaceGlobals.")
+	assert.Same(t, logger, L(), "Unexpected global logger.")
+}
+
+func TestGlobalGuard(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		withUnsetGlobals(t)
+		assert.NotPanics(t, func() { L().Info("") }, "Unexpected panic with no guard.")
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		withUnsetGlobals(t)
+		SetGlobalGuard(GlobalGuardPanic)
+		assert.Panics(t, func() { L() }, "Expected L to panic before globals are set.")
+		assert.Panics(t, func() { S() }, "Expected S to panic before globals are set.")
+
+		SetGlobalOnce(NewNop())
+		assert.NotPanics(t, func() { L() }, "Unexpected panic after globals are set.")
+	})
+
+	t.Run("warn", func(t *testing.T) {
+		withUnsetGlobals(t)
+		var buf bytes.Buffer
+		defer func(w io.Writer, warned bool) {
+			_globalsErrOutput = w
+			_warned.Store(warned)
+		}(_globalsErrOutput, _warned.Load())
+		_globalsErrOutput = &buf
+		_warned.Store(false)
+
+		SetGlobalGuard(GlobalGuardWarn)
+		L()
+		S()
+		assert.Equal(t, 1, strings.Count(buf.String(), "global logger used before being set"), "Expected exactly one warning.")
+	})
+}
+
+func TestNewDefault(t *testing.T) {
+	tests := []struct {
+		desc    string
+		env     string
+		unset   bool
+		want    zapcore.Level
+		wantNop bool
+	}{
+		{desc: "unset", unset: true, want: InfoLevel},
+		{desc: "level", env: "debug", want: DebugLevel},
+		{desc: "capital level", env: "ERROR", want: ErrorLevel},
+		{desc: "invalid", env: "loud", want: InfoLevel},
+		{desc: "off", env: "OFF", wantNop: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if tt.unset {
+				t.Setenv(_defaultLevelEnv, "")
+				require.NoError(t, os.Unsetenv(_defaultLevelEnv), "Unexpected error unsetting environment variable.")
+			} else {
+				t.Setenv(_defaultLevelEnv, tt.env)
+			}
+
+			logger := NewDefault()
+			if tt.wantNop {
+				assert.Equal(t, zapcore.InvalidLevel, logger.Level(), "Expected a no-op logger.")
+				return
+			}
+			assert.Equal(t, tt.want, logger.Level(), "Unexpected level.")
+		})
+	}
+}
+
 func TestNewStdLog(t *testing.T) {
 	withLogger(t, DebugLevel, []Option{AddCaller()}, func(l *Logger, logs *observer.ObservedLogs) {
 		std := NewStdLog(l)
@@ -0,0 +1,278 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	_grpcStatusKey = "grpc_status"
+
+	// _grpcCodeUnknown is the gRPC code of errors that don't carry a status.
+	_grpcCodeUnknown = 2
+
+	// _defaultProtoMaxSize is the default limit on the encoded size of
+	// logged protobuf messages.
+	_defaultProtoMaxSize = 16 * 1024
+)
+
+// _grpcCodeNames are the names of the canonical gRPC status codes.
+var _grpcCodeNames = []string{
+	"OK",
+	"Canceled",
+	"Unknown",
+	"InvalidArgument",
+	"DeadlineExceeded",
+	"NotFound",
+	"AlreadyExists",
+	"PermissionDenied",
+	"ResourceExhausted",
+	"FailedPrecondition",
+	"Aborted",
+	"OutOfRange",
+	"Unimplemented",
+	"Internal",
+	"Unavailable",
+	"DataLoss",
+	"Unauthenticated",
+}
+
+// A GRPCStatusExtractor reports the gRPC status code, message, and details
+// carried by an error. It returns false if the error doesn't carry a status.
+//
+// Extractors allow GRPCStatus to support gRPC without zap depending on it.
+// For example, grpc-go users can register:
+//
+//	zap.RegisterGRPCStatusExtractor(func(err error) (uint32, string, []interface{}, bool) {
+//		s, ok := status.FromError(err)
+//		if !ok {
+//			return 0, "", nil, false
+//		}
+//		return uint32(s.Code()), s.Message(), s.Details(), true
+//	})
+type GRPCStatusExtractor func(err error) (code uint32, message string, details []interface{}, ok bool)
+
+// A ProtoMarshaler encodes a protobuf message as JSON. For example,
+// google.golang.org/protobuf users can register:
+//
+//	zap.RegisterProtoMarshaler(func(msg interface{}) ([]byte, error) {
+//		m, ok := msg.(proto.Message)
+//		if !ok {
+//			return json.Marshal(msg)
+//		}
+//		return protojson.Marshal(m)
+//	})
+type ProtoMarshaler func(msg interface{}) ([]byte, error)
+
+var _grpcHooks struct {
+	mu         sync.RWMutex
+	extractors []GRPCStatusExtractor
+	marshal    ProtoMarshaler
+}
+
+// RegisterGRPCStatusExtractor adds an extractor consulted by GRPCStatus.
+// Extractors are consulted in registration order.
+func RegisterGRPCStatusExtractor(e GRPCStatusExtractor) {
+	_grpcHooks.mu.Lock()
+	defer _grpcHooks.mu.Unlock()
+	_grpcHooks.extractors = append(_grpcHooks.extractors, e)
+}
+
+// RegisterProtoMarshaler sets the marshaler used by Proto and GRPCStatus,
+// replacing any previously registered one. Until one is registered, or if
+// nil is registered, messages are encoded with encoding/json.
+func RegisterProtoMarshaler(m ProtoMarshaler) {
+	_grpcHooks.mu.Lock()
+	defer _grpcHooks.mu.Unlock()
+	_grpcHooks.marshal = m
+}
+
+// GRPCStatus constructs a field that logs the gRPC status carried by err
+// under the "grpc_status" key, with the keys code, code_name, message, and
+// details (if any). Details are encoded like Proto fields. The status is
+// taken from the first registered GRPCStatusExtractor that recognizes the
+// error; errors that don't carry a status are logged with the Unknown code
+// and their error message, matching grpc-go. If err is nil, the field is a
+// no-op.
+func GRPCStatus(err error) Field {
+	if err == nil {
+		return Skip()
+	}
+
+	st := grpcStatus{code: _grpcCodeUnknown, message: err.Error()}
+	_grpcHooks.mu.RLock()
+	extractors := _grpcHooks.extractors
+	_grpcHooks.mu.RUnlock()
+	for _, extract := range extractors {
+		if code, msg, details, ok := extract(err); ok {
+			st.code, st.message = code, msg
+			for _, d := range details {
+				if derr, ok := d.(error); ok {
+					// grpc-go reports details it can't decode as errors.
+					st.details = append(st.details, protoMessage{err: derr})
+					continue
+				}
+				st.details = append(st.details, newProtoMessage(d, _defaultProtoMaxSize))
+			}
+			break
+		}
+	}
+	return Object(_grpcStatusKey, st)
+}
+
+type grpcStatus struct {
+	code    uint32
+	message string
+	details protoMessages
+}
+
+func (s grpcStatus) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddUint32("code", s.code)
+	if int(s.code) < len(_grpcCodeNames) {
+		enc.AddString("code_name", _grpcCodeNames[s.code])
+	} else {
+		enc.AddString("code_name", "Code("+strconv.FormatUint(uint64(s.code), 10)+")")
+	}
+	enc.AddString("message", s.message)
+	if len(s.details) > 0 {
+		return enc.AddArray("details", s.details)
+	}
+	return nil
+}
+
+// ProtoOption configures the fields built by Proto.
+type ProtoOption interface {
+	apply(*protoOptions)
+}
+
+type protoOptions struct {
+	maxSize int
+}
+
+type protoOptionFunc func(*protoOptions)
+
+func (f protoOptionFunc) apply(o *protoOptions) {
+	f(o)
+}
+
+// ProtoMaxSize limits the encoded size of logged messages to n bytes; the
+// default is 16KiB. Larger messages are logged as an object with the keys
+// truncated and size instead. Values of zero or less disable the limit.
+func ProtoMaxSize(n int) ProtoOption {
+	return protoOptionFunc(func(o *protoOptions) {
+		o.maxSize = n
+	})
+}
+
+// Proto constructs a field that logs a protobuf message as a nested object.
+// The message is encoded to JSON with the registered ProtoMarshaler (see
+// RegisterProtoMarshaler) when the field is constructed, so it may be
+// modified afterwards. Messages that don't encode to a JSON object, such as
+// well-known wrapper types, are logged under a "value" key. If encoding
+// fails, the error is logged under the key with an "Error" suffix instead.
+func Proto(key string, msg interface{}, opts ...ProtoOption) Field {
+	o := protoOptions{maxSize: _defaultProtoMaxSize}
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return Object(key, newProtoMessage(msg, o.maxSize))
+}
+
+type protoMessage struct {
+	fields map[string]interface{}
+	size   int // set if the message was too large
+	err    error
+}
+
+func newProtoMessage(msg interface{}, maxSize int) protoMessage {
+	_grpcHooks.mu.RLock()
+	marshal := _grpcHooks.marshal
+	_grpcHooks.mu.RUnlock()
+	if marshal == nil {
+		marshal = json.Marshal
+	}
+
+	b, err := marshal(msg)
+	if err != nil {
+		return protoMessage{err: err}
+	}
+	if maxSize > 0 && len(b) > maxSize {
+		return protoMessage{size: len(b)}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return protoMessage{err: fmt.Errorf("invalid JSON from proto marshaler: %w", err)}
+	}
+	fields, ok := v.(map[string]interface{})
+	if !ok {
+		fields = map[string]interface{}{"value": v}
+	}
+	return protoMessage{fields: fields}
+}
+
+func (m protoMessage) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.size > 0 {
+		enc.AddBool("truncated", true)
+		enc.AddInt("size", m.size)
+		return nil
+	}
+
+	keys := make([]string, 0, len(m.fields))
+	for k := range m.fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var errs error
+	for _, k := range keys {
+		errs = multierr.Append(errs, enc.AddReflected(k, m.fields[k]))
+	}
+	return errs
+}
+
+type protoMessages []protoMessage
+
+func (ms protoMessages) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, m := range ms {
+		if m.err != nil {
+			enc.AppendString(m.err.Error())
+			continue
+		}
+		if err := enc.AppendObject(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+type testStatusError struct {
+	code    uint32
+	msg     string
+	details []interface{}
+}
+
+func (e *testStatusError) Error() string { return e.msg }
+
+type testProto struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestGRPCStatus(t *testing.T) {
+	RegisterGRPCStatusExtractor(func(err error) (uint32, string, []interface{}, bool) {
+		var se *testStatusError
+		if !errors.As(err, &se) {
+			return 0, "", nil, false
+		}
+		return se.code, se.msg, se.details, true
+	})
+	defer func() { _grpcHooks.extractors = nil }()
+
+	tests := []struct {
+		desc string
+		give error
+		want interface{}
+	}{
+		{
+			desc: "nil",
+			give: nil,
+			want: nil,
+		},
+		{
+			desc: "no status",
+			give: errors.New("boom"),
+			want: map[string]interface{}{"code": uint32(2), "code_name": "Unknown", "message": "boom"},
+		},
+		{
+			desc: "wrapped status",
+			give: fmt.Errorf("calling: %w", &testStatusError{code: 5, msg: "no such user"}),
+			want: map[string]interface{}{"code": uint32(5), "code_name": "NotFound", "message": "no such user"},
+		},
+		{
+			desc: "non-canonical code",
+			give: &testStatusError{code: 42, msg: "custom"},
+			want: map[string]interface{}{"code": uint32(42), "code_name": "Code(42)", "message": "custom"},
+		},
+		{
+			desc: "details",
+			give: &testStatusError{code: 3, msg: "bad", details: []interface{}{
+				testProto{Name: "field", Count: 1},
+				errors.New("unknown detail type"),
+			}},
+			want: map[string]interface{}{
+				"code":      uint32(3),
+				"code_name": "InvalidArgument",
+				"message":   "bad",
+				"details": []interface{}{
+					map[string]interface{}{"name": "field", "count": json.Number("1")},
+					"unknown detail type",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := zapcore.NewMapObjectEncoder()
+			GRPCStatus(tt.give).AddTo(enc)
+			assert.Equal(t, tt.want, enc.Fields["grpc_status"], "Unexpected status object.")
+		})
+	}
+}
+
+func TestProto(t *testing.T) {
+	tests := []struct {
+		desc    string
+		marshal ProtoMarshaler
+		give    interface{}
+		opts    []ProtoOption
+		want    map[string]interface{}
+	}{
+		{
+			desc: "default marshaler",
+			give: &testProto{Name: "a", Count: 2},
+			want: map[string]interface{}{
+				"msg": map[string]interface{}{"name": "a", "count": json.Number("2")},
+			},
+		},
+		{
+			desc: "registered marshaler",
+			marshal: func(interface{}) ([]byte, error) {
+				return []byte(`{"custom":true}`), nil
+			},
+			give: &testProto{},
+			want: map[string]interface{}{"msg": map[string]interface{}{"custom": true}},
+		},
+		{
+			desc: "non-object",
+			marshal: func(interface{}) ([]byte, error) {
+				return []byte(`"2026-01-01T00:00:00Z"`), nil
+			},
+			give: &testProto{},
+			want: map[string]interface{}{"msg": map[string]interface{}{"value": "2026-01-01T00:00:00Z"}},
+		},
+		{
+			desc: "too large",
+			give: &testProto{Name: "abcdefghij"},
+			opts: []ProtoOption{ProtoMaxSize(10)},
+			want: map[string]interface{}{"msg": map[string]interface{}{"truncated": true, "size": 31}},
+		},
+		{
+			desc: "marshal error",
+			marshal: func(interface{}) ([]byte, error) {
+				return nil, errors.New("cannot marshal")
+			},
+			give: &testProto{},
+			want: map[string]interface{}{"msg": map[string]interface{}{}, "msgError": "cannot marshal"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			RegisterProtoMarshaler(tt.marshal)
+			defer RegisterProtoMarshaler(nil)
+
+			enc := zapcore.NewMapObjectEncoder()
+			Proto("msg", tt.give, tt.opts...).AddTo(enc)
+			assert.Equal(t, tt.want, enc.Fields, "Unexpected proto fields.")
+		})
+	}
+}
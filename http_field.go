@@ -0,0 +1,205 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	_httpRequestKey  = "http_request"
+	_httpResponseKey = "http_response"
+)
+
+// HTTPOption configures the fields built by HTTPRequest.
+type HTTPOption interface {
+	apply(*httpOptions)
+}
+
+type httpOptions struct {
+	headers []string
+	maxBody int
+}
+
+type httpOptionFunc func(*httpOptions)
+
+func (f httpOptionFunc) apply(o *httpOptions) {
+	f(o)
+}
+
+// HTTPHeaders logs the named request headers, which are matched
+// case-insensitively. No headers are logged by default, so that credentials
+// such as Authorization and Cookie aren't logged by accident.
+func HTTPHeaders(names ...string) HTTPOption {
+	return httpOptionFunc(func(o *httpOptions) {
+		for _, name := range names {
+			o.headers = append(o.headers, http.CanonicalHeaderKey(name))
+		}
+	})
+}
+
+// HTTPBody logs up to limit bytes of the request body. The captured bytes are
+// put back in front of the unread remainder of the body, so handlers still
+// see the complete body. Bodies aren't captured by default.
+func HTTPBody(limit int) HTTPOption {
+	return httpOptionFunc(func(o *httpOptions) {
+		o.maxBody = limit
+	})
+}
+
+// HTTPRequest constructs a field that logs an HTTP request under the
+// "http_request" key, so that middleware and ad hoc call sites log requests
+// in the same shape. The object has the keys method, url, proto, host,
+// remote_addr, user_agent, and content_length, plus headers, body, and
+// body_truncated if requested with HTTPHeaders and HTTPBody. Passwords in
+// the URL are redacted.
+//
+// The request is read when the field is constructed, so it may be modified
+// afterwards.
+func HTTPRequest(r *http.Request, opts ...HTTPOption) Field {
+	if r == nil {
+		return Skip()
+	}
+
+	var o httpOptions
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+
+	req := httpRequest{
+		method:        r.Method,
+		proto:         r.Proto,
+		host:          r.Host,
+		remoteAddr:    r.RemoteAddr,
+		userAgent:     r.UserAgent(),
+		contentLength: r.ContentLength,
+	}
+	if r.URL != nil {
+		req.url = r.URL.Redacted()
+	}
+	for _, name := range o.headers {
+		if vals := r.Header.Values(name); len(vals) > 0 {
+			req.headers = append(req.headers, httpHeader{name, strings.Join(vals, ", ")})
+		}
+	}
+	if o.maxBody > 0 && r.Body != nil && r.Body != http.NoBody {
+		req.captured = true
+		req.body, req.bodyTruncated = captureBody(r, o.maxBody)
+	}
+	return Object(_httpRequestKey, req)
+}
+
+// captureBody reads up to limit bytes of the request body, then restores the
+// body so that it can be read again in full.
+func captureBody(r *http.Request, limit int) (body string, truncated bool) {
+	// Read one extra byte to tell whether the body was truncated.
+	buf, _ := io.ReadAll(io.LimitReader(r.Body, int64(limit)+1))
+	r.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(buf), r.Body),
+		Closer: r.Body,
+	}
+	if len(buf) > limit {
+		return string(buf[:limit]), true
+	}
+	return string(buf), false
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+type httpHeader struct {
+	name, value string
+}
+
+type httpHeaders []httpHeader
+
+func (hs httpHeaders) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, h := range hs {
+		enc.AddString(h.name, h.value)
+	}
+	return nil
+}
+
+type httpRequest struct {
+	method        string
+	url           string
+	proto         string
+	host          string
+	remoteAddr    string
+	userAgent     string
+	contentLength int64
+	headers       httpHeaders
+	captured      bool
+	body          string
+	bodyTruncated bool
+}
+
+func (r httpRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("method", r.method)
+	enc.AddString("url", r.url)
+	enc.AddString("proto", r.proto)
+	enc.AddString("host", r.host)
+	if r.remoteAddr != "" {
+		enc.AddString("remote_addr", r.remoteAddr)
+	}
+	if r.userAgent != "" {
+		enc.AddString("user_agent", r.userAgent)
+	}
+	enc.AddInt64("content_length", r.contentLength)
+	if len(r.headers) > 0 {
+		if err := enc.AddObject("headers", r.headers); err != nil {
+			return err
+		}
+	}
+	if r.captured {
+		enc.AddString("body", r.body)
+		enc.AddBool("body_truncated", r.bodyTruncated)
+	}
+	return nil
+}
+
+// HTTPResponse constructs a field that logs an HTTP response under the
+// "http_response" key, with the keys status, size, and duration. Use it
+// alongside HTTPRequest, typically in middleware once the handler returns.
+func HTTPResponse(status int, size int64, dur time.Duration) Field {
+	return Object(_httpResponseKey, httpResponse{status: status, size: size, dur: dur})
+}
+
+type httpResponse struct {
+	status int
+	size   int64
+	dur    time.Duration
+}
+
+func (r httpResponse) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("status", r.status)
+	enc.AddInt64("size", r.size)
+	enc.AddDuration("duration", r.dur)
+	return nil
+}
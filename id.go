@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"encoding/binary"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	_hexDigits       = "0123456789abcdef"
+	_crockfordDigits = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// UUID constructs a field that carries a UUID in its canonical form, such as
+// "f47ac10b-58cc-4372-a567-0e02b2c3d479". The UUID types of the common
+// libraries, like github.com/google/uuid's, are [16]byte arrays and can be
+// passed directly.
+//
+// The ID is formatted only if the field is encoded, without fmt or
+// reflection. Building the field allocates only to hold the 16 bytes; use
+// UUIDp to avoid even that.
+func UUID(key string, id [16]byte) Field {
+	return Field{Key: key, Type: zapcore.StringerType, Interface: uuidText(id)}
+}
+
+// UUIDp is like UUID, but takes a pointer to the ID, which it doesn't copy,
+// so building the field doesn't allocate. The pointer must not be modified
+// until the entry is written. If the pointer is nil, the field is logged as
+// null. Convert pointers to named UUID types, like *uuid.UUID, with
+// (*[16]byte)(p).
+func UUIDp(key string, id *[16]byte) Field {
+	if id == nil {
+		return nilField(key)
+	}
+	return Field{Key: key, Type: zapcore.StringerType, Interface: (*uuidText)(id)}
+}
+
+// ULID constructs a field that carries a ULID in its canonical 26-character
+// Crockford base32 form, such as "01ARZ3NDEKTSV4RRFFQ69G5FAV". ULID types
+// like github.com/oklog/ulid's are [16]byte arrays and can be passed
+// directly.
+//
+// The ID is formatted only if the field is encoded, without fmt or
+// reflection. Building the field allocates only to hold the 16 bytes; use
+// ULIDp to avoid even that.
+func ULID(key string, id [16]byte) Field {
+	return Field{Key: key, Type: zapcore.StringerType, Interface: ulidText(id)}
+}
+
+// ULIDp is like ULID, but takes a pointer to the ID, which it doesn't copy,
+// so building the field doesn't allocate. The pointer must not be modified
+// until the entry is written. If the pointer is nil, the field is logged as
+// null.
+func ULIDp(key string, id *[16]byte) Field {
+	if id == nil {
+		return nilField(key)
+	}
+	return Field{Key: key, Type: zapcore.StringerType, Interface: (*ulidText)(id)}
+}
+
+// uuidText formats a UUID when it's encoded.
+type uuidText [16]byte
+
+func (id uuidText) String() string {
+	var buf [36]byte
+	j := 0
+	for i, b := range id {
+		if i == 4 || i == 6 || i == 8 || i == 10 {
+			buf[j] = '-'
+			j++
+		}
+		buf[j] = _hexDigits[b>>4]
+		buf[j+1] = _hexDigits[b&0x0f]
+		j += 2
+	}
+	return string(buf[:])
+}
+
+// ulidText formats a ULID when it's encoded.
+type ulidText [16]byte
+
+func (id ulidText) String() string {
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+
+	// 26 digits of 5 bits hold 130 bits, so the first digit carries only the
+	// top 3 bits of the ID.
+	var buf [26]byte
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = _crockfordDigits[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buf[:])
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+// encodeID returns the value a field encodes under the "id" key.
+func encodeID(f Field) interface{} {
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	return enc.Fields["id"]
+}
+
+func TestUUID(t *testing.T) {
+	tests := []struct {
+		desc string
+		give [16]byte
+		want string
+	}{
+		{
+			desc: "zero",
+			want: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			desc: "v4",
+			give: [16]byte{0xf4, 0x7a, 0xc1, 0x0b, 0x58, 0xcc, 0x43, 0x72, 0xa5, 0x67, 0x0e, 0x02, 0xb2, 0xc3, 0xd4, 0x79},
+			want: "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, encodeID(UUID("id", tt.give)), "Unexpected UUID.")
+			assert.Equal(t, tt.want, encodeID(UUIDp("id", &tt.give)), "Unexpected UUID from pointer.")
+		})
+	}
+}
+
+func TestULID(t *testing.T) {
+	tests := []struct {
+		desc string
+		give [16]byte
+		want string
+	}{
+		{
+			desc: "zero",
+			want: "00000000000000000000000000",
+		},
+		{
+			desc: "max",
+			give: [16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			want: "7ZZZZZZZZZZZZZZZZZZZZZZZZZ",
+		},
+		{
+			// From the ULID specification's examples.
+			desc: "spec",
+			give: [16]byte{0x01, 0x56, 0x3e, 0x3a, 0xb5, 0xd3, 0xd6, 0x76, 0x4c, 0x61, 0xef, 0xb9, 0x93, 0x02, 0xbd, 0x5b},
+			want: "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, encodeID(ULID("id", tt.give)), "Unexpected ULID.")
+			assert.Equal(t, tt.want, encodeID(ULIDp("id", &tt.give)), "Unexpected ULID from pointer.")
+		})
+	}
+}
+
+func TestNilIDPointers(t *testing.T) {
+	assert.Equal(t, nilField("id"), UUIDp("id", nil), "Unexpected field for nil UUID.")
+	assert.Equal(t, nilField("id"), ULIDp("id", nil), "Unexpected field for nil ULID.")
+}
+
+var _idFieldSink Field
+
+func TestIDAllocations(t *testing.T) {
+	id := [16]byte{0xf4, 0x7a, 0xc1, 0x0b}
+	assert.Equal(t, 1.0, testing.AllocsPerRun(100, func() { _idFieldSink = UUID("id", id) }), "Expected UUID to allocate only to hold the ID.")
+	assert.Equal(t, 1.0, testing.AllocsPerRun(100, func() { _idFieldSink = ULID("id", id) }), "Expected ULID to allocate only to hold the ID.")
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _idFieldSink = UUIDp("id", &id) }), "Expected UUIDp not to allocate.")
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _idFieldSink = ULIDp("id", &id) }), "Expected ULIDp not to allocate.")
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package arena provides the experimental region-based allocation mode,
+// enabled with the zaparena build tag:
+//
+//	go build -tags zaparena ./...
+//
+// In this mode, the per-entry slices of Fields built by the SugaredLogger
+// come from pooled slabs, and are released at a single point, right after
+// the entry is written. CheckedEntries and encoding buffers are pooled in
+// every build. Cores must not retain the fields passed to Write after it
+// returns; zap's own Cores copy any fields they keep, which the zap
+// package's zaparena tests check.
+//
+// Without the build tag, Enabled is false and callers should allocate as
+// usual; the compiler removes code guarded by Enabled.
+package arena // import "go.uber.org/zap/internal/arena"
+
+import (
+	"go.uber.org/zap/internal/pool"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// _defaultFields is the capacity of new slabs.
+	_defaultFields = 16
+	// _maxFields is the largest capacity of slabs returned to the pool, so
+	// that rare huge entries don't pin memory.
+	_maxFields = 1024
+)
+
+// Fields is a slab of Fields for a single entry.
+type Fields struct {
+	// Fields is empty when the slab is handed out; callers append to it.
+	Fields []zapcore.Field
+}
+
+var _fieldsPool = pool.New(func() *Fields {
+	return &Fields{Fields: make([]zapcore.Field, 0, _defaultFields)}
+})
+
+// GetFields returns an empty slab with room for at least n Fields.
+func GetFields(n int) *Fields {
+	f := _fieldsPool.Get()
+	if cap(f.Fields) < n {
+		f.Fields = make([]zapcore.Field, 0, n)
+	}
+	return f
+}
+
+// Free returns the slab to the pool. Neither the slab nor its Fields may be
+// used afterwards.
+func (f *Fields) Free() {
+	if cap(f.Fields) > _maxFields {
+		return
+	}
+	for i := range f.Fields {
+		// Don't keep references to logged values.
+		f.Fields[i] = zapcore.Field{}
+	}
+	f.Fields = f.Fields[:0]
+	_fieldsPool.Put(f)
+}
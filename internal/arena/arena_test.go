@@ -0,0 +1,49 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package arena
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFields(t *testing.T) {
+	f := GetFields(3)
+	assert.Empty(t, f.Fields, "Expected an empty slab.")
+	assert.GreaterOrEqual(t, cap(f.Fields), 3, "Expected room for the requested fields.")
+
+	f.Fields = append(f.Fields, zapcore.Field{Key: "k", Type: zapcore.StringType, String: "v"})
+	backing := f.Fields[:1]
+	f.Free()
+	assert.Equal(t, zapcore.Field{}, backing[0], "Expected freed fields to be cleared.")
+	assert.Empty(t, f.Fields, "Expected freed slab to be empty.")
+}
+
+func TestFieldsLarge(t *testing.T) {
+	f := GetFields(_maxFields + 1)
+	assert.GreaterOrEqual(t, cap(f.Fields), _maxFields+1, "Expected room for the requested fields.")
+	f.Fields = append(f.Fields, zapcore.Field{Key: "k"})
+	f.Free()
+	assert.Len(t, f.Fields, 1, "Expected oversized slab not to be reset or pooled.")
+}
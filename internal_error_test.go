@@ -0,0 +1,136 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"sync"
+	"testing"
+
+	"go.uber.org/zap/internal/ztest"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type internalErrors struct {
+	mu   sync.Mutex
+	errs []string
+	ents []zapcore.Entry
+}
+
+func (ie *internalErrors) handle(err error, ent zapcore.Entry) {
+	ie.mu.Lock()
+	defer ie.mu.Unlock()
+	ie.errs = append(ie.errs, err.Error())
+	ie.ents = append(ie.ents, ent)
+}
+
+func (ie *internalErrors) take() []string {
+	ie.mu.Lock()
+	defer ie.mu.Unlock()
+	errs := ie.errs
+	ie.errs, ie.ents = nil, nil
+	return errs
+}
+
+func TestSetInternalErrorHandler(t *testing.T) {
+	var ie internalErrors
+	restore := SetInternalErrorHandler(ie.handle)
+	defer restore()
+
+	t.Run("write failure", func(t *testing.T) {
+		logger := New(
+			zapcore.NewCore(
+				zapcore.NewJSONEncoder(NewProductionConfig().EncoderConfig),
+				zapcore.Lock(zapcore.AddSync(ztest.FailWriter{})),
+				DebugLevel,
+			),
+			ErrorOutput(&ztest.Buffer{}),
+		)
+		logger.Info("foo")
+		assert.Equal(t, []string{"failed"}, ie.take(), "Expected write failure to be reported.")
+	})
+
+	t.Run("entry", func(t *testing.T) {
+		core, logs := observer.New(DebugLevel)
+		logs.FailWrites(func(observer.LoggedEntry) error { return assert.AnError })
+		New(core, ErrorOutput(&ztest.Buffer{})).Warn("foo")
+
+		ie.mu.Lock()
+		require.Len(t, ie.ents, 1, "Expected one internal error.")
+		assert.Equal(t, WarnLevel, ie.ents[0].Level, "Unexpected entry level.")
+		assert.Equal(t, "foo", ie.ents[0].Message, "Unexpected entry message.")
+		ie.mu.Unlock()
+		ie.take()
+	})
+
+	t.Run("caller failure", func(t *testing.T) {
+		withLogger(t, DebugLevel, opts(AddCaller(), AddCallerSkip(1e3), ErrorOutput(&ztest.Buffer{})), func(log *Logger, _ *observer.ObservedLogs) {
+			log.Info("foo")
+			assert.Equal(t, []string{"failed to get caller"}, ie.take(), "Expected caller failure to be reported.")
+		})
+	})
+
+	t.Run("sugar misuse", func(t *testing.T) {
+		withSugar(t, DebugLevel, nil, func(log *SugaredLogger, _ *observer.ObservedLogs) {
+			log.Infow("foo", "odd")
+			log.Infow("foo", 42, "value")
+			log.Infow("foo", assert.AnError, assert.AnError)
+			assert.Equal(t, []string{
+				errOddNumber.Error(),
+				errNonStringKey.Error(),
+				errMultipleErrors.Error(),
+			}, ie.take(), "Expected sugar misuse to be reported.")
+		})
+	})
+
+	t.Run("sugar misuse dropped", func(t *testing.T) {
+		withSugar(t, DebugLevel, opts(WithKeyValuePolicy(KeyValueDrop)), func(log *SugaredLogger, _ *observer.ObservedLogs) {
+			log.Infow("foo", "odd")
+			assert.Empty(t, ie.take(), "Expected misuse to go unreported with KeyValueDrop.")
+		})
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		var other internalErrors
+		undo := SetInternalErrorHandler(other.handle)
+		withSugar(t, DebugLevel, nil, func(log *SugaredLogger, _ *observer.ObservedLogs) {
+			log.Infow("foo", "odd")
+		})
+		undo()
+		withSugar(t, DebugLevel, nil, func(log *SugaredLogger, _ *observer.ObservedLogs) {
+			log.Infow("foo", "odd")
+		})
+		assert.Len(t, other.take(), 1, "Expected the replacement handler to be called once.")
+		assert.Len(t, ie.take(), 1, "Expected the original handler to be restored.")
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		undo := SetInternalErrorHandler(nil)
+		defer undo()
+		withSugar(t, DebugLevel, nil, func(log *SugaredLogger, _ *observer.ObservedLogs) {
+			log.Infow("foo", "odd")
+		})
+		assert.Empty(t, ie.take(), "Expected no handler to be called.")
+	})
+}
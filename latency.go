@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Latency bucket labels.
+const (
+	_latencyFast      = "fast"
+	_latencyTolerable = "tolerable"
+	_latencySlow      = "slow"
+)
+
+// LatencyThresholds divide durations into Apdex-style buckets: durations up
+// to Satisfied are fast, those up to Tolerating are tolerable, and longer
+// ones are slow. If Tolerating is less than Satisfied, there's no tolerable
+// bucket.
+type LatencyThresholds struct {
+	Satisfied  time.Duration
+	Tolerating time.Duration
+}
+
+// ApdexThresholds returns the standard Apdex thresholds for a target
+// duration: durations up to target are fast, and those up to four times
+// target are tolerable.
+func ApdexThresholds(target time.Duration) LatencyThresholds {
+	return LatencyThresholds{Satisfied: target, Tolerating: 4 * target}
+}
+
+// Bucket returns the label of d's bucket: "fast", "tolerable", or "slow".
+func (t LatencyThresholds) Bucket(d time.Duration) string {
+	switch {
+	case d <= t.Satisfied:
+		return _latencyFast
+	case d <= t.Tolerating:
+		return _latencyTolerable
+	default:
+		return _latencySlow
+	}
+}
+
+// score returns d's Apdex score: 1 for fast, 0.5 for tolerable, and 0 for
+// slow.
+func (t LatencyThresholds) score(d time.Duration) float64 {
+	switch t.Bucket(d) {
+	case _latencyFast:
+		return 1
+	case _latencyTolerable:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// DurationBucket constructs a field that records a latency along with its
+// bucket, so that services log latencies in the same shape. It adds an
+// object under key with the keys
+//
+//	duration  d, encoded by the EncoderConfig's EncodeDuration
+//	ms        d in milliseconds, as a float, for histograms
+//	bucket    "fast", "tolerable", or "slow"
+//	apdex     1, 0.5, or 0 for the bucket, which average to an Apdex score
+//
+// For example,
+//
+//	logger.Info("request served", zap.DurationBucket("latency", elapsed, zap.ApdexThresholds(100*time.Millisecond)))
+func DurationBucket(key string, d time.Duration, thresholds LatencyThresholds) Field {
+	return Object(key, durationBucket{d: d, thresholds: thresholds})
+}
+
+type durationBucket struct {
+	d          time.Duration
+	thresholds LatencyThresholds
+}
+
+func (b durationBucket) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddDuration("duration", b.d)
+	enc.AddFloat64("ms", float64(b.d)/float64(time.Millisecond))
+	enc.AddString("bucket", b.thresholds.Bucket(b.d))
+	enc.AddFloat64("apdex", b.thresholds.score(b.d))
+	return nil
+}
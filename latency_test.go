@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestDurationBucket(t *testing.T) {
+	apdex := ApdexThresholds(100 * time.Millisecond)
+	tests := []struct {
+		desc       string
+		give       time.Duration
+		thresholds LatencyThresholds
+		wantBucket string
+		wantApdex  float64
+	}{
+		{"fast", 40 * time.Millisecond, apdex, "fast", 1},
+		{"satisfied boundary", 100 * time.Millisecond, apdex, "fast", 1},
+		{"tolerable", 250 * time.Millisecond, apdex, "tolerable", 0.5},
+		{"tolerating boundary", 400 * time.Millisecond, apdex, "tolerable", 0.5},
+		{"slow", 401 * time.Millisecond, apdex, "slow", 0},
+		{
+			desc:       "no tolerable bucket",
+			give:       150 * time.Millisecond,
+			thresholds: LatencyThresholds{Satisfied: 100 * time.Millisecond},
+			wantBucket: "slow",
+			wantApdex:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := zapcore.NewMapObjectEncoder()
+			DurationBucket("latency", tt.give, tt.thresholds).AddTo(enc)
+			assert.Equal(t, map[string]interface{}{
+				"duration": tt.give,
+				"ms":       float64(tt.give) / float64(time.Millisecond),
+				"bucket":   tt.wantBucket,
+				"apdex":    tt.wantApdex,
+			}, enc.Fields["latency"], "Unexpected latency object.")
+		})
+	}
+}
+
+func TestDurationBucketJSON(t *testing.T) {
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{EncodeDuration: zapcore.StringDurationEncoder})
+	buf, err := enc.EncodeEntry(zapcore.Entry{}, []Field{
+		DurationBucket("latency", 1500*time.Microsecond, ApdexThresholds(time.Millisecond)),
+	})
+	assert.NoError(t, err, "Unexpected error encoding.")
+	assert.Equal(t, `{"latency":{"duration":"1.5ms","ms":1.5,"bucket":"tolerable","apdex":0.5}}`+"\n", buf.String(), "Unexpected JSON.")
+}
@@ -28,6 +28,9 @@ import (
 )
 
 const (
+	// TraceLevel logs are even more detailed than DebugLevel logs, and are
+	// almost always disabled.
+	TraceLevel = zapcore.TraceLevel
 	// DebugLevel logs are typically voluminous, and are usually disabled in
 	// production.
 	DebugLevel = zapcore.DebugLevel
@@ -48,6 +51,23 @@ const (
 	FatalLevel = zapcore.FatalLevel
 )
 
+// VerbosityLevel maps the flags commonly accepted by command-line tools to a
+// logging level. Each verbose step (e.g., -v, -vv) lowers the level from
+// InfoLevel, down to TraceLevel. If quiet is true (e.g., -q), only WarnLevel
+// and above are enabled, regardless of verbose.
+func VerbosityLevel(verbose int, quiet bool) zapcore.Level {
+	switch {
+	case quiet:
+		return WarnLevel
+	case verbose <= 0:
+		return InfoLevel
+	case verbose == 1:
+		return DebugLevel
+	default:
+		return TraceLevel
+	}
+}
+
 // LevelEnablerFunc is a convenient way to implement zapcore.LevelEnabler with
 // an anonymous function.
 //
@@ -59,6 +79,55 @@ type LevelEnablerFunc func(zapcore.Level) bool
 // Enabled calls the wrapped function.
 func (f LevelEnablerFunc) Enabled(lvl zapcore.Level) bool { return f(lvl) }
 
+// LevelAnd returns a LevelEnabler that enables a level only if all of the
+// given enablers enable it. With no enablers, every level is enabled.
+func LevelAnd(enablers ...zapcore.LevelEnabler) zapcore.LevelEnabler {
+	return LevelEnablerFunc(func(lvl zapcore.Level) bool {
+		for _, enab := range enablers {
+			if !enab.Enabled(lvl) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// LevelOr returns a LevelEnabler that enables a level if any of the given
+// enablers enable it. With no enablers, no level is enabled.
+func LevelOr(enablers ...zapcore.LevelEnabler) zapcore.LevelEnabler {
+	return LevelEnablerFunc(func(lvl zapcore.Level) bool {
+		for _, enab := range enablers {
+			if enab.Enabled(lvl) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// LevelNot returns a LevelEnabler that enables exactly the levels the given
+// enabler doesn't.
+func LevelNot(enab zapcore.LevelEnabler) zapcore.LevelEnabler {
+	return LevelEnablerFunc(func(lvl zapcore.Level) bool {
+		return !enab.Enabled(lvl)
+	})
+}
+
+// LevelBetween returns a LevelEnabler that enables levels from min to max,
+// inclusive. It's useful for splitting output between cores by level; for
+// example, to send InfoLevel and WarnLevel logs to one file and more severe
+// logs to another:
+//
+//	core := zapcore.NewTee(
+//		zapcore.NewCore(enc, infoFile, zap.LevelBetween(zap.InfoLevel, zap.WarnLevel)),
+//		zapcore.NewCore(enc, errorFile, zap.ErrorLevel),
+//	)
+func LevelBetween(min, max zapcore.Level) zapcore.LevelEnabler {
+	return LevelEnablerFunc(func(lvl zapcore.Level) bool {
+		return lvl >= min && lvl <= max
+	})
+}
+
 // An AtomicLevel is an atomically changeable, dynamic logging level. It lets
 // you safely change the log level of a tree of loggers (the root logger and
 // any children created by adding context) at runtime.
@@ -121,6 +190,7 @@ func (lvl AtomicLevel) Level() zapcore.Level {
 // SetLevel alters the logging level.
 func (lvl AtomicLevel) SetLevel(l zapcore.Level) {
 	lvl.l.Store(int32(l))
+	levelChanged()
 }
 
 // String returns the string representation of the underlying Level.
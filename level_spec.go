@@ -0,0 +1,233 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// A LevelSpec sets logging levels per logger name, in the style of
+// RUST_LOG. A spec is a comma-separated list of directives: a bare level sets
+// the default, and name=level overrides it for the named logger and its
+// descendants. For example,
+//
+//	info,db=debug,http.client=warn
+//
+// logs InfoLevel and above by default, DebugLevel and above from loggers
+// named "db" or "db.*", and WarnLevel and above from "http.client" and
+// "http.client.*". The most specific name wins. Without a bare level, the
+// default is InfoLevel.
+//
+// A LevelSpec is a LevelEnabler that enables any level some logger could
+// log, so it's suitable as the level of a Core; wrap that Core with
+// LevelSpec.Core to apply the per-name levels. Like AtomicLevel, a LevelSpec
+// may be changed at runtime with Set, and it implements flag.Value and
+// encoding.TextUnmarshaler so it can be used in flags and configuration.
+// The zero value logs InfoLevel and above from every logger.
+type LevelSpec struct {
+	rules atomic.Pointer[levelSpecRules]
+}
+
+var _ zapcore.LevelEnabler = (*LevelSpec)(nil)
+
+type levelSpecRules struct {
+	text      string
+	def       zapcore.Level
+	min       zapcore.Level
+	overrides []levelOverride // longest names first
+}
+
+type levelOverride struct {
+	name  string
+	level zapcore.Level
+}
+
+// ParseLevelSpec parses a LevelSpec from its text representation.
+func ParseLevelSpec(spec string) (*LevelSpec, error) {
+	var s LevelSpec
+	if err := s.Set(spec); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func parseLevelSpecRules(spec string) (*levelSpecRules, error) {
+	rules := &levelSpecRules{def: InfoLevel}
+	var (
+		sawDefault bool
+		seen       = make(map[string]struct{})
+		texts      []string
+	)
+	for _, directive := range strings.Split(spec, ",") {
+		directive = strings.TrimSpace(directive)
+		if directive == "" {
+			continue
+		}
+
+		name, lvlText, hasName := strings.Cut(directive, "=")
+		if !hasName {
+			lvlText, name = name, ""
+		}
+		name, lvlText = strings.TrimSpace(name), strings.TrimSpace(lvlText)
+
+		lvl, err := zapcore.ParseLevel(lvlText)
+		if err != nil || lvlText == "" {
+			return nil, fmt.Errorf("invalid level %q in level spec directive %q", lvlText, directive)
+		}
+
+		switch {
+		case !hasName:
+			if sawDefault {
+				return nil, fmt.Errorf("level spec %q sets the default level more than once", spec)
+			}
+			sawDefault = true
+			rules.def = lvl
+		case name == "":
+			return nil, fmt.Errorf("missing logger name in level spec directive %q", directive)
+		default:
+			if _, ok := seen[name]; ok {
+				return nil, fmt.Errorf("level spec %q sets the level of %q more than once", spec, name)
+			}
+			seen[name] = struct{}{}
+			rules.overrides = append(rules.overrides, levelOverride{name: name, level: lvl})
+		}
+		if hasName {
+			texts = append(texts, name+"="+lvl.String())
+		} else {
+			texts = append([]string{lvl.String()}, texts...)
+		}
+	}
+
+	sort.SliceStable(rules.overrides, func(i, j int) bool {
+		return len(rules.overrides[i].name) > len(rules.overrides[j].name)
+	})
+	rules.min = rules.def
+	for _, o := range rules.overrides {
+		if o.level < rules.min {
+			rules.min = o.level
+		}
+	}
+	rules.text = strings.Join(texts, ",")
+	return rules, nil
+}
+
+func (s *LevelSpec) load() *levelSpecRules {
+	if rules := s.rules.Load(); rules != nil {
+		return rules
+	}
+	return &levelSpecRules{text: InfoLevel.String(), def: InfoLevel, min: InfoLevel}
+}
+
+// LevelFor returns the minimum enabled level for the logger with the given
+// name.
+func (s *LevelSpec) LevelFor(name string) zapcore.Level {
+	rules := s.load()
+	for _, o := range rules.overrides {
+		if name == o.name || strings.HasPrefix(name, o.name+".") {
+			return o.level
+		}
+	}
+	return rules.def
+}
+
+// Enabled reports whether any logger may log at the given level.
+func (s *LevelSpec) Enabled(lvl zapcore.Level) bool {
+	return lvl >= s.load().min
+}
+
+// Level returns the lowest level enabled for any logger.
+func (s *LevelSpec) Level() zapcore.Level {
+	return s.load().min
+}
+
+// Set replaces the spec, atomically changing the levels of all loggers that
+// use it. It implements flag.Value.
+func (s *LevelSpec) Set(spec string) error {
+	rules, err := parseLevelSpecRules(spec)
+	if err != nil {
+		return err
+	}
+	s.rules.Store(rules)
+	levelChanged()
+	return nil
+}
+
+// String returns a normalized text representation of the spec. It
+// implements flag.Value.
+func (s *LevelSpec) String() string {
+	return s.load().text
+}
+
+// UnmarshalText parses a spec, like Set. It makes LevelSpecs usable in
+// YAML, TOML, and JSON configuration files.
+func (s *LevelSpec) UnmarshalText(text []byte) error {
+	return s.Set(string(text))
+}
+
+// MarshalText returns the normalized text representation of the spec.
+func (s *LevelSpec) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Core wraps a Core so that entries are dropped unless the LevelSpec enables
+// their level for their logger's name. The wrapped Core's own level still
+// applies, so it's typically built with the LevelSpec as its LevelEnabler:
+//
+//	spec, err := zap.ParseLevelSpec("info,db=debug")
+//	// ...
+//	logger := zap.New(spec.Core(zapcore.NewCore(enc, ws, spec)))
+func (s *LevelSpec) Core(core zapcore.Core) zapcore.Core {
+	return &levelSpecCore{Core: core, spec: s}
+}
+
+type levelSpecCore struct {
+	zapcore.Core
+
+	spec *LevelSpec
+}
+
+func (c *levelSpecCore) Enabled(lvl zapcore.Level) bool {
+	return c.spec.Enabled(lvl) && c.Core.Enabled(lvl)
+}
+
+func (c *levelSpecCore) Level() zapcore.Level {
+	lvl := zapcore.LevelOf(c.Core)
+	if min := c.spec.Level(); min > lvl {
+		return min
+	}
+	return lvl
+}
+
+func (c *levelSpecCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelSpecCore{Core: c.Core.With(fields), spec: c.spec}
+}
+
+func (c *levelSpecCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level < c.spec.LevelFor(ent.LoggerName) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
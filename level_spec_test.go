@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevelSpec(t *testing.T) {
+	spec, err := ParseLevelSpec("warn, db=debug ,http.client=error,http=info,")
+	require.NoError(t, err, "Unexpected error parsing level spec.")
+
+	tests := []struct {
+		name string
+		want zapcore.Level
+	}{
+		{"", WarnLevel},
+		{"app", WarnLevel},
+		{"db", DebugLevel},
+		{"db.pool", DebugLevel},
+		{"dbx", WarnLevel},
+		{"http", InfoLevel},
+		{"http.server", InfoLevel},
+		{"http.client", ErrorLevel},
+		{"http.client.retry", ErrorLevel},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, spec.LevelFor(tt.name), "Unexpected level for logger %q.", tt.name)
+	}
+
+	assert.Equal(t, DebugLevel, spec.Level(), "Unexpected minimum level.")
+	assert.True(t, spec.Enabled(DebugLevel), "Expected DebugLevel to be enabled for some logger.")
+	assert.False(t, spec.Enabled(TraceLevel), "Expected TraceLevel to be disabled for every logger.")
+	assert.Equal(t, "warn,db=debug,http.client=error,http=info", spec.String(), "Unexpected normalized spec.")
+}
+
+func TestParseLevelSpecDefaults(t *testing.T) {
+	spec, err := ParseLevelSpec("db=debug")
+	require.NoError(t, err, "Unexpected error parsing level spec.")
+	assert.Equal(t, InfoLevel, spec.LevelFor("app"), "Expected InfoLevel by default.")
+
+	var zero LevelSpec
+	assert.Equal(t, InfoLevel, zero.LevelFor("app"), "Expected zero value to use InfoLevel.")
+	assert.Equal(t, "info", zero.String(), "Unexpected zero value string.")
+}
+
+func TestParseLevelSpecErrors(t *testing.T) {
+	tests := []struct {
+		give string
+		err  string
+	}{
+		{"loud", `invalid level "loud" in level spec directive "loud"`},
+		{"db=", `invalid level "" in level spec directive "db="`},
+		{"=debug", `missing logger name in level spec directive "=debug"`},
+		{"info,warn", "sets the default level more than once"},
+		{"db=info,db=warn", `sets the level of "db" more than once`},
+	}
+	for _, tt := range tests {
+		_, err := ParseLevelSpec(tt.give)
+		assert.ErrorContains(t, err, tt.err, "Unexpected error parsing %q.", tt.give)
+	}
+}
+
+func TestLevelSpecCore(t *testing.T) {
+	spec, err := ParseLevelSpec("warn,db=debug")
+	require.NoError(t, err, "Unexpected error parsing level spec.")
+
+	obs, logs := observer.New(spec)
+	logger := New(spec.Core(obs))
+	logger.Info("dropped")
+	logger.Warn("kept")
+	logger.Named("db").Debug("kept")
+	logger.Named("db").With(String("k", "v")).Trace("dropped")
+	logger.Named("db").Named("pool").Debug("kept")
+
+	assert.Equal(t, []string{"kept", "kept", "kept"}, messagesOf(logs), "Unexpected messages.")
+	assert.Equal(t, DebugLevel, logger.Level(), "Unexpected logger level.")
+
+	require.NoError(t, spec.Set("error"), "Unexpected error changing spec.")
+	logger.Named("db").Warn("dropped")
+	assert.Equal(t, 3, logs.Len(), "Expected spec changes to apply to existing loggers.")
+	assert.Equal(t, ErrorLevel, logger.Level(), "Unexpected logger level after change.")
+}
+
+func TestLevelSpecText(t *testing.T) {
+	var cfg struct {
+		Levels *LevelSpec `json:"levels"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"levels": "debug,db=warn"}`), &cfg), "Unexpected error unmarshaling.")
+	assert.Equal(t, WarnLevel, cfg.Levels.LevelFor("db"), "Unexpected level after unmarshaling.")
+
+	out, err := json.Marshal(cfg)
+	require.NoError(t, err, "Unexpected error marshaling.")
+	assert.JSONEq(t, `{"levels": "debug,db=warn"}`, string(out), "Unexpected marshaled spec.")
+
+	var spec LevelSpec
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&spec, "log", "")
+	require.NoError(t, fs.Parse([]string{"-log", "error,http=info"}), "Unexpected error parsing flags.")
+	assert.Equal(t, InfoLevel, spec.LevelFor("http"), "Unexpected level set by flag.")
+	assert.Error(t, fs.Parse([]string{"-log", "bogus"}), "Expected invalid specs to be rejected.")
+}
+
+func messagesOf(logs *observer.ObservedLogs) []string {
+	var msgs []string
+	for _, ent := range logs.All() {
+		msgs = append(msgs, ent.Message)
+	}
+	return msgs
+}
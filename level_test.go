@@ -49,6 +49,83 @@ func TestLevelEnablerFunc(t *testing.T) {
 	}
 }
 
+func TestLevelEnablerCombinators(t *testing.T) {
+	allLevels := []zapcore.Level{TraceLevel, DebugLevel, InfoLevel, WarnLevel, ErrorLevel, DPanicLevel, PanicLevel, FatalLevel}
+	enabled := func(enab zapcore.LevelEnabler) []zapcore.Level {
+		var lvls []zapcore.Level
+		for _, lvl := range allLevels {
+			if enab.Enabled(lvl) {
+				lvls = append(lvls, lvl)
+			}
+		}
+		return lvls
+	}
+
+	tests := []struct {
+		desc string
+		give zapcore.LevelEnabler
+		want []zapcore.Level
+	}{
+		{
+			desc: "between",
+			give: LevelBetween(InfoLevel, WarnLevel),
+			want: []zapcore.Level{InfoLevel, WarnLevel},
+		},
+		{
+			desc: "and",
+			give: LevelAnd(InfoLevel, LevelNot(ErrorLevel)),
+			want: []zapcore.Level{InfoLevel, WarnLevel},
+		},
+		{
+			desc: "empty and",
+			give: LevelAnd(),
+			want: allLevels,
+		},
+		{
+			desc: "or",
+			give: LevelOr(LevelBetween(DebugLevel, DebugLevel), PanicLevel),
+			want: []zapcore.Level{DebugLevel, PanicLevel, FatalLevel},
+		},
+		{
+			desc: "empty or",
+			give: LevelOr(),
+			want: nil,
+		},
+		{
+			desc: "not",
+			give: LevelNot(InfoLevel),
+			want: []zapcore.Level{TraceLevel, DebugLevel},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, enabled(tt.give), "Unexpected enabled levels.")
+		})
+	}
+
+	assert.Equal(t, InfoLevel, zapcore.LevelOf(LevelBetween(InfoLevel, WarnLevel)), "Unexpected minimum level.")
+}
+
+func TestVerbosityLevel(t *testing.T) {
+	tests := []struct {
+		verbose int
+		quiet   bool
+		want    zapcore.Level
+	}{
+		{0, false, InfoLevel},
+		{-1, false, InfoLevel},
+		{1, false, DebugLevel},
+		{2, false, TraceLevel},
+		{3, false, TraceLevel},
+		{0, true, WarnLevel},
+		{2, true, WarnLevel},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, VerbosityLevel(tt.verbose, tt.quiet), "Unexpected level for verbose=%d quiet=%v.", tt.verbose, tt.quiet)
+	}
+}
+
 func TestNewAtomicLevel(t *testing.T) {
 	lvl := NewAtomicLevel()
 	assert.Equal(t, InfoLevel, lvl.Level(), "Unexpected initial level.")
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// A LineBuilder accumulates fields over the lifetime of a unit of work, like
+// an HTTP request, and logs them all as a single wide entry when the work is
+// done. Searching and aggregating one "canonical log line" per request is
+// often easier than piecing together many narrow entries.
+//
+// Fields are merged by key: adding a field whose key was already added
+// replaces the earlier value but keeps its position in the entry. Fields
+// without keys, like Inline fields, are always appended.
+//
+// A LineBuilder is safe for concurrent use. Its methods are no-ops on a nil
+// LineBuilder, so code that reads one from a context doesn't need to check
+// whether it's there.
+type LineBuilder struct {
+	log *Logger
+
+	mu      sync.Mutex
+	fields  []Field
+	keys    map[string]int // index of each key in fields
+	emitted bool
+}
+
+// NewLineBuilder returns a LineBuilder that logs its entry with the given
+// logger.
+func NewLineBuilder(log *Logger) *LineBuilder {
+	return &LineBuilder{
+		log:  log,
+		keys: make(map[string]int),
+	}
+}
+
+// Add merges fields into the entry. It has no effect once the entry has
+// been emitted.
+func (b *LineBuilder) Add(fields ...Field) {
+	if b == nil || len(fields) == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.emitted {
+		return
+	}
+	for _, f := range fields {
+		if f.Type == zapcore.SkipType {
+			continue
+		}
+		if f.Key == "" {
+			b.fields = append(b.fields, f)
+			continue
+		}
+		if i, ok := b.keys[f.Key]; ok {
+			b.fields[i] = f
+			continue
+		}
+		b.keys[f.Key] = len(b.fields)
+		b.fields = append(b.fields, f)
+	}
+}
+
+// Emit logs the accumulated fields as a single entry at the given level. Only
+// the first call logs anything; it reports whether this call was the one
+// that did.
+func (b *LineBuilder) Emit(lvl zapcore.Level, msg string) bool {
+	if b == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	if b.emitted {
+		b.mu.Unlock()
+		return false
+	}
+	b.emitted = true
+	fields := b.fields
+	b.fields, b.keys = nil, nil
+	b.mu.Unlock()
+
+	if ce := b.log.check(lvl, msg); ce != nil {
+		ce.Write(fields...)
+	}
+	return true
+}
+
+type lineBuilderKey struct{}
+
+// ContextWithLineBuilder returns a copy of ctx carrying the given
+// LineBuilder, so that code handling a request can add to its canonical log
+// line with LineBuilderFromContext.
+func ContextWithLineBuilder(ctx context.Context, b *LineBuilder) context.Context {
+	return context.WithValue(ctx, lineBuilderKey{}, b)
+}
+
+// LineBuilderFromContext returns the LineBuilder attached to ctx with
+// ContextWithLineBuilder, or nil if there isn't one.
+func LineBuilderFromContext(ctx context.Context) *LineBuilder {
+	if ctx == nil {
+		return nil
+	}
+	b, _ := ctx.Value(lineBuilderKey{}).(*LineBuilder)
+	return b
+}
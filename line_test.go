@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLineBuilder(t *testing.T) {
+	withLogger(t, DebugLevel, nil, func(log *Logger, logs *observer.ObservedLogs) {
+		b := NewLineBuilder(log)
+		b.Add(String("method", "GET"), Int("status", 0))
+		b.Add(Skip(), String("path", "/"))
+		b.Add(Int("status", 200), Inline(username("phil")))
+
+		assert.True(t, b.Emit(InfoLevel, "request"), "Expected first Emit to log.")
+		assert.False(t, b.Emit(InfoLevel, "request"), "Expected second Emit to be a no-op.")
+		b.Add(String("late", "value"))
+
+		require.Equal(t, 1, logs.Len(), "Expected exactly one entry.")
+		entry := logs.All()[0]
+		assert.Equal(t, "request", entry.Message, "Unexpected message.")
+		assert.Equal(t, []Field{
+			String("method", "GET"),
+			Int("status", 200),
+			String("path", "/"),
+			Inline(username("phil")),
+		}, entry.Context, "Unexpected fields.")
+	})
+}
+
+func TestLineBuilderConcurrentAdds(t *testing.T) {
+	withLogger(t, DebugLevel, nil, func(log *Logger, logs *observer.ObservedLogs) {
+		b := NewLineBuilder(log)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				b.Add(Int("k"+strconv.Itoa(i), i), Bool("shared", true))
+			}(i)
+		}
+		wg.Wait()
+		b.Emit(WarnLevel, "done")
+
+		require.Equal(t, 1, logs.Len(), "Expected exactly one entry.")
+		entry := logs.All()[0]
+		assert.Equal(t, WarnLevel, entry.Level, "Unexpected level.")
+		assert.Len(t, entry.Context, 11, "Expected merged fields.")
+	})
+}
+
+func TestLineBuilderDisabledLevel(t *testing.T) {
+	withLogger(t, WarnLevel, nil, func(log *Logger, logs *observer.ObservedLogs) {
+		b := NewLineBuilder(log)
+		b.Add(String("foo", "bar"))
+		assert.True(t, b.Emit(InfoLevel, "request"), "Expected Emit to finish the builder.")
+		assert.Zero(t, logs.Len(), "Expected no entries below the enabled level.")
+	})
+}
+
+func TestLineBuilderCaller(t *testing.T) {
+	withLogger(t, DebugLevel, opts(AddCaller()), func(log *Logger, logs *observer.ObservedLogs) {
+		NewLineBuilder(log).Emit(InfoLevel, "request")
+		require.Equal(t, 1, logs.Len(), "Expected exactly one entry.")
+		assert.Contains(t, logs.All()[0].Caller.String(), "line_test.go", "Expected caller to be Emit's caller.")
+	})
+}
+
+func TestLineBuilderContext(t *testing.T) {
+	var nilBuilder *LineBuilder
+	assert.NotPanics(t, func() {
+		nilBuilder.Add(String("foo", "bar"))
+		assert.False(t, nilBuilder.Emit(InfoLevel, "request"), "Expected nil builder not to log.")
+	}, "Expected nil LineBuilder to be a no-op.")
+
+	assert.Nil(t, LineBuilderFromContext(context.Background()), "Expected no builder in empty context.")
+
+	b := NewLineBuilder(NewNop())
+	ctx := ContextWithLineBuilder(context.Background(), b)
+	assert.Same(t, b, LineBuilderFromContext(ctx), "Expected builder from context.")
+	LineBuilderFromContext(ctx).Add(String("foo", "bar"))
+	assert.Equal(t, []Field{String("foo", "bar")}, b.fields, "Expected field added through context.")
+	assert.True(t, b.Emit(zapcore.InfoLevel, "request"), "Expected Emit to succeed.")
+}
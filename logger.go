@@ -21,9 +21,13 @@
 package zap
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"runtime"
 	"strings"
 
 	"go.uber.org/zap/internal/bufferpool"
@@ -31,6 +35,10 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
+// errNoCaller is reported to the internal error handler when the Logger
+// can't find its caller.
+var errNoCaller = errors.New("failed to get caller")
+
 // A Logger provides fast, leveled, structured logging. All methods are safe
 // for concurrent use.
 //
@@ -42,7 +50,11 @@ type Logger struct {
 	core zapcore.Core
 
 	development bool
+	dpanic      *DPanicPolicy // overrides development for DPanic logs if set
+	ctx         context.Context
+	kvPolicy    KeyValuePolicy // for the SugaredLogger
 	addCaller   bool
+	addCallerPC bool                   // before the Core checks the entry
 	onPanic     zapcore.CheckWriteHook // default is WriteThenPanic
 	onFatal     zapcore.CheckWriteHook // default is WriteThenFatal
 
@@ -54,6 +66,8 @@ type Logger struct {
 	callerSkip int
 
 	clock zapcore.Clock
+
+	stack *fieldStack // shared by all loggers derived from this one
 }
 
 // New constructs a new Logger from the provided zapcore.Core and Options. If
@@ -75,6 +89,7 @@ func New(core zapcore.Core, options ...Option) *Logger {
 		errorOutput: zapcore.Lock(os.Stderr),
 		addStack:    zapcore.FatalLevel + 1,
 		clock:       zapcore.DefaultClock,
+		stack:       &fieldStack{},
 	}
 	return log.WithOptions(options...)
 }
@@ -90,6 +105,7 @@ func NewNop() *Logger {
 		errorOutput: zapcore.AddSync(io.Discard),
 		addStack:    zapcore.FatalLevel + 1,
 		clock:       zapcore.DefaultClock,
+		stack:       &fieldStack{},
 	}
 }
 
@@ -109,6 +125,14 @@ func NewDevelopment(options ...Option) (*Logger, error) {
 	return NewDevelopmentConfig().Build(options...)
 }
 
+// NewCLI builds a Logger for command-line tools that writes InfoLevel and
+// above logs to standard error, without timestamps or callers.
+//
+// It's a shortcut for NewCLIConfig().Build(...Option).
+func NewCLI(options ...Option) (*Logger, error) {
+	return NewCLIConfig().Build(options...)
+}
+
 // Must is a helper that wraps a call to a function returning (*Logger, error)
 // and panics if the error is non-nil. It is intended for use in variable
 // initialization such as:
@@ -129,6 +153,7 @@ func Must(logger *Logger, err error) *Logger {
 func NewExample(options ...Option) *Logger {
 	encoderCfg := zapcore.EncoderConfig{
 		MessageKey:     "msg",
+		EventKey:       "event",
 		LevelKey:       "level",
 		NameKey:        "logger",
 		EncodeLevel:    zapcore.LowercaseLevelEncoder,
@@ -208,6 +233,45 @@ func (log *Logger) WithLazy(fields ...Field) *Logger {
 	}))
 }
 
+// Ctx returns a child logger that passes ctx along with every entry it
+// logs. Cores that implement zapcore.ContextCore receive ctx when they write
+// these entries, so they can extract trace IDs, honor deadlines, or route by
+// tenant without relying on globals. Cores that don't implement the
+// interface are unaffected.
+//
+//	logger.Ctx(r.Context()).Info("handled request")
+func (log *Logger) Ctx(ctx context.Context) *Logger {
+	l := log.clone()
+	l.ctx = ctx
+	return l
+}
+
+// AppendFields adds structured context to this logger in place, returning a
+// function that removes it again. It's intended for middleware that wants
+// to annotate everything logged while it runs without threading a child
+// logger through every layer:
+//
+//	undo := logger.AppendFields(zap.String("job", name))
+//	defer undo()
+//
+// Unlike With, the fields are seen by this logger and by every logger
+// derived from it with With, Named, WithOptions, Ctx, or Sugar, including
+// loggers derived before the call. That also means every goroutine logging
+// through those loggers sees them: to annotate a single request on a logger
+// shared by concurrent requests, use the package-level AppendFields with the
+// request's context instead.
+//
+// The fields are written after those added with With and those carried by
+// an entry's context, and before those passed at the log site. They're only
+// encoded for entries that are written. Calling the returned function more
+// than once has no further effect.
+func (log *Logger) AppendFields(fields ...Field) (undo func()) {
+	if len(fields) == 0 || log.stack == nil {
+		return func() {}
+	}
+	return log.stack.Push(fields)
+}
+
 // Level reports the minimum enabled level for this logger.
 //
 // For NopLoggers, this is [zapcore.InvalidLevel].
@@ -215,6 +279,18 @@ func (log *Logger) Level() zapcore.Level {
 	return zapcore.LevelOf(log.core)
 }
 
+// EnabledAt returns a cheap, cached view of whether this logger logs at the
+// given level. The view follows changes to any AtomicLevel or LevelSpec
+// controlling the logger; see EnabledFlag for details.
+func (log *Logger) EnabledAt(lvl zapcore.Level) *EnabledFlag {
+	return newEnabledFlag(log.core, lvl)
+}
+
+// DebugEnabled is shorthand for EnabledAt(DebugLevel).
+func (log *Logger) DebugEnabled() *EnabledFlag {
+	return log.EnabledAt(DebugLevel)
+}
+
 // Check returns a CheckedEntry if logging a message at the specified level
 // is enabled. It's a completely optional optimization; in high-performance
 // applications, Check can help avoid allocating a slice to hold fields.
@@ -232,6 +308,29 @@ func (log *Logger) Log(lvl zapcore.Level, msg string, fields ...Field) {
 	}
 }
 
+// Event logs an InfoLevel entry that records a named event, like
+// "user.signup", rather than a message for humans to read. Encoders write the
+// name under their EventKey and omit the entry's empty message; see
+// zapcore.EncoderConfig. The entry includes any fields passed at the log site,
+// as well as any fields accumulated on the logger.
+//
+// Events let applications send logs and analytics-style events through the
+// same pipeline, while keeping them easy to tell apart downstream.
+func (log *Logger) Event(name string, fields ...Field) {
+	// This skips Logger.checkEntry and Logger.Event.
+	if ce := log.checkEntry(zapcore.Entry{Level: InfoLevel, Event: name}, 2); ce != nil {
+		ce.Write(fields...)
+	}
+}
+
+// Trace logs a message at TraceLevel. The message includes any fields passed
+// at the log site, as well as any fields accumulated on the logger.
+func (log *Logger) Trace(msg string, fields ...Field) {
+	if ce := log.check(TraceLevel, msg); ce != nil {
+		ce.Write(fields...)
+	}
+}
+
 // Debug logs a message at DebugLevel. The message includes any fields passed
 // at the log site, as well as any fields accumulated on the logger.
 func (log *Logger) Debug(msg string, fields ...Field) {
@@ -297,6 +396,49 @@ func (log *Logger) Fatal(msg string, fields ...Field) {
 	}
 }
 
+// V returns a Verbose logger for glog-style verbosity levels. V(0) logs at
+// InfoLevel, V(1) at DebugLevel, V(2) at TraceLevel, and each further step
+// at the next lower (unnamed) level. Negative values are treated as zero.
+//
+//	if v := logger.V(3); v.Enabled() {
+//		v.Info("cache state", zap.Any("entries", expensiveDump()))
+//	}
+func (log *Logger) V(n int) Verbose {
+	lvl := InfoLevel
+	if n > 0 {
+		if n > int(InfoLevel)-math.MinInt8 {
+			n = int(InfoLevel) - math.MinInt8
+		}
+		lvl = InfoLevel - zapcore.Level(n)
+	}
+	return Verbose{log: log, lvl: lvl}
+}
+
+// Verbose logs messages at a fixed verbosity level. See Logger.V.
+type Verbose struct {
+	log *Logger
+	lvl zapcore.Level
+}
+
+// Enabled reports whether messages at this verbosity level are logged.
+func (v Verbose) Enabled() bool {
+	return v.log.core.Enabled(v.lvl)
+}
+
+// Level returns the level this Verbose logs at.
+func (v Verbose) Level() zapcore.Level {
+	return v.lvl
+}
+
+// Info logs a message at this verbosity level. The message includes any
+// fields passed at the log site, as well as any fields accumulated on the
+// logger.
+func (v Verbose) Info(msg string, fields ...Field) {
+	if ce := v.log.check(v.lvl, msg); ce != nil {
+		ce.Write(fields...)
+	}
+}
+
 // Sync calls the underlying Core's Sync method, flushing any buffered log
 // entries. Applications should take care to call Sync before exiting.
 func (log *Logger) Sync() error {
@@ -322,23 +464,32 @@ func (log *Logger) clone() *Logger {
 func (log *Logger) check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry {
 	// Logger.check must always be called directly by a method in the
 	// Logger interface (e.g., Check, Info, Fatal).
-	// This skips Logger.check and the Info/Fatal/Check/etc. method that
-	// called it.
-	const callerSkipOffset = 2
+	// This skips Logger.checkEntry, Logger.check, and the
+	// Info/Fatal/Check/etc. method that called it.
+	return log.checkEntry(zapcore.Entry{Level: lvl, Message: msg}, 3)
+}
 
+// checkEntry checks an entry with the given level, message, and event,
+// skipping callerSkipOffset frames of zap's own functions to find the
+// caller.
+func (log *Logger) checkEntry(ent zapcore.Entry, callerSkipOffset int) *zapcore.CheckedEntry {
 	// Check the level first to reduce the cost of disabled log calls.
 	// Since Panic and higher may exit, we skip the optimization for those levels.
+	lvl := ent.Level
 	if lvl < zapcore.DPanicLevel && !log.core.Enabled(lvl) {
 		return nil
 	}
 
 	// Create basic checked entry thru the core; this will be non-nil if the
 	// log message will actually be written somewhere.
-	ent := zapcore.Entry{
-		LoggerName: log.name,
-		Time:       log.clock.Now(),
-		Level:      lvl,
-		Message:    msg,
+	ent.LoggerName = log.name
+	ent.Time = log.clock.Now()
+	if log.addCallerPC {
+		var pcs [1]uintptr
+		// +1 to skip runtime.Callers itself.
+		if runtime.Callers(log.callerSkip+callerSkipOffset+1, pcs[:]) > 0 {
+			ent.Caller.PC = pcs[0]
+		}
 	}
 	ce := log.core.Check(ent, nil)
 	willWrite := ce != nil
@@ -350,9 +501,7 @@ func (log *Logger) check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry {
 	case zapcore.FatalLevel:
 		ce = ce.After(ent, terminalHookOverride(zapcore.WriteThenFatal, log.onFatal))
 	case zapcore.DPanicLevel:
-		if log.development {
-			ce = ce.After(ent, terminalHookOverride(zapcore.WriteThenPanic, log.onPanic))
-		}
+		ce = log.dpanicHook(ce, ent)
 	}
 
 	// Only do further annotation if we're going to write this message; checked
@@ -362,10 +511,12 @@ func (log *Logger) check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry {
 		return ce
 	}
 
-	// Thread the error output through to the CheckedEntry.
+	// Thread the error output and context through to the CheckedEntry.
 	ce.ErrorOutput = log.errorOutput
+	ce.Context = log.stack.Context(log.ctx)
 
-	addStack := log.addStack.Enabled(ce.Level)
+	addStack := log.addStack.Enabled(ce.Level) ||
+		(ce.Level == zapcore.DPanicLevel && log.dpanic != nil && log.dpanic.Stacktrace)
 	if !log.addCaller && !addStack {
 		return ce
 	}
@@ -387,6 +538,7 @@ func (log *Logger) check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry {
 				ent.Time.UTC(),
 			)
 			_ = log.errorOutput.Sync()
+			zapcore.ReportInternalError(errNoCaller, ent)
 		}
 		return ce
 	}
@@ -421,6 +573,40 @@ func (log *Logger) check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry {
 	return ce
 }
 
+// dpanicHook sets up the behavior configured for DPanic logs.
+func (log *Logger) dpanicHook(ce *zapcore.CheckedEntry, ent zapcore.Entry) *zapcore.CheckedEntry {
+	panics := log.development
+	var fn func(zapcore.Entry)
+	if p := log.dpanic; p != nil {
+		panics, fn = p.Panic, p.Hook
+	}
+
+	var hook zapcore.CheckWriteHook
+	if panics {
+		hook = terminalHookOverride(zapcore.WriteThenPanic, log.onPanic)
+	}
+	if fn != nil {
+		hook = dpanicFuncHook{fn: fn, next: hook}
+	}
+	if hook == nil {
+		return ce
+	}
+	return ce.After(ent, hook)
+}
+
+// dpanicFuncHook runs a DPanicPolicy's Hook before any other hook.
+type dpanicFuncHook struct {
+	fn   func(zapcore.Entry)
+	next zapcore.CheckWriteHook
+}
+
+func (h dpanicFuncHook) OnWrite(ce *zapcore.CheckedEntry, fields []zapcore.Field) {
+	h.fn(ce.Entry)
+	if h.next != nil {
+		h.next.OnWrite(ce, fields)
+	}
+}
+
 func terminalHookOverride(defaultHook, override zapcore.CheckWriteHook) zapcore.CheckWriteHook {
 	// A nil or WriteThenNoop hook will lead to continued execution after
 	// a Panic or Fatal log entry, which is unexpected. For example,
@@ -359,3 +359,44 @@ func BenchmarkAny(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkTyped(b *testing.B) {
+	type userID int64
+	id := userID(1 << 20)
+	n := 1 << 20
+
+	b.Run("int", func(b *testing.B) {
+		b.Run("typed", func(b *testing.B) {
+			withBenchedLogger(b, func(log *Logger) {
+				log.Info("", Int("n", n))
+			})
+		})
+		b.Run("generic", func(b *testing.B) {
+			withBenchedLogger(b, func(log *Logger) {
+				log.Info("", Typed("n", n))
+			})
+		})
+		b.Run("any", func(b *testing.B) {
+			withBenchedLogger(b, func(log *Logger) {
+				log.Info("", Any("n", n))
+			})
+		})
+	})
+	b.Run("named int", func(b *testing.B) {
+		b.Run("typed", func(b *testing.B) {
+			withBenchedLogger(b, func(log *Logger) {
+				log.Info("", Int64("id", int64(id)))
+			})
+		})
+		b.Run("any", func(b *testing.B) {
+			withBenchedLogger(b, func(log *Logger) {
+				log.Info("", Any("id", id))
+			})
+		})
+		b.Run("reflect", func(b *testing.B) {
+			withBenchedLogger(b, func(log *Logger) {
+				log.Info("", Reflect("id", id))
+			})
+		})
+	})
+}
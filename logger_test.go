@@ -21,12 +21,16 @@
 package zap
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"runtime"
 	"strconv"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"go.uber.org/zap/internal/exit"
 	"go.uber.org/zap/internal/ztest"
@@ -175,6 +179,156 @@ func TestLoggerWith(t *testing.T) {
 	}
 }
 
+func TestLoggerAppendFields(t *testing.T) {
+	withLogger(t, DebugLevel, opts(Fields(Int("foo", 42))), func(logger *Logger, logs *observer.ObservedLogs) {
+		child := logger.With(String("child", "yes"))
+
+		ctx, undoOuter := AppendFields(context.Background(), String("request", "abc"))
+		ctx, undoInner := AppendFields(ctx, String("user", "alice"))
+		logger.Ctx(ctx).Info("")
+		child.Ctx(ctx).Info("")
+		logger.Sugar().Ctx(ctx).Infow("", "sugar", true)
+		logger.Info("") // without the context
+
+		undoOuter()
+		logger.Ctx(ctx).Info("")
+		undoOuter() // no-op
+		undoInner()
+		logger.Ctx(ctx).Info("")
+
+		assert.Equal(t, []observer.LoggedEntry{
+			{Context: []Field{Int("foo", 42), String("request", "abc"), String("user", "alice")}},
+			{Context: []Field{Int("foo", 42), String("child", "yes"), String("request", "abc"), String("user", "alice")}},
+			{Context: []Field{Int("foo", 42), String("request", "abc"), String("user", "alice"), Bool("sugar", true)}},
+			{Context: []Field{Int("foo", 42)}},
+			{Context: []Field{Int("foo", 42), String("user", "alice")}},
+			{Context: []Field{Int("foo", 42)}},
+		}, logs.AllUntimed(), "Unexpected fields with appended fields.")
+	})
+}
+
+func TestLoggerAppendFieldsEmpty(t *testing.T) {
+	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		ctx, undo := AppendFields(context.Background())
+		undo()
+		logger.Ctx(ctx).Info("")
+		assert.Equal(t, []observer.LoggedEntry{{Context: []Field{}}}, logs.AllUntimed(), "Unexpected fields.")
+	})
+}
+
+func TestLoggerAppendFieldsDisabled(t *testing.T) {
+	withLogger(t, InfoLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		var marshaled int
+		ctx, undo := AppendFields(context.Background(), Object("obj", zapcore.ObjectMarshalerFunc(
+			func(zapcore.ObjectEncoder) error {
+				marshaled++
+				return nil
+			})))
+		defer undo()
+
+		logger.Ctx(ctx).Debug("")
+		assert.Zero(t, marshaled, "Expected fields not to be encoded for disabled entries.")
+		assert.Zero(t, logs.Len(), "Expected disabled entry not to be written.")
+	})
+}
+
+func TestLoggerAppendFieldsConcurrent(t *testing.T) {
+	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		var (
+			wg sync.WaitGroup
+			id atomic.Int64
+		)
+		runConcurrently(5, 10, &wg, func() {
+			want := id.Add(1)
+			ctx, undo := AppendFields(context.Background(), Int64("request", want))
+			defer undo()
+			logger.Ctx(ctx).Info("", Int64("want", want))
+		})
+		wg.Wait()
+
+		require.Equal(t, 50, logs.Len(), "Unexpected number of logs written.")
+		for _, ent := range logs.All() {
+			assert.Equal(t, []Field{Int64("request", ent.Context[1].Integer), ent.Context[1]}, ent.Context,
+				"Expected only this request's fields.")
+			assert.Equal(t, "want", ent.Context[1].Key, "Unexpected field order.")
+		}
+	})
+}
+
+func TestLoggerAppendFieldsInPlace(t *testing.T) {
+	withLogger(t, DebugLevel, opts(Fields(Int("foo", 42))), func(logger *Logger, logs *observer.ObservedLogs) {
+		child := logger.With(String("child", "yes"))
+
+		undoOuter := logger.AppendFields(String("request", "abc"))
+		undoInner := logger.AppendFields(String("user", "alice"))
+		logger.Info("")
+		child.Info("")
+		logger.Sugar().Infow("", "sugar", true)
+		ctx, undoCtx := AppendFields(context.Background(), String("span", "s1"))
+		logger.Ctx(ctx).Info("")
+		undoCtx()
+
+		undoOuter()
+		logger.Info("")
+		undoOuter() // no-op
+		undoInner()
+		logger.Info("")
+
+		assert.Equal(t, []observer.LoggedEntry{
+			{Context: []Field{Int("foo", 42), String("request", "abc"), String("user", "alice")}},
+			{Context: []Field{Int("foo", 42), String("child", "yes"), String("request", "abc"), String("user", "alice")}},
+			{Context: []Field{Int("foo", 42), String("request", "abc"), String("user", "alice"), Bool("sugar", true)}},
+			{Context: []Field{Int("foo", 42), String("span", "s1"), String("request", "abc"), String("user", "alice")}},
+			{Context: []Field{Int("foo", 42), String("user", "alice")}},
+			{Context: []Field{Int("foo", 42)}},
+		}, logs.AllUntimed(), "Unexpected fields with fields appended to the logger.")
+	})
+}
+
+func TestLoggerAppendFieldsInPlaceScope(t *testing.T) {
+	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		other := New(logger.Core())
+		undo := logger.AppendFields(String("k", "v"))
+		defer undo()
+
+		other.Info("")
+		NewNop().AppendFields(String("k", "v"))()
+		assert.Equal(t, []observer.LoggedEntry{{Context: []Field{}}}, logs.AllUntimed(), "Expected loggers built separately not to share fields.")
+	})
+}
+
+func TestLoggerAppendFieldsInPlaceDisabled(t *testing.T) {
+	withLogger(t, InfoLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		var marshaled int
+		undo := logger.AppendFields(Object("obj", zapcore.ObjectMarshalerFunc(
+			func(zapcore.ObjectEncoder) error {
+				marshaled++
+				return nil
+			})))
+		defer undo()
+
+		logger.Debug("")
+		assert.Zero(t, marshaled, "Expected fields not to be encoded for disabled entries.")
+		assert.Zero(t, logs.Len(), "Expected disabled entry not to be written.")
+	})
+}
+
+func TestLoggerAppendFieldsInPlaceConcurrent(t *testing.T) {
+	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		var wg sync.WaitGroup
+		runConcurrently(5, 10, &wg, func() {
+			undo := logger.AppendFields(String("k", "v"))
+			logger.Info("")
+			undo()
+		})
+		wg.Wait()
+
+		assert.Equal(t, 50, logs.Len(), "Unexpected number of logs written.")
+		logger.Info("")
+		assert.Empty(t, logs.AllUntimed()[50].Context, "Expected all appended fields to be removed.")
+	})
+}
+
 func TestLoggerWithCaptures(t *testing.T) {
 	type withF func(*Logger, ...Field) *Logger
 	tests := []struct {
@@ -430,11 +584,12 @@ func TestLoggerLogFatal(t *testing.T) {
 }
 
 func TestLoggerLeveledMethods(t *testing.T) {
-	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+	withLogger(t, TraceLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
 		tests := []struct {
 			method        func(string, ...Field)
 			expectedLevel zapcore.Level
 		}{
+			{logger.Trace, TraceLevel},
 			{logger.Debug, DebugLevel},
 			{logger.Info, InfoLevel},
 			{logger.Warn, WarnLevel},
@@ -455,6 +610,99 @@ func TestLoggerLeveledMethods(t *testing.T) {
 	})
 }
 
+type loggerCtxKey struct{}
+
+type ctxCore struct {
+	zapcore.Core
+
+	got []context.Context
+}
+
+func (c *ctxCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(ent, c)
+}
+
+func (c *ctxCore) WriteContext(ctx context.Context, ent zapcore.Entry, fields []zapcore.Field) error {
+	c.got = append(c.got, ctx)
+	return c.Core.Write(ent, fields)
+}
+
+func TestLoggerEvent(t *testing.T) {
+	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		logger.With(String("tenant", "acme")).Event("user.signup", Int("user", 42))
+		assert.Equal(t, []observer.LoggedEntry{{
+			Entry:   zapcore.Entry{Level: InfoLevel, Event: "user.signup"},
+			Context: []Field{String("tenant", "acme"), Int("user", 42)},
+		}}, logs.AllUntimed(), "Unexpected output.")
+	})
+
+	withLogger(t, WarnLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		logger.Event("user.signup")
+		assert.Zero(t, logs.Len(), "Expected events to be logged at InfoLevel.")
+	})
+}
+
+func TestLoggerEventCaller(t *testing.T) {
+	withLogger(t, DebugLevel, opts(AddCaller()), func(logger *Logger, logs *observer.ObservedLogs) {
+		logger.Event("user.signup")
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 1, "Expected one entry.")
+		assert.Regexp(t, `logger_test.go:\d+$`, entries[0].Caller.String(), "Expected caller to be the call site of Event.")
+	})
+}
+
+func TestLoggerCtx(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	core := &ctxCore{Core: obs}
+	logger := New(core)
+	ctx := context.WithValue(context.Background(), loggerCtxKey{}, "tenant")
+
+	logger.Info("no context")
+	logger.Ctx(ctx).Info("context")
+	logger.Ctx(ctx).Named("child").Info("named context")
+	logger.Sugar().Ctx(ctx).Info("sugared context")
+
+	assert.Equal(t, 4, logs.Len(), "Unexpected number of logs.")
+	require.Len(t, core.got, 3, "Expected WriteContext for every entry logged with a context.")
+	for _, got := range core.got {
+		assert.Equal(t, "tenant", got.Value(loggerCtxKey{}), "Unexpected context passed to core.")
+	}
+}
+
+func TestLoggerV(t *testing.T) {
+	tests := []struct {
+		n    int
+		want zapcore.Level
+	}{
+		{-1, InfoLevel},
+		{0, InfoLevel},
+		{1, DebugLevel},
+		{2, TraceLevel},
+		{5, zapcore.Level(-5)},
+		{1000, zapcore.Level(-128)},
+	}
+	for _, tt := range tests {
+		withLogger(t, zapcore.Level(-128), nil, func(logger *Logger, logs *observer.ObservedLogs) {
+			v := logger.V(tt.n)
+			assert.Equal(t, tt.want, v.Level(), "Unexpected level for V(%d).", tt.n)
+			assert.True(t, v.Enabled(), "Expected V(%d) to be enabled.", tt.n)
+
+			v.Info("hello", Int("n", tt.n))
+			assert.Equal(t, []observer.LoggedEntry{{
+				Entry:   zapcore.Entry{Level: tt.want, Message: "hello"},
+				Context: []Field{Int("n", tt.n)},
+			}}, logs.AllUntimed(), "Unexpected output from V(%d).", tt.n)
+		})
+	}
+
+	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		assert.True(t, logger.V(1).Enabled(), "Expected V(1) to be enabled at DebugLevel.")
+		assert.False(t, logger.V(2).Enabled(), "Expected V(2) to be disabled at DebugLevel.")
+		logger.V(2).Info("hello")
+		assert.Zero(t, logs.Len(), "Expected disabled verbosity levels to be dropped.")
+	})
+}
+
 func TestLoggerLogLevels(t *testing.T) {
 	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
 		levels := []zapcore.Level{
@@ -536,6 +784,51 @@ func TestLoggerDPanic(t *testing.T) {
 	})
 }
 
+func TestLoggerDPanicPolicy(t *testing.T) {
+	var count int
+	countHook := func(ent zapcore.Entry) {
+		assert.Equal(t, DPanicLevel, ent.Level, "Unexpected level passed to DPanic hook.")
+		count++
+	}
+
+	t.Run("panic in production", func(t *testing.T) {
+		count = 0
+		withLogger(t, DebugLevel, opts(WithDPanicPolicy(DPanicPolicy{Panic: true, Hook: countHook})), func(logger *Logger, logs *observer.ObservedLogs) {
+			assert.Panics(t, func() { logger.DPanic("") }, "Expected policy to make DPanic panic.")
+			assert.Equal(t, 1, count, "Expected hook to run before panicking.")
+			assert.Equal(t, 1, logs.Len(), "Expected DPanic to be logged.")
+		})
+	})
+
+	t.Run("overrides development", func(t *testing.T) {
+		count = 0
+		withLogger(t, DebugLevel, opts(Development(), WithDPanicPolicy(DPanicPolicy{Hook: countHook})), func(logger *Logger, logs *observer.ObservedLogs) {
+			assert.NotPanics(t, func() { logger.DPanic("") }, "Expected policy to override Development.")
+			assert.Equal(t, 1, count, "Expected hook to run.")
+		})
+	})
+
+	t.Run("hook runs when disabled", func(t *testing.T) {
+		count = 0
+		withLogger(t, FatalLevel, opts(WithDPanicPolicy(DPanicPolicy{Hook: countHook})), func(logger *Logger, logs *observer.ObservedLogs) {
+			logger.DPanic("")
+			assert.Equal(t, 1, count, "Expected hook to run even if DPanicLevel is disabled.")
+			assert.Zero(t, logs.Len(), "Expected no output.")
+		})
+	})
+
+	t.Run("stacktrace", func(t *testing.T) {
+		withLogger(t, DebugLevel, opts(WithDPanicPolicy(DPanicPolicy{Stacktrace: true})), func(logger *Logger, logs *observer.ObservedLogs) {
+			logger.DPanic("")
+			logger.Error("")
+			entries := logs.AllUntimed()
+			require.Len(t, entries, 2, "Unexpected number of logs.")
+			assert.Contains(t, entries[0].Stack, "zap.TestLoggerDPanicPolicy", "Expected stack trace on DPanic logs.")
+			assert.Empty(t, entries[1].Stack, "Expected no stack trace on Error logs.")
+		})
+	})
+}
+
 func TestLoggerNoOpsDisabledLevels(t *testing.T) {
 	withLogger(t, WarnLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
 		logger.Info("silence!")
@@ -795,6 +1088,87 @@ func TestLoggerHooks(t *testing.T) {
 	assert.Equal(t, int64(2), seen.Load(), "Hook saw an unexpected number of logs.")
 }
 
+func TestLoggerFieldHooks(t *testing.T) {
+	hook := func(ent zapcore.Entry, fields []Field) ([]Field, error) {
+		return append(fields, String("level", ent.Level.String())), nil
+	}
+	withLogger(t, DebugLevel, opts(FieldHooks(hook)), func(logger *Logger, logs *observer.ObservedLogs) {
+		logger.With(Int("foo", 42)).Info("", Bool("bar", true))
+		assert.Equal(t, []observer.LoggedEntry{{
+			Entry:   zapcore.Entry{Level: InfoLevel},
+			Context: []Field{Int("foo", 42), Bool("bar", true), String("level", "info")},
+		}}, logs.AllUntimed(), "Unexpected fields after running hooks.")
+	})
+}
+
+func TestLoggerAddCallerPC(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	core := zapcore.NewSamplerWithOptions(obs, time.Minute, 2, 0, zapcore.SamplerByCaller())
+	logger := New(core, AddCallerPC())
+
+	for i := 0; i < 5; i++ {
+		logger.Info(fmt.Sprintf("first site %d", i))
+		logger.Sugar().Infof("second site %d", i)
+	}
+
+	assert.Equal(t, 4, logs.Len(), "Expected two entries from each call site.")
+	for _, ent := range logs.All() {
+		assert.NotZero(t, ent.Caller.PC, "Expected caller PC to be recorded.")
+		assert.False(t, ent.Caller.Defined, "Expected caller not to be resolved without AddCaller.")
+		frame, _ := runtime.CallersFrames([]uintptr{ent.Caller.PC}).Next()
+		assert.Equal(t, "logger_test.go", filepath.Base(frame.File), "Expected PC to identify the caller.")
+	}
+}
+
+func TestLoggerWithKeyCase(t *testing.T) {
+	withLogger(t, DebugLevel, opts(WithKeyCase(zapcore.SnakeCaseKeys)), func(logger *Logger, logs *observer.ObservedLogs) {
+		logger.With(Int("userID", 42)).Sugar().Infow("", "requestId", "abc")
+		assert.Equal(t, []observer.LoggedEntry{{
+			Entry:   zapcore.Entry{Level: InfoLevel},
+			Context: []Field{Int("user_id", 42), String("request_id", "abc")},
+		}}, logs.AllUntimed(), "Unexpected keys.")
+	})
+}
+
+func TestLoggerWithSequence(t *testing.T) {
+	withLogger(t, DebugLevel, opts(WithSequence("seq")), func(logger *Logger, logs *observer.ObservedLogs) {
+		logger.Info("first")
+		logger.Named("child").With(Int("foo", 42)).Info("second", Bool("bar", true))
+		logger.Info("third")
+		assert.Equal(t, []observer.LoggedEntry{
+			{
+				Entry:   zapcore.Entry{Level: InfoLevel, Message: "first"},
+				Context: []Field{Uint64("seq", 1)},
+			},
+			{
+				Entry:   zapcore.Entry{Level: InfoLevel, LoggerName: "child", Message: "second"},
+				Context: []Field{Int("foo", 42), Bool("bar", true), Uint64("seq", 2)},
+			},
+			{
+				Entry:   zapcore.Entry{Level: InfoLevel, Message: "third"},
+				Context: []Field{Uint64("seq", 3)},
+			},
+		}, logs.AllUntimed(), "Expected loggers to share one sequence.")
+	})
+}
+
+func TestLoggerWithPipelineDebug(t *testing.T) {
+	var out ztest.Buffer
+	withLogger(t, InfoLevel, opts(WithPipelineDebug(&out)), func(logger *Logger, logs *observer.ObservedLogs) {
+		assert.Equal(t, InfoLevel, logger.Level(), "Expected the logger's level to be unchanged.")
+
+		logger.Debug("quiet")
+		logger.Info("loud")
+
+		assert.Equal(t, 1, logs.Len(), "Expected only enabled entries to be logged.")
+		lines := out.Lines()
+		require.Len(t, lines, 3, "Unexpected number of reports: %v", lines)
+		assert.Equal(t, `zap pipeline: dropped debug "quiet": level disabled for every core`, lines[0], "Unexpected report for disabled entry.")
+		assert.Equal(t, `zap pipeline: core (*observer.contextObserver) accepted info "loud"`, lines[1], "Unexpected report for enabled entry.")
+		assert.Contains(t, lines[2], `zap pipeline: core (*observer.contextObserver) wrote info "loud": took `, "Unexpected write report.")
+	})
+}
+
 func TestLoggerConcurrent(t *testing.T) {
 	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
 		child := logger.With(String("foo", "bar"))
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Money constructs a field that logs a monetary amount as an object with
+// the keys amount and currency. The amount is given in the currency's minor
+// units, such as cents, and exponent is the number of minor-unit digits (2
+// for USD, 0 for JPY, 3 for KWD). It's logged as an exact decimal string,
+// so Money(key, 1999, "USD", 2) logs {"amount": "19.99", "currency": "USD"}.
+// Unlike with Float64, amounts never pick up floating-point rounding errors.
+//
+// The currency should be an ISO 4217 code; it's upper-cased. Negative
+// exponents are treated as zero, and exponents above 18 as 18. The amount
+// is only formatted when the entry is encoded.
+func Money(key string, amount int64, currency string, exponent int) Field {
+	return Object(key, money{amount: amount, currency: currency, exponent: exponent})
+}
+
+// _maxMoneyExponent bounds the digits formatMinorUnits pads amounts to, so
+// that a bad exponent can't allocate without bound.
+const _maxMoneyExponent = 18
+
+type money struct {
+	amount   int64
+	currency string
+	exponent int
+}
+
+func (m money) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("amount", formatMinorUnits(m.amount, m.exponent))
+	enc.AddString("currency", strings.ToUpper(m.currency))
+	return nil
+}
+
+// formatMinorUnits formats amount with the decimal point exponent digits
+// from the right.
+func formatMinorUnits(amount int64, exponent int) string {
+	if exponent <= 0 {
+		return strconv.FormatInt(amount, 10)
+	}
+	if exponent > _maxMoneyExponent {
+		exponent = _maxMoneyExponent
+	}
+
+	// Work with the magnitude as a uint64 so that math.MinInt64 is handled.
+	abs := uint64(amount)
+	if amount < 0 {
+		abs = -abs
+	}
+	digits := strconv.FormatUint(abs, 10)
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	var b strings.Builder
+	b.Grow(len(digits) + 2)
+	if amount < 0 {
+		b.WriteByte('-')
+	}
+	b.WriteString(digits[:len(digits)-exponent])
+	b.WriteByte('.')
+	b.WriteString(digits[len(digits)-exponent:])
+	return b.String()
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestMoney(t *testing.T) {
+	tests := []struct {
+		desc     string
+		amount   int64
+		currency string
+		exponent int
+		want     map[string]interface{}
+	}{
+		{"cents", 1999, "USD", 2, map[string]interface{}{"amount": "19.99", "currency": "USD"}},
+		{"small", 5, "usd", 2, map[string]interface{}{"amount": "0.05", "currency": "USD"}},
+		{"exact", 100, "EUR", 2, map[string]interface{}{"amount": "1.00", "currency": "EUR"}},
+		{"negative", -1234, "GBP", 2, map[string]interface{}{"amount": "-12.34", "currency": "GBP"}},
+		{"negative small", -7, "GBP", 2, map[string]interface{}{"amount": "-0.07", "currency": "GBP"}},
+		{"no minor units", 500, "JPY", 0, map[string]interface{}{"amount": "500", "currency": "JPY"}},
+		{"three digits", 1500, "KWD", 3, map[string]interface{}{"amount": "1.500", "currency": "KWD"}},
+		{"negative exponent", 42, "XXX", -1, map[string]interface{}{"amount": "42", "currency": "XXX"}},
+		{"min int64", math.MinInt64, "USD", 2, map[string]interface{}{"amount": "-92233720368547758.08", "currency": "USD"}},
+		{"huge exponent", 1, "USD", math.MaxInt32, map[string]interface{}{"amount": "0.000000000000000001", "currency": "USD"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := zapcore.NewMapObjectEncoder()
+			Money("price", tt.amount, tt.currency, tt.exponent).AddTo(enc)
+			assert.Equal(t, tt.want, enc.Fields["price"], "Unexpected money object.")
+		})
+	}
+}
+
+var _moneyFieldSink Field
+
+func TestMoneyAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() { _moneyFieldSink = Money("price", 1999, "usd", 2) })
+	assert.Equal(t, 1.0, allocs, "Expected Money to allocate only to hold the amount, deferring formatting.")
+}
@@ -22,6 +22,7 @@ package zap
 
 import (
 	"fmt"
+	"io"
 
 	"go.uber.org/zap/zapcore"
 )
@@ -49,15 +50,57 @@ func WrapCore(f func(zapcore.Core) zapcore.Core) Option {
 // out an Entry. Repeated use of Hooks is additive.
 //
 // Hooks are useful for simple side effects, like capturing metrics for the
-// number of emitted logs. More complex side effects, including anything that
-// requires access to the Entry's structured fields, should be implemented as
-// a zapcore.Core instead. See zapcore.RegisterHooks for details.
+// number of emitted logs. Hooks that need the Entry's structured fields
+// should use FieldHooks, and more complex side effects should be implemented
+// as a zapcore.Core instead. See zapcore.RegisterHooks for details.
 func Hooks(hooks ...func(zapcore.Entry) error) Option {
 	return optionFunc(func(log *Logger) {
 		log.core = zapcore.RegisterHooks(log.core, hooks...)
 	})
 }
 
+// FieldHooks registers functions which will be called each time the Logger
+// writes out an Entry, before it's encoded. Each hook receives the fields
+// passed at the log site and returns the fields to log, so hooks can
+// implement redaction, enrichment, or metrics by field. Repeated use of
+// FieldHooks is additive. See zapcore.RegisterFieldHooks for details.
+func FieldHooks(hooks ...func(zapcore.Entry, []Field) ([]Field, error)) Option {
+	return optionFunc(func(log *Logger) {
+		log.core = zapcore.RegisterFieldHooks(log.core, hooks...)
+	})
+}
+
+// WithSequence adds a field with the given key and an increasing sequence
+// number to every entry the Logger writes. The Logger and the loggers derived
+// from it share one sequence, so gaps reveal entries lost after logging. See
+// zapcore.NewSequenceCore for details.
+func WithSequence(key string) Option {
+	return optionFunc(func(log *Logger) {
+		log.core = zapcore.NewSequenceCore(log.core, key)
+	})
+}
+
+// WithKeyCase converts the keys of all fields the Logger writes to the given
+// case, like snake_case or camelCase. Context added to the Logger before
+// this option is applied keeps its keys. See zapcore.NewKeyCaseCore for
+// details.
+func WithKeyCase(kc zapcore.KeyCase) Option {
+	return optionFunc(func(log *Logger) {
+		log.core = zapcore.NewKeyCaseCore(log.core, kc)
+	})
+}
+
+// WithPipelineDebug reports to w, for every entry the Logger logs, which
+// Cores accepted or rejected it and how long it took to encode and write,
+// to help debug log lines that go missing in complex stacks of Cores. Apply
+// it after any options that wrap the Core. It's slow, so it's meant for
+// debugging only. See zapcore.NewPipelineDebugCore for details.
+func WithPipelineDebug(w io.Writer) Option {
+	return optionFunc(func(log *Logger) {
+		log.core = zapcore.NewPipelineDebugCore(log.core, zapcore.Lock(zapcore.AddSync(w)))
+	})
+}
+
 // Fields adds fields to the Logger.
 func Fields(fs ...Field) Option {
 	return optionFunc(func(log *Logger) {
@@ -79,13 +122,76 @@ func ErrorOutput(w zapcore.WriteSyncer) Option {
 }
 
 // Development puts the logger in development mode, which makes DPanic-level
-// logs panic instead of simply logging an error.
+// logs panic instead of simply logging an error. In development mode, the
+// SugaredLogger also checks that the templates passed to its printf-style
+// methods match their arguments.
 func Development() Option {
 	return optionFunc(func(log *Logger) {
 		log.development = true
 	})
 }
 
+// DPanicPolicy configures what happens after a DPanic-level log is written.
+// Without a policy, DPanic logs panic in development mode (see Development)
+// and are otherwise treated like Error logs.
+type DPanicPolicy struct {
+	// Panic makes DPanic logs panic, regardless of the Development option.
+	// This is useful to crash on invariant violations only in some
+	// environments, such as canaries.
+	Panic bool
+	// Stacktrace attaches a stack trace to DPanic logs, even if AddStacktrace
+	// wouldn't.
+	Stacktrace bool
+	// Hook, if non-nil, is called with every DPanic log, before panicking if
+	// Panic is set. It's called even if no Core logs at DPanicLevel, so it
+	// can be used to count invariant violations.
+	Hook func(zapcore.Entry)
+}
+
+// WithDPanicPolicy configures how the Logger handles DPanic-level logs. The
+// policy takes precedence over the Development option. For example, the
+// following logs DPanics with a stack trace and counts them, but only
+// panics in canaries:
+//
+//	zap.WithDPanicPolicy(zap.DPanicPolicy{
+//		Panic:      isCanary,
+//		Stacktrace: true,
+//		Hook:       func(zapcore.Entry) { dpanics.Inc() },
+//	})
+func WithDPanicPolicy(policy DPanicPolicy) Option {
+	return optionFunc(func(log *Logger) {
+		log.dpanic = &policy
+	})
+}
+
+// KeyValuePolicy controls how the SugaredLogger handles malformed key-value
+// pairs: keys without values and keys that aren't strings.
+type KeyValuePolicy uint8
+
+const (
+	// KeyValueError logs an Error-level entry describing the malformed
+	// pairs and leaves them out of the entry being logged. This is the
+	// default.
+	KeyValueError KeyValuePolicy = iota
+	// KeyValueDPanic is like KeyValueError, but logs the description at
+	// DPanicLevel, so that mistakes panic in development.
+	KeyValueDPanic
+	// KeyValueDrop silently leaves malformed pairs out of the entry.
+	KeyValueDrop
+	// KeyValueCoerce keeps malformed pairs in the entry: keys that aren't
+	// strings are converted with fmt.Sprint, and a key without a value is
+	// logged as a value under the key "ignored".
+	KeyValueCoerce
+)
+
+// WithKeyValuePolicy configures how the SugaredLogger handles malformed
+// key-value pairs passed to With and the methods ending in "w", like Infow.
+func WithKeyValuePolicy(policy KeyValuePolicy) Option {
+	return optionFunc(func(log *Logger) {
+		log.kvPolicy = policy
+	})
+}
+
 // AddCaller configures the Logger to annotate each message with the filename,
 // line number, and function name of zap's caller. See also WithCaller.
 func AddCaller() Option {
@@ -101,6 +207,18 @@ func WithCaller(enabled bool) Option {
 	})
 }
 
+// AddCallerPC configures the Logger to record the program counter of zap's
+// caller in each Entry before the Core checks it, so that Cores can make
+// decisions by call site; see zapcore.SamplerByCaller. Unlike AddCaller, it
+// doesn't resolve the caller's file, line, or function, but it does cost a
+// little for every entry at an enabled level, including entries the Core
+// then drops.
+func AddCallerPC() Option {
+	return optionFunc(func(log *Logger) {
+		log.addCallerPC = true
+	})
+}
+
 // AddCallerSkip increases the number of callers skipped by caller annotation
 // (as enabled by the AddCaller option). When building wrappers around the
 // Logger and SugaredLogger, supplying this Option prevents zap from always
@@ -130,6 +248,7 @@ func IncreaseLevel(lvl zapcore.LevelEnabler) Option {
 				"failed to IncreaseLevel: %v\n",
 				err,
 			)
+			zapcore.ReportInternalError(fmt.Errorf("failed to IncreaseLevel: %w", err), zapcore.Entry{})
 		} else {
 			log.core = core
 		}
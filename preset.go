@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	errNoPresetNameSpecified = errors.New("no preset name specified")
+
+	_presets = map[string]func() Config{
+		"production":  NewProductionConfig,
+		"development": NewDevelopmentConfig,
+		"cli":         NewCLIConfig,
+	}
+	_presetMutex sync.RWMutex
+)
+
+// RegisterPreset registers a function returning a Config under a name, so
+// that an organization can define its logging conventions once and have
+// every service use them with NewPreset or PresetConfig:
+//
+//	func init() {
+//		zap.RegisterPreset("acme-prod", func() zap.Config {
+//			cfg := zap.NewProductionConfig()
+//			cfg.EncoderConfig.TimeKey = "@timestamp"
+//			cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+//			return cfg
+//		})
+//	}
+//
+// The function is called each time the preset is used, so it must return a
+// new Config each time rather than sharing one; Configs hold an AtomicLevel
+// that would otherwise tie the levels of unrelated loggers together. By
+// default, the "production", "development", and "cli" presets are registered.
+//
+// Attempting to register a preset whose name is already taken returns an
+// error.
+func RegisterPreset(name string, newConfig func() Config) error {
+	_presetMutex.Lock()
+	defer _presetMutex.Unlock()
+	if name == "" {
+		return errNoPresetNameSpecified
+	}
+	if newConfig == nil {
+		return fmt.Errorf("nil Config function for preset %q", name)
+	}
+	if _, ok := _presets[name]; ok {
+		return fmt.Errorf("preset already registered for name %q", name)
+	}
+	_presets[name] = newConfig
+	return nil
+}
+
+// PresetConfig returns the Config of the named preset, which callers may
+// amend before building a logger.
+func PresetConfig(name string) (Config, error) {
+	_presetMutex.RLock()
+	newConfig, ok := _presets[name]
+	_presetMutex.RUnlock()
+	if !ok {
+		return Config{}, fmt.Errorf("no preset registered for name %q", name)
+	}
+	return newConfig(), nil
+}
+
+// NewPreset builds a Logger from the named preset's Config and the given
+// Options. It's a shortcut for PresetConfig(name) followed by Build.
+func NewPreset(name string, options ...Option) (*Logger, error) {
+	cfg, err := PresetConfig(name)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Build(options...)
+}
+
+// Presets returns the names of the registered presets, in sorted order.
+func Presets() []string {
+	_presetMutex.RLock()
+	defer _presetMutex.RUnlock()
+	names := make([]string, 0, len(_presets))
+	for name := range _presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
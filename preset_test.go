@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withTestPresets runs f with a copy of the preset registry, so that
+// presets registered by f don't leak into other tests.
+func withTestPresets(f func()) {
+	existing := _presets
+	_presets = make(map[string]func() Config, len(existing))
+	for name, newConfig := range existing {
+		_presets[name] = newConfig
+	}
+	defer func() { _presets = existing }()
+	f()
+}
+
+func TestBuiltinPresets(t *testing.T) {
+	assert.Equal(t, []string{"cli", "development", "production"}, Presets(), "Unexpected built-in presets.")
+
+	cfg, err := PresetConfig("development")
+	require.NoError(t, err, "Unexpected error getting preset.")
+	assert.Equal(t, NewDevelopmentConfig().Encoding, cfg.Encoding, "Expected the development Config.")
+
+	logger, err := NewPreset("production")
+	require.NoError(t, err, "Unexpected error building preset.")
+	assert.Equal(t, InfoLevel, logger.Level(), "Unexpected level.")
+}
+
+func TestRegisterPreset(t *testing.T) {
+	withTestPresets(func() {
+		logOut := filepath.Join(t.TempDir(), "test.log")
+		require.NoError(t, RegisterPreset("acme-prod", func() Config {
+			cfg := NewProductionConfig()
+			cfg.EncoderConfig.TimeKey = ""
+			cfg.OutputPaths = []string{logOut}
+			cfg.InitialFields = map[string]interface{}{"org": "acme"}
+			return cfg
+		}), "Unexpected error registering preset.")
+		assert.Contains(t, Presets(), "acme-prod", "Expected preset to be listed.")
+
+		logger, err := NewPreset("acme-prod", WithCaller(false), Fields(String("service", "api")))
+		require.NoError(t, err, "Unexpected error building preset.")
+		logger.Info("hello")
+		require.NoError(t, logger.Sync(), "Unexpected error syncing logger.")
+
+		contents, err := os.ReadFile(logOut)
+		require.NoError(t, err, "Couldn't read log file.")
+		assert.Equal(t,
+			`{"level":"info","msg":"hello","org":"acme","service":"api"}`+"\n",
+			string(contents), "Expected options to amend the preset.")
+
+		a, err := PresetConfig("acme-prod")
+		require.NoError(t, err, "Unexpected error getting preset.")
+		b, err := PresetConfig("acme-prod")
+		require.NoError(t, err, "Unexpected error getting preset.")
+		a.Level.SetLevel(DebugLevel)
+		assert.Equal(t, InfoLevel, b.Level.Level(), "Expected each use to get a new Config.")
+	})
+}
+
+func TestRegisterPresetErrors(t *testing.T) {
+	withTestPresets(func() {
+		assert.Equal(t, errNoPresetNameSpecified, RegisterPreset("", NewProductionConfig),
+			"Expected an error registering a preset without a name.")
+		assert.EqualError(t, RegisterPreset("foo", nil), `nil Config function for preset "foo"`,
+			"Expected an error registering a nil preset.")
+		assert.EqualError(t, RegisterPreset("production", NewProductionConfig),
+			`preset already registered for name "production"`,
+			"Expected an error registering a preset twice.")
+	})
+
+	_, err := NewPreset("missing")
+	assert.EqualError(t, err, `no preset registered for name "missing"`,
+		"Expected an error using an unregistered preset.")
+}
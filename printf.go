@@ -0,0 +1,277 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// checkFormat reports whether a printf-style template matches the given
+// arguments. It finds the mistakes that fmt renders as %!v(MISSING),
+// %!(EXTRA ...), %!(BADINDEX), and %!(NOVERB), following fmt's rules for
+// flags, widths, precisions, and explicit argument indexes. Like the printf
+// check of go vet, it also finds verbs that fmt doesn't know and arguments
+// of the wrong type for their verb, like a string for %d, which fmt renders
+// as %!d(string=...).
+func checkFormat(template string, args []interface{}) error {
+	p := formatChecker{template: template, args: args}
+	for p.i < len(template) {
+		if template[p.i] != '%' {
+			p.i++
+			continue
+		}
+		if err := p.verb(); err != nil {
+			return err
+		}
+	}
+	if !p.reordered && p.argNum < len(args) {
+		return fmt.Errorf("%d unused argument(s)", len(args)-p.argNum)
+	}
+	return nil
+}
+
+type formatChecker struct {
+	template  string
+	args      []interface{}
+	i         int  // position in template
+	argNum    int  // next argument to consume
+	reordered bool // whether explicit argument indexes were used
+}
+
+// verb checks the directive that starts at the '%' at p.i.
+func (p *formatChecker) verb() error {
+	start := p.i
+	p.i++
+	for p.i < len(p.template) && strings.IndexByte("#0+- ", p.template[p.i]) >= 0 {
+		p.i++
+	}
+
+	// Width.
+	if err := p.argIndex(); err != nil {
+		return err
+	}
+	if err := p.starOrNumber(start); err != nil {
+		return err
+	}
+
+	// Precision.
+	if p.i < len(p.template) && p.template[p.i] == '.' {
+		p.i++
+		if err := p.argIndex(); err != nil {
+			return err
+		}
+		if err := p.starOrNumber(start); err != nil {
+			return err
+		}
+	}
+
+	if err := p.argIndex(); err != nil {
+		return err
+	}
+	if p.i >= len(p.template) {
+		return fmt.Errorf("missing verb at end of %q", p.template[start:])
+	}
+	verb, size := utf8.DecodeRuneInString(p.template[p.i:])
+	p.i += size
+	if verb == '%' {
+		return nil
+	}
+	accepts, ok := _printVerbs[verb]
+	if !ok {
+		return fmt.Errorf("unrecognized verb in %q", p.template[start:p.i])
+	}
+	if p.argNum >= len(p.args) {
+		return fmt.Errorf("missing argument for %q", p.template[start:p.i])
+	}
+	arg := p.args[p.argNum]
+	p.argNum++
+	if accepts != argAny && !matchArgType(reflect.TypeOf(arg), accepts, true, nil) {
+		return fmt.Errorf("wrong type %T for %q", arg, p.template[start:p.i])
+	}
+	return nil
+}
+
+// starOrNumber consumes a width or precision: either a '*', which takes an
+// argument, or a number.
+func (p *formatChecker) starOrNumber(start int) error {
+	if p.i < len(p.template) && p.template[p.i] == '*' {
+		p.i++
+		if p.argNum >= len(p.args) {
+			return fmt.Errorf("missing argument for '*' in %q", p.template[start:p.i])
+		}
+		arg := p.args[p.argNum]
+		p.argNum++
+		if t := reflect.TypeOf(arg); t == nil || !isIntKind(t.Kind()) {
+			return fmt.Errorf("non-integer argument %T for '*' in %q", arg, p.template[start:p.i])
+		}
+		return nil
+	}
+	for p.i < len(p.template) && '0' <= p.template[p.i] && p.template[p.i] <= '9' {
+		p.i++
+	}
+	return nil
+}
+
+// argIndex consumes an explicit argument index like "[2]", if there's one.
+func (p *formatChecker) argIndex() error {
+	if p.i >= len(p.template) || p.template[p.i] != '[' {
+		return nil
+	}
+	p.reordered = true
+	end := strings.IndexByte(p.template[p.i:], ']')
+	if end < 0 {
+		return fmt.Errorf("unterminated argument index in %q", p.template[p.i:])
+	}
+	n, err := strconv.Atoi(p.template[p.i+1 : p.i+end])
+	if err != nil || n < 1 || n > len(p.args) {
+		return fmt.Errorf("bad argument index %q for %d argument(s)", p.template[p.i:p.i+end+1], len(p.args))
+	}
+	p.argNum = n - 1
+	p.i += end + 1
+	return nil
+}
+
+// argType is a set of kinds of arguments a verb accepts, as in go vet's
+// printf check.
+type argType int
+
+const (
+	argBool argType = 1 << iota
+	argInt
+	argRune
+	argString
+	argFloat
+	argComplex
+	argPointer
+
+	argAny argType = -1
+)
+
+// _printVerbs lists the verbs fmt supports for Sprintf and the kinds of
+// arguments each accepts.
+var _printVerbs = map[rune]argType{
+	'b': argInt | argFloat | argComplex | argPointer,
+	'c': argRune | argInt,
+	'd': argInt | argPointer,
+	'e': argFloat | argComplex,
+	'E': argFloat | argComplex,
+	'f': argFloat | argComplex,
+	'F': argFloat | argComplex,
+	'g': argFloat | argComplex,
+	'G': argFloat | argComplex,
+	'o': argInt | argPointer,
+	'O': argInt | argPointer,
+	'p': argPointer,
+	'q': argRune | argInt | argString,
+	's': argString,
+	't': argBool,
+	'T': argAny,
+	'U': argRune | argInt,
+	'v': argAny,
+	'x': argRune | argInt | argString | argPointer | argFloat | argComplex,
+	'X': argRune | argInt | argString | argPointer | argFloat | argComplex,
+}
+
+var (
+	_errorType     = reflect.TypeOf((*error)(nil)).Elem()
+	_stringerType  = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	_formatterType = reflect.TypeOf((*fmt.Formatter)(nil)).Elem()
+)
+
+// matchArgType reports whether fmt can format values of type t with a verb
+// accepting the given kinds of arguments. Like fmt, it applies the verb to
+// the elements of slices, arrays, maps, and structs, and to the target of a
+// top-level pointer to one of those.
+func matchArgType(t reflect.Type, accepts argType, top bool, seen map[reflect.Type]bool) bool {
+	if t == nil {
+		// Only %v and %T, which accept anything, handle untyped nils.
+		return false
+	}
+	if t.Implements(_formatterType) {
+		return true
+	}
+	if accepts&argString != 0 && (t.Implements(_errorType) || t.Implements(_stringerType)) {
+		return true
+	}
+	if seen[t] {
+		return true // recursive type; the other fields decide
+	}
+
+	switch k := t.Kind(); {
+	case k == reflect.Bool:
+		return accepts&argBool != 0
+	case isIntKind(k):
+		return accepts&(argInt|argRune) != 0
+	case k == reflect.Float32 || k == reflect.Float64:
+		return accepts&argFloat != 0
+	case k == reflect.Complex64 || k == reflect.Complex128:
+		return accepts&argComplex != 0
+	case k == reflect.String:
+		return accepts&argString != 0
+	case k == reflect.Slice || k == reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && accepts&argString != 0 {
+			return true // byte slices format like strings
+		}
+		return matchArgType(t.Elem(), accepts, false, markSeen(seen, t))
+	case k == reflect.Map:
+		seen = markSeen(seen, t)
+		return matchArgType(t.Key(), accepts, false, seen) && matchArgType(t.Elem(), accepts, false, seen)
+	case k == reflect.Struct:
+		seen = markSeen(seen, t)
+		for i := 0; i < t.NumField(); i++ {
+			if !matchArgType(t.Field(i).Type, accepts, false, seen) {
+				return false
+			}
+		}
+		return true
+	case k == reflect.Pointer:
+		if top {
+			switch t.Elem().Kind() {
+			case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+				return matchArgType(t.Elem(), accepts, false, markSeen(seen, t))
+			}
+		}
+		return accepts&argPointer != 0
+	case k == reflect.Chan || k == reflect.Func || k == reflect.UnsafePointer:
+		return accepts&argPointer != 0
+	case k == reflect.Interface:
+		// Only reachable through elements and fields, whose dynamic types
+		// aren't known from the type alone.
+		return true
+	}
+	return false
+}
+
+func markSeen(seen map[reflect.Type]bool, t reflect.Type) map[reflect.Type]bool {
+	if seen == nil {
+		seen = make(map[reflect.Type]bool)
+	}
+	seen[t] = true
+	return seen
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Int64) || (k >= reflect.Uint && k <= reflect.Uintptr)
+}
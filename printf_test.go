@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stringerArg struct{}
+
+func (stringerArg) String() string { return "stringer" }
+
+type formatterArg struct{}
+
+func (formatterArg) Format(f fmt.State, _ rune) { fmt.Fprint(f, "formatter") }
+
+type pointArg struct{ X, Y int }
+
+type nodeArg struct {
+	Name     string
+	Children []nodeArg
+}
+
+func TestCheckFormat(t *testing.T) {
+	ints := func(n int) []interface{} {
+		args := make([]interface{}, n)
+		for i := range args {
+			args[i] = 1
+		}
+		return args
+	}
+	n := 1
+	tests := []struct {
+		format  string
+		args    []interface{}
+		wantErr string
+	}{
+		{"", nil, ""},
+		{"plain", nil, ""},
+		{"%v", ints(1), ""},
+		{"100%%", nil, ""},
+		{"%d%%", ints(1), ""},
+		{"%+v %#x % d %-5s %05d", []interface{}{1, 1, 1, "s", 1}, ""},
+		{"%6.2f", []interface{}{1.5}, ""},
+		{"%*d", ints(2), ""},
+		{"%.*f", []interface{}{2, 1.5}, ""},
+		{"%*.*f", []interface{}{5, 2, 1.5}, ""},
+		{"%[2]d %[1]d", ints(2), ""},
+		{"%[1]d %[1]d", ints(2), ""}, // fmt allows unused arguments once indexes are used
+		{"%[2]*[1]d", ints(2), ""},
+		{"%v", ints(0), `missing argument for "%v"`},
+		{"%v %5.2f", []interface{}{1.5}, `missing argument for "%5.2f"`},
+		{"%*d", ints(1), `missing argument for "%*d"`},
+		{"%*", ints(0), `missing argument for '*' in "%*"`},
+		{"%v", ints(2), "1 unused argument(s)"},
+		{"", ints(1), "1 unused argument(s)"},
+		{"trailing %", ints(1), `missing verb at end of "%"`},
+		{"%[3]d", ints(2), `bad argument index "[3]" for 2 argument(s)`},
+		{"%[0]d", ints(1), `bad argument index "[0]" for 1 argument(s)`},
+		{"%[x]d", ints(1), `bad argument index "[x]" for 1 argument(s)`},
+		{"%[1d", ints(1), `unterminated argument index in "[1d"`},
+
+		// Verbs and argument types.
+		{"%☃", ints(1), `unrecognized verb in "%☃"`},
+		{"%w", []interface{}{errors.New("e")}, `unrecognized verb in "%w"`},
+		{"%d", []interface{}{"s"}, `wrong type string for "%d"`},
+		{"%s", ints(1), `wrong type int for "%s"`},
+		{"%t", ints(1), `wrong type int for "%t"`},
+		{"%f", []interface{}{int64(1)}, `wrong type int64 for "%f"`},
+		{"%s", []interface{}{nil}, `wrong type <nil> for "%s"`},
+		{"%*d", []interface{}{"5", 1}, `non-integer argument string for '*' in "%*"`},
+		{"%s %v %T", []interface{}{"s", nil, nil}, ""},
+		{"%s %q %x", []interface{}{stringerArg{}, errors.New("e"), []byte("b")}, ""},
+		{"%d", []interface{}{formatterArg{}}, ""},
+		{"%c %U %q", []interface{}{'x', 'x', 'x'}, ""},
+		{"%x %X %b", []interface{}{1.5, "s", 3}, ""},
+		{"%d %p %x", []interface{}{&n, &n, &n}, ""},
+		{"%s", []interface{}{&n}, `wrong type *int for "%s"`},
+		{"%d", []interface{}{[]int{1, 2}}, ""},
+		{"%d", []interface{}{map[int]int{1: 2}}, ""},
+		{"%d", []interface{}{map[string]int{"a": 2}}, `wrong type map[string]int for "%d"`},
+		{"%d", []interface{}{pointArg{1, 2}}, ""},
+		{"%d", []interface{}{&pointArg{1, 2}}, ""},
+		{"%s", []interface{}{pointArg{1, 2}}, `wrong type zap.pointArg for "%s"`},
+		{"%s", []interface{}{[]stringerArg{{}}}, ""},
+		{"%s", []interface{}{nodeArg{Name: "a", Children: []nodeArg{{Name: "b"}}}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			err := checkFormat(tt.format, tt.args)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "Unexpected error.")
+			} else {
+				assert.EqualError(t, err, tt.wantErr, "Unexpected error.")
+			}
+
+			// Cross-check with fmt's own reporting.
+			out := fmt.Sprintf(tt.format, tt.args...)
+			assert.Equal(t, tt.wantErr != "", strings.Contains(out, "%!"),
+				"Expected fmt to report a problem if and only if checkFormat does, got %q.", out)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// TimeRange constructs a field that logs a time window as an object with
+// the keys start, end, and duration, so that jobs and batches log windows
+// in the same shape. The encoder controls how the times and the duration
+// are serialized.
+func TimeRange(key string, start, end time.Time) Field {
+	return Object(key, timeRange{start: start, end: end})
+}
+
+type timeRange struct {
+	start, end time.Time
+}
+
+func (r timeRange) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddTime("start", r.start)
+	enc.AddTime("end", r.end)
+	enc.AddDuration("duration", r.end.Sub(r.start))
+	return nil
+}
+
+// DurationRange constructs a field that logs a window given as offsets, for
+// example from the start of a job or a media stream, as an object with the
+// keys start, end, and duration.
+func DurationRange(key string, start, end time.Duration) Field {
+	return Object(key, durationRange{start: start, end: end})
+}
+
+type durationRange struct {
+	start, end time.Duration
+}
+
+func (r durationRange) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddDuration("start", r.start)
+	enc.AddDuration("end", r.end)
+	enc.AddDuration("duration", r.end-r.start)
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestTimeRange(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+
+	enc := zapcore.NewMapObjectEncoder()
+	TimeRange("window", start, end).AddTo(enc)
+	assert.Equal(t, map[string]interface{}{
+		"start":    start,
+		"end":      end,
+		"duration": 90 * time.Minute,
+	}, enc.Fields["window"], "Unexpected time range.")
+}
+
+func TestDurationRange(t *testing.T) {
+	enc := zapcore.NewMapObjectEncoder()
+	DurationRange("segment", 10*time.Second, 25*time.Second).AddTo(enc)
+	assert.Equal(t, map[string]interface{}{
+		"start":    10 * time.Second,
+		"end":      25 * time.Second,
+		"duration": 15 * time.Second,
+	}, enc.Fields["segment"], "Unexpected duration range.")
+}
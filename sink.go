@@ -29,7 +29,9 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -59,12 +61,14 @@ type sinkRegistry struct {
 	mu        sync.Mutex
 	factories map[string]func(*url.URL) (Sink, error)          // keyed by scheme
 	openFile  func(string, int, os.FileMode) (*os.File, error) // type matches os.OpenFile
+	files     map[*fileSink]struct{}                           // open file sinks, for ReopenFiles
 }
 
 func newSinkRegistry() *sinkRegistry {
 	sr := &sinkRegistry{
 		factories: make(map[string]func(*url.URL) (Sink, error)),
 		openFile:  os.OpenFile,
+		files:     make(map[*fileSink]struct{}),
 	}
 	// Infallible operation: the registry is empty, so we can't have a conflict.
 	_ = sr.RegisterSink(schemeFile, sr.newFileSinkFromURL)
@@ -96,7 +100,7 @@ func (sr *sinkRegistry) newSink(rawURL string) (Sink, error) {
 	// To avoid Windows-specific URL handling, we instead check IsAbs to open as a file.
 	// filepath.IsAbs is OS-specific, so IsAbs('c:/log.txt') is false outside of Windows.
 	if filepath.IsAbs(rawURL) {
-		return sr.newFileSinkFromPath(rawURL)
+		return sr.newFileSinkFromPath(rawURL, defaultFileSinkOptions())
 	}
 
 	u, err := url.Parse(rawURL)
@@ -116,6 +120,34 @@ func (sr *sinkRegistry) newSink(rawURL string) (Sink, error) {
 	return factory(u)
 }
 
+// checkURL reports problems that newSink would find with rawURL, without
+// opening it: URLs that can't be parsed, schemes without a registered
+// factory, and malformed file URLs.
+func (sr *sinkRegistry) checkURL(rawURL string) error {
+	if filepath.IsAbs(rawURL) {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("can't parse %q as a URL: %v", rawURL, err)
+	}
+	if u.Scheme == "" {
+		u.Scheme = schemeFile
+	}
+
+	sr.mu.Lock()
+	_, ok := sr.factories[u.Scheme]
+	sr.mu.Unlock()
+	if !ok {
+		return &errSinkNotFound{u.Scheme}
+	}
+	if u.Scheme == schemeFile {
+		_, err := fileSinkOptionsFromURL(u)
+		return err
+	}
+	return nil
+}
+
 // RegisterSink registers a user-supplied factory for all sinks with a
 // particular scheme.
 //
@@ -128,34 +160,133 @@ func RegisterSink(scheme string, factory func(*url.URL) (Sink, error)) error {
 }
 
 func (sr *sinkRegistry) newFileSinkFromURL(u *url.URL) (Sink, error) {
+	opts, err := fileSinkOptionsFromURL(u)
+	if err != nil {
+		return nil, err
+	}
+
+	sink, err := sr.newFileSinkFromPath(u.Path, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.bufferSize > 0 || opts.flushInterval > 0 {
+		sink = newBufferedSink(sink, opts)
+	}
+	return sink, nil
+}
+
+// fileSinkOptionsFromURL checks that a file URL is well-formed and parses
+// the options in its query parameters.
+func fileSinkOptionsFromURL(u *url.URL) (fileSinkOptions, error) {
 	if u.User != nil {
-		return nil, fmt.Errorf("user and password not allowed with file URLs: got %v", u)
+		return fileSinkOptions{}, fmt.Errorf("user and password not allowed with file URLs: got %v", u)
 	}
 	if u.Fragment != "" {
-		return nil, fmt.Errorf("fragments not allowed with file URLs: got %v", u)
-	}
-	if u.RawQuery != "" {
-		return nil, fmt.Errorf("query parameters not allowed with file URLs: got %v", u)
+		return fileSinkOptions{}, fmt.Errorf("fragments not allowed with file URLs: got %v", u)
 	}
 	// Error messages are better if we check hostname and port separately.
 	if u.Port() != "" {
-		return nil, fmt.Errorf("ports not allowed with file URLs: got %v", u)
+		return fileSinkOptions{}, fmt.Errorf("ports not allowed with file URLs: got %v", u)
 	}
 	if hn := u.Hostname(); hn != "" && hn != "localhost" {
-		return nil, fmt.Errorf("file URLs must leave host empty or use localhost: got %v", u)
+		return fileSinkOptions{}, fmt.Errorf("file URLs must leave host empty or use localhost: got %v", u)
 	}
 
-	return sr.newFileSinkFromPath(u.Path)
+	params := NewSinkParams(u)
+	opts := fileSinkOptions{
+		mode:          params.FileMode("create_mode", _defaultFileMode),
+		dirMode:       params.FileMode("dir_mode", _defaultDirMode),
+		mkdir:         params.Bool("mkdir", false),
+		append:        params.Bool("append", true),
+		sync:          params.Bool("sync", false),
+		bufferSize:    params.Size("buffer", 0),
+		flushInterval: params.Duration("flush", 0),
+	}
+	if err := params.Err(); err != nil {
+		return fileSinkOptions{}, fmt.Errorf("invalid file URL %v: %v", u, err)
+	}
+	return opts, nil
+}
+
+const (
+	_defaultFileMode = 0o666
+	_defaultDirMode  = 0o755
+)
+
+// fileSinkOptions holds the settings for file sinks that can be specified
+// as query parameters of file URLs. See Open for details.
+type fileSinkOptions struct {
+	mode          os.FileMode
+	dirMode       os.FileMode
+	mkdir         bool
+	append        bool
+	sync          bool
+	bufferSize    int
+	flushInterval time.Duration
+}
+
+func defaultFileSinkOptions() fileSinkOptions {
+	return fileSinkOptions{
+		mode:    _defaultFileMode,
+		dirMode: _defaultDirMode,
+		append:  true,
+	}
+}
+
+// flag returns the flags to pass to os.OpenFile.
+func (o fileSinkOptions) flag() int {
+	flag := os.O_WRONLY | os.O_CREATE
+	if o.append {
+		flag |= os.O_APPEND
+	} else {
+		flag |= os.O_TRUNC
+	}
+	if o.sync {
+		flag |= os.O_SYNC
+	}
+	return flag
 }
 
-func (sr *sinkRegistry) newFileSinkFromPath(path string) (Sink, error) {
+func (sr *sinkRegistry) newFileSinkFromPath(path string, opts fileSinkOptions) (Sink, error) {
 	switch path {
 	case "stdout":
 		return nopCloserSink{os.Stdout}, nil
 	case "stderr":
 		return nopCloserSink{os.Stderr}, nil
 	}
-	return sr.openFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o666)
+	if opts.mkdir {
+		if err := os.MkdirAll(filepath.Dir(path), opts.dirMode); err != nil {
+			return nil, err
+		}
+	}
+	sink, err := sr.newFileSink(path, opts.flag(), opts.mode)
+	if err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+// bufferedSink wraps a Sink with a BufferedWriteSyncer, flushing it when
+// closed.
+type bufferedSink struct {
+	*zapcore.BufferedWriteSyncer
+
+	closer io.Closer
+}
+
+func newBufferedSink(sink Sink, opts fileSinkOptions) Sink {
+	return &bufferedSink{
+		BufferedWriteSyncer: &zapcore.BufferedWriteSyncer{
+			WS:            sink,
+			Size:          opts.bufferSize,
+			FlushInterval: opts.flushInterval,
+		},
+		closer: sink,
+	}
+}
+
+func (s *bufferedSink) Close() error {
+	return multierr.Append(s.Stop(), s.closer.Close())
 }
 
 func normalizeScheme(s string) (string, error) {
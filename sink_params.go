@@ -0,0 +1,224 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// SinkParams provides typed access to the query parameters of a sink URL.
+// It's intended for the factories passed to RegisterSink, so that
+// Config.OutputPaths can carry per-sink settings like
+//
+//	file:///var/log/app.log?buffer=256kb&flush=5s&create_mode=0640
+//
+// Each accessor returns the parsed value of a parameter, or the given
+// default if the parameter is absent. Parsing errors are accumulated rather
+// than returned individually; once all parameters have been read, Err
+// reports them along with any parameters that were never read.
+//
+//	params := zap.NewSinkParams(u)
+//	size := params.Size("buffer", 0)
+//	interval := params.Duration("flush", time.Second)
+//	if err := params.Err(); err != nil {
+//		return nil, err
+//	}
+type SinkParams struct {
+	values url.Values
+	read   map[string]struct{}
+	err    error
+}
+
+// NewSinkParams parses the query parameters of the given URL.
+func NewSinkParams(u *url.URL) *SinkParams {
+	p := &SinkParams{read: make(map[string]struct{})}
+	p.values, p.err = url.ParseQuery(u.RawQuery)
+	return p
+}
+
+// lookup returns the value of the named parameter and marks it as read.
+func (p *SinkParams) lookup(name string) (string, bool) {
+	p.read[name] = struct{}{}
+	vs, ok := p.values[name]
+	if !ok || len(vs) == 0 {
+		return "", false
+	}
+	if len(vs) > 1 {
+		p.fail(name, vs[len(vs)-1], fmt.Errorf("parameter specified %d times", len(vs)))
+	}
+	return vs[len(vs)-1], true
+}
+
+func (p *SinkParams) fail(name, value string, err error) {
+	p.err = multierr.Append(p.err, fmt.Errorf("invalid value %q for query parameter %q: %v", value, name, err))
+}
+
+// Has reports whether the named parameter is present, marking it as read.
+func (p *SinkParams) Has(name string) bool {
+	_, ok := p.lookup(name)
+	return ok
+}
+
+// String returns the value of the named parameter.
+func (p *SinkParams) String(name, def string) string {
+	if v, ok := p.lookup(name); ok {
+		return v
+	}
+	return def
+}
+
+// Bool returns the value of the named parameter, parsed with
+// strconv.ParseBool.
+func (p *SinkParams) Bool(name string, def bool) bool {
+	v, ok := p.lookup(name)
+	if !ok {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		p.fail(name, v, err)
+		return def
+	}
+	return b
+}
+
+// Int returns the value of the named parameter as a base-10 integer.
+func (p *SinkParams) Int(name string, def int) int {
+	v, ok := p.lookup(name)
+	if !ok {
+		return def
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		p.fail(name, v, err)
+		return def
+	}
+	return i
+}
+
+// Duration returns the value of the named parameter, parsed with
+// time.ParseDuration.
+func (p *SinkParams) Duration(name string, def time.Duration) time.Duration {
+	v, ok := p.lookup(name)
+	if !ok {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		p.fail(name, v, err)
+		return def
+	}
+	return d
+}
+
+// Size returns the value of the named parameter as a number of bytes. Values
+// may use the suffixes b, kb, mb, and gb (case-insensitive), which are
+// multiples of 1024, as in "256kb".
+func (p *SinkParams) Size(name string, def int) int {
+	v, ok := p.lookup(name)
+	if !ok {
+		return def
+	}
+	size, err := parseSize(v)
+	if err != nil {
+		p.fail(name, v, err)
+		return def
+	}
+	return size
+}
+
+// FileMode returns the value of the named parameter as octal Unix
+// permission bits, as in "0640".
+func (p *SinkParams) FileMode(name string, def os.FileMode) os.FileMode {
+	v, ok := p.lookup(name)
+	if !ok {
+		return def
+	}
+	mode, err := strconv.ParseUint(v, 8, 32)
+	if err == nil && mode&^uint64(os.ModePerm) != 0 {
+		err = fmt.Errorf("must be at most %o", os.ModePerm)
+	}
+	if err != nil {
+		p.fail(name, v, err)
+		return def
+	}
+	return os.FileMode(mode)
+}
+
+// Err returns any errors encountered while parsing parameters, as well as an
+// error for each parameter present in the URL that wasn't read.
+func (p *SinkParams) Err() error {
+	var unknown []string
+	for name := range p.values {
+		if _, ok := p.read[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+
+	err := p.err
+	for _, name := range unknown {
+		err = multierr.Append(err, fmt.Errorf("unknown query parameter %q", name))
+	}
+	return err
+}
+
+var _sizeSuffixes = []struct {
+	suffix string
+	scale  int
+}{
+	// Longer suffixes first so that "kb" isn't mistaken for "b".
+	{"kb", 1 << 10},
+	{"mb", 1 << 20},
+	{"gb", 1 << 30},
+	{"b", 1},
+}
+
+func parseSize(s string) (int, error) {
+	lower := strings.ToLower(strings.TrimSpace(s))
+	scale := 1
+	for _, suf := range _sizeSuffixes {
+		if strings.HasSuffix(lower, suf.suffix) {
+			lower = strings.TrimSpace(strings.TrimSuffix(lower, suf.suffix))
+			scale = suf.scale
+			break
+		}
+	}
+	n, err := strconv.Atoi(lower)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("size must not be negative")
+	}
+	if n > int(^uint(0)>>1)/scale {
+		return 0, fmt.Errorf("size is too large")
+	}
+	return n * scale, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSinkParams(t *testing.T) {
+	u, err := url.Parse("mem://host?s=str&b=true&i=42&d=5s&size=256kb&mode=0640&flag")
+	require.NoError(t, err, "Unexpected error parsing URL.")
+
+	p := NewSinkParams(u)
+	assert.Equal(t, "str", p.String("s", "def"), "Unexpected string.")
+	assert.True(t, p.Bool("b", false), "Unexpected bool.")
+	assert.Equal(t, 42, p.Int("i", 0), "Unexpected int.")
+	assert.Equal(t, 5*time.Second, p.Duration("d", 0), "Unexpected duration.")
+	assert.Equal(t, 256*1024, p.Size("size", 0), "Unexpected size.")
+	assert.Equal(t, os.FileMode(0o640), p.FileMode("mode", 0), "Unexpected file mode.")
+	assert.True(t, p.Has("flag"), "Expected flag to be present.")
+	assert.NoError(t, p.Err(), "Unexpected error after reading all parameters.")
+
+	assert.Equal(t, "def", p.String("missing", "def"), "Expected default for missing parameter.")
+	assert.Equal(t, 7, p.Int("missing", 7), "Expected default for missing parameter.")
+	assert.False(t, p.Has("missing"), "Expected missing parameter to be absent.")
+}
+
+func TestSinkParamsErrors(t *testing.T) {
+	u, err := url.Parse("mem://host?b=maybe&i=x&d=5&size=lots&mode=999&dup=1&dup=2&extra=1&another=2")
+	require.NoError(t, err, "Unexpected error parsing URL.")
+
+	p := NewSinkParams(u)
+	assert.False(t, p.Bool("b", false), "Expected default on error.")
+	assert.Equal(t, 1, p.Int("i", 1), "Expected default on error.")
+	assert.Equal(t, time.Minute, p.Duration("d", time.Minute), "Expected default on error.")
+	assert.Equal(t, 10, p.Size("size", 10), "Expected default on error.")
+	assert.Equal(t, os.FileMode(0o600), p.FileMode("mode", 0o600), "Expected default on error.")
+	assert.Equal(t, "2", p.String("dup", ""), "Expected last value of repeated parameter.")
+
+	err = p.Err()
+	for _, want := range []string{
+		`invalid value "maybe" for query parameter "b"`,
+		`invalid value "x" for query parameter "i"`,
+		`invalid value "5" for query parameter "d"`,
+		`invalid value "lots" for query parameter "size"`,
+		`invalid value "999" for query parameter "mode"`,
+		`invalid value "2" for query parameter "dup": parameter specified 2 times`,
+		`unknown query parameter "another"; unknown query parameter "extra"`,
+	} {
+		assert.ErrorContains(t, err, want, "Missing expected error.")
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		give    string
+		want    int
+		wantErr string
+	}{
+		{give: "0", want: 0},
+		{give: "512", want: 512},
+		{give: "512b", want: 512},
+		{give: "256kb", want: 256 << 10},
+		{give: "256KB", want: 256 << 10},
+		{give: "4 mb", want: 4 << 20},
+		{give: "1gb", want: 1 << 30},
+		{give: "-1kb", wantErr: "must not be negative"},
+		{give: "kb", wantErr: "invalid syntax"},
+		{give: "1tb", wantErr: "invalid syntax"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := parseSize(tt.give)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr, "Expected error.")
+				return
+			}
+			require.NoError(t, err, "Unexpected error.")
+			assert.Equal(t, tt.want, got, "Unexpected size.")
+		})
+	}
+}
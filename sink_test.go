@@ -24,6 +24,9 @@ import (
 	"bytes"
 	"io"
 	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -105,3 +108,84 @@ func TestRegisterSinkErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestFileSinkQueryParams(t *testing.T) {
+	t.Run("create mode", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "log.txt")
+		sink, err := newSinkRegistry().newSink("file://" + filepath.ToSlash(path) + "?create_mode=0600")
+		require.NoError(t, err, "Unexpected error opening sink.")
+		defer func() { assert.NoError(t, sink.Close()) }()
+
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(path)
+			require.NoError(t, err, "Unexpected error statting log file.")
+			assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "Unexpected file mode.")
+		}
+	})
+
+	t.Run("buffered", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "log.txt")
+		sink, err := newSinkRegistry().newSink("file://" + filepath.ToSlash(path) + "?buffer=1kb&flush=1h")
+		require.NoError(t, err, "Unexpected error opening sink.")
+
+		_, err = sink.Write([]byte("foo"))
+		require.NoError(t, err, "Unexpected error writing.")
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err, "Unexpected error reading log file.")
+		assert.Empty(t, contents, "Expected write to be buffered.")
+
+		require.NoError(t, sink.Close(), "Unexpected error closing sink.")
+		contents, err = os.ReadFile(path)
+		require.NoError(t, err, "Unexpected error reading log file.")
+		assert.Equal(t, "foo", string(contents), "Expected Close to flush the buffer.")
+	})
+
+	t.Run("mkdir", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "a", "b", "log.txt")
+		_, err := newSinkRegistry().newSink("file://" + filepath.ToSlash(path))
+		require.Error(t, err, "Expected an error opening a file in a missing directory.")
+
+		sink, err := newSinkRegistry().newSink("file://" + filepath.ToSlash(path) + "?mkdir=true")
+		require.NoError(t, err, "Unexpected error opening sink.")
+		assert.NoError(t, sink.Close(), "Unexpected error closing sink.")
+		assert.FileExists(t, path, "Expected log file to be created.")
+	})
+
+	t.Run("append", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "log.txt")
+		require.NoError(t, os.WriteFile(path, []byte("foo\n"), 0o600), "Unexpected error writing file.")
+		url := "file://" + filepath.ToSlash(path)
+
+		for _, suffix := range []string{"", "?append=false"} {
+			sink, err := newSinkRegistry().newSink(url + suffix)
+			require.NoError(t, err, "Unexpected error opening sink.")
+			_, err = sink.Write([]byte("bar\n"))
+			require.NoError(t, err, "Unexpected error writing.")
+			require.NoError(t, sink.Close(), "Unexpected error closing sink.")
+		}
+
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err, "Unexpected error reading log file.")
+		assert.Equal(t, "bar\n", string(contents), "Expected append=false to truncate the file.")
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		sr := newSinkRegistry()
+		var flag int
+		sr.openFile = func(path string, f int, mode os.FileMode) (*os.File, error) {
+			flag = f
+			return os.OpenFile(path, f, mode)
+		}
+		sink, err := sr.newSink("file://" + filepath.ToSlash(filepath.Join(t.TempDir(), "log.txt")) + "?sync=true")
+		require.NoError(t, err, "Unexpected error opening sink.")
+		defer func() { assert.NoError(t, sink.Close()) }()
+		assert.NotZero(t, flag&os.O_SYNC, "Expected file to be opened with O_SYNC.")
+		assert.NotZero(t, flag&os.O_APPEND, "Expected file to be opened with O_APPEND.")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := newSinkRegistry().newSink("file:///tmp/log.txt?buffer=lots&create_mode=rw")
+		assert.ErrorContains(t, err, `invalid value "lots" for query parameter "buffer"`)
+		assert.ErrorContains(t, err, `invalid value "rw" for query parameter "create_mode"`)
+	})
+}
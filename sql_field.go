@@ -0,0 +1,263 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// _defaultSQLMaxLength is the default limit on the length of logged queries.
+const _defaultSQLMaxLength = 2048
+
+// SQLOption configures the fields built by SQL.
+type SQLOption interface {
+	apply(*sqlOptions)
+}
+
+type sqlOptions struct {
+	values              bool
+	doubleQuotedStrings bool
+	maxLength           int
+}
+
+type sqlOptionFunc func(*sqlOptions)
+
+func (f sqlOptionFunc) apply(o *sqlOptions) {
+	f(o)
+}
+
+// SQLValues logs parameter values and literals in the query as is, instead
+// of replacing them with placeholders. Only use it when the values are known
+// not to be sensitive.
+func SQLValues() SQLOption {
+	return sqlOptionFunc(func(o *sqlOptions) {
+		o.values = true
+	})
+}
+
+// SQLDoubleQuotedStrings treats double-quoted text in the query as string
+// literals, as MySQL does unless ANSI_QUOTES is set, rather than as quoted
+// identifiers.
+func SQLDoubleQuotedStrings() SQLOption {
+	return sqlOptionFunc(func(o *sqlOptions) {
+		o.doubleQuotedStrings = true
+	})
+}
+
+// SQLMaxLength truncates queries longer than n bytes; the default is 2048.
+// Values of zero or less disable truncation.
+func SQLMaxLength(n int) SQLOption {
+	return sqlOptionFunc(func(o *sqlOptions) {
+		o.maxLength = n
+	})
+}
+
+// SQL constructs a field that logs a database query and its arguments, so
+// that database layers log queries safely and in the same shape. The object
+// has the keys query, truncated (only if the query was truncated), and args.
+//
+// Runs of whitespace in the query are collapsed to a single space. By
+// default, literals in the query are replaced with "?", and each argument is
+// replaced by a placeholder naming its type, such as "<string>". Use
+// SQLValues to log them as is.
+//
+// Single-quoted strings, with either doubled or backslash-escaped quotes,
+// PostgreSQL dollar-quoted strings, and decimal, hex and binary numbers are
+// scrubbed. Double-quoted text is an identifier, as in standard SQL, and is
+// kept; use SQLDoubleQuotedStrings for MySQL queries that quote strings with
+// double quotes.
+func SQL(key, query string, args []interface{}, opts ...SQLOption) Field {
+	o := sqlOptions{maxLength: _defaultSQLMaxLength}
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+
+	q := sqlQuery{args: args, values: o.values}
+	q.query = normalizeSQL(query, !o.values, o.doubleQuotedStrings)
+	if o.maxLength > 0 && len(q.query) > o.maxLength {
+		n := o.maxLength
+		for n > 0 && !utf8.RuneStart(q.query[n]) {
+			n--
+		}
+		q.query = q.query[:n]
+		q.truncated = true
+	}
+	return Object(key, q)
+}
+
+type sqlQuery struct {
+	query     string
+	truncated bool
+	args      []interface{}
+	values    bool
+}
+
+func (q sqlQuery) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("query", q.query)
+	if q.truncated {
+		enc.AddBool("truncated", true)
+	}
+	if len(q.args) == 0 {
+		return nil
+	}
+	return enc.AddArray("args", zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+		for _, arg := range q.args {
+			if q.values {
+				if err := arr.AppendReflected(arg); err != nil {
+					return err
+				}
+				continue
+			}
+			if arg == nil {
+				arr.AppendString("<nil>")
+			} else {
+				arr.AppendString(fmt.Sprintf("<%T>", arg))
+			}
+		}
+		return nil
+	}))
+}
+
+// normalizeSQL collapses whitespace outside quoted strings and identifiers
+// and, if scrub is set, replaces string and numeric literals with "?".
+// Numbered placeholders like $1 and digits within identifiers are kept.
+func normalizeSQL(query string, scrub, doubleQuotedStrings bool) string {
+	var b strings.Builder
+	b.Grow(len(query))
+	space := false
+	for i := 0; i < len(query); {
+		c := query[i]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' {
+			space = true
+			i++
+			continue
+		}
+
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			literal := c == '\'' || (c == '"' && doubleQuotedStrings)
+			end := quoteEnd(query, i, literal)
+			if literal && scrub {
+				b.WriteByte('?')
+			} else {
+				b.WriteString(query[i:end])
+			}
+			i = end
+		case c == '$' && !continuesIdent(b.String()) && dollarTagEnd(query, i) > 0:
+			tagEnd := dollarTagEnd(query, i)
+			end := len(query)
+			if j := strings.Index(query[tagEnd:], query[i:tagEnd]); j >= 0 {
+				end = tagEnd + j + tagEnd - i
+			}
+			if scrub {
+				b.WriteByte('?')
+			} else {
+				b.WriteString(query[i:end])
+			}
+			i = end
+		case isSQLDigit(c) && !continuesIdent(b.String()):
+			// Consume the whole token so that hex and binary literals like
+			// 0xFF and 0b101 are scrubbed along with decimal ones.
+			end := i + 1
+			for end < len(query) && (isSQLIdentByte(query[end]) || query[end] == '.' ||
+				((query[end] == '+' || query[end] == '-') && (query[end-1] == 'e' || query[end-1] == 'E'))) {
+				end++
+			}
+			if scrub {
+				b.WriteByte('?')
+			} else {
+				b.WriteString(query[i:end])
+			}
+			i = end
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// quoteEnd returns the index just past the quoted string or identifier
+// starting at query[start]. A doubled quote character is an escaped quote,
+// and so, in string literals, is a quote preceded by a backslash.
+// Unterminated quotes run to the end of the query.
+func quoteEnd(query string, start int, literal bool) int {
+	q := query[start]
+	for i := start + 1; i < len(query); i++ {
+		if literal && query[i] == '\\' {
+			i++
+			continue
+		}
+		if query[i] != q {
+			continue
+		}
+		if i+1 < len(query) && query[i+1] == q {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(query)
+}
+
+// dollarTagEnd returns the index just past the opening delimiter of a
+// PostgreSQL dollar-quoted string, like $$ or $tag$, starting at
+// query[start], or zero if there isn't one there.
+func dollarTagEnd(query string, start int) int {
+	i := start + 1
+	if i < len(query) && !isSQLDigit(query[i]) {
+		for i < len(query) && isSQLIdentByte(query[i]) {
+			i++
+		}
+	}
+	if i < len(query) && query[i] == '$' {
+		return i + 1
+	}
+	return 0
+}
+
+func isSQLDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isSQLIdentByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
+		isSQLDigit(c) || c >= utf8.RuneSelf
+}
+
+// continuesIdent reports whether a digit following s is part of an
+// identifier or placeholder, like t1 or $1, rather than a numeric literal.
+func continuesIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	c := s[len(s)-1]
+	return c == '$' || c == ':' || c == '@' || isSQLIdentByte(c)
+}
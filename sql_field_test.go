@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSQL(t *testing.T) {
+	tests := []struct {
+		desc  string
+		query string
+		args  []interface{}
+		opts  []SQLOption
+		want  map[string]interface{}
+	}{
+		{
+			desc:  "whitespace",
+			query: "\n\tSELECT id,  name\n\tFROM users\n",
+			want:  map[string]interface{}{"query": "SELECT id, name FROM users"},
+		},
+		{
+			desc:  "scrubbed literals",
+			query: "SELECT * FROM t1 WHERE name = 'O''Brien' AND age > 42 AND score < 1.5e-3",
+			want:  map[string]interface{}{"query": "SELECT * FROM t1 WHERE name = ? AND age > ? AND score < ?"},
+		},
+		{
+			desc:  "backslash-escaped quotes",
+			query: `SELECT * FROM t WHERE a = 'a\'secret' AND b = 'c:\\' AND c = 1`,
+			want:  map[string]interface{}{"query": "SELECT * FROM t WHERE a = ? AND b = ? AND c = ?"},
+		},
+		{
+			desc:  "double-quoted strings",
+			query: `SELECT * FROM t WHERE a = "secret" AND b = "x\"secret"`,
+			opts:  []SQLOption{SQLDoubleQuotedStrings()},
+			want:  map[string]interface{}{"query": "SELECT * FROM t WHERE a = ? AND b = ?"},
+		},
+		{
+			desc:  "dollar-quoted strings",
+			query: "SELECT $$secret$$, $tag$it's $$ secret$tag$, $1 FROM t",
+			want:  map[string]interface{}{"query": "SELECT ?, ?, $1 FROM t"},
+		},
+		{
+			desc:  "unterminated dollar-quoted string",
+			query: "SELECT $q$secret",
+			want:  map[string]interface{}{"query": "SELECT ?"},
+		},
+		{
+			desc:  "dollar signs in identifiers",
+			query: "SELECT a$b$ FROM t",
+			want:  map[string]interface{}{"query": "SELECT a$b$ FROM t"},
+		},
+		{
+			desc:  "hex and binary literals",
+			query: "SELECT * FROM t WHERE a = 0xDEADBEEF AND b = 0b101 AND c = 1e+5",
+			want:  map[string]interface{}{"query": "SELECT * FROM t WHERE a = ? AND b = ? AND c = ?"},
+		},
+		{
+			desc:  "dollar-quoted values",
+			query: "SELECT  $$a  b$$,  0xFF",
+			opts:  []SQLOption{SQLValues()},
+			want:  map[string]interface{}{"query": "SELECT $$a  b$$, 0xFF"},
+		},
+		{
+			desc:  "placeholders and quoted identifiers",
+			query: `SELECT "col  2" FROM users WHERE id = $1 AND org = :org1 AND x = @p2`,
+			args:  []interface{}{42, "acme", nil},
+			want: map[string]interface{}{
+				"query": `SELECT "col  2" FROM users WHERE id = $1 AND org = :org1 AND x = @p2`,
+				"args":  []interface{}{"<int>", "<string>", "<nil>"},
+			},
+		},
+		{
+			desc:  "values",
+			query: "SELECT * FROM users WHERE id = ? AND name = 'bob'",
+			args:  []interface{}{42, "bob"},
+			opts:  []SQLOption{SQLValues()},
+			want: map[string]interface{}{
+				"query": "SELECT * FROM users WHERE id = ? AND name = 'bob'",
+				"args":  []interface{}{42, "bob"},
+			},
+		},
+		{
+			desc:  "truncated",
+			query: "SELECT héllo FROM t",
+			opts:  []SQLOption{SQLMaxLength(9)},
+			want:  map[string]interface{}{"query": "SELECT h", "truncated": true},
+		},
+		{
+			desc:  "truncation disabled",
+			query: "SELECT a FROM t",
+			opts:  []SQLOption{SQLMaxLength(0)},
+			want:  map[string]interface{}{"query": "SELECT a FROM t"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := zapcore.NewMapObjectEncoder()
+			SQL("sql", tt.query, tt.args, tt.opts...).AddTo(enc)
+			assert.Equal(t, tt.want, enc.Fields["sql"], "Unexpected SQL object.")
+		})
+	}
+}
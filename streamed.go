@@ -0,0 +1,258 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// _maxStreamedDepth limits how deeply streamed values may nest, since each
+// level of nesting takes stack space. It's well below encoding/json's limit,
+// so that entries with streamed values can still be decoded.
+const _maxStreamedDepth = 1000
+
+var (
+	errStreamedDepth  = fmt.Errorf("streamed value nests more than %d levels deep", _maxStreamedDepth)
+	errStreamedReused = errors.New("streamed value was already encoded")
+	errStreamedEmpty  = errors.New("no JSON value to stream")
+	errStreamedTrail  = errors.New("unexpected data after JSON value")
+)
+
+// Streamed constructs a field that copies a JSON value, usually a large
+// object or array, from r into the log entry as it's encoded. The value is
+// decoded and re-encoded one token at a time, so it's never held in memory
+// as a whole, and it's validated along the way.
+//
+// At most limit bytes are read from r; limit <= 0 reads until io.EOF. If
+// the value is longer, nests objects and arrays more than 1000 levels deep,
+// or is invalid, the encoded value is cut short but kept well-formed, and a
+// "<key>Error" field describes the problem.
+//
+// Since r can only be read once, the field can only be encoded once: it
+// should be passed to a single log call, not to With, and Cores that write
+// it after the first one (like the second child of a Tee) write an error
+// instead.
+func Streamed(key string, r io.Reader, limit int64) Field {
+	return streamedField(key, &streamed{key: key, r: r, limit: limit})
+}
+
+// StreamedLines is like Streamed, but reads a sequence of JSON values, like
+// JSON Lines, from r and encodes them as an array.
+func StreamedLines(key string, r io.Reader, limit int64) Field {
+	return streamedField(key, &streamed{key: key, r: r, limit: limit, lines: true})
+}
+
+func streamedField(key string, s *streamed) Field {
+	// The value is added as an inline marshaler, since its type isn't known
+	// until it's read. The key is set too, so that errors are reported under
+	// "<key>Error".
+	return Field{Key: key, Type: zapcore.InlineMarshalerType, Interface: s}
+}
+
+type streamed struct {
+	key   string
+	r     io.Reader
+	limit int64
+	lines bool
+
+	used atomic.Bool
+}
+
+func (s *streamed) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if s.used.Swap(true) {
+		return errStreamedReused
+	}
+
+	r := s.r
+	var lr *io.LimitedReader
+	if s.limit > 0 {
+		lr = &io.LimitedReader{R: s.r, N: s.limit}
+		r = lr
+	}
+	js := jsonStream{dec: json.NewDecoder(r)}
+	js.dec.UseNumber()
+
+	var err error
+	if s.lines {
+		err = enc.AddArray(s.key, zapcore.ArrayMarshalerFunc(js.appendLines))
+	} else {
+		err = js.addDocument(enc, s.key)
+	}
+	if err != nil && lr != nil && lr.N <= 0 {
+		return fmt.Errorf("streamed value exceeds limit of %d bytes", s.limit)
+	}
+	return err
+}
+
+// jsonStream re-encodes the tokens of a JSON stream with an ObjectEncoder
+// or ArrayEncoder as they're decoded.
+type jsonStream struct {
+	dec   *json.Decoder
+	depth int // objects and arrays enclosing the current value
+}
+
+// addDocument adds the single JSON value in the stream under key.
+func (js jsonStream) addDocument(enc zapcore.ObjectEncoder, key string) error {
+	tok, err := js.dec.Token()
+	if err == io.EOF {
+		return errStreamedEmpty
+	}
+	if err != nil {
+		return err
+	}
+	if err := js.addValue(enc, key, tok); err != nil {
+		return err
+	}
+	if js.dec.More() {
+		return errStreamedTrail
+	}
+	return nil
+}
+
+// appendLines appends every JSON value in the stream to enc.
+func (js jsonStream) appendLines(enc zapcore.ArrayEncoder) error {
+	for {
+		tok, err := js.dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := js.appendValue(enc, tok); err != nil {
+			return err
+		}
+	}
+}
+
+// addValue adds the value starting with tok under key, consuming the rest of
+// it from the stream.
+func (js jsonStream) addValue(enc zapcore.ObjectEncoder, key string, tok json.Token) error {
+	switch t := tok.(type) {
+	case json.Delim:
+		if js.depth >= _maxStreamedDepth {
+			return errStreamedDepth
+		}
+		js.depth++
+		if t == '{' {
+			return enc.AddObject(key, zapcore.ObjectMarshalerFunc(js.addMembers))
+		}
+		return enc.AddArray(key, zapcore.ArrayMarshalerFunc(js.appendElements))
+	case string:
+		enc.AddString(key, t)
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			enc.AddInt64(key, i)
+		} else if f, err := t.Float64(); err == nil {
+			enc.AddFloat64(key, f)
+		} else {
+			enc.AddString(key, t.String())
+		}
+	case bool:
+		enc.AddBool(key, t)
+	case nil:
+		return enc.AddReflected(key, nil)
+	}
+	return nil
+}
+
+// appendValue is like addValue, but appends the value to an array.
+func (js jsonStream) appendValue(enc zapcore.ArrayEncoder, tok json.Token) error {
+	switch t := tok.(type) {
+	case json.Delim:
+		if js.depth >= _maxStreamedDepth {
+			return errStreamedDepth
+		}
+		js.depth++
+		if t == '{' {
+			return enc.AppendObject(zapcore.ObjectMarshalerFunc(js.addMembers))
+		}
+		return enc.AppendArray(zapcore.ArrayMarshalerFunc(js.appendElements))
+	case string:
+		enc.AppendString(t)
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			enc.AppendInt64(i)
+		} else if f, err := t.Float64(); err == nil {
+			enc.AppendFloat64(f)
+		} else {
+			enc.AppendString(t.String())
+		}
+	case bool:
+		enc.AppendBool(t)
+	case nil:
+		return enc.AppendReflected(nil)
+	}
+	return nil
+}
+
+// addMembers adds the members of an object whose opening brace has been
+// consumed, up to and including its closing brace.
+func (js jsonStream) addMembers(enc zapcore.ObjectEncoder) error {
+	for {
+		tok, err := js.dec.Token()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil // json.Decoder only returns '}' here
+		}
+		if tok, err = js.dec.Token(); err != nil {
+			return unexpectedEOF(err)
+		}
+		if err := js.addValue(enc, key, tok); err != nil {
+			return err
+		}
+	}
+}
+
+// appendElements appends the elements of an array whose opening bracket has
+// been consumed, up to and including its closing bracket.
+func (js jsonStream) appendElements(enc zapcore.ArrayEncoder) error {
+	for {
+		tok, err := js.dec.Token()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		if tok == json.Delim(']') {
+			return nil
+		}
+		if err := js.appendValue(enc, tok); err != nil {
+			return err
+		}
+	}
+}
+
+// unexpectedEOF converts io.EOF in the middle of a value to
+// io.ErrUnexpectedEOF.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
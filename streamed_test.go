@@ -0,0 +1,208 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func encodeStreamed(t testing.TB, fields ...Field) string {
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{})
+	buf, err := enc.EncodeEntry(zapcore.Entry{}, fields)
+	require.NoError(t, err, "Unexpected error encoding entry.")
+	defer buf.Free()
+
+	out := strings.TrimSpace(buf.String())
+	assert.True(t, json.Valid([]byte(out)), "Expected valid JSON, got %s.", out)
+	return out
+}
+
+func TestStreamed(t *testing.T) {
+	tests := []struct {
+		desc  string
+		give  string
+		limit int64
+		want  string
+	}{
+		{
+			desc: "object",
+			give: `{"a": 1, "b": "two", "c": [true, null, 1.5], "d": {"e": {}}}`,
+			want: `{"k":{"a":1,"b":"two","c":[true,null,1.5],"d":{"e":{}}}}`,
+		},
+		{
+			desc: "array of objects",
+			give: ` [{"a": 1}, {"a": 2}, []] `,
+			want: `{"k":[{"a":1},{"a":2},[]]}`,
+		},
+		{
+			desc: "scalar",
+			give: `"hello"`,
+			want: `{"k":"hello"}`,
+		},
+		{
+			desc: "big number",
+			give: `[18446744073709551615]`,
+			want: `{"k":[18446744073709552000]}`,
+		},
+		{
+			desc:  "truncated at limit",
+			give:  `{"a": [1, 2, 3], "b": {"c": "long value"}}`,
+			limit: 30,
+			want:  `{"k":{"a":[1,2,3],"b":{}},"kError":"streamed value exceeds limit of 30 bytes"}`,
+		},
+		{
+			desc:  "within limit",
+			give:  `[1, 2]`,
+			limit: 6,
+			want:  `{"k":[1,2]}`,
+		},
+		{
+			desc: "invalid",
+			give: `{"a": [1, }`,
+			want: `{"k":{"a":[1]},"kError":"invalid character ',' looking for beginning of value"}`,
+		},
+		{
+			desc: "unexpected EOF",
+			give: `{"a": [1`,
+			want: `{"k":{"a":[1]},"kError":"unexpected EOF"}`,
+		},
+		{
+			desc: "empty",
+			give: ``,
+			want: `{"kError":"no JSON value to stream"}`,
+		},
+		{
+			desc: "trailing data",
+			give: `{} {}`,
+			want: `{"k":{},"kError":"unexpected data after JSON value"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := encodeStreamed(t, Streamed("k", strings.NewReader(tt.give), tt.limit))
+			assert.JSONEq(t, tt.want, got, "Unexpected output.")
+		})
+	}
+}
+
+func TestStreamedLines(t *testing.T) {
+	tests := []struct {
+		desc string
+		give string
+		want string
+	}{
+		{
+			desc: "lines",
+			give: "{\"a\": 1}\n{\"a\": 2}\n\"three\"\n",
+			want: `{"k":[{"a":1},{"a":2},"three"]}`,
+		},
+		{
+			desc: "empty",
+			give: "",
+			want: `{"k":[]}`,
+		},
+		{
+			desc: "invalid line",
+			give: "{\"a\": 1}\nnope\n",
+			want: `{"k":[{"a":1}],"kError":"invalid character 'o' in literal null (expecting 'u')"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := encodeStreamed(t, StreamedLines("k", strings.NewReader(tt.give), 0))
+			assert.JSONEq(t, tt.want, got, "Unexpected output.")
+		})
+	}
+}
+
+func TestStreamedOnce(t *testing.T) {
+	f := Streamed("k", strings.NewReader(`[1]`), 0)
+	assert.JSONEq(t, `{"k":[1]}`, encodeStreamed(t, f), "Unexpected output on first use.")
+	assert.JSONEq(t, `{"kError":"streamed value was already encoded"}`, encodeStreamed(t, f), "Unexpected output on reuse.")
+}
+
+// countingReader produces a JSON array of n small objects without holding
+// it in memory.
+type countingReader struct {
+	n, i int
+	buf  []byte
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	for len(r.buf) < len(p) && r.i <= r.n+1 {
+		switch {
+		case r.i == 0:
+			r.buf = append(r.buf, '[')
+		case r.i == r.n+1:
+			r.buf = append(r.buf, ']')
+		case r.i == 1:
+			r.buf = append(r.buf, `{"x":1}`...)
+		default:
+			r.buf = append(r.buf, `,{"x":1}`...)
+		}
+		r.i++
+	}
+	if len(r.buf) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func TestStreamedLarge(t *testing.T) {
+	const n = 100000
+	got := encodeStreamed(t, Streamed("k", &countingReader{n: n}, 0))
+
+	var decoded struct {
+		K []struct{ X int }
+	}
+	require.NoError(t, json.Unmarshal([]byte(got), &decoded), "Unexpected error decoding output.")
+	assert.Len(t, decoded.K, n, "Unexpected number of elements.")
+}
+
+func TestStreamedDepth(t *testing.T) {
+	deep := strings.Repeat(`[{"a":`, 1<<20)
+	got := encodeStreamed(t, Streamed("k", strings.NewReader(deep), 0))
+	assert.Contains(t, got, `"kError":"streamed value nests more than 1000 levels deep"`, "Expected deeply nested input to be rejected.")
+
+	ok := strings.Repeat("[", _maxStreamedDepth) + strings.Repeat("]", _maxStreamedDepth)
+	got = encodeStreamed(t, StreamedLines("k", strings.NewReader(ok), 0))
+	assert.NotContains(t, got, "kError", "Expected input at the depth limit to be encoded.")
+}
+
+func TestStreamedObserved(t *testing.T) {
+	enc := zapcore.NewMapObjectEncoder()
+	Streamed("k", strings.NewReader(`{"a": [1, "b"]}`), 0).AddTo(enc)
+	assert.Equal(t, map[string]interface{}{
+		"k": map[string]interface{}{"a": []interface{}{int64(1), "b"}},
+	}, enc.Fields, "Unexpected fields.")
+}
@@ -21,8 +21,11 @@
 package zap
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
+	"go.uber.org/zap/internal/arena"
 	"go.uber.org/zap/zapcore"
 
 	"go.uber.org/multierr"
@@ -32,6 +35,15 @@ const (
 	_oddNumberErrMsg    = "Ignored key without a value."
 	_nonStringKeyErrMsg = "Ignored key-value pairs with non-string keys."
 	_multipleErrMsg     = "Multiple errors without a key."
+	_badFormatErrMsg    = "Mismatched format string and arguments."
+)
+
+// Errors reported to the internal error handler when the SugaredLogger is
+// misused; see SetInternalErrorHandler.
+var (
+	errOddNumber      = errors.New("sugared logger: ignored key without a value")
+	errNonStringKey   = errors.New("sugared logger: ignored key-value pairs with non-string keys")
+	errMultipleErrors = errors.New("sugared logger: multiple errors without a key")
 )
 
 // A SugaredLogger wraps the base Logger functionality in a slower, but less
@@ -52,6 +64,10 @@ const (
 //	Infow(...any)          Structured logging (read as "info with")
 //	Infof(string, ...any)  Printf-style logging
 //	Infoln(...any)         Println-style logging
+//
+// In development mode (see Development), the printf-style methods log a
+// DPanic if their templates don't consume exactly the arguments they're
+// given, rather than let the mistakes surface as %!v(MISSING) and the like.
 type SugaredLogger struct {
 	base *Logger
 }
@@ -71,6 +87,12 @@ func (s *SugaredLogger) Named(name string) *SugaredLogger {
 	return &SugaredLogger{base: s.base.Named(name)}
 }
 
+// Ctx returns a child logger that passes ctx along with every entry it logs.
+// See Logger.Ctx for details.
+func (s *SugaredLogger) Ctx(ctx context.Context) *SugaredLogger {
+	return &SugaredLogger{base: s.base.Ctx(ctx)}
+}
+
 // WithOptions clones the current SugaredLogger, applies the supplied Options,
 // and returns the result. It's safe to use concurrently.
 func (s *SugaredLogger) WithOptions(opts ...Option) *SugaredLogger {
@@ -137,12 +159,24 @@ func (s *SugaredLogger) Level() zapcore.Level {
 	return zapcore.LevelOf(s.base.core)
 }
 
+// DebugEnabled returns a cheap, cached view of whether this logger logs at
+// DebugLevel. See EnabledFlag for details.
+func (s *SugaredLogger) DebugEnabled() *EnabledFlag {
+	return s.base.DebugEnabled()
+}
+
 // Log logs the provided arguments at provided level.
 // Spaces are added between arguments when neither is a string.
 func (s *SugaredLogger) Log(lvl zapcore.Level, args ...interface{}) {
 	s.log(lvl, "", args, nil)
 }
 
+// Trace logs the provided arguments at [TraceLevel].
+// Spaces are added between arguments when neither is a string.
+func (s *SugaredLogger) Trace(args ...interface{}) {
+	s.log(TraceLevel, "", args, nil)
+}
+
 // Debug logs the provided arguments at [DebugLevel].
 // Spaces are added between arguments when neither is a string.
 func (s *SugaredLogger) Debug(args ...interface{}) {
@@ -192,6 +226,12 @@ func (s *SugaredLogger) Logf(lvl zapcore.Level, template string, args ...interfa
 	s.log(lvl, template, args, nil)
 }
 
+// Tracef formats the message according to the format specifier
+// and logs it at [TraceLevel].
+func (s *SugaredLogger) Tracef(template string, args ...interface{}) {
+	s.log(TraceLevel, template, args, nil)
+}
+
 // Debugf formats the message according to the format specifier
 // and logs it at [DebugLevel].
 func (s *SugaredLogger) Debugf(template string, args ...interface{}) {
@@ -241,6 +281,12 @@ func (s *SugaredLogger) Logw(lvl zapcore.Level, msg string, keysAndValues ...int
 	s.log(lvl, msg, nil, keysAndValues)
 }
 
+// Tracew logs a message with some additional context. The variadic key-value
+// pairs are treated as they are in With.
+func (s *SugaredLogger) Tracew(msg string, keysAndValues ...interface{}) {
+	s.log(TraceLevel, msg, nil, keysAndValues)
+}
+
 // Debugw logs a message with some additional context. The variadic key-value
 // pairs are treated as they are in With.
 //
@@ -294,6 +340,12 @@ func (s *SugaredLogger) Logln(lvl zapcore.Level, args ...interface{}) {
 	s.logln(lvl, args, nil)
 }
 
+// Traceln logs a message at [TraceLevel].
+// Spaces are always added between arguments.
+func (s *SugaredLogger) Traceln(args ...interface{}) {
+	s.logln(TraceLevel, args, nil)
+}
+
 // Debugln logs a message at [DebugLevel].
 // Spaces are always added between arguments.
 func (s *SugaredLogger) Debugln(args ...interface{}) {
@@ -350,9 +402,13 @@ func (s *SugaredLogger) log(lvl zapcore.Level, template string, fmtArgs []interf
 		return
 	}
 
+	if s.base.development && template != "" && len(fmtArgs) > 0 {
+		s.checkFormat(template, fmtArgs)
+	}
+
 	msg := getMessage(template, fmtArgs)
 	if ce := s.base.Check(lvl, msg); ce != nil {
-		ce.Write(s.sweetenFields(context)...)
+		s.write(ce, context)
 	}
 }
 
@@ -364,7 +420,17 @@ func (s *SugaredLogger) logln(lvl zapcore.Level, fmtArgs []interface{}, context
 
 	msg := getMessageln(fmtArgs)
 	if ce := s.base.Check(lvl, msg); ce != nil {
-		ce.Write(s.sweetenFields(context)...)
+		s.write(ce, context)
+	}
+}
+
+// checkFormat logs a DPanic if template doesn't match fmtArgs, so that
+// mistakes that would otherwise show up as %!v(MISSING), %!d(string=...),
+// and the like in production logs are caught in development.
+func (s *SugaredLogger) checkFormat(template string, fmtArgs []interface{}) {
+	if err := checkFormat(template, fmtArgs); err != nil {
+		zapcore.ReportInternalError(fmt.Errorf("sugared logger: bad template %q: %w", template, err), zapcore.Entry{})
+		s.base.DPanic(_badFormatErrMsg, String("template", template), Int("args", len(fmtArgs)), Error(err))
 	}
 }
 
@@ -392,15 +458,33 @@ func getMessageln(fmtArgs []interface{}) string {
 	return msg[:len(msg)-1]
 }
 
+// write writes a checked entry with the given loosely-typed context. In the
+// experimental zaparena mode, the fields are built in a pooled slab that's
+// released once the entry is written.
+func (s *SugaredLogger) write(ce *zapcore.CheckedEntry, context []interface{}) {
+	if !arena.Enabled || len(context) == 0 {
+		ce.Write(s.sweetenFields(context)...)
+		return
+	}
+	slab := arena.GetFields(len(context))
+	slab.Fields = s.appendSweetenedFields(slab.Fields, context)
+	ce.Write(slab.Fields...)
+	slab.Free()
+}
+
 func (s *SugaredLogger) sweetenFields(args []interface{}) []Field {
 	if len(args) == 0 {
 		return nil
 	}
+	// Allocate enough space for the worst case; if users pass only structured
+	// fields, we shouldn't penalize them with extra allocations.
+	return s.appendSweetenedFields(make([]Field, 0, len(args)), args)
+}
 
+// appendSweetenedFields appends the fields built from loosely-typed
+// key-value pairs to fields.
+func (s *SugaredLogger) appendSweetenedFields(fields []Field, args []interface{}) []Field {
 	var (
-		// Allocate enough space for the worst case; if users pass only structured
-		// fields, we shouldn't penalize them with extra allocations.
-		fields    = make([]Field, 0, len(args))
 		invalid   invalidPairs
 		seenError bool
 	)
@@ -419,6 +503,7 @@ func (s *SugaredLogger) sweetenFields(args []interface{}) []Field {
 				seenError = true
 				fields = append(fields, Error(err))
 			} else {
+				zapcore.ReportInternalError(errMultipleErrors, zapcore.Entry{})
 				s.base.Error(_multipleErrMsg, Error(err))
 			}
 			i++
@@ -427,32 +512,53 @@ func (s *SugaredLogger) sweetenFields(args []interface{}) []Field {
 
 		// Make sure this element isn't a dangling key.
 		if i == len(args)-1 {
-			s.base.Error(_oddNumberErrMsg, Any("ignored", args[i]))
+			if s.base.kvPolicy == KeyValueCoerce {
+				fields = append(fields, Any("ignored", args[i]))
+			} else {
+				s.reportInvalid(errOddNumber, _oddNumberErrMsg, Any("ignored", args[i]))
+			}
 			break
 		}
 
 		// Consume this value and the next, treating them as a key-value pair. If the
 		// key isn't a string, add this pair to the slice of invalid pairs.
 		key, val := args[i], args[i+1]
-		if keyStr, ok := key.(string); !ok {
+		if keyStr, ok := key.(string); ok {
+			fields = append(fields, Any(keyStr, val))
+		} else if s.base.kvPolicy == KeyValueCoerce {
+			fields = append(fields, Any(fmt.Sprint(key), val))
+		} else {
 			// Subsequent errors are likely, so allocate once up front.
 			if cap(invalid) == 0 {
 				invalid = make(invalidPairs, 0, len(args)/2)
 			}
 			invalid = append(invalid, invalidPair{i, key, val})
-		} else {
-			fields = append(fields, Any(keyStr, val))
 		}
 		i += 2
 	}
 
-	// If we encountered any invalid key-value pairs, log an error.
+	// If we encountered any invalid key-value pairs, report them.
 	if len(invalid) > 0 {
-		s.base.Error(_nonStringKeyErrMsg, Array("invalid", invalid))
+		s.reportInvalid(errNonStringKey, _nonStringKeyErrMsg, Array("invalid", invalid))
 	}
 	return fields
 }
 
+// reportInvalid reports malformed key-value pairs as the logger's
+// KeyValuePolicy dictates.
+func (s *SugaredLogger) reportInvalid(err error, msg string, field Field) {
+	switch s.base.kvPolicy {
+	case KeyValueDPanic:
+		zapcore.ReportInternalError(err, zapcore.Entry{})
+		s.base.DPanic(msg, field)
+	case KeyValueDrop, KeyValueCoerce:
+		// Nothing to report.
+	default:
+		zapcore.ReportInternalError(err, zapcore.Entry{})
+		s.base.Error(msg, field)
+	}
+}
+
 type invalidPair struct {
 	position   int
 	key, value interface{}
@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build zaparena
+
+package zap
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"go.uber.org/zap/internal/arena"
+	"go.uber.org/zap/internal/ztest"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSugarArenaRetainingCores checks the claim that the arena relies on:
+// that zap's own Cores don't keep the fields passed to Write after it
+// returns. Each entry is logged with a pooled slab that's cleared and reused
+// for the next one, so a Core that kept the slab itself would see its
+// earlier entries change.
+func TestSugarArenaRetainingCores(t *testing.T) {
+	require.True(t, arena.Enabled, "Expected the zaparena build tag to enable the arena.")
+
+	const entries = 10
+	// perEntry is the context each entry should have been logged with.
+	perEntry := func(extra map[string]interface{}) []map[string]interface{} {
+		want := make([]map[string]interface{}, entries)
+		for i := range want {
+			want[i] = map[string]interface{}{fmt.Sprintf("k%d", i): fmt.Sprintf("v%d", i)}
+			for k, v := range extra {
+				want[i][k] = v
+			}
+		}
+		return want
+	}
+	// contexts reads the fields directly rather than with ContextMap, which
+	// panics on the zero Fields left behind in a cleared slab.
+	contexts := func(logs ...*observer.ObservedLogs) []map[string]interface{} {
+		var got []map[string]interface{}
+		for _, l := range logs {
+			for _, e := range l.AllUntimed() {
+				m := make(map[string]interface{}, len(e.Context))
+				for _, f := range e.Context {
+					m[f.Key] = f.String
+				}
+				got = append(got, m)
+			}
+		}
+		return got
+	}
+
+	tests := []struct {
+		desc string
+		// core returns the Core to log to and a function that returns the
+		// contexts it retained or wrote once logging is done.
+		core func() (zapcore.Core, func() []map[string]interface{})
+		want []map[string]interface{}
+	}{
+		{
+			desc: "observer",
+			core: func() (zapcore.Core, func() []map[string]interface{}) {
+				core, logs := observer.New(DebugLevel)
+				return core, func() []map[string]interface{} { return contexts(logs) }
+			},
+			want: perEntry(nil),
+		},
+		{
+			desc: "LineBuilder",
+			core: func() (zapcore.Core, func() []map[string]interface{}) {
+				obs, logs := observer.New(DebugLevel)
+				b := NewLineBuilder(New(obs))
+				discard := zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{}), &ztest.Discarder{}, DebugLevel)
+				core := zapcore.RegisterFieldHooks(discard, func(_ zapcore.Entry, fs []zapcore.Field) ([]zapcore.Field, error) {
+					b.Add(fs...)
+					return fs, nil
+				})
+				return core, func() []map[string]interface{} {
+					b.Emit(InfoLevel, "line")
+					return contexts(logs)
+				}
+			},
+			want: func() []map[string]interface{} {
+				all := make(map[string]interface{})
+				for _, m := range perEntry(nil) {
+					for k, v := range m {
+						all[k] = v
+					}
+				}
+				return []map[string]interface{}{all}
+			}(),
+		},
+		{
+			desc: "ioCore with deferred context",
+			core: func() (zapcore.Core, func() []map[string]interface{}) {
+				buf := &ztest.Buffer{}
+				core := zapcore.NewCore(
+					zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}),
+					buf,
+					DebugLevel,
+				).With([]Field{String("ctx", "c")})
+				return core, func() []map[string]interface{} {
+					var got []map[string]interface{}
+					for _, line := range buf.Lines() {
+						var m map[string]interface{}
+						require.NoError(t, json.Unmarshal([]byte(line), &m), "Unexpected invalid JSON.")
+						delete(m, "msg")
+						got = append(got, m)
+					}
+					return got
+				}
+			},
+			want: perEntry(map[string]interface{}{"ctx": "c"}),
+		},
+		{
+			desc: "Tee",
+			core: func() (zapcore.Core, func() []map[string]interface{}) {
+				core1, logs1 := observer.New(DebugLevel)
+				core2, logs2 := observer.New(DebugLevel)
+				return zapcore.NewTee(core1, core2), func() []map[string]interface{} { return contexts(logs1, logs2) }
+			},
+			want: append(perEntry(nil), perEntry(nil)...),
+		},
+		{
+			desc: "parallel Tee",
+			core: func() (zapcore.Core, func() []map[string]interface{}) {
+				core1, logs1 := observer.New(DebugLevel)
+				core2, logs2 := observer.New(DebugLevel)
+				tee := zapcore.NewTeeWithOptions([]zapcore.Core{core1, core2}, zapcore.TeeParallel(2))
+				return tee, func() []map[string]interface{} { return contexts(logs1, logs2) }
+			},
+			want: append(perEntry(nil), perEntry(nil)...),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			core, retained := tt.core()
+			sugar := New(core).Sugar()
+			for i := 0; i < entries; i++ {
+				sugar.Infow("entry", fmt.Sprintf("k%d", i), fmt.Sprintf("v%d", i))
+			}
+			assert.Equal(t, tt.want, retained(), "Expected retained fields to be unaffected by slab reuse.")
+		})
+	}
+}
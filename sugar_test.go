@@ -287,6 +287,69 @@ func TestSugarFieldsInvalidPairs(t *testing.T) {
 	})
 }
 
+func TestSugarKeyValuePolicy(t *testing.T) {
+	args := []interface{}{"k", "v", 42, "foo", "dangling"}
+	invalid := Array("invalid", invalidPairs{{2, 42, "foo"}})
+
+	tests := []struct {
+		desc        string
+		policy      KeyValuePolicy
+		wantFields  []Field
+		wantReports []observer.LoggedEntry
+	}{
+		{
+			desc:       "error",
+			policy:     KeyValueError,
+			wantFields: []Field{String("k", "v")},
+			wantReports: []observer.LoggedEntry{
+				{Entry: zapcore.Entry{Level: ErrorLevel, Message: _oddNumberErrMsg}, Context: []Field{Any("ignored", "dangling")}},
+				{Entry: zapcore.Entry{Level: ErrorLevel, Message: _nonStringKeyErrMsg}, Context: []Field{invalid}},
+			},
+		},
+		{
+			desc:       "dpanic",
+			policy:     KeyValueDPanic,
+			wantFields: []Field{String("k", "v")},
+			wantReports: []observer.LoggedEntry{
+				{Entry: zapcore.Entry{Level: DPanicLevel, Message: _oddNumberErrMsg}, Context: []Field{Any("ignored", "dangling")}},
+				{Entry: zapcore.Entry{Level: DPanicLevel, Message: _nonStringKeyErrMsg}, Context: []Field{invalid}},
+			},
+		},
+		{
+			desc:       "drop",
+			policy:     KeyValueDrop,
+			wantFields: []Field{String("k", "v")},
+		},
+		{
+			desc:       "coerce",
+			policy:     KeyValueCoerce,
+			wantFields: []Field{String("k", "v"), String("42", "foo"), String("ignored", "dangling")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			opts := []Option{WithKeyValuePolicy(tt.policy)}
+			withSugar(t, DebugLevel, opts, func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+				logger.Infow("msg", args...)
+
+				want := append(tt.wantReports, observer.LoggedEntry{
+					Entry:   zapcore.Entry{Level: InfoLevel, Message: "msg"},
+					Context: tt.wantFields,
+				})
+				assert.Equal(t, want, logs.AllUntimed(), "Unexpected output.")
+			})
+		})
+	}
+}
+
+func TestSugarKeyValuePolicyDPanicsInDevelopment(t *testing.T) {
+	opts := []Option{Development(), WithKeyValuePolicy(KeyValueDPanic)}
+	withSugar(t, DebugLevel, opts, func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		assert.Panics(t, func() { logger.Infow("msg", "dangling") }, "Expected a DPanic.")
+	})
+}
+
 func TestSugarStructuredLogging(t *testing.T) {
 	tests := []struct {
 		msg       string
@@ -325,6 +388,23 @@ func TestSugarStructuredLogging(t *testing.T) {
 	}
 }
 
+func TestSugarTraceLogging(t *testing.T) {
+	withSugar(t, TraceLevel, nil, func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		logger.Trace("foo", "bar")
+		logger.Tracef("foo%s", "bar")
+		logger.Tracew("foobar", "baz", false)
+		logger.Traceln("foo", "bar")
+
+		expected := []observer.LoggedEntry{
+			{Entry: zapcore.Entry{Level: TraceLevel, Message: "foobar"}, Context: []Field{}},
+			{Entry: zapcore.Entry{Level: TraceLevel, Message: "foobar"}, Context: []Field{}},
+			{Entry: zapcore.Entry{Level: TraceLevel, Message: "foobar"}, Context: []Field{Bool("baz", false)}},
+			{Entry: zapcore.Entry{Level: TraceLevel, Message: "foo bar"}, Context: []Field{}},
+		}
+		assert.Equal(t, expected, logs.AllUntimed(), "Unexpected log output.")
+	})
+}
+
 func TestSugarConcatenatingLogging(t *testing.T) {
 	tests := []struct {
 		args   []interface{}
@@ -395,6 +475,81 @@ func TestSugarTemplatedLogging(t *testing.T) {
 	}
 }
 
+func TestSugarTemplateCheck(t *testing.T) {
+	tests := []struct {
+		desc     string
+		opts     []Option
+		format   string
+		args     []interface{}
+		wantErrs []string
+	}{
+		{
+			desc:   "matching",
+			opts:   []Option{Development()},
+			format: "%v and %*d",
+			args:   []interface{}{"foo", 4, 2},
+		},
+		{
+			desc:   "no arguments",
+			opts:   []Option{Development()},
+			format: "100%",
+		},
+		{
+			desc:     "missing argument",
+			opts:     []Option{Development()},
+			format:   "%s and %s",
+			args:     []interface{}{"foo"},
+			wantErrs: []string{`missing argument for "%s"`},
+		},
+		{
+			desc:     "extra argument",
+			opts:     []Option{Development()},
+			format:   "%s",
+			args:     []interface{}{"foo", "bar"},
+			wantErrs: []string{"1 unused argument(s)"},
+		},
+		{
+			desc:     "wrong argument type",
+			opts:     []Option{Development()},
+			format:   "%d requests",
+			args:     []interface{}{"many"},
+			wantErrs: []string{`wrong type string for "%d"`},
+		},
+		{
+			desc:   "not in development",
+			format: "%s",
+			args:   []interface{}{"foo", "bar"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// Don't panic, so that we can see what's logged.
+			opts := append(tt.opts, WithDPanicPolicy(DPanicPolicy{}))
+			withSugar(t, DebugLevel, opts, func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+				logger.Infof(tt.format, tt.args...)
+
+				errs := logs.FilterMessage(_badFormatErrMsg)
+				require.Equal(t, len(tt.wantErrs), errs.Len(), "Unexpected number of format errors.")
+				for i, entry := range errs.AllUntimed() {
+					assert.Equal(t, DPanicLevel, entry.Level, "Unexpected level.")
+					fields := entry.ContextMap()
+					assert.Equal(t, tt.format, fields["template"], "Unexpected template.")
+					assert.Equal(t, tt.wantErrs[i], fields["error"], "Unexpected error.")
+				}
+				assert.Equal(t, 1, logs.FilterMessage(getMessage(tt.format, tt.args)).Len(),
+					"Expected the entry to be logged regardless.")
+			})
+		})
+	}
+}
+
+func TestSugarTemplateCheckPanicsInDevelopment(t *testing.T) {
+	withSugar(t, DebugLevel, []Option{Development()}, func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		assert.Panics(t, func() { logger.Infof("%s %s", "foo") }, "Expected a DPanic.")
+	})
+}
+
 func TestSugarLnLogging(t *testing.T) {
 	tests := []struct {
 		args   []interface{}
@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	_traceIDKey = "trace_id"
+	_spanIDKey  = "span_id"
+)
+
+var (
+	errTraceParentFormat  = errors.New("traceparent must be of the form version-traceid-spanid-flags")
+	errTraceParentVersion = errors.New("traceparent version ff is invalid")
+	errTraceParentZeroID  = errors.New("traceparent trace and span IDs must not be all zeroes")
+)
+
+// A TraceExtractor reports the W3C trace and span IDs associated with a
+// context, as lowercase hex strings. It returns false if the context doesn't
+// carry a trace.
+//
+// Extractors allow TraceContext to support tracing libraries without zap
+// depending on them. For example, OpenTelemetry users can register:
+//
+//	zap.RegisterTraceExtractor(func(ctx context.Context) (string, string, bool) {
+//		sc := trace.SpanContextFromContext(ctx)
+//		if !sc.IsValid() {
+//			return "", "", false
+//		}
+//		return sc.TraceID().String(), sc.SpanID().String(), true
+//	})
+type TraceExtractor func(ctx context.Context) (traceID, spanID string, ok bool)
+
+var _traceExtractors struct {
+	mu         sync.RWMutex
+	extractors []TraceExtractor
+}
+
+// RegisterTraceExtractor adds an extractor consulted by TraceContext.
+// Extractors are consulted in registration order, after any traceparent
+// attached with ContextWithTraceParent.
+func RegisterTraceExtractor(e TraceExtractor) {
+	_traceExtractors.mu.Lock()
+	defer _traceExtractors.mu.Unlock()
+	_traceExtractors.extractors = append(_traceExtractors.extractors, e)
+}
+
+type traceParentKey struct{}
+
+// ContextWithTraceParent returns a copy of ctx carrying the given W3C
+// traceparent header value (for example, as received from an incoming HTTP
+// request), for use with TraceContext.
+func ContextWithTraceParent(ctx context.Context, traceparent string) context.Context {
+	return context.WithValue(ctx, traceParentKey{}, traceparent)
+}
+
+// TraceContext constructs a field that adds the W3C trace and span IDs
+// carried by ctx under the standard "trace_id" and "span_id" keys. IDs are
+// taken from a traceparent attached with ContextWithTraceParent, or else
+// from the first registered TraceExtractor that recognizes the context. If
+// the context carries no trace, the field is a no-op.
+func TraceContext(ctx context.Context) Field {
+	if ctx == nil {
+		return Skip()
+	}
+	if tp, ok := ctx.Value(traceParentKey{}).(string); ok {
+		if f := TraceParent(tp); f.Type != zapcore.SkipType {
+			return f
+		}
+	}
+
+	_traceExtractors.mu.RLock()
+	defer _traceExtractors.mu.RUnlock()
+	for _, extract := range _traceExtractors.extractors {
+		if traceID, spanID, ok := extract(ctx); ok {
+			return Inline(traceIDs{traceID: traceID, spanID: spanID})
+		}
+	}
+	return Skip()
+}
+
+// TraceParent constructs a field from an explicit W3C traceparent header
+// value, adding its trace and span IDs under the "trace_id" and "span_id"
+// keys. If the value isn't a valid traceparent, the field is a no-op.
+func TraceParent(traceparent string) Field {
+	traceID, spanID, err := ParseTraceParent(traceparent)
+	if err != nil {
+		return Skip()
+	}
+	return Inline(traceIDs{traceID: traceID, spanID: spanID})
+}
+
+// ParseTraceParent extracts the trace and span IDs from a W3C traceparent
+// header value, such as
+//
+//	00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+//
+// Values with versions newer than 00 are accepted as long as they start with
+// the fields defined by version 00.
+func ParseTraceParent(traceparent string) (traceID, spanID string, err error) {
+	// version(2) - trace-id(32) - parent-id(16) - flags(2)
+	const size = 2 + 1 + 32 + 1 + 16 + 1 + 2
+	if len(traceparent) < size {
+		return "", "", errTraceParentFormat
+	}
+	version := traceparent[:2]
+	if !isLowerHex(version) {
+		return "", "", errTraceParentFormat
+	}
+	if version == "ff" {
+		return "", "", errTraceParentVersion
+	}
+	if len(traceparent) > size && (version == "00" || traceparent[size] != '-') {
+		return "", "", errTraceParentFormat
+	}
+	if traceparent[2] != '-' || traceparent[35] != '-' || traceparent[52] != '-' {
+		return "", "", errTraceParentFormat
+	}
+
+	traceID, spanID = traceparent[3:35], traceparent[36:52]
+	if !isLowerHex(traceID) || !isLowerHex(spanID) || !isLowerHex(traceparent[53:55]) {
+		return "", "", errTraceParentFormat
+	}
+	if isAllZeroes(traceID) || isAllZeroes(spanID) {
+		return "", "", errTraceParentZeroID
+	}
+	return traceID, spanID, nil
+}
+
+type traceIDs struct {
+	traceID string
+	spanID  string
+}
+
+func (t traceIDs) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString(_traceIDKey, t.traceID)
+	if t.spanID != "" {
+		enc.AddString(_spanIDKey, t.spanID)
+	}
+	return nil
+}
+
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAllZeroes(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '0' {
+			return false
+		}
+	}
+	return true
+}
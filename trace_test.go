@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		give        string
+		wantTraceID string
+		wantSpanID  string
+		wantErr     error
+	}{
+		{
+			give:        "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantSpanID:  "00f067aa0ba902b7",
+		},
+		{
+			give:        "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future",
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantSpanID:  "00f067aa0ba902b7",
+		},
+		{give: "", wantErr: errTraceParentFormat},
+		{give: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", wantErr: errTraceParentFormat},
+		{give: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", wantErr: errTraceParentFormat},
+		{give: "00_4bf92f3577b34da6a3ce929d0e0e4736_00f067aa0ba902b7_01", wantErr: errTraceParentFormat},
+		{give: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", wantErr: errTraceParentVersion},
+		{give: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", wantErr: errTraceParentZeroID},
+		{give: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", wantErr: errTraceParentZeroID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			traceID, spanID, err := ParseTraceParent(tt.give)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err, "Unexpected error.")
+				return
+			}
+			require.NoError(t, err, "Unexpected error.")
+			assert.Equal(t, tt.wantTraceID, traceID, "Unexpected trace ID.")
+			assert.Equal(t, tt.wantSpanID, spanID, "Unexpected span ID.")
+		})
+	}
+}
+
+func TestTraceContext(t *testing.T) {
+	type extractorKey struct{}
+	RegisterTraceExtractor(func(ctx context.Context) (string, string, bool) {
+		ids, ok := ctx.Value(extractorKey{}).([2]string)
+		return ids[0], ids[1], ok
+	})
+	defer func() { _traceExtractors.extractors = nil }()
+
+	tests := []struct {
+		desc string
+		ctx  context.Context
+		want map[string]interface{}
+	}{
+		{
+			desc: "nil context",
+			ctx:  nil,
+			want: map[string]interface{}{},
+		},
+		{
+			desc: "no trace",
+			ctx:  context.Background(),
+			want: map[string]interface{}{},
+		},
+		{
+			desc: "traceparent",
+			ctx: ContextWithTraceParent(
+				context.Background(),
+				"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			),
+			want: map[string]interface{}{
+				"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+				"span_id":  "00f067aa0ba902b7",
+			},
+		},
+		{
+			desc: "invalid traceparent falls back to extractors",
+			ctx: context.WithValue(
+				ContextWithTraceParent(context.Background(), "garbage"),
+				extractorKey{}, [2]string{"abc", "def"},
+			),
+			want: map[string]interface{}{
+				"trace_id": "abc",
+				"span_id":  "def",
+			},
+		},
+		{
+			desc: "extractor without span",
+			ctx:  context.WithValue(context.Background(), extractorKey{}, [2]string{"abc", ""}),
+			want: map[string]interface{}{
+				"trace_id": "abc",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := zapcore.NewMapObjectEncoder()
+			TraceContext(tt.ctx).AddTo(enc)
+			assert.Equal(t, tt.want, enc.Fields, "Unexpected fields.")
+		})
+	}
+}
+
+func TestTraceParentField(t *testing.T) {
+	assert.Equal(t, Skip(), TraceParent("garbage"), "Expected invalid traceparent to be skipped.")
+
+	enc := zapcore.NewMapObjectEncoder()
+	TraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01").AddTo(enc)
+	assert.Equal(t, map[string]interface{}{
+		"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id":  "00f067aa0ba902b7",
+	}, enc.Fields, "Unexpected fields.")
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"os"
+
+	"go.uber.org/zap/internal/term"
+)
+
+// _autoEncoding is the Config.Encoding value that picks between the console
+// and JSON encoders depending on whether the output is a terminal.
+const _autoEncoding = "auto"
+
+// _isTerminal is swapped out in tests.
+var _isTerminal = term.IsTerminal
+
+// outputsAreTerminals reports whether every one of the given output paths is
+// a terminal. Only the special "stdout" and "stderr" paths can be
+// terminals; anything else is assumed to be a file or a network destination.
+func outputsAreTerminals(paths []string) bool {
+	if len(paths) == 0 {
+		return false
+	}
+	for _, path := range paths {
+		var f *os.File
+		switch path {
+		case "stdout":
+			f = os.Stdout
+		case "stderr":
+			f = os.Stderr
+		default:
+			return false
+		}
+		if !_isTerminal(f) {
+			return false
+		}
+	}
+	return true
+}
@@ -39,8 +39,28 @@ import (
 // factories for other schemes using RegisterSink.
 //
 // URLs with the "file" scheme must use absolute paths on the local
-// filesystem. No user, password, port, fragments, or query parameters are
-// allowed, and the hostname must be empty or "localhost".
+// filesystem. No user, password, port, or fragments are allowed, and the
+// hostname must be empty or "localhost". The following query parameters are
+// supported:
+//
+//   - create_mode: permissions for newly created files, in octal (e.g., "0640")
+//   - mkdir: create missing parent directories if true
+//   - dir_mode: permissions for directories created by mkdir, in octal
+//     (default "0755")
+//   - append: append to existing files if true (the default), or truncate
+//     them if false
+//   - sync: open files with O_SYNC if true, so each write reaches the disk
+//     before returning
+//   - buffer: buffer writes in memory up to the given size (e.g., "256kb")
+//   - flush: flush buffered writes at the given interval (e.g., "5s")
+//
+// For example, "file:///var/log/app.log?buffer=256kb&flush=5s". Factories
+// registered with RegisterSink may use SinkParams to support query
+// parameters in the same way.
+//
+// Files opened this way can be reopened at their original path with
+// ReopenFiles, which makes zap work with external rotation tools like
+// logrotate. See ReopenOnSignal.
 //
 // Since it's common to write logs to the local filesystem, URLs without a
 // scheme (e.g., "/var/log/foo.log") are treated as local file paths. Without
@@ -202,7 +202,7 @@ func TestOpenOtherErrors(t *testing.T) {
 		{
 			msg:     "file url with query",
 			paths:   []string{"file://localhost" + tempName + "?foo=bar"},
-			wantErr: "query parameters not allowed",
+			wantErr: `unknown query parameter "foo"`,
 		},
 		{
 			msg:     "file with port",
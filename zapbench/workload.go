@@ -0,0 +1,307 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapbench
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// A Workload is a standardized way of logging one entry.
+type Workload struct {
+	// Name identifies the workload; Run uses it as the sub-benchmark name.
+	Name string
+
+	// Setup, if non-nil, prepares the Logger before the benchmark starts,
+	// for example by adding context to it.
+	Setup func(*zap.Logger) *zap.Logger
+
+	// Log logs one entry. i is the benchmark iteration; workloads use it to
+	// vary the message.
+	Log func(log *zap.Logger, i int)
+}
+
+// Workloads returns the standard workloads:
+//
+//   - NoFields logs a message without any fields.
+//   - TenFields logs a message with ten fields of various types.
+//   - NestedObjects logs a message with objects nested three levels deep.
+//   - WithContext logs a message with a Logger that has ten fields of
+//     context.
+//   - Sugar logs a message with ten loosely-typed key-value pairs.
+//   - SugarFormatting logs a printf-style message with ten arguments.
+//   - Disabled logs a message at DebugLevel, which Cores built for Run
+//     should not enable.
+func Workloads() []Workload {
+	return []Workload{
+		{
+			Name: "NoFields",
+			Log: func(log *zap.Logger, i int) {
+				log.Info(Message(i))
+			},
+		},
+		{
+			Name: "TenFields",
+			Log: func(log *zap.Logger, i int) {
+				log.Info(Message(i), TenFields()...)
+			},
+		},
+		{
+			Name: "NestedObjects",
+			Log: func(log *zap.Logger, i int) {
+				log.Info(Message(i), zap.Object("team", _team))
+			},
+		},
+		{
+			Name: "WithContext",
+			Setup: func(log *zap.Logger) *zap.Logger {
+				return log.With(TenFields()...)
+			},
+			Log: func(log *zap.Logger, i int) {
+				log.Info(Message(i))
+			},
+		},
+		{
+			Name: "Sugar",
+			Log: func(log *zap.Logger, i int) {
+				log.Sugar().Infow(Message(i), tenSugarFields()...)
+			},
+		},
+		{
+			Name: "SugarFormatting",
+			Log: func(log *zap.Logger, i int) {
+				log.Sugar().Infof("%v %v %v %s %v %v %v %v %v %s", tenFmtArgs()...)
+			},
+		},
+		{
+			Name: "Disabled",
+			Log: func(log *zap.Logger, i int) {
+				log.Debug(Message(i), TenFields()...)
+			},
+		},
+	}
+}
+
+// Run runs every workload as a parallel sub-benchmark of b, reporting
+// allocations. newCore is called once per workload and should return a Core
+// that enables InfoLevel and above, and that discards its output or writes
+// it somewhere cheap.
+func Run(b *testing.B, newCore func() zapcore.Core) {
+	for _, w := range Workloads() {
+		w := w
+		b.Run(w.Name, func(b *testing.B) {
+			RunWorkload(b, newCore(), w)
+		})
+	}
+}
+
+// RunWorkload benchmarks a single workload against a Core.
+func RunWorkload(b *testing.B, core zapcore.Core, w Workload) {
+	log := w.logger(core)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		var i int
+		for pb.Next() {
+			w.Log(log, i)
+			i++
+		}
+	})
+}
+
+// logger builds the Logger the workload logs with.
+func (w Workload) logger(core zapcore.Core) *zap.Logger {
+	log := zap.New(core)
+	if w.Setup != nil {
+		log = w.Setup(log)
+	}
+	return log
+}
+
+// NewCore builds the reference Core the workloads are calibrated against:
+// zap's JSON encoder with the production encoder configuration, writing to
+// w at InfoLevel and above. Benchmark it next to a custom Core for an
+// apples-to-apples baseline.
+func NewCore(w io.Writer) zapcore.Core {
+	ec := zap.NewProductionEncoderConfig()
+	ec.EncodeDuration = zapcore.NanosDurationEncoder
+	ec.EncodeTime = zapcore.EpochNanosTimeEncoder
+	return zapcore.NewCore(zapcore.NewJSONEncoder(ec), zapcore.AddSync(w), zapcore.InfoLevel)
+}
+
+const _numMessages = 1000
+
+var _messages = func() []string {
+	messages := make([]string, _numMessages)
+	for i := range messages {
+		messages[i] = fmt.Sprintf("Test logging, but use a somewhat realistic message length. (#%v)", i)
+	}
+	return messages
+}()
+
+// Message returns the log message the workloads use for the ith iteration.
+func Message(i int) string {
+	if i < 0 {
+		i = -i
+	}
+	return _messages[i%_numMessages]
+}
+
+// TenFields returns the ten fields logged by the TenFields and WithContext
+// workloads.
+func TenFields() []zap.Field {
+	return []zap.Field{
+		zap.Int("int", _tenInts[0]),
+		zap.Ints("ints", _tenInts),
+		zap.String("string", _tenStrings[0]),
+		zap.Strings("strings", _tenStrings),
+		zap.Time("time", _tenTimes[0]),
+		zap.Times("times", _tenTimes),
+		zap.Object("user1", _oneUser),
+		zap.Object("user2", _oneUser),
+		zap.Array("users", _tenUsers),
+		zap.Error(errExample),
+	}
+}
+
+func tenSugarFields() []interface{} {
+	return []interface{}{
+		"int", _tenInts[0],
+		"ints", _tenInts,
+		"string", _tenStrings[0],
+		"strings", _tenStrings,
+		"time", _tenTimes[0],
+		"times", _tenTimes,
+		"user1", _oneUser,
+		"user2", _oneUser,
+		"users", _tenUsers,
+		"error", errExample,
+	}
+}
+
+func tenFmtArgs() []interface{} {
+	// Need to keep this a function instead of a package-global var so that we
+	// pay the cast-to-interface{} penalty on each call.
+	return []interface{}{
+		_tenInts[0],
+		_tenInts,
+		_tenStrings[0],
+		_tenStrings,
+		_tenTimes[0],
+		_tenTimes,
+		_oneUser,
+		_oneUser,
+		_tenUsers,
+		errExample,
+	}
+}
+
+var (
+	errExample = errors.New("fail")
+
+	_tenInts    = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 0}
+	_tenStrings = []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+	_tenTimes   = []time.Time{
+		time.Unix(0, 0),
+		time.Unix(1, 0),
+		time.Unix(2, 0),
+		time.Unix(3, 0),
+		time.Unix(4, 0),
+		time.Unix(5, 0),
+		time.Unix(6, 0),
+		time.Unix(7, 0),
+		time.Unix(8, 0),
+		time.Unix(9, 0),
+	}
+	_oneUser = &user{
+		Name:      "Jane Doe",
+		Email:     "jane@test.com",
+		CreatedAt: time.Date(1980, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	_tenUsers = users{
+		_oneUser, _oneUser, _oneUser, _oneUser, _oneUser,
+		_oneUser, _oneUser, _oneUser, _oneUser, _oneUser,
+	}
+	_team = &team{
+		Name: "core",
+		Lead: _oneUser,
+		Sub: &team{
+			Name:    "encoders",
+			Lead:    _oneUser,
+			Members: _tenUsers[:3],
+			Sub: &team{
+				Name:    "json",
+				Lead:    _oneUser,
+				Members: _tenUsers[:2],
+			},
+		},
+		Members: _tenUsers,
+	}
+)
+
+type users []*user
+
+func (uu users) MarshalLogArray(arr zapcore.ArrayEncoder) error {
+	var err error
+	for i := range uu {
+		err = multierr.Append(err, arr.AppendObject(uu[i]))
+	}
+	return err
+}
+
+type user struct {
+	Name      string
+	Email     string
+	CreatedAt time.Time
+}
+
+func (u *user) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", u.Name)
+	enc.AddString("email", u.Email)
+	enc.AddInt64("createdAt", u.CreatedAt.UnixNano())
+	return nil
+}
+
+type team struct {
+	Name    string
+	Lead    *user
+	Members users
+	Sub     *team // may be nil
+}
+
+func (t *team) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", t.Name)
+	err := multierr.Combine(
+		enc.AddObject("lead", t.Lead),
+		enc.AddArray("members", t.Members),
+	)
+	if t.Sub != nil {
+		err = multierr.Append(err, enc.AddObject("sub", t.Sub))
+	}
+	return err
+}
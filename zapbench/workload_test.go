@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapbench
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWorkloads(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantLogs   int
+		wantFields int
+	}{
+		{name: "NoFields", wantLogs: 1},
+		{name: "TenFields", wantLogs: 1, wantFields: 10},
+		{name: "NestedObjects", wantLogs: 1, wantFields: 1},
+		{name: "WithContext", wantLogs: 1, wantFields: 10},
+		{name: "Sugar", wantLogs: 1, wantFields: 10},
+		{name: "SugarFormatting", wantLogs: 1},
+		{name: "Disabled"},
+	}
+
+	workloads := Workloads()
+	require.Len(t, workloads, len(tests), "Unexpected number of workloads.")
+	for i, tt := range tests {
+		w := workloads[i]
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.name, w.Name, "Unexpected workload name.")
+
+			core, logs := observer.New(zapcore.InfoLevel)
+			log := w.logger(core)
+			w.Log(log, 1)
+
+			require.Equal(t, tt.wantLogs, logs.Len(), "Unexpected number of logs.")
+			if tt.wantLogs > 0 {
+				assert.Len(t, logs.All()[0].Context, tt.wantFields, "Unexpected number of fields.")
+			}
+		})
+	}
+}
+
+func TestWorkloadsEncode(t *testing.T) {
+	for _, w := range Workloads() {
+		w := w
+		t.Run(w.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w.Log(w.logger(NewCore(&buf)), 1)
+
+			for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+				if len(line) == 0 {
+					continue
+				}
+				assert.True(t, json.Valid(line), "Expected valid JSON, got %s.", line)
+			}
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	assert.Equal(t, Message(3), Message(3+_numMessages), "Expected messages to repeat.")
+	assert.NotEqual(t, Message(3), Message(4), "Expected messages to vary.")
+	assert.Equal(t, Message(3), Message(-3), "Expected negative iterations to be handled.")
+}
+
+func BenchmarkReferenceCore(b *testing.B) {
+	Run(b, func() zapcore.Core {
+		return NewCore(io.Discard)
+	})
+}
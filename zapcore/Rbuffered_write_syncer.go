@@ -99,6 +99,42 @@ type BufferedWriteSyncer struct {
 	// Defaults to the system clock.
 	Clock Clock
 
+	// FlushCount, if positive, flushes the buffer as soon as it holds this
+	// many writes. Cores write each log entry with a single call, so this
+	// bounds the number of entries that can be held back.
+	//
+	// Disabled by default.
+	FlushCount int
+
+	// FlushLevel, if specified, flushes the buffer immediately after writing
+	// an entry at an enabled level, so that errors become visible without
+	// waiting for the buffer to fill up. For example, set it to ErrorLevel to
+	// flush on Error and above.
+	//
+	// Levels are only known for entries written by Cores that support
+	// LevelWriter, like those created by NewCore. Disabled by default.
+	FlushLevel LevelEnabler
+
+	// BypassLevel, if specified, writes entries at enabled levels directly to
+	// WS instead of buffering them, after flushing anything already buffered
+	// to preserve ordering. For example, set it to WarnLevel to keep Debug
+	// and Info entries buffered while writing Warn and above synchronously.
+	//
+	// Like FlushLevel, this only applies to entries written by Cores that
+	// support LevelWriter. Disabled by default.
+	BypassLevel LevelEnabler
+
+	// SyncOnBypass additionally calls WS.Sync after each entry that bypasses
+	// the buffer (for files, this is an fsync), trading throughput for
+	// durability of important entries.
+	SyncOnBypass bool
+
+	// OnFlush, if specified, is called after the buffer is flushed to WS,
+	// with the result of the flush and the number of bytes flushed. It's
+	// called with the BufferedWriteSyncer locked, so it must not call back
+	// into it.
+	OnFlush func(err error, bytes int)
+
 	// unexported fields for state
 	mu          sync.Mutex
 	initialized bool // whether initialize() has run
@@ -107,6 +143,24 @@ type BufferedWriteSyncer struct {
 	ticker      *time.Ticker
 	stop        chan struct{} // closed when flushLoop should stop
 	done        chan struct{} // closed when flushLoop has stopped
+
+	pending int // writes buffered since the last flush
+	stats   BufferedWriteSyncerStats
+}
+
+// BufferedWriteSyncerStats reports metrics about a BufferedWriteSyncer.
+type BufferedWriteSyncerStats struct {
+	// BufferedBytes is the number of bytes currently waiting to be flushed.
+	BufferedBytes int
+	// BufferedWrites is the number of writes currently waiting to be flushed.
+	BufferedWrites int
+	// Flushes is the number of times the buffer has been flushed.
+	Flushes uint64
+	// FailedFlushes is the number of flushes that returned an error. The data
+	// in a failed flush may have been partially or entirely lost.
+	FailedFlushes uint64
+	// FlushedBytes is the total number of bytes flushed successfully.
+	FlushedBytes uint64
 }
 
 // Write writes log data into buffer syncer directly, multiple Write calls will be batched,
@@ -115,39 +169,124 @@ func (s *BufferedWriteSyncer) Write(bs []byte) (int, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if !s.initialized {
-		size := s.Size
-		if size == 0 {
-			size = _defaultBufferSize
-		}
+	return s.write(bs)
+}
 
-		flushInterval := s.FlushInterval
-		if flushInterval == 0 {
-			flushInterval = _defaultFlushInterval
-		}
+// WriteLevel writes log data like Write, honoring BypassLevel, SyncOnBypass,
+// and FlushLevel for the given level.
+func (s *BufferedWriteSyncer) WriteLevel(lvl Level, bs []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-		if s.Clock == nil {
-			s.Clock = DefaultClock
-		}
+	if s.BypassLevel != nil && s.BypassLevel.Enabled(lvl) {
+		return s.bypass(bs)
+	}
+
+	n, err := s.write(bs)
+	if err == nil && s.FlushLevel != nil && s.FlushLevel.Enabled(lvl) {
+		err = s.flush()
+	}
+	return n, err
+}
+
+// Stats returns a snapshot of the BufferedWriteSyncer's metrics.
+func (s *BufferedWriteSyncer) Stats() BufferedWriteSyncerStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.stats
+	if s.initialized {
+		stats.BufferedBytes = s.writer.Buffered()
+		stats.BufferedWrites = s.pending
+	}
+	return stats
+}
+
+func (s *BufferedWriteSyncer) initialize() {
+	size := s.Size
+	if size == 0 {
+		size = _defaultBufferSize
+	}
+
+	flushInterval := s.FlushInterval
+	if flushInterval == 0 {
+		flushInterval = _defaultFlushInterval
+	}
+
+	if s.Clock == nil {
+		s.Clock = DefaultClock
+	}
 
-		s.ticker = s.Clock.NewTicker(flushInterval)
-		s.writer = bufio.NewWriterSize(s.WS, size)
-		s.stop = make(chan struct{})
-		s.done = make(chan struct{})
-		s.initialized = true
-		go s.flushLoop()
+	s.ticker = s.Clock.NewTicker(flushInterval)
+	s.writer = bufio.NewWriterSize(s.WS, size)
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	s.initialized = true
+	go s.flushLoop()
+}
+
+// write must be called with s.mu held.
+func (s *BufferedWriteSyncer) write(bs []byte) (int, error) {
+	if !s.initialized {
+		s.initialize()
 	}
 
 	// To avoid partial writes from being flushed, we manually flush the existing buffer if:
 	// * The current write doesn't fit into the buffer fully, and
 	// * The buffer is not empty (since bufio will not split large writes when the buffer is empty)
 	if len(bs) > s.writer.Available() && s.writer.Buffered() > 0 {
-		if err := s.writer.Flush(); err != nil {
+		if err := s.flush(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := s.writer.Write(bs)
+	if err != nil {
+		return n, err
+	}
+	s.pending++
+	if s.FlushCount > 0 && s.pending >= s.FlushCount {
+		err = s.flush()
+	}
+	return n, err
+}
+
+// bypass writes bs directly to WS after flushing the buffer. It must be
+// called with s.mu held.
+func (s *BufferedWriteSyncer) bypass(bs []byte) (int, error) {
+	if s.initialized {
+		if err := s.flush(); err != nil {
 			return 0, err
 		}
 	}
 
-	return s.writer.Write(bs)
+	n, err := s.WS.Write(bs)
+	if err == nil && s.SyncOnBypass {
+		err = s.WS.Sync()
+	}
+	return n, err
+}
+
+// flush writes the buffer to WS and records the result. It must be called
+// with s.mu held after initialization.
+func (s *BufferedWriteSyncer) flush() error {
+	n := s.writer.Buffered()
+	err := s.writer.Flush()
+	s.pending = 0
+	if n == 0 && err == nil {
+		return nil
+	}
+
+	s.stats.Flushes++
+	if err != nil {
+		s.stats.FailedFlushes++
+	} else {
+		s.stats.FlushedBytes += uint64(n)
+	}
+	if s.OnFlush != nil {
+		s.OnFlush(err, n)
+	}
+	return err
 }
 
 // Sync flushes buffered log data into disk directly.
@@ -157,12 +296,18 @@ func (s *BufferedWriteSyncer) Sync() error {
 
 	var err error
 	if s.initialized {
-		err = s.writer.Flush()
+		err = s.flush()
 	}
 
 	return multierr.Append(err, s.WS.Sync())
 }
 
+// Healthy reports the health of the wrapped WriteSyncer, if it implements
+// HealthChecker.
+func (s *BufferedWriteSyncer) Healthy() error {
+	return checkHealth(s.WS)
+}
+
 // flushLoop flushes the buffer at the configured interval until Stop is
 // called.
 func (s *BufferedWriteSyncer) flushLoop() {
@@ -135,6 +135,141 @@ func TestBufferWriter(t *testing.T) {
 	})
 }
 
+func TestBufferWriterFlushTriggers(t *testing.T) {
+	t.Run("count", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		ws := &BufferedWriteSyncer{WS: AddSync(buf), FlushCount: 2}
+		defer func() { assert.NoError(t, ws.Stop()) }()
+
+		requireWriteWorks(t, ws)
+		assert.Empty(t, buf.String(), "Expected first write to be buffered.")
+		requireWriteWorks(t, ws)
+		assert.Equal(t, "foofoo", buf.String(), "Expected second write to flush.")
+		requireWriteWorks(t, ws)
+		assert.Equal(t, "foofoo", buf.String(), "Expected count to reset after flush.")
+	})
+
+	t.Run("level", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		ws := &BufferedWriteSyncer{WS: AddSync(buf), FlushLevel: ErrorLevel}
+		defer func() { assert.NoError(t, ws.Stop()) }()
+
+		_, err := ws.WriteLevel(WarnLevel, []byte("warn "))
+		require.NoError(t, err, "Unexpected error writing.")
+		assert.Empty(t, buf.String(), "Expected warn to be buffered.")
+		_, err = ws.WriteLevel(ErrorLevel, []byte("error"))
+		require.NoError(t, err, "Unexpected error writing.")
+		assert.Equal(t, "warn error", buf.String(), "Expected error to flush.")
+	})
+
+	t.Run("level through core", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		ws := &BufferedWriteSyncer{WS: AddSync(buf), FlushLevel: ErrorLevel}
+		defer func() { assert.NoError(t, ws.Stop()) }()
+
+		core := NewCore(NewJSONEncoder(EncoderConfig{MessageKey: "m"}), Lock(ws), DebugLevel)
+		require.NoError(t, core.Write(Entry{Level: InfoLevel, Message: "info"}, nil))
+		assert.Empty(t, buf.String(), "Expected info to be buffered.")
+		require.NoError(t, core.Write(Entry{Level: ErrorLevel, Message: "error"}, nil))
+		assert.Equal(t, `{"m":"info"}`+"\n"+`{"m":"error"}`+"\n", buf.String(), "Expected error to flush.")
+	})
+}
+
+func TestBufferWriterBypass(t *testing.T) {
+	t.Run("bypass", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		sink := &writeSyncSpy{Writer: buf}
+		ws := &BufferedWriteSyncer{WS: sink, BypassLevel: WarnLevel}
+		defer func() { assert.NoError(t, ws.Stop()) }()
+
+		_, err := ws.WriteLevel(InfoLevel, []byte("info "))
+		require.NoError(t, err, "Unexpected error writing.")
+		assert.Empty(t, buf.String(), "Expected info to be buffered.")
+
+		_, err = ws.WriteLevel(WarnLevel, []byte("warn "))
+		require.NoError(t, err, "Unexpected error writing.")
+		assert.Equal(t, "info warn ", buf.String(), "Expected warn to bypass the buffer in order.")
+		assert.False(t, sink.Called(), "Expected no Sync without SyncOnBypass.")
+
+		_, err = ws.WriteLevel(DebugLevel, []byte("debug"))
+		require.NoError(t, err, "Unexpected error writing.")
+		assert.Equal(t, "info warn ", buf.String(), "Expected debug to be buffered.")
+	})
+
+	t.Run("bypass before first buffered write", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		ws := &BufferedWriteSyncer{WS: AddSync(buf), BypassLevel: WarnLevel}
+		_, err := ws.WriteLevel(ErrorLevel, []byte("error"))
+		require.NoError(t, err, "Unexpected error writing.")
+		assert.Equal(t, "error", buf.String(), "Expected error to bypass the buffer.")
+		assert.NoError(t, ws.Stop())
+	})
+
+	t.Run("sync on bypass", func(t *testing.T) {
+		sink := &writeSyncSpy{Writer: &bytes.Buffer{}}
+		ws := &BufferedWriteSyncer{WS: sink, BypassLevel: WarnLevel, SyncOnBypass: true}
+		defer func() { assert.NoError(t, ws.Stop()) }()
+
+		_, err := ws.WriteLevel(InfoLevel, []byte("info"))
+		require.NoError(t, err, "Unexpected error writing.")
+		assert.False(t, sink.Called(), "Expected no Sync for buffered entries.")
+		_, err = ws.WriteLevel(WarnLevel, []byte("warn"))
+		require.NoError(t, err, "Unexpected error writing.")
+		assert.True(t, sink.Called(), "Expected Sync after bypassing entries.")
+	})
+
+	t.Run("flush error", func(t *testing.T) {
+		ws := &BufferedWriteSyncer{WS: &ztest.FailWriter{}, BypassLevel: WarnLevel}
+		_, err := ws.WriteLevel(InfoLevel, []byte("info"))
+		require.NoError(t, err, "Unexpected error writing.")
+		_, err = ws.WriteLevel(WarnLevel, []byte("warn"))
+		assert.Error(t, err, "Expected flush error to be returned.")
+		assert.Error(t, ws.Stop(), "Expected stop to fail.")
+	})
+}
+
+func TestBufferWriterStats(t *testing.T) {
+	var (
+		flushErrs  []error
+		flushBytes []int
+	)
+	fail := &ztest.FailWriter{}
+	buf := &bytes.Buffer{}
+	ws := &BufferedWriteSyncer{
+		WS: NewMultiWriteSyncer(AddSync(buf)),
+		OnFlush: func(err error, n int) {
+			flushErrs = append(flushErrs, err)
+			flushBytes = append(flushBytes, n)
+		},
+	}
+	assert.Equal(t, BufferedWriteSyncerStats{}, ws.Stats(), "Unexpected stats before first write.")
+
+	requireWriteWorks(t, ws)
+	requireWriteWorks(t, ws)
+	assert.Equal(t, BufferedWriteSyncerStats{BufferedBytes: 6, BufferedWrites: 2}, ws.Stats(), "Unexpected stats after writes.")
+
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	require.NoError(t, ws.Sync(), "Unexpected error syncing empty buffer.")
+	assert.Equal(t, BufferedWriteSyncerStats{Flushes: 1, FlushedBytes: 6}, ws.Stats(), "Unexpected stats after sync.")
+	assert.NoError(t, ws.Stop())
+
+	ws = &BufferedWriteSyncer{
+		WS: fail,
+		OnFlush: func(err error, n int) {
+			flushErrs = append(flushErrs, err)
+			flushBytes = append(flushBytes, n)
+		},
+	}
+	requireWriteWorks(t, ws)
+	assert.Error(t, ws.Stop(), "Expected flush to fail.")
+	assert.Equal(t, BufferedWriteSyncerStats{Flushes: 1, FailedFlushes: 1}, ws.Stats(), "Unexpected stats after failed flush.")
+
+	require.Len(t, flushErrs, 2, "Unexpected number of OnFlush calls.")
+	assert.NoError(t, flushErrs[0], "Unexpected error in first flush.")
+	assert.Error(t, flushErrs[1], "Expected error in second flush.")
+	assert.Equal(t, []int{6, 3}, flushBytes, "Unexpected flushed sizes.")
+}
+
 func TestBufferWriterWithoutStart(t *testing.T) {
 	t.Run("stop", func(t *testing.T) {
 		ws := &BufferedWriteSyncer{WS: AddSync(new(bytes.Buffer))}
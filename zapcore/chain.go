@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+// A Middleware wraps a Core to change how entries are checked or written,
+// like NewSampler, NewFieldFilterCore, and NewKeyCaseCore do. Constructors
+// with extra arguments can be adapted with a closure:
+//
+//	sample := func(c Core) Core {
+//		return NewSampler(c, time.Second, 100, 100)
+//	}
+type Middleware func(Core) Core
+
+// Chain wraps core in the given middlewares. The first middleware is the
+// outermost, so it sees each entry first: Chain(core, a, b) is a(b(core)).
+// Nil middlewares are skipped.
+//
+// Listing wrappers in a single Chain call keeps their order visible in one
+// place. For example, sampling before redaction avoids redacting entries
+// that would be dropped anyway:
+//
+//	core = Chain(core, sample, redact)
+func Chain(core Core, middlewares ...Middleware) Core {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if m := middlewares[i]; m != nil {
+			core = m(core)
+		}
+	}
+	return core
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+
+	var applied []string
+	tag := func(name string) Middleware {
+		return func(c Core) Core {
+			applied = append(applied, name)
+			return c.With([]Field{makeInt64Field(name, 1)})
+		}
+	}
+	omitB := func(c Core) Core {
+		return NewFieldFilterCore(c, nil, []string{"b"})
+	}
+
+	core := Chain(obs, tag("outer"), nil, omitB, tag("inner"))
+	assert.Equal(t, []string{"inner", "outer"}, applied,
+		"Expected middlewares to be applied from last to first.")
+
+	ent := Entry{Level: InfoLevel, Message: "hello"}
+	if ce := core.Check(ent, nil); assert.NotNil(t, ce, "Expected entry to be checked.") {
+		ce.Write(makeInt64Field("a", 1), makeInt64Field("b", 2))
+	}
+	assert.Equal(t, []observer.LoggedEntry{{
+		Entry: ent,
+		Context: []Field{
+			makeInt64Field("inner", 1),
+			makeInt64Field("outer", 1),
+			makeInt64Field("a", 1),
+		},
+	}}, logs.AllUntimed(), "Expected the outer fields to pass through the filter.")
+
+	assert.Equal(t, obs, Chain(obs), "Expected Chain without middlewares to return the input.")
+}
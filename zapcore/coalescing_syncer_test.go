@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/internal/ztest"
+)
+
+// gatedWriter records each Write call, blocking the first one until
+// released.
+type gatedWriter struct {
+	ztest.Syncer
+
+	entered chan struct{}
+	release chan struct{}
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func newGatedWriter() *gatedWriter {
+	return &gatedWriter{
+		entered: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+}
+
+func (w *gatedWriter) Write(bs []byte) (int, error) {
+	w.mu.Lock()
+	w.calls = append(w.calls, string(bs))
+	first := len(w.calls) == 1
+	w.mu.Unlock()
+
+	if first {
+		w.entered <- struct{}{}
+		<-w.release
+	}
+	return len(bs), nil
+}
+
+func (w *gatedWriter) Calls() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.calls...)
+}
+
+func pendingWrites(s *coalescingSyncer) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pending == nil {
+		return 0
+	}
+	return len(s.pending.bufs)
+}
+
+func TestCoalescingSyncerPassesThrough(t *testing.T) {
+	sink := &ztest.Buffer{}
+	ws := NewCoalescingWriteSyncer(sink)
+	requireWriteWorks(t, ws)
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.True(t, sink.Called(), "Expected Sync to be forwarded.")
+}
+
+func TestCoalescingSyncerBatchesConcurrentWrites(t *testing.T) {
+	const followers = 5
+
+	sink := newGatedWriter()
+	ws := NewCoalescingWriteSyncer(sink).(*coalescingSyncer)
+
+	var wg sync.WaitGroup
+	write := func(s string) {
+		defer wg.Done()
+		n, err := ws.Write([]byte(s))
+		assert.NoError(t, err, "Unexpected error writing.")
+		assert.Equal(t, len(s), n, "Unexpected number of bytes written.")
+	}
+
+	wg.Add(1)
+	go write("leader\n")
+	<-sink.entered
+
+	// While the first write is blocked, queue up the next batch.
+	for i := 0; i < followers; i++ {
+		wg.Add(1)
+		go write("follower\n")
+	}
+	require.Eventually(t, func() bool {
+		return pendingWrites(ws) == followers
+	}, time.Second, time.Millisecond, "Expected followers to join the pending batch.")
+
+	close(sink.release)
+	wg.Wait()
+
+	calls := sink.Calls()
+	require.Len(t, calls, 2, "Expected concurrent writes to be coalesced.")
+	assert.Equal(t, "leader\n", calls[0], "Unexpected first write.")
+	assert.Equal(t, strings.Repeat("follower\n", followers), calls[1], "Unexpected coalesced write.")
+}
+
+func TestCoalescingSyncerErrors(t *testing.T) {
+	ws := NewCoalescingWriteSyncer(AddSync(&ztest.FailWriter{}))
+	n, err := ws.Write([]byte("foo"))
+	assert.Error(t, err, "Expected write errors to be returned.")
+	assert.Zero(t, n, "Expected no bytes to be reported written on failure.")
+}
+
+type connSyncer struct{ net.Conn }
+
+func (connSyncer) Sync() error { return nil }
+
+func TestCoalescingSyncerVectoredWrites(t *testing.T) {
+	tests := []struct {
+		desc string
+		wrap func(net.Conn) WriteSyncer
+	}{
+		{"conn", func(c net.Conn) WriteSyncer { return connSyncer{c} }},
+		{"AddSync", func(c net.Conn) WriteSyncer { return AddSync(c) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			client, server := net.Pipe()
+			defer server.Close()
+
+			got := make(chan string, 1)
+			go func() {
+				bs, _ := io.ReadAll(server)
+				got <- string(bs)
+			}()
+
+			s := NewCoalescingWriteSyncer(tt.wrap(client)).(*coalescingSyncer)
+			assert.NotNil(t, s.conn, "Expected the connection to be used for vectored writes.")
+			require.NoError(t, s.flush(net.Buffers{[]byte("a\n"), []byte("b\n"), []byte("c\n")}), "Unexpected error flushing.")
+			require.NoError(t, client.Close(), "Unexpected error closing.")
+			assert.Equal(t, "a\nb\nc\n", <-got, "Unexpected bytes written to connection.")
+		})
+	}
+
+	s := NewCoalescingWriteSyncer(AddSync(&bytes.Buffer{})).(*coalescingSyncer)
+	assert.Nil(t, s.conn, "Expected other writers to be written contiguously.")
+}
+
+func TestCoalescingSyncerConcurrency(t *testing.T) {
+	sink := &ztest.Buffer{}
+	ws := NewCoalescingWriteSyncer(sink)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, err := ws.Write([]byte("line\n"))
+				assert.NoError(t, err, "Unexpected error writing.")
+			}
+		}()
+	}
+	wg.Wait()
+
+	lines := sink.Lines()
+	sort.Strings(lines)
+	assert.Len(t, lines, 800, "Expected every write to be flushed.")
+	assert.Equal(t, "line", lines[0], "Expected writes not to interleave.")
+	assert.Equal(t, "line", lines[len(lines)-1], "Expected writes not to interleave.")
+}
+
+func TestCoalescingSyncerHealth(t *testing.T) {
+	err := errors.New("unhealthy")
+	ws := NewCoalescingWriteSyncer(&healthySpy{err: err})
+	assert.Equal(t, err, ws.(HealthChecker).Healthy(), "Expected health to be forwarded.")
+}
@@ -21,7 +21,10 @@
 package zapcore
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"go.uber.org/zap/buffer"
 	"go.uber.org/zap/internal/bufferpool"
@@ -97,10 +100,13 @@ func (c consoleEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer,
 		if c.CallerKey != "" && c.EncodeCaller != nil {
 			c.EncodeCaller(ent.Caller, arr)
 		}
-		if c.FunctionKey != "" {
+		if c.FunctionKey != "" && !(c.SkipEmptyFunction && ent.Caller.Function == "") {
 			arr.AppendString(ent.Caller.Function)
 		}
 	}
+	if ent.Event != "" && c.EventKey != "" {
+		arr.AppendString(ent.Event)
+	}
 	for i := range arr.elems {
 		if i > 0 {
 			line.AppendString(c.ConsoleSeparator)
@@ -110,13 +116,15 @@ func (c consoleEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer,
 	putSliceEncoder(arr)
 
 	// Add the message itself.
-	if c.MessageKey != "" {
+	if msg, ok := c.entryMessage(ent, fields); ok {
 		c.addSeparatorIfNecessary(line)
-		line.AppendString(ent.Message)
+		line.AppendString(msg)
 	}
 
-	// Add any structured context.
-	c.writeContext(line, fields)
+	// Add any structured context, followed by the fields written as blocks.
+	inline, blocks := c.splitMultiline(fields)
+	c.writeContext(line, inline)
+	writeMultiline(line, blocks)
 
 	// If there's no stacktrace key, honor that; this allows users to force
 	// single-line output.
@@ -155,3 +163,99 @@ func (c consoleEncoder) addSeparatorIfNecessary(line *buffer.Buffer) {
 		line.AppendString(c.ConsoleSeparator)
 	}
 }
+
+// Indentation of the keys and the lines of fields written as blocks.
+const (
+	_multilineKeyIndent = "  "
+	_multilineIndent    = "    "
+)
+
+// splitMultiline separates the fields that should be written as blocks
+// below the entry from those written inline. It only allocates if some
+// fields are written as blocks.
+func (c consoleEncoder) splitMultiline(fields []Field) (inline, blocks []Field) {
+	if len(c.ConsoleMultilineKeys) == 0 {
+		return fields, nil
+	}
+	n := 0
+	for i := range fields {
+		if c.isMultiline(fields[i]) {
+			n++
+		}
+	}
+	if n == 0 {
+		return fields, nil
+	}
+
+	inline = make([]Field, 0, len(fields)-n)
+	blocks = make([]Field, 0, n)
+	for _, f := range fields {
+		if c.isMultiline(f) {
+			blocks = append(blocks, f)
+		} else {
+			inline = append(inline, f)
+		}
+	}
+	return inline, blocks
+}
+
+func (c consoleEncoder) isMultiline(f Field) bool {
+	switch f.Type {
+	case StringType, ByteStringType, ReflectType:
+	default:
+		return false
+	}
+	for _, k := range c.ConsoleMultilineKeys {
+		if k == f.Key {
+			return true
+		}
+	}
+	return false
+}
+
+// writeMultiline writes each field as its key on a line of its own,
+// followed by its value's lines, indented.
+func writeMultiline(line *buffer.Buffer, blocks []Field) {
+	for _, f := range blocks {
+		val := multilineValue(f)
+		if val == "" {
+			continue
+		}
+		line.AppendByte('\n')
+		line.AppendString(_multilineKeyIndent)
+		line.AppendString(f.Key)
+		line.AppendByte(':')
+		for _, l := range strings.Split(strings.TrimRight(val, "\n"), "\n") {
+			line.AppendByte('\n')
+			line.AppendString(_multilineIndent)
+			line.AppendString(l)
+		}
+	}
+}
+
+// multilineValue returns the text to write for a field written as a block,
+// pretty-printing JSON.
+func multilineValue(f Field) string {
+	var val string
+	switch f.Type {
+	case StringType:
+		val = f.String
+	case ByteStringType:
+		val = string(f.Interface.([]byte))
+	case ReflectType:
+		b, err := json.MarshalIndent(f.Interface, "", "  ")
+		if err != nil {
+			return fmt.Sprintf("%+v", f.Interface)
+		}
+		return string(b)
+	}
+
+	trimmed := strings.TrimSpace(val)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		var pretty bytes.Buffer
+		if json.Indent(&pretty, []byte(trimmed), "", "  ") == nil {
+			return pretty.String()
+		}
+	}
+	return val
+}
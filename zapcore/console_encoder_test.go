@@ -133,3 +133,82 @@ func encoderTestEncoderConfig(separator string) EncoderConfig {
 	testEncoder.ConsoleSeparator = separator
 	return testEncoder
 }
+
+func TestConsoleMultilineKeys(t *testing.T) {
+	tests := []struct {
+		desc   string
+		fields []Field
+		want   string
+	}{
+		{
+			desc:   "no designated fields",
+			fields: []Field{{Key: "k", Type: StringType, String: "a\nb"}},
+			want:   "info\thello\t{\"k\": \"a\\nb\"}\nfake-stack\n",
+		},
+		{
+			desc: "string",
+			fields: []Field{
+				{Key: "k", Type: StringType, String: "v"},
+				{Key: "query", Type: StringType, String: "SELECT *\nFROM users\n"},
+			},
+			want: "info\thello\t{\"k\": \"v\"}\n" +
+				"  query:\n" +
+				"    SELECT *\n" +
+				"    FROM users\n" +
+				"fake-stack\n",
+		},
+		{
+			desc:   "byte string with JSON",
+			fields: []Field{{Key: "payload", Type: ByteStringType, Interface: []byte(`{"a":1,"b":[true]}`)}},
+			want: "info\thello\n" +
+				"  payload:\n" +
+				"    {\n" +
+				"      \"a\": 1,\n" +
+				"      \"b\": [\n" +
+				"        true\n" +
+				"      ]\n" +
+				"    }\n" +
+				"fake-stack\n",
+		},
+		{
+			desc:   "reflected",
+			fields: []Field{{Key: "payload", Type: ReflectType, Interface: map[string]int{"a": 1}}},
+			want: "info\thello\n" +
+				"  payload:\n" +
+				"    {\n" +
+				"      \"a\": 1\n" +
+				"    }\n" +
+				"fake-stack\n",
+		},
+		{
+			desc:   "invalid JSON",
+			fields: []Field{{Key: "payload", Type: StringType, String: "{not json"}},
+			want:   "info\thello\n  payload:\n    {not json\nfake-stack\n",
+		},
+		{
+			desc:   "empty",
+			fields: []Field{{Key: "query", Type: StringType}},
+			want:   "info\thello\nfake-stack\n",
+		},
+		{
+			desc:   "other types stay inline",
+			fields: []Field{{Key: "query", Type: Int64Type, Integer: 1}},
+			want:   "info\thello\t{\"query\": 1}\nfake-stack\n",
+		},
+	}
+
+	cfg := testEncoderConfig()
+	cfg.ConsoleMultilineKeys = []string{"query", "payload"}
+	enc := RNewConsoleEncoder(cfg)
+	ent := Entry{Level: InfoLevel, Message: "hello", Stack: "fake-stack"}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf, err := enc.EncodeEntry(ent, tt.fields)
+			if assert.NoError(t, err, "Unexpected console encoding error.") {
+				assert.Equal(t, tt.want, buf.String(), "Incorrect encoded entry.")
+			}
+			buf.Free()
+		})
+	}
+}
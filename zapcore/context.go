@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// ContextCore is an optional interface for Cores that use the
+// context.Context a message was logged with (see zap's Logger.Ctx). For
+// example, a ContextCore may extract trace IDs, honor deadlines when writing
+// to a remote sink, or route messages by tenant.
+//
+// If a CheckedEntry carries a Context, it calls WriteContext instead of Write
+// on the Cores that implement this interface. Cores that wrap other Cores and
+// register themselves with the CheckedEntry (rather than letting the wrapped
+// Cores register directly) should implement ContextCore to pass the context
+// along.
+type ContextCore interface {
+	Core
+
+	// WriteContext is like Write, but also receives the context the message
+	// was logged with.
+	WriteContext(ctx context.Context, ent Entry, fields []Field) error
+}
+
+// writeContext writes to core, passing ctx along if core is a ContextCore.
+// A nil ctx means that the message wasn't logged with a context.
+func writeContext(ctx context.Context, core Core, ent Entry, fields []Field) error {
+	if ctx != nil {
+		if cc, ok := core.(ContextCore); ok {
+			return cc.WriteContext(ctx, ent, fields)
+		}
+	}
+	return core.Write(ent, fields)
+}
+
+type fieldFrameKey struct{}
+
+// fieldFrame is one call's worth of fields added with ContextWithFields.
+// Frames form an immutable list from the innermost to the outermost call,
+// so contexts derived from one another share their common frames.
+type fieldFrame struct {
+	parent  *fieldFrame
+	fields  []Field
+	removed atomic.Bool
+}
+
+// ContextWithFields returns a copy of ctx carrying the given fields, which a
+// CheckedEntry adds ahead of the fields passed to Write when its Context is
+// ctx or a context derived from it. The returned function removes the fields
+// again, even from messages logged later with contexts that still carry
+// them; calling it more than once has no further effect.
+//
+// Since the fields are carried by the context, they're scoped to whatever
+// the context is scoped to, like a single request, rather than to a logger
+// shared by concurrent requests. They're only encoded for entries that are
+// written.
+func ContextWithFields(ctx context.Context, fields ...Field) (context.Context, func()) {
+	if len(fields) == 0 {
+		return ctx, func() {}
+	}
+	parent, _ := ctx.Value(fieldFrameKey{}).(*fieldFrame)
+	frame := &fieldFrame{
+		parent: parent,
+		fields: append([]Field(nil), fields...),
+	}
+	return context.WithValue(ctx, fieldFrameKey{}, frame), func() {
+		frame.removed.Store(true)
+	}
+}
+
+// FieldsFromContext returns the fields added to ctx with ContextWithFields
+// and not yet removed, outermost first.
+func FieldsFromContext(ctx context.Context) []Field {
+	return withContextFields(ctx, nil)
+}
+
+// withContextFields returns the fields carried by ctx followed by fields. If
+// ctx carries none, fields is returned unchanged.
+func withContextFields(ctx context.Context, fields []Field) []Field {
+	if ctx == nil {
+		return fields
+	}
+	top, _ := ctx.Value(fieldFrameKey{}).(*fieldFrame)
+	n := 0
+	for f := top; f != nil; f = f.parent {
+		if !f.removed.Load() {
+			n += len(f.fields)
+		}
+	}
+	if n == 0 {
+		return fields
+	}
+
+	all := make([]Field, n+len(fields))
+	copy(all[n:], fields)
+	for f := top; f != nil; f = f.parent {
+		if !f.removed.Load() {
+			n -= len(f.fields)
+			copy(all[n:], f.fields)
+		}
+	}
+	return all
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"context"
+	"testing"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKey struct{}
+
+// contextRecordingCore records the value of ctxKey in the contexts it's
+// written with, or "<none>" for plain writes.
+type contextRecordingCore struct {
+	Core
+
+	values *[]string
+}
+
+func newContextRecordingCore() (*contextRecordingCore, *[]string) {
+	obs, _ := observer.New(DebugLevel)
+	values := new([]string)
+	return &contextRecordingCore{Core: obs, values: values}, values
+}
+
+func (c *contextRecordingCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *contextRecordingCore) Write(Entry, []Field) error {
+	*c.values = append(*c.values, "<none>")
+	return nil
+}
+
+func (c *contextRecordingCore) WriteContext(ctx context.Context, _ Entry, _ []Field) error {
+	v, _ := ctx.Value(ctxKey{}).(string)
+	*c.values = append(*c.values, v)
+	return nil
+}
+
+func TestCheckedEntryContext(t *testing.T) {
+	core, values := newContextRecordingCore()
+	plain, logs := observer.New(DebugLevel)
+
+	tests := []struct {
+		desc string
+		wrap func(Core) Core
+		// Wrappers that implement ContextCore pass context.Background() to
+		// entries logged without a context.
+		wantPlain string
+	}{
+		{
+			desc:      "bare",
+			wrap:      func(c Core) Core { return c },
+			wantPlain: "<none>",
+		},
+		{
+			desc:      "tee",
+			wrap:      func(c Core) Core { return NewTee(c, plain) },
+			wantPlain: "<none>",
+		},
+		{
+			desc: "tee with options",
+			wrap: func(c Core) Core {
+				return NewTeeWithOptions([]Core{c, plain}, TeeErrorHandler(func(int, error) {}))
+			},
+		},
+		{
+			desc: "router",
+			wrap: func(c Core) Core {
+				return NewRouterCore(RouteTo(c).WithField(Field{Key: "k", Type: StringType, String: "v"}))
+			},
+		},
+		{
+			desc: "hooks",
+			wrap: func(c Core) Core {
+				return RegisterHooks(c, func(Entry) error { return nil })
+			},
+			wantPlain: "<none>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			*values = nil
+			wrapped := tt.wrap(core)
+			fields := []Field{{Key: "k", Type: StringType, String: "v"}}
+
+			ce := wrapped.Check(Entry{Level: InfoLevel}, nil)
+			ce.Context = context.WithValue(context.Background(), ctxKey{}, "tenant")
+			ce.Write(fields...)
+
+			wrapped.Check(Entry{Level: InfoLevel}, nil).Write(fields...)
+
+			assert.Equal(t, []string{"tenant", tt.wantPlain}, *values, "Unexpected contexts passed to ContextCore.")
+		})
+	}
+	assert.NotZero(t, logs.Len(), "Expected plain cores to keep working.")
+}
+
+func TestContextWithFields(t *testing.T) {
+	a, b := makeInt64Field("a", 1), makeInt64Field("b", 2)
+
+	assert.Empty(t, FieldsFromContext(context.Background()), "Expected no fields on a bare context.")
+
+	outer, undoOuter := ContextWithFields(context.Background(), a)
+	inner, undoInner := ContextWithFields(outer, b)
+	sibling, _ := ContextWithFields(outer, makeInt64Field("c", 3))
+	assert.Equal(t, []Field{a, b}, FieldsFromContext(inner), "Unexpected fields, outermost first.")
+	assert.Equal(t, []Field{a}, FieldsFromContext(outer), "Expected outer context not to see inner fields.")
+	assert.Equal(t, []Field{a, makeInt64Field("c", 3)}, FieldsFromContext(sibling), "Unexpected sibling fields.")
+
+	obs, logs := observer.New(DebugLevel)
+	ce := obs.Check(Entry{Level: InfoLevel}, nil)
+	ce.Context = inner
+	ce.Write(makeInt64Field("site", 0))
+	assert.Equal(t, []Field{a, b, makeInt64Field("site", 0)}, logs.All()[0].Context,
+		"Expected context fields ahead of log site fields.")
+
+	undoOuter()
+	assert.Equal(t, []Field{b}, FieldsFromContext(inner), "Expected removed fields to be skipped.")
+	undoInner()
+	assert.Empty(t, FieldsFromContext(inner), "Expected all fields to be removed.")
+}
@@ -20,6 +20,13 @@
 
 package zapcore
 
+import (
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/buffer"
+)
+
 // Core is a minimal, fast logger interface. It's designed for library authors
 // to wrap in a more user-friendly API.
 type Core interface {
@@ -67,6 +74,10 @@ type ioCore struct {
 	LevelEnabler
 	enc Encoder
 	out WriteSyncer
+
+	// deferred holds context that hasn't been added to enc yet; nil if
+	// there's none.
+	deferred *deferredContext
 }
 
 var (
@@ -79,7 +90,18 @@ func (c *ioCore) Level() Level {
 }
 
 func (c *ioCore) With(fields []Field) Core {
+	if d, ok := c.deferred.with(c.enc, fields); ok {
+		return &ioCore{
+			LevelEnabler: c.LevelEnabler,
+			enc:          c.enc,
+			out:          c.out,
+			deferred:     d,
+		}
+	}
 	clone := c.clone()
+	if c.deferred != nil {
+		addFields(clone.enc, c.deferred.fields)
+	}
 	addFields(clone.enc, fields)
 	return clone
 }
@@ -92,16 +114,21 @@ func (c *ioCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
 }
 
 func (c *ioCore) Write(ent Entry, fields []Field) error {
-	buf, err := c.enc.EncodeEntry(ent, fields)
+	buf, err := c.encoder().EncodeEntry(ent, fields)
 	if err != nil {
 		return err
 	}
-	_, err = c.out.Write(buf.Bytes())
+	return c.writeBuffer(ent.Level, buf)
+}
+
+// writeBuffer writes an encoded entry to the output and frees the buffer.
+func (c *ioCore) writeBuffer(lvl Level, buf *buffer.Buffer) error {
+	_, err := writeLevel(c.out, lvl, buf.Bytes())
 	buf.Free()
 	if err != nil {
 		return err
 	}
-	if ent.Level > ErrorLevel {
+	if lvl > ErrorLevel {
 		// Since we may be crashing the program, sync the output.
 		// Ignore Sync errors, pending a clean solution to issue #370.
 		_ = c.Sync()
@@ -110,9 +137,30 @@ func (c *ioCore) Write(ent Entry, fields []Field) error {
 }
 
 func (c *ioCore) Sync() error {
-	return c.out.Sync()
+	return multierr.Append(c.out.Sync(), checkHealth(c.out))
+}
+
+// encoder returns the core's Encoder with all of its context added.
+func (c *ioCore) encoder() Encoder {
+	if c.deferred == nil {
+		return c.enc
+	}
+	return c.deferred.encoder()
+}
+
+// withContext returns a copy of the core that uses the encoder and context
+// of another ioCore.
+func (c *ioCore) withContext(other *ioCore) *ioCore {
+	return &ioCore{
+		LevelEnabler: c.LevelEnabler,
+		enc:          other.enc,
+		out:          c.out,
+		deferred:     other.deferred,
+	}
 }
 
+// clone returns a copy of the core with a copy of its encoder and no
+// deferred context.
 func (c *ioCore) clone() *ioCore {
 	return &ioCore{
 		LevelEnabler: c.LevelEnabler,
@@ -120,3 +168,67 @@ func (c *ioCore) clone() *ioCore {
 		out:          c.out,
 	}
 }
+
+// _maxDeferredFields is the most context fields an ioCore holds on to
+// before cloning its encoder and encoding them.
+const _maxDeferredFields = 8
+
+// deferredContext is context added to an ioCore with With that hasn't been
+// encoded yet. Since cloning an encoder copies all of the context encoded so
+// far, deep chains of With calls that add a few fields each would otherwise
+// use memory quadratic in the depth of the chain, even if most loggers in
+// the chain never write an entry.
+//
+// The fields are added to a clone of the base encoder the first time the
+// core writes an entry; cores that share a deferredContext share the clone.
+type deferredContext struct {
+	fields []Field
+
+	once sync.Once
+	enc  Encoder
+}
+
+// with returns a deferredContext that holds d's fields followed by the given
+// ones, or false if they should be encoded right away instead. d may be nil.
+func (d *deferredContext) with(base Encoder, fields []Field) (*deferredContext, bool) {
+	var held []Field
+	if d != nil {
+		held = d.fields
+	}
+	if len(held)+len(fields) > _maxDeferredFields {
+		return nil, false
+	}
+	for i := range fields {
+		if !isDeferrable(fields[i]) {
+			return nil, false
+		}
+	}
+	all := make([]Field, 0, len(held)+len(fields))
+	all = append(all, held...)
+	all = append(all, fields...)
+	return &deferredContext{fields: all, enc: base}, true
+}
+
+func (d *deferredContext) encoder() Encoder {
+	d.once.Do(func() {
+		d.enc = d.enc.Clone()
+		addFields(d.enc, d.fields)
+	})
+	return d.enc
+}
+
+// isDeferrable reports whether a field encodes the same way no matter when
+// it's encoded. Fields that refer to values the caller may mutate, like
+// ObjectMarshalers, byte slices, and errors, are encoded by With right away.
+func isDeferrable(f Field) bool {
+	switch f.Type {
+	case BoolType, DurationType, TimeType, TimeFullType, StringType,
+		Complex128Type, Complex64Type, Float64Type, Float32Type,
+		Int64Type, Int32Type, Int16Type, Int8Type,
+		Uint64Type, Uint32Type, Uint16Type, Uint8Type, UintptrType,
+		SkipType:
+		return true
+	default:
+		return false
+	}
+}
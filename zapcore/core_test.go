@@ -21,6 +21,7 @@
 package zapcore_test
 
 import (
+	"bytes"
 	"errors"
 	"os"
 	"testing"
@@ -108,6 +109,51 @@ func TestIOCore(t *testing.T) {
 	)
 }
 
+func TestIOCoreWithDefersSmallContext(t *testing.T) {
+	var (
+		clones int
+		buf    bytes.Buffer
+	)
+	enc := &cloneCountingEncoder{Encoder: NewJSONEncoder(testEncoderConfig()), clones: &clones}
+	core := NewCore(enc, AddSync(&buf), DebugLevel)
+
+	parent := core.With([]Field{makeInt64Field("a", 1)}).With([]Field{{Key: "b", Type: StringType, String: "two"}})
+	child := parent.With([]Field{{Key: "c", Type: BoolType, Integer: 1}})
+	assert.Zero(t, clones, "Expected small primitive fields not to clone the encoder.")
+
+	write := func(c Core) {
+		if ce := c.Check(Entry{Level: InfoLevel, Message: "hello"}, nil); ce != nil {
+			ce.Write(makeInt64Field("d", 4))
+		}
+	}
+	write(child)
+	write(child)
+	write(parent)
+	assert.Equal(t, 2, clones, "Expected one clone per core that writes.")
+	assert.Equal(t,
+		`{"level":"info","msg":"hello","a":1,"b":"two","c":true,"d":4}`+"\n"+
+			`{"level":"info","msg":"hello","a":1,"b":"two","c":true,"d":4}`+"\n"+
+			`{"level":"info","msg":"hello","a":1,"b":"two","d":4}`+"\n",
+		buf.String(),
+		"Unexpected output.",
+	)
+
+	t.Run("too many fields", func(t *testing.T) {
+		clones = 0
+		c := core
+		for i := 0; i < 10; i++ {
+			c = c.With([]Field{makeInt64Field("k", i)})
+		}
+		assert.Equal(t, 1, clones, "Expected context to be encoded once it grows too large.")
+	})
+
+	t.Run("mutable fields", func(t *testing.T) {
+		clones = 0
+		core.With([]Field{{Key: "b", Type: BinaryType, Interface: []byte("foo")}})
+		assert.Equal(t, 1, clones, "Expected fields that reference mutable values to be encoded right away.")
+	})
+}
+
 func TestIOCoreSyncFail(t *testing.T) {
 	sink := &ztest.Discarder{}
 	err := errors.New("failed")
@@ -22,9 +22,13 @@ package zapcore
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"strings"
 	"time"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap/buffer"
 )
 
@@ -114,6 +118,14 @@ func EpochMillisTimeEncoder(t time.Time, enc PrimitiveArrayEncoder) {
 	enc.AppendFloat64(millis)
 }
 
+// EpochMillisIntTimeEncoder serializes a time.Time to an integer number of
+// milliseconds since the Unix epoch. Unlike EpochMillisTimeEncoder, it
+// doesn't lose precision for current times, and suits schemas that expect
+// an integer.
+func EpochMillisIntTimeEncoder(t time.Time, enc PrimitiveArrayEncoder) {
+	enc.AppendInt64(t.UnixMilli())
+}
+
 // EpochNanosTimeEncoder serializes a time.Time to an integer number of
 // nanoseconds since the Unix epoch.
 func EpochNanosTimeEncoder(t time.Time, enc PrimitiveArrayEncoder) {
@@ -159,6 +171,25 @@ func RFC3339NanoTimeEncoder(t time.Time, enc PrimitiveArrayEncoder) {
 	encodeTimeLayout(t, time.RFC3339Nano, enc)
 }
 
+// RFC3339PrecisionTimeEncoder returns a TimeEncoder that serializes a
+// time.Time to an RFC3339-formatted string with exactly the given number of
+// fractional second digits, from 0 to 9. Unlike RFC3339NanoTimeEncoder, it
+// keeps trailing zeros, so every timestamp has the same width and sorts
+// lexically. Values outside the range are clamped.
+func RFC3339PrecisionTimeEncoder(digits int) TimeEncoder {
+	if digits < 0 {
+		digits = 0
+	}
+	if digits > 9 {
+		digits = 9
+	}
+	layout := time.RFC3339
+	if digits > 0 {
+		layout = "2006-01-02T15:04:05." + "000000000"[:digits] + "Z07:00"
+	}
+	return TimeEncoderOfLayout(layout)
+}
+
 // TimeEncoderOfLayout returns TimeEncoder which serializes a time.Time using
 // given layout.
 func TimeEncoderOfLayout(layout string) TimeEncoder {
@@ -170,11 +201,24 @@ func TimeEncoderOfLayout(layout string) TimeEncoder {
 // UnmarshalText unmarshals text to a TimeEncoder.
 // "rfc3339nano" and "RFC3339Nano" are unmarshaled to RFC3339NanoTimeEncoder.
 // "rfc3339" and "RFC3339" are unmarshaled to RFC3339TimeEncoder.
+// "rfc3339.N" and "RFC3339.N", where N is a digit, are unmarshaled to
+// RFC3339PrecisionTimeEncoder(N); other values with these prefixes are
+// rejected.
 // "iso8601" and "ISO8601" are unmarshaled to ISO8601TimeEncoder.
 // "millis" is unmarshaled to EpochMillisTimeEncoder.
+// "millisint" is unmarshaled to EpochMillisIntTimeEncoder.
 // "nanos" is unmarshaled to EpochNanosEncoder.
 // Anything else is unmarshaled to EpochTimeEncoder.
 func (e *TimeEncoder) UnmarshalText(text []byte) error {
+	if s := string(text); strings.HasPrefix(s, "rfc3339.") || strings.HasPrefix(s, "RFC3339.") {
+		digits := s[len("rfc3339."):]
+		if len(digits) != 1 || digits[0] < '0' || digits[0] > '9' {
+			return fmt.Errorf("invalid RFC3339 precision %q in time encoder %q: must be a digit", digits, s)
+		}
+		*e = RFC3339PrecisionTimeEncoder(int(digits[0] - '0'))
+		return nil
+	}
+
 	switch string(text) {
 	case "rfc3339nano", "RFC3339Nano":
 		*e = RFC3339NanoTimeEncoder
@@ -184,6 +228,8 @@ func (e *TimeEncoder) UnmarshalText(text []byte) error {
 		*e = ISO8601TimeEncoder
 	case "millis":
 		*e = EpochMillisTimeEncoder
+	case "millisint":
+		*e = EpochMillisIntTimeEncoder
 	case "nanos":
 		*e = EpochNanosTimeEncoder
 	default:
@@ -332,6 +378,7 @@ type EncoderConfig struct {
 	// Set the keys used for each log entry. If any key is empty, that portion
 	// of the entry is omitted.
 	MessageKey     string `json:"messageKey" yaml:"messageKey"`
+	EventKey       string `json:"eventKey" yaml:"eventKey"`
 	LevelKey       string `json:"levelKey" yaml:"levelKey"`
 	TimeKey        string `json:"timeKey" yaml:"timeKey"`
 	NameKey        string `json:"nameKey" yaml:"nameKey"`
@@ -340,6 +387,11 @@ type EncoderConfig struct {
 	StacktraceKey  string `json:"stacktraceKey" yaml:"stacktraceKey"`
 	SkipLineEnding bool   `json:"skipLineEnding" yaml:"skipLineEnding"`
 	lineEnding     string `json:"lineEnding" yaml:"lineEnding"`
+	// Omit the message and function keys entirely when their values are
+	// empty, rather than writing out empty strings. Empty logger names, zero
+	// times, and undefined callers are always omitted.
+	SkipEmptyMessage  bool `json:"skipEmptyMessage" yaml:"skipEmptyMessage"`
+	SkipEmptyFunction bool `json:"skipEmptyFunction" yaml:"skipEmptyFunction"`
 	// Configure the primitive representations of common complex types. For
 	// example, some users may want all time.Times serialized as floating-point
 	// seconds since epoch, while others may prefer ISO8601 strings.
@@ -356,6 +408,86 @@ type EncoderConfig struct {
 	// Configures the field separator used by the console encoder. Defaults
 	// to tab.
 	ConsoleSeparator string `json:"consoleSeparator" yaml:"consoleSeparator"`
+	// ConsoleMultilineKeys lists the keys of fields that the console encoder
+	// writes as indented blocks below the entry, rather than as escaped
+	// strings in the entry's JSON context. It's meant for long, multi-line
+	// values like stack traces, SQL queries, and JSON payloads; strings
+	// holding JSON objects or arrays, and reflected values, are
+	// pretty-printed. Only string, byte string, and reflected fields passed
+	// at the log site are affected; context added with With has already
+	// been encoded.
+	ConsoleMultilineKeys []string `json:"consoleMultilineKeys" yaml:"consoleMultilineKeys"`
+	// MessageFormatter, if provided, computes the message written under
+	// MessageKey from the entry and the fields passed at the log site. The
+	// fields are still encoded as usual, so the message may embed selected
+	// field values for human readers without losing structure. Context added
+	// with With has already been encoded and isn't passed to the formatter.
+	MessageFormatter func(Entry, []Field) string `json:"-" yaml:"-"`
+	// By default, the JSON and console encoders recover from panics in
+	// ObjectMarshalers and ArrayMarshalers: the value is cut short, a
+	// "<key>Error" field holding "PANIC=<panic value>" is added, and the
+	// panic is reported with ReportInternalError. Set
+	// PropagateMarshalerPanics to let such panics crash the goroutine that
+	// logged instead, as earlier versions of zap did.
+	PropagateMarshalerPanics bool `json:"propagateMarshalerPanics" yaml:"propagateMarshalerPanics"`
+}
+
+// entryMessage returns the message to write for an entry under MessageKey,
+// and whether to write it at all. Events without a message omit it, unless
+// there's no EventKey, in which case the event's name is used instead so
+// that it isn't lost.
+func (cfg *EncoderConfig) entryMessage(ent Entry, fields []Field) (string, bool) {
+	if cfg.MessageKey == "" {
+		return "", false
+	}
+	msg := ent.Message
+	if cfg.MessageFormatter != nil {
+		msg = cfg.MessageFormatter(ent, fields)
+	}
+	if msg == "" && ent.Event != "" {
+		if cfg.EventKey == "" {
+			return ent.Event, true
+		}
+		return "", false
+	}
+	return msg, !(cfg.SkipEmptyMessage && msg == "")
+}
+
+// Validate reports every problem with the configuration that would make
+// encoders built from it fail or write malformed entries: time and caller
+// keys without functions to encode their values, and the same key used for
+// more than one part of the entry. Problems are combined with multierr.
+func (cfg EncoderConfig) Validate() error {
+	var err error
+	if cfg.TimeKey != "" && cfg.EncodeTime == nil {
+		err = multierr.Append(err, errors.New("missing EncodeTime in EncoderConfig"))
+	}
+	if cfg.CallerKey != "" && cfg.EncodeCaller == nil {
+		err = multierr.Append(err, errors.New("missing EncodeCaller in EncoderConfig"))
+	}
+
+	keys := []struct{ name, key string }{
+		{"MessageKey", cfg.MessageKey},
+		{"EventKey", cfg.EventKey},
+		{"LevelKey", cfg.LevelKey},
+		{"TimeKey", cfg.TimeKey},
+		{"NameKey", cfg.NameKey},
+		{"CallerKey", cfg.CallerKey},
+		{"FunctionKey", cfg.FunctionKey},
+		{"StacktraceKey", cfg.StacktraceKey},
+	}
+	seen := make(map[string]string, len(keys))
+	for _, k := range keys {
+		if k.key == OmitKey {
+			continue
+		}
+		if prev, ok := seen[k.key]; ok {
+			err = multierr.Append(err, fmt.Errorf("%v and %v are both %q in EncoderConfig", prev, k.name, k.key))
+			continue
+		}
+		seen[k.key] = k.name
+	}
+	return err
 }
 
 func (e *EncoderConfig) GetLineEnding() string {
@@ -427,6 +559,54 @@ type ArrayEncoder interface {
 	AppendReflected(value interface{}) error
 }
 
+// RawJSONObjectEncoder is an optional extension of ObjectEncoder for encoders
+// that can embed already-encoded JSON, such as the output of a
+// json.Marshaler, without decoding it first. Rather than asserting it
+// directly, use AddRawJSON, which falls back to AddReflected.
+type RawJSONObjectEncoder interface {
+	// AddRawJSON adds raw, which must hold a single valid JSON value, under
+	// the given key. It returns an error and adds nothing if raw isn't
+	// valid.
+	AddRawJSON(key string, raw []byte) error
+}
+
+// RawJSONArrayEncoder is the ArrayEncoder counterpart of
+// RawJSONObjectEncoder. Rather than asserting it directly, use
+// AppendRawJSON.
+type RawJSONArrayEncoder interface {
+	// AppendRawJSON appends raw, which must hold a single valid JSON value.
+	// It returns an error and appends nothing if raw isn't valid.
+	AppendRawJSON(raw []byte) error
+}
+
+var errInvalidRawJSON = errors.New("invalid raw JSON")
+
+// AddRawJSON adds raw, which must hold a single valid JSON value, to enc
+// under the given key. If enc implements RawJSONObjectEncoder, the JSON is
+// embedded directly; otherwise, it's validated and passed to AddReflected
+// as a json.RawMessage.
+func AddRawJSON(enc ObjectEncoder, key string, raw []byte) error {
+	if re, ok := enc.(RawJSONObjectEncoder); ok {
+		return re.AddRawJSON(key, raw)
+	}
+	if !json.Valid(raw) {
+		return errInvalidRawJSON
+	}
+	return enc.AddReflected(key, json.RawMessage(raw))
+}
+
+// AppendRawJSON appends raw, which must hold a single valid JSON value, to
+// enc. It's the ArrayEncoder counterpart of AddRawJSON.
+func AppendRawJSON(enc ArrayEncoder, raw []byte) error {
+	if re, ok := enc.(RawJSONArrayEncoder); ok {
+		return re.AppendRawJSON(raw)
+	}
+	if !json.Valid(raw) {
+		return errInvalidRawJSON
+	}
+	return enc.AppendReflected(json.RawMessage(raw))
+}
+
 // PrimitiveArrayEncoder is the subset of the ArrayEncoder interface that deals
 // only in Go's built-in types. It's included only so that Duration- and
 // TimeEncoders cannot trigger infinite recursion.
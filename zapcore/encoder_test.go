@@ -22,6 +22,7 @@ package zapcore_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -268,6 +269,114 @@ func TestEncoderConfiguration(t *testing.T) {
 			expectedJSON:    `{"L":"info","T":0,"N":"main","C":"foo.go:42","F":"foo.Foo","M":"hello"}` + "\n",
 			expectedConsole: "0\tinfo\tmain\tfoo.go:42\tfoo.Foo\thello\n",
 		},
+		{
+			desc: "skip empty message if SkipEmptyMessage is 'true'",
+			cfg: EncoderConfig{
+				LevelKey:         "L",
+				TimeKey:          "T",
+				MessageKey:       "M",
+				NameKey:          "N",
+				CallerKey:        "C",
+				FunctionKey:      "F",
+				StacktraceKey:    "S",
+				LineEnding:       base.LineEnding,
+				SkipEmptyMessage: true,
+				EncodeTime:       base.EncodeTime,
+				EncodeDuration:   base.EncodeDuration,
+				EncodeLevel:      base.EncodeLevel,
+				EncodeCaller:     base.EncodeCaller,
+			},
+			amendEntry: func(ent Entry) Entry {
+				ent.Message = ""
+				return ent
+			},
+			expectedJSON:    `{"L":"info","T":0,"N":"main","C":"foo.go:42","F":"foo.Foo","S":"fake-stack"}` + "\n",
+			expectedConsole: "0\tinfo\tmain\tfoo.go:42\tfoo.Foo\nfake-stack\n",
+		},
+		{
+			desc: "keep non-empty message if SkipEmptyMessage is 'true'",
+			cfg: EncoderConfig{
+				LevelKey:         "L",
+				TimeKey:          "T",
+				MessageKey:       "M",
+				NameKey:          "N",
+				CallerKey:        "C",
+				FunctionKey:      "F",
+				StacktraceKey:    "S",
+				LineEnding:       base.LineEnding,
+				SkipEmptyMessage: true,
+				EncodeTime:       base.EncodeTime,
+				EncodeDuration:   base.EncodeDuration,
+				EncodeLevel:      base.EncodeLevel,
+				EncodeCaller:     base.EncodeCaller,
+			},
+			expectedJSON:    `{"L":"info","T":0,"N":"main","C":"foo.go:42","F":"foo.Foo","M":"hello","S":"fake-stack"}` + "\n",
+			expectedConsole: "0\tinfo\tmain\tfoo.go:42\tfoo.Foo\thello\nfake-stack\n",
+		},
+		{
+			desc: "write events under EventKey and omit their empty messages",
+			cfg: func() EncoderConfig {
+				cfg := base
+				cfg.EventKey = "event"
+				return cfg
+			}(),
+			amendEntry: func(ent Entry) Entry {
+				ent.Message = ""
+				ent.Event = "user.signup"
+				return ent
+			},
+			expectedJSON:    `{"level":"info","ts":0,"name":"main","caller":"foo.go:42","func":"foo.Foo","event":"user.signup","stacktrace":"fake-stack"}` + "\n",
+			expectedConsole: "0\tinfo\tmain\tfoo.go:42\tfoo.Foo\tuser.signup\nfake-stack\n",
+		},
+		{
+			desc: "keep event messages",
+			cfg: func() EncoderConfig {
+				cfg := base
+				cfg.EventKey = "event"
+				return cfg
+			}(),
+			amendEntry: func(ent Entry) Entry {
+				ent.Event = "user.signup"
+				return ent
+			},
+			expectedJSON:    `{"level":"info","ts":0,"name":"main","caller":"foo.go:42","func":"foo.Foo","event":"user.signup","msg":"hello","stacktrace":"fake-stack"}` + "\n",
+			expectedConsole: "0\tinfo\tmain\tfoo.go:42\tfoo.Foo\tuser.signup\thello\nfake-stack\n",
+		},
+		{
+			desc: "write event names as messages without EventKey",
+			cfg:  base,
+			amendEntry: func(ent Entry) Entry {
+				ent.Message = ""
+				ent.Event = "user.signup"
+				return ent
+			},
+			expectedJSON:    `{"level":"info","ts":0,"name":"main","caller":"foo.go:42","func":"foo.Foo","msg":"user.signup","stacktrace":"fake-stack"}` + "\n",
+			expectedConsole: "0\tinfo\tmain\tfoo.go:42\tfoo.Foo\tuser.signup\nfake-stack\n",
+		},
+		{
+			desc: "skip empty function if SkipEmptyFunction is 'true'",
+			cfg: EncoderConfig{
+				LevelKey:          "L",
+				TimeKey:           "T",
+				MessageKey:        "M",
+				NameKey:           "N",
+				CallerKey:         "C",
+				FunctionKey:       "F",
+				StacktraceKey:     "S",
+				LineEnding:        base.LineEnding,
+				SkipEmptyFunction: true,
+				EncodeTime:        base.EncodeTime,
+				EncodeDuration:    base.EncodeDuration,
+				EncodeLevel:       base.EncodeLevel,
+				EncodeCaller:      base.EncodeCaller,
+			},
+			amendEntry: func(ent Entry) Entry {
+				ent.Caller.Function = ""
+				return ent
+			},
+			expectedJSON:    `{"L":"info","T":0,"N":"main","C":"foo.go:42","M":"hello","S":"fake-stack"}` + "\n",
+			expectedConsole: "0\tinfo\tmain\tfoo.go:42\thello\nfake-stack\n",
+		},
 		{
 			desc: "use the supplied EncodeTime, for both the entry and any times added",
 			cfg: EncoderConfig{
@@ -561,6 +670,52 @@ func TestEncoderConfiguration(t *testing.T) {
 	}
 }
 
+func TestEncoderMessageFormatter(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.MessageFormatter = func(ent Entry, fields []Field) string {
+		var method, path string
+		for _, f := range fields {
+			switch f.Key {
+			case "method":
+				method = f.String
+			case "path":
+				path = f.String
+			}
+		}
+		return fmt.Sprintf("%s %s %s", ent.Message, method, path)
+	}
+	fields := []Field{
+		{Key: "method", Type: StringType, String: "GET"},
+		{Key: "path", Type: StringType, String: "/users"},
+	}
+
+	tests := []struct {
+		desc string
+		enc  Encoder
+		want string
+	}{
+		{
+			desc: "json",
+			enc:  NewJSONEncoder(cfg),
+			want: `{"level":"info","ts":0,"name":"main","caller":"foo.go:42","func":"foo.Foo","msg":"hello GET /users","method":"GET","path":"/users","stacktrace":"fake-stack"}` + "\n",
+		},
+		{
+			desc: "console",
+			enc:  RNewConsoleEncoder(cfg),
+			want: "0\tinfo\tmain\tfoo.go:42\tfoo.Foo\thello GET /users\t" + `{"method": "GET", "path": "/users"}` + "\nfake-stack\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf, err := tt.enc.EncodeEntry(_testEntry, fields)
+			require.NoError(t, err, "Unexpected error encoding entry.")
+			defer buf.Free()
+			assert.Equal(t, tt.want, buf.String(), "Unexpected output.")
+		})
+	}
+}
+
 func TestLevelEncoders(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -601,6 +756,11 @@ func TestTimeEncoders(t *testing.T) {
 		{"timeEncoder: RFC3339", "1970-01-01T00:01:40Z"},
 		{"timeEncoder: rfc3339nano", "1970-01-01T00:01:40.050005Z"},
 		{"timeEncoder: RFC3339Nano", "1970-01-01T00:01:40.050005Z"},
+		{"timeEncoder: rfc3339.0", "1970-01-01T00:01:40Z"},
+		{"timeEncoder: rfc3339.3", "1970-01-01T00:01:40.050Z"},
+		{"timeEncoder: RFC3339.6", "1970-01-01T00:01:40.050005Z"},
+		{"timeEncoder: rfc3339.9", "1970-01-01T00:01:40.050005000Z"},
+		{"timeEncoder: millisint", int64(100050)},
 	}
 
 	for _, tt := range tests {
@@ -616,10 +776,44 @@ func TestTimeEncoders(t *testing.T) {
 	}
 }
 
+func TestRFC3339PrecisionTimeEncoder(t *testing.T) {
+	moment := time.Date(2026, time.October, 16, 12, 30, 0, 120000000, time.FixedZone("", -7*60*60))
+	tests := []struct {
+		digits int
+		want   string
+	}{
+		{-1, "2026-10-16T12:30:00-07:00"},
+		{0, "2026-10-16T12:30:00-07:00"},
+		{2, "2026-10-16T12:30:00.12-07:00"},
+		{3, "2026-10-16T12:30:00.120-07:00"},
+		{12, "2026-10-16T12:30:00.120000000-07:00"},
+	}
+	for _, tt := range tests {
+		assertAppended(
+			t,
+			tt.want,
+			func(arr ArrayEncoder) { RFC3339PrecisionTimeEncoder(tt.digits)(moment, arr) },
+			"Unexpected output with %d digits.", tt.digits,
+		)
+	}
+}
+
+func TestEpochMillisIntTimeEncoder(t *testing.T) {
+	assertAppended(
+		t,
+		int64(-1),
+		func(arr ArrayEncoder) { EpochMillisIntTimeEncoder(time.Unix(0, -500000), arr) },
+		"Expected times before the epoch to round down.",
+	)
+}
+
 func TestTimeEncodersWrongYAML(t *testing.T) {
 	tests := []string{
-		"timeEncoder: [1, 2, 3]", // wrong type
-		"timeEncoder: {foo:bar",  // broken yaml
+		"timeEncoder: [1, 2, 3]",  // wrong type
+		"timeEncoder: {foo:bar",   // broken yaml
+		"timeEncoder: rfc3339.",   // missing precision
+		"timeEncoder: rfc3339.x",  // non-numeric precision
+		"timeEncoder: rfc3339.10", // too precise
 	}
 	for _, tt := range tests {
 		cfg := EncoderConfig{}
@@ -634,6 +828,7 @@ func TestTimeEncodersParseFromJSON(t *testing.T) {
 		expected interface{} // output of serializing moment
 	}{
 		{`{"timeEncoder": "iso8601"}`, "1970-01-01T00:01:40.050Z"},
+		{`{"timeEncoder": "rfc3339.3"}`, "1970-01-01T00:01:40.050Z"},
 		{`{"timeEncoder": {"layout": "06/01/02 03:04pm"}}`, "70/01/01 12:01am"},
 	}
 
@@ -732,3 +927,142 @@ func assertAppended(t testing.TB, expected interface{}, f func(ArrayEncoder), ms
 	require.Equal(t, 1, len(arr), "Expected to append exactly one element to array.")
 	assert.Equal(t, expected, arr[0], msgAndArgs...)
 }
+
+// plainObjectEncoder hides any optional extensions of the wrapped encoder.
+type plainObjectEncoder struct {
+	ObjectEncoder
+}
+
+func TestAddRawJSON(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    string
+		want    string
+		wantErr bool
+	}{
+		{desc: "object", give: `{"a":[1,2]}`, want: `{"k":{"a":[1,2]}}`},
+		{desc: "scalar", give: `42`, want: `{"k":42}`},
+		{desc: "multi-line", give: "{\n  \"a\": 1\n}", want: `{"k":{"a":1}}`},
+		{desc: "invalid", give: `{"a":`, want: `{}`, wantErr: true},
+		{desc: "invalid multi-line", give: "{\n", want: `{}`, wantErr: true},
+		{desc: "empty", give: ``, want: `{}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := NewJSONEncoder(EncoderConfig{})
+			err := AddRawJSON(enc, "k", []byte(tt.give))
+			if tt.wantErr {
+				assert.Error(t, err, "Expected invalid JSON to be rejected.")
+			} else {
+				assert.NoError(t, err, "Unexpected error adding raw JSON.")
+			}
+			buf, err := enc.EncodeEntry(Entry{}, nil)
+			require.NoError(t, err, "Unexpected error encoding.")
+			assert.Equal(t, tt.want+"\n", buf.String(), "Unexpected JSON.")
+
+			m := NewMapObjectEncoder()
+			err = AddRawJSON(plainObjectEncoder{m}, "k", []byte(tt.give))
+			if tt.wantErr {
+				assert.Error(t, err, "Expected the fallback to reject invalid JSON.")
+				assert.Empty(t, m.Fields, "Expected nothing to be added for invalid JSON.")
+			} else {
+				assert.NoError(t, err, "Unexpected error from the fallback.")
+				assert.Equal(t, json.RawMessage(tt.give), m.Fields["k"], "Expected the fallback to use AddReflected.")
+			}
+		})
+	}
+}
+
+func TestAppendRawJSON(t *testing.T) {
+	enc := NewJSONEncoder(EncoderConfig{})
+	err := enc.AddArray("k", ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+		arr.AppendInt(1)
+		if err := AppendRawJSON(arr, []byte(`{"b":true}`)); err != nil {
+			return err
+		}
+		assert.Error(t, AppendRawJSON(arr, []byte(`nope`)), "Expected invalid JSON to be rejected.")
+		return AppendRawJSON(arr, []byte("[\n1]"))
+	}))
+	require.NoError(t, err, "Unexpected error adding array.")
+	buf, err := enc.EncodeEntry(Entry{}, nil)
+	require.NoError(t, err, "Unexpected error encoding.")
+	assert.Equal(t, `{"k":[1,{"b":true},[1]]}`+"\n", buf.String(), "Unexpected JSON.")
+
+	m := NewMapObjectEncoder()
+	err = m.AddArray("k", ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+		return AppendRawJSON(arr, []byte(`"x"`))
+	}))
+	require.NoError(t, err, "Unexpected error adding array.")
+	assert.Equal(t, []interface{}{json.RawMessage(`"x"`)}, m.Fields["k"], "Unexpected array.")
+}
+
+func TestRawJSONField(t *testing.T) {
+	m := NewMapObjectEncoder()
+	Field{Key: "k", Type: RawJSONType, Interface: []byte(`{"a":1}`)}.AddTo(m)
+	Field{Key: "bad", Type: RawJSONType, Interface: []byte(`{`)}.AddTo(m)
+	assert.Equal(t, map[string]interface{}{
+		"k":        json.RawMessage(`{"a":1}`),
+		"badError": "invalid raw JSON",
+	}, m.Fields, "Unexpected fields.")
+}
+
+func TestEncoderConfigValidate(t *testing.T) {
+	tests := []struct {
+		desc string
+		give func(*EncoderConfig)
+		want []string
+	}{
+		{
+			desc: "valid",
+			give: func(*EncoderConfig) {},
+		},
+		{
+			desc: "omitted keys",
+			give: func(cfg *EncoderConfig) {
+				cfg.TimeKey = OmitKey
+				cfg.CallerKey = OmitKey
+				cfg.EncodeTime = nil
+				cfg.EncodeCaller = nil
+			},
+		},
+		{
+			desc: "missing encoders",
+			give: func(cfg *EncoderConfig) {
+				cfg.EncodeTime = nil
+				cfg.EncodeCaller = nil
+			},
+			want: []string{
+				"missing EncodeTime in EncoderConfig",
+				"missing EncodeCaller in EncoderConfig",
+			},
+		},
+		{
+			desc: "duplicate keys",
+			give: func(cfg *EncoderConfig) {
+				cfg.LevelKey = "msg"
+				cfg.NameKey = "ts"
+			},
+			want: []string{
+				`MessageKey and LevelKey are both "msg" in EncoderConfig`,
+				`TimeKey and NameKey are both "ts" in EncoderConfig`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := testEncoderConfig()
+			tt.give(&cfg)
+			err := cfg.Validate()
+			if len(tt.want) == 0 {
+				assert.NoError(t, err, "Unexpected validation error.")
+				return
+			}
+			require.Error(t, err, "Expected a validation error.")
+			for _, msg := range tt.want {
+				assert.Contains(t, err.Error(), msg, "Expected problem to be reported.")
+			}
+		})
+	}
+}
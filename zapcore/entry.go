@@ -21,6 +21,7 @@
 package zapcore
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"strings"
@@ -147,6 +148,10 @@ type Entry struct {
 	Message    string
 	Caller     EntryCaller
 	Stack      string
+
+	// Event names the event that the entry records, for entries logged
+	// with the Logger's Event method. Events needn't have a message.
+	Event string
 }
 
 // CheckWriteHook is a custom action that may be executed after an entry is
@@ -210,14 +215,19 @@ var _ CheckWriteHook = CheckWriteAction(0)
 type CheckedEntry struct {
 	Entry
 	ErrorOutput WriteSyncer
-	dirty       bool // best-effort detection of pool misuse
-	after       CheckWriteHook
-	cores       []Core
+	// Context is the context the entry was logged with, if any. It's passed
+	// to Cores that implement ContextCore, and fields added to it with
+	// ContextWithFields are written with the entry.
+	Context context.Context
+	dirty   bool // best-effort detection of pool misuse
+	after   CheckWriteHook
+	cores   []Core
 }
 
 func (ce *CheckedEntry) reset() {
 	ce.Entry = Entry{}
 	ce.ErrorOutput = nil
+	ce.Context = nil
 	ce.dirty = false
 	ce.after = nil
 	for i := range ce.cores {
@@ -249,13 +259,15 @@ func (ce *CheckedEntry) Write(fields ...Field) {
 			)
 			_ = ce.ErrorOutput.Sync() // ignore error
 		}
+		ReportInternalError(ErrUnsafeReuse, ce.Entry)
 		return
 	}
 	ce.dirty = true
 
+	fields = withContextFields(ce.Context, fields)
 	var err error
 	for i := range ce.cores {
-		err = multierr.Append(err, ce.cores[i].Write(ce.Entry, fields))
+		err = multierr.Append(err, writeContext(ce.Context, ce.cores[i], ce.Entry, fields))
 	}
 	if err != nil && ce.ErrorOutput != nil {
 		_, _ = fmt.Fprintf(
@@ -266,6 +278,9 @@ func (ce *CheckedEntry) Write(fields ...Field) {
 		)
 		_ = ce.ErrorOutput.Sync() // ignore error
 	}
+	if err != nil {
+		ReportInternalError(err, ce.Entry)
+	}
 
 	hook := ce.after
 	if hook != nil {
@@ -274,6 +289,50 @@ func (ce *CheckedEntry) Write(fields ...Field) {
 	putCheckedEntry(ce)
 }
 
+// WriteTo writes the entry, with the given fields, to a single Core. Unlike
+// Write, it doesn't return the CheckedEntry to the pool or run its After
+// hook, so the entry can be sent to other destinations with different fields
+// before the final call to Write:
+//
+//	if ce := logger.Check(zap.InfoLevel, "request served"); ce != nil {
+//		ce.WriteTo(summaryCore, summaryFields...)
+//		ce.Write(detailFields...)
+//	}
+//
+// WriteTo doesn't consult the Core's Enabled or Check methods, and it
+// returns errors rather than reporting them to ErrorOutput. Calling it on a
+// nil CheckedEntry does nothing, and calling it after Write returns
+// ErrUnsafeReuse.
+func (ce *CheckedEntry) WriteTo(core Core, fields ...Field) error {
+	if ce == nil {
+		return nil
+	}
+	if ce.dirty {
+		ReportInternalError(ErrUnsafeReuse, ce.Entry)
+		return ErrUnsafeReuse
+	}
+	return writeContext(ce.Context, core, ce.Entry, withContextFields(ce.Context, fields))
+}
+
+// Clone returns a copy of the CheckedEntry with the same Entry, Cores,
+// ErrorOutput, and Context, so that the entry can be written more than once
+// with different fields. The copy doesn't carry the After hook, which runs
+// only when the original is written: write the clone first, since hooks
+// like WriteThenFatal don't return. Like the original, the clone is returned
+// to a pool when it's written, and must be written exactly once. Cloning a
+// nil CheckedEntry returns nil.
+func (ce *CheckedEntry) Clone() *CheckedEntry {
+	if ce == nil {
+		return nil
+	}
+	clone := getCheckedEntry()
+	clone.Entry = ce.Entry
+	clone.ErrorOutput = ce.ErrorOutput
+	clone.Context = ce.Context
+	clone.cores = append(clone.cores, ce.cores...)
+	return clone
+}
+
 // AddCore adds a Core that has agreed to log this CheckedEntry. It's intended to be
 // used by Core.Check implementations, and is safe to call on nil CheckedEntry
 // references.
@@ -286,6 +345,15 @@ func (ce *CheckedEntry) AddCore(ent Entry, core Core) *CheckedEntry {
 	return ce
 }
 
+// numCores returns the number of Cores that agreed to log the entry; it's
+// safe to call on nil CheckedEntry references.
+func (ce *CheckedEntry) numCores() int {
+	if ce == nil {
+		return 0
+	}
+	return len(ce.cores)
+}
+
 // Should sets this CheckedEntry's CheckWriteAction, which controls whether a
 // Core will panic or fatal after writing this log entry. Like AddCore, it's
 // safe to call on nil CheckedEntry references.
@@ -0,0 +1,461 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotJSONEntry is returned by EntryDecoder.DecodeJSON when the input
+// isn't a JSON object.
+var ErrNotJSONEntry = errors.New("not a JSON log entry")
+
+// An EntryDecoder parses the output of zap's JSON and console encoders back
+// into Entries and Fields, using the EncoderConfig the output was written
+// with to recognize the entry's metadata. This lets tools process logs
+// written by zap, tests make assertions against real output, and logs be
+// re-emitted through other Cores.
+//
+// Decoding is best-effort, since encoders don't preserve every detail of
+// what was logged:
+//
+//   - Times may be strings in RFC 3339 or ISO 8601 format, or numbers of
+//     seconds, milliseconds, microseconds, or nanoseconds since the Unix
+//     epoch, told apart by their magnitude.
+//   - Fields are decoded by JSON type: strings become String fields, numbers
+//     become Int64, Uint64, or Float64 fields, and so on. Objects and arrays
+//     (including namespaces) become ObjectMarshaler and ArrayMarshaler
+//     fields that re-encode them as they were written.
+//   - In console output, the logger name, caller, function, and event are
+//     only written when set, so they're recognized positionally: a column
+//     shaped like a caller ("file.go:42") anchors the name before it and the
+//     function after it.
+//
+// Re-encoding a decoded entry with the same EncoderConfig reproduces the
+// original output for entries logged with zap's standard encoders.
+type EntryDecoder struct {
+	cfg EncoderConfig
+}
+
+// NewEntryDecoder builds an EntryDecoder for output written with the given
+// EncoderConfig.
+func NewEntryDecoder(cfg EncoderConfig) *EntryDecoder {
+	if cfg.ConsoleSeparator == "" {
+		cfg.ConsoleSeparator = "\t"
+	}
+	return &EntryDecoder{cfg: cfg}
+}
+
+// Decode decodes a single entry written by either the JSON or the console
+// encoder, using DecodeJSON for input that looks like a JSON object and
+// DecodeConsole for anything else.
+func (d *EntryDecoder) Decode(line []byte) (Entry, []Field, error) {
+	if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 && trimmed[0] == '{' {
+		return d.DecodeJSON(line)
+	}
+	return d.DecodeConsole(line)
+}
+
+// DecodeJSON decodes a single entry written by the JSON encoder.
+func (d *EntryDecoder) DecodeJSON(line []byte) (Entry, []Field, error) {
+	obj, err := decodeJSONObject(line)
+	if err != nil {
+		return Entry{}, nil, err
+	}
+
+	var (
+		ent    Entry
+		fields []Field
+		seen   = make(map[string]bool, 8)
+	)
+	for _, m := range obj {
+		if d.decodeMeta(&ent, seen, m.Key, m.Value) {
+			continue
+		}
+		fields = append(fields, jsonField(m.Key, m.Value))
+	}
+	return ent, fields, nil
+}
+
+// decodeMeta records the value of a metadata key in ent, reporting whether
+// it did so. Only the first occurrence of each key counts, and values that
+// can't be parsed are left to be decoded as fields.
+func (d *EntryDecoder) decodeMeta(ent *Entry, seen map[string]bool, key string, v interface{}) bool {
+	if key == "" || seen[key] {
+		return false
+	}
+
+	s, isString := v.(string)
+	ok := false
+	switch key {
+	case d.cfg.MessageKey:
+		ent.Message, ok = s, isString
+	case d.cfg.LevelKey:
+		if isString {
+			ent.Level, ok = parseLevel(s)
+		}
+	case d.cfg.TimeKey:
+		ent.Time, ok = parseTime(v)
+	case d.cfg.NameKey:
+		ent.LoggerName, ok = s, isString
+	case d.cfg.CallerKey:
+		if isString {
+			ent.Caller, ok = parseCaller(s, ent.Caller.Function)
+		}
+	case d.cfg.FunctionKey:
+		if isString {
+			ent.Caller.Function, ok = s, true
+		}
+	case d.cfg.EventKey:
+		ent.Event, ok = s, isString
+	case d.cfg.StacktraceKey:
+		ent.Stack, ok = s, isString
+	}
+	if ok {
+		seen[key] = true
+	}
+	return ok
+}
+
+// DecodeConsole decodes a single entry written by the console encoder. If
+// the entry has a stack trace, line must include it.
+func (d *EntryDecoder) DecodeConsole(line []byte) (Entry, []Field, error) {
+	text := strings.TrimRight(string(line), "\r\n")
+	var ent Entry
+	if first, stack, ok := strings.Cut(text, "\n"); ok {
+		text = first
+		if d.cfg.StacktraceKey != "" {
+			ent.Stack = stack
+		}
+	}
+
+	cols := strings.Split(text, d.cfg.ConsoleSeparator)
+	var fields []Field
+	if last := cols[len(cols)-1]; len(cols) > 1 && strings.HasPrefix(last, "{") {
+		if obj, err := decodeJSONObject([]byte(last)); err == nil {
+			cols = cols[:len(cols)-1]
+			fields = make([]Field, 0, len(obj))
+			for _, m := range obj {
+				fields = append(fields, jsonField(m.Key, m.Value))
+			}
+		}
+	}
+
+	if d.cfg.TimeKey != "" && len(cols) > 1 {
+		if t, ok := parseTime(consoleValue(cols[0])); ok {
+			ent.Time = t
+			cols = cols[1:]
+		}
+	}
+	if d.cfg.LevelKey != "" && len(cols) > 1 {
+		if lvl, ok := parseLevel(cols[0]); ok {
+			ent.Level = lvl
+			cols = cols[1:]
+		}
+	}
+
+	// The name, caller, function, and event are all optional, so anchor on
+	// the caller if there is one.
+	callerAt := -1
+	if d.cfg.CallerKey != "" {
+		for i := 0; i < len(cols)-1 && i < 2; i++ {
+			if _callerPattern.MatchString(cols[i]) {
+				callerAt = i
+				break
+			}
+		}
+	}
+	switch {
+	case callerAt >= 0:
+		if callerAt == 1 {
+			ent.LoggerName = cols[0]
+		}
+		ent.Caller, _ = parseCaller(cols[callerAt], "")
+		cols = cols[callerAt+1:]
+		if d.cfg.FunctionKey != "" && len(cols) > 1 {
+			ent.Caller.Function = cols[0]
+			cols = cols[1:]
+		}
+	case d.cfg.NameKey != "" && len(cols) > 1:
+		ent.LoggerName = cols[0]
+		cols = cols[1:]
+	}
+	if d.cfg.EventKey != "" && len(cols) > 1 {
+		ent.Event = cols[0]
+		cols = cols[1:]
+	}
+
+	ent.Message = strings.Join(cols, d.cfg.ConsoleSeparator)
+	return ent, fields, nil
+}
+
+// consoleValue returns the value of an unquoted console column, as a number
+// if it looks like one.
+func consoleValue(s string) interface{} {
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return json.Number(s)
+	}
+	return s
+}
+
+var (
+	_ansiPattern   = regexp.MustCompile("\x1b\\[[0-9;]*m")
+	_callerPattern = regexp.MustCompile(`^\S+:\d+$`)
+)
+
+func parseLevel(s string) (Level, bool) {
+	lvl, err := ParseLevel(_ansiPattern.ReplaceAllString(s, ""))
+	return lvl, err == nil
+}
+
+func parseCaller(s, function string) (EntryCaller, bool) {
+	i := strings.LastIndexByte(s, ':')
+	if i <= 0 {
+		return EntryCaller{}, false
+	}
+	line, err := strconv.Atoi(s[i+1:])
+	if err != nil {
+		return EntryCaller{}, false
+	}
+	return EntryCaller{Defined: true, File: s[:i], Line: line, Function: function}, true
+}
+
+var _timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000Z0700", // ISO8601TimeEncoder
+	"2006-01-02 15:04:05.000Z0700",
+}
+
+func parseTime(v interface{}) (time.Time, bool) {
+	switch v := v.(type) {
+	case string:
+		for _, layout := range _timeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	case json.Number:
+		return parseEpoch(string(v))
+	}
+	return time.Time{}, false
+}
+
+// parseEpoch parses a (possibly fractional) number of seconds, milliseconds,
+// microseconds, or nanoseconds since the epoch, guessing the unit from the
+// magnitude of the number. Fractions are parsed exactly, rather than through
+// a float64, to keep nanosecond precision.
+func parseEpoch(s string) (time.Time, bool) {
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsInf(f, 0) || math.Abs(f) >= 1<<62 {
+			return time.Time{}, false
+		}
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	n, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if len(frac) > 9 {
+		frac = frac[:9]
+	}
+	fracNanos := int64(0)
+	if frac != "" {
+		fracNanos, err = strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+	}
+
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+	unit := int64(1)
+	switch {
+	case abs < 1e11:
+		unit = int64(time.Second)
+	case abs < 1e14:
+		unit = int64(time.Millisecond)
+	case abs < 1e17:
+		unit = int64(time.Microsecond)
+	}
+
+	fracNanos = fracNanos * unit / int64(time.Second)
+	if strings.HasPrefix(whole, "-") {
+		fracNanos = -fracNanos
+	}
+	// Split into seconds and nanoseconds rather than multiplying n by unit,
+	// which overflows for seconds past the year 2262.
+	perSec := int64(time.Second) / unit
+	return time.Unix(n/perSec, n%perSec*unit+fracNanos), true
+}
+
+// jsonMember is a single key-value pair in a decoded JSON object.
+type jsonMember struct {
+	Key   string
+	Value interface{}
+}
+
+// jsonObject is a decoded JSON object that keeps its keys in order. It
+// re-encodes itself as it was decoded.
+type jsonObject []jsonMember
+
+func (o jsonObject) MarshalLogObject(enc ObjectEncoder) error {
+	for _, m := range o {
+		jsonField(m.Key, m.Value).AddTo(enc)
+	}
+	return nil
+}
+
+// jsonArray is a decoded JSON array. It re-encodes itself as it was decoded.
+type jsonArray []interface{}
+
+func (a jsonArray) MarshalLogArray(enc ArrayEncoder) error {
+	for _, v := range a {
+		switch v := v.(type) {
+		case string:
+			enc.AppendString(v)
+		case bool:
+			enc.AppendBool(v)
+		case json.Number:
+			switch f := jsonField("", v); f.Type {
+			case Int64Type:
+				enc.AppendInt64(f.Integer)
+			case Uint64Type:
+				enc.AppendUint64(uint64(f.Integer))
+			default:
+				enc.AppendFloat64(math.Float64frombits(uint64(f.Integer)))
+			}
+		case jsonObject:
+			if err := enc.AppendObject(v); err != nil {
+				return err
+			}
+		case jsonArray:
+			if err := enc.AppendArray(v); err != nil {
+				return err
+			}
+		default:
+			if err := enc.AppendReflected(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonField builds a field for a decoded JSON value.
+func jsonField(key string, v interface{}) Field {
+	switch v := v.(type) {
+	case string:
+		return Field{Key: key, Type: StringType, String: v}
+	case bool:
+		var i int64
+		if v {
+			i = 1
+		}
+		return Field{Key: key, Type: BoolType, Integer: i}
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return Field{Key: key, Type: Int64Type, Integer: i}
+		}
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return Field{Key: key, Type: Uint64Type, Integer: int64(u)}
+		}
+		f, _ := strconv.ParseFloat(string(v), 64)
+		return Field{Key: key, Type: Float64Type, Integer: int64(math.Float64bits(f))}
+	case jsonObject:
+		return Field{Key: key, Type: ObjectMarshalerType, Interface: v}
+	case jsonArray:
+		return Field{Key: key, Type: ArrayMarshalerType, Interface: v}
+	default:
+		return Field{Key: key, Type: ReflectType, Interface: v}
+	}
+}
+
+// decodeJSONObject decodes a single JSON object, keeping its keys in order.
+func decodeJSONObject(bs []byte) (jsonObject, error) {
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, ErrNotJSONEntry
+	}
+	obj, err := decodeJSONMembers(dec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON log entry: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid JSON log entry: unexpected data after object")
+	}
+	return obj, nil
+}
+
+func decodeJSONMembers(dec *json.Decoder) (jsonObject, error) {
+	obj := jsonObject{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		v, err := decodeJSONValue(dec)
+		if err != nil {
+			return nil, err
+		}
+		obj = append(obj, jsonMember{Key: key, Value: v})
+	}
+	_, err := dec.Token() // closing brace
+	return obj, err
+}
+
+func decodeJSONValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		return decodeJSONMembers(dec)
+	case json.Delim('['):
+		arr := jsonArray{}
+		for dec.More() {
+			v, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		_, err := dec.Token() // closing bracket
+		return arr, err
+	}
+	return tok, nil
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decoderTestFields() []Field {
+	return []Field{
+		{Key: "str", Type: StringType, String: "foo\tbar"},
+		makeInt64Field("int", -42),
+		{Key: "uint", Type: Uint64Type, Integer: -1},
+		{Key: "float", Type: Float64Type, Integer: int64(math.Float64bits(3.25))},
+		{Key: "bool", Type: BoolType, Integer: 1},
+		{Key: "nil", Type: ReflectType},
+		{Key: "err", Type: ErrorType, Interface: errors.New("boom")},
+		{Key: "obj", Type: ObjectMarshalerType, Interface: ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+			enc.AddString("k", "v")
+			return enc.AddArray("list", ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+				arr.AppendInt64(1)
+				arr.AppendString("two")
+				arr.AppendBool(false)
+				arr.AppendFloat64(0.5)
+				return arr.AppendReflected(nil)
+			}))
+		})},
+		{Key: "ns", Type: NamespaceType},
+		makeInt64Field("nested", 1),
+	}
+}
+
+func TestEntryDecoderRoundTrip(t *testing.T) {
+	productionConfig := EncoderConfig{
+		MessageKey:    "msg",
+		LevelKey:      "level",
+		NameKey:       "logger",
+		TimeKey:       "ts",
+		CallerKey:     "caller",
+		FunctionKey:   "func",
+		EventKey:      "event",
+		StacktraceKey: "stacktrace",
+		LineEnding:    "\n",
+		EncodeLevel:   CapitalColorLevelEncoder,
+		EncodeTime:    ISO8601TimeEncoder,
+		EncodeCaller:  ShortCallerEncoder,
+	}
+	entries := []Entry{
+		_testEntry,
+		{
+			Level:   WarnLevel,
+			Time:    _epoch.Add(1500 * time.Millisecond),
+			Message: "no name or caller",
+		},
+		{
+			Level:      ErrorLevel,
+			Time:       _epoch.Add(time.Hour),
+			LoggerName: "svc.db",
+			Message:    "evented",
+			Event:      "query",
+		},
+	}
+
+	tests := []struct {
+		desc   string
+		cfg    EncoderConfig
+		newEnc func(EncoderConfig) Encoder
+	}{
+		{desc: "JSON", cfg: testEncoderConfig(), newEnc: NewJSONEncoder},
+		{desc: "JSON production", cfg: productionConfig, newEnc: NewJSONEncoder},
+		{desc: "console", cfg: testEncoderConfig(), newEnc: NewConsoleEncoder},
+		{desc: "console production", cfg: productionConfig, newEnc: NewConsoleEncoder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dec := NewEntryDecoder(tt.cfg)
+			for _, ent := range entries {
+				buf, err := tt.newEnc(tt.cfg).EncodeEntry(ent, decoderTestFields())
+				require.NoError(t, err, "Unexpected error encoding entry.")
+				original := buf.String()
+				buf.Free()
+
+				decoded, fields, err := dec.Decode([]byte(original))
+				require.NoError(t, err, "Unexpected error decoding %q.", original)
+				assert.Equal(t, ent.Level, decoded.Level, "Unexpected level decoding %q.", original)
+				assert.Equal(t, ent.Message, decoded.Message, "Unexpected message decoding %q.", original)
+				assert.Equal(t, ent.LoggerName, decoded.LoggerName, "Unexpected name decoding %q.", original)
+				if tt.cfg.EventKey != "" {
+					assert.Equal(t, ent.Event, decoded.Event, "Unexpected event decoding %q.", original)
+				}
+				assert.Equal(t, ent.Stack, decoded.Stack, "Unexpected stack decoding %q.", original)
+				assert.True(t, ent.Time.Equal(decoded.Time), "Unexpected time %v decoding %q.", decoded.Time, original)
+				assert.Equal(t, ent.Caller.Defined, decoded.Caller.Defined, "Unexpected caller decoding %q.", original)
+
+				buf, err = tt.newEnc(tt.cfg).EncodeEntry(decoded, fields)
+				require.NoError(t, err, "Unexpected error re-encoding entry.")
+				assert.Equal(t, original, buf.String(), "Expected re-encoding to reproduce the original.")
+				buf.Free()
+			}
+		})
+	}
+}
+
+func TestEntryDecoderJSONFields(t *testing.T) {
+	dec := NewEntryDecoder(testEncoderConfig())
+	ent, fields, err := dec.DecodeJSON([]byte(`{"msg":"hi","level":7,"a":1,"b":"x","msg":"again"}`))
+	require.NoError(t, err, "Unexpected error decoding.")
+
+	assert.Equal(t, Entry{Message: "hi"}, ent, "Unexpected entry.")
+	require.Len(t, fields, 4, "Unexpected number of fields.")
+	assert.Equal(t, makeInt64Field("level", 7), fields[0], "Expected unparseable metadata to be kept as a field.")
+	assert.Equal(t, makeInt64Field("a", 1), fields[1], "Unexpected field.")
+	assert.Equal(t, Field{Key: "msg", Type: StringType, String: "again"}, fields[3], "Expected repeated keys to be kept as fields.")
+}
+
+func TestEntryDecoderTimes(t *testing.T) {
+	want := time.Unix(1700000000, 123456789)
+	tests := []struct {
+		give string
+		want time.Time
+	}{
+		{give: `"2023-11-14T22:13:20.123456789Z"`, want: want},
+		{give: `"2023-11-14T23:13:20.123+0100"`, want: want.Truncate(time.Millisecond)},
+		{give: `1700000000.123456789`, want: want},
+		{give: `1700000000123.456789`, want: want},
+		{give: `1700000000123456.789`, want: want},
+		{give: `1700000000123456789`, want: want},
+		{give: `1.7e9`, want: time.Unix(1700000000, 0)},
+		{give: `-1.5`, want: time.Unix(-1, -5e8)},
+		{give: `-1700000000123.5`, want: time.Unix(-1700000000, -123500000)},
+		{give: `10000000000.5`, want: time.Unix(1e10, 5e8)},
+		{give: `99999999999`, want: time.Unix(99999999999, 0)},
+		{give: `9.9e10`, want: time.Unix(99e9, 0)},
+	}
+
+	dec := NewEntryDecoder(testEncoderConfig())
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			ent, fields, err := dec.DecodeJSON([]byte(`{"ts":` + tt.give + `}`))
+			require.NoError(t, err, "Unexpected error decoding.")
+			assert.Empty(t, fields, "Expected the time to be recognized.")
+			assert.True(t, tt.want.Equal(ent.Time), "Expected %v, got %v.", tt.want, ent.Time)
+		})
+	}
+}
+
+func TestEntryDecoderConsole(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.ConsoleSeparator = " | "
+	dec := NewEntryDecoder(cfg)
+
+	ent, fields, err := dec.DecodeConsole([]byte("1 | info | main | hi | there | {\"k\":1}\nstack\ntrace\n"))
+	require.NoError(t, err, "Unexpected error decoding.")
+	assert.Equal(t, Entry{
+		Level:      InfoLevel,
+		Time:       time.Unix(1, 0),
+		LoggerName: "main",
+		Message:    "hi | there",
+		Stack:      "stack\ntrace",
+	}, ent, "Unexpected entry.")
+	assert.Equal(t, []Field{makeInt64Field("k", 1)}, fields, "Unexpected fields.")
+
+	ent, fields, err = dec.DecodeConsole([]byte("just a message"))
+	require.NoError(t, err, "Unexpected error decoding.")
+	assert.Equal(t, Entry{Message: "just a message"}, ent, "Expected a lone column to be the message.")
+	assert.Empty(t, fields, "Unexpected fields.")
+}
+
+func TestEntryDecoderErrors(t *testing.T) {
+	dec := NewEntryDecoder(testEncoderConfig())
+	tests := []struct {
+		give string
+		want string
+	}{
+		{give: `["not", "an", "object"]`, want: "not a JSON log entry"},
+		{give: `{"a": [1, }`, want: "invalid JSON log entry"},
+		{give: `{"a": 1} {"b": 2}`, want: "invalid JSON log entry: unexpected data after object"},
+	}
+	for _, tt := range tests {
+		_, _, err := dec.DecodeJSON([]byte(tt.give))
+		assert.ErrorContains(t, err, tt.want, "Unexpected error decoding %q.", tt.give)
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestCheckedEntryIllegalReuse(t *testing.T) {
@@ -53,3 +54,59 @@ func TestCheckedEntryIllegalReuse(t *testing.T) {
 	assert.Contains(t, errOut.String(), "Unsafe CheckedEntry re-use near Entry",
 		"Expected error logged on second write.")
 }
+
+func TestCheckedEntryWriteTo(t *testing.T) {
+	t.Parallel()
+
+	detailCore, details := observer.New(zapcore.DebugLevel)
+	summaryCore, summaries := observer.New(zapcore.ErrorLevel)
+
+	ent := zapcore.Entry{Level: zapcore.InfoLevel, Message: "request served"}
+	ce := detailCore.Check(ent, nil)
+	assert.NoError(t, ce.WriteTo(summaryCore, zap.Int("status", 200)),
+		"Unexpected error writing to summary core.")
+	ce.Write(zap.Int("status", 200), zap.String("path", "/"))
+
+	assert.Equal(t, []observer.LoggedEntry{{
+		Entry:   ent,
+		Context: []zapcore.Field{zap.Int("status", 200)},
+	}}, summaries.AllUntimed(), "Expected WriteTo to bypass the core's level.")
+	assert.Equal(t, []observer.LoggedEntry{{
+		Entry:   ent,
+		Context: []zapcore.Field{zap.Int("status", 200), zap.String("path", "/")},
+	}}, details.AllUntimed(), "Unexpected detailed entries.")
+
+	assert.ErrorIs(t, ce.WriteTo(summaryCore), zapcore.ErrUnsafeReuse,
+		"Expected an error writing after Write.")
+	assert.Equal(t, 1, summaries.Len(), "Expected no write after Write.")
+
+	var nilCE *zapcore.CheckedEntry
+	assert.NoError(t, nilCE.WriteTo(summaryCore), "Expected nil CheckedEntry to be a no-op.")
+}
+
+func TestCheckedEntryClone(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	var hookRuns int
+	ent := zapcore.Entry{Level: zapcore.InfoLevel, Message: "hello"}
+	ce := core.Check(ent, nil).After(ent, hookFunc(func() { hookRuns++ }))
+
+	clone := ce.Clone()
+	clone.Write(zap.String("view", "summary"))
+	assert.Zero(t, hookRuns, "Expected clone not to run the After hook.")
+	ce.Write(zap.String("view", "detail"))
+	assert.Equal(t, 1, hookRuns, "Expected original to run the After hook.")
+
+	assert.Equal(t, []observer.LoggedEntry{
+		{Entry: ent, Context: []zapcore.Field{zap.String("view", "summary")}},
+		{Entry: ent, Context: []zapcore.Field{zap.String("view", "detail")}},
+	}, logs.AllUntimed(), "Expected both the clone and original to be written.")
+
+	var nilCE *zapcore.CheckedEntry
+	assert.Nil(t, nilCE.Clone(), "Expected clone of nil CheckedEntry to be nil.")
+}
+
+type hookFunc func()
+
+func (f hookFunc) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) { f() }
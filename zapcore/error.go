@@ -23,6 +23,7 @@ package zapcore
 import (
 	"fmt"
 	"reflect"
+	"unicode/utf8"
 
 	"go.uber.org/zap/internal/pool"
 )
@@ -44,7 +45,26 @@ import (
 //	    ...
 //	  ],
 //	}
-func encodeError(key string, err error, enc ObjectEncoder) (retErr error) {
+func encodeError(key string, err error, enc ObjectEncoder) error {
+	return encodeErrorVerbosity(key, err, errorVerbosity{}, enc)
+}
+
+// errorVerbosity controls how the verbose representation of an error is
+// encoded.
+type errorVerbosity struct {
+	// key is the key for the verbose representation. If empty, it defaults
+	// to the error's key with a "Verbose" suffix.
+	key string
+	// omit drops the verbose representation entirely.
+	omit bool
+	// maxSize is the maximum size in bytes of the verbose representation.
+	// Values <= 0 impose no limit.
+	maxSize int
+}
+
+// Encodes the given error like encodeError, but with the verbose
+// representation governed by the given errorVerbosity.
+func encodeErrorVerbosity(key string, err error, v errorVerbosity, enc ObjectEncoder) (retErr error) {
 	// Try to capture panics (from nil references or otherwise) when calling
 	// the Error() method
 	defer func() {
@@ -58,6 +78,7 @@ func encodeError(key string, err error, enc ObjectEncoder) (retErr error) {
 			}
 
 			retErr = fmt.Errorf("PANIC=%v", rerr)
+			ReportInternalError(retErr, Entry{})
 		}
 	}()
 
@@ -66,18 +87,37 @@ func encodeError(key string, err error, enc ObjectEncoder) (retErr error) {
 
 	switch e := err.(type) {
 	case errorGroup:
-		return enc.AddArray(key+"Causes", errArray(e.Errors()))
+		return enc.AddArray(key+"Causes", errArray{errs: e.Errors(), verbosity: v})
 	case fmt.Formatter:
+		if v.omit {
+			break
+		}
 		verbose := fmt.Sprintf("%+v", e)
 		if verbose != basic {
 			// This is a rich error type, like those produced by
 			// github.com/pkg/errors.
-			enc.AddString(key+"Verbose", verbose)
+			verboseKey := v.key
+			if verboseKey == "" {
+				verboseKey = key + "Verbose"
+			}
+			enc.AddString(verboseKey, truncateString(verbose, v.maxSize))
 		}
 	}
 	return nil
 }
 
+// truncateString shortens s to at most n bytes without splitting a UTF-8
+// sequence. Values of n <= 0 leave s untouched.
+func truncateString(s string, n int) string {
+	if n <= 0 || len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
 type errorGroup interface {
 	// Provides read-only access to the underlying list of errors, preferably
 	// without causing any allocs.
@@ -89,15 +129,19 @@ type errorGroup interface {
 // that would require exporting errArray as part of the zapcore API.
 
 // Encodes a list of errors using the standard error encoding logic.
-type errArray []error
+type errArray struct {
+	errs      []error
+	verbosity errorVerbosity
+}
 
-func (errs errArray) MarshalLogArray(arr ArrayEncoder) error {
+func (a errArray) MarshalLogArray(arr ArrayEncoder) error {
+	errs := a.errs
 	for i := range errs {
 		if errs[i] == nil {
 			continue
 		}
 
-		el := newErrArrayElem(errs[i])
+		el := newErrArrayElem(errs[i], a.verbosity)
 		err := arr.AppendObject(el)
 		el.Free()
 		if err != nil {
@@ -114,11 +158,15 @@ var _RerrArrayElemPool = pool.New(func() *errArrayElem {
 // Encodes any error into a {"error": ...} re-using the same errors logic.
 //
 // May be passed in place of an array to build a single-element array.
-type errArrayElem struct{ err error }
+type errArrayElem struct {
+	err       error
+	verbosity errorVerbosity
+}
 
-func newErrArrayElem(err error) *errArrayElem {
+func newErrArrayElem(err error, v errorVerbosity) *errArrayElem {
 	e := _RerrArrayElemPool.Get()
 	e.err = err
+	e.verbosity = v
 	return e
 }
 
@@ -127,10 +175,11 @@ func (e *errArrayElem) MarshalLogArray(arr ArrayEncoder) error {
 }
 
 func (e *errArrayElem) MarshalLogObject(enc ObjectEncoder) error {
-	return encodeError("error", e.err, enc)
+	return encodeErrorVerbosity("error", e.err, e.verbosity, enc)
 }
 
 func (e *errArrayElem) Free() {
 	e.err = nil
+	e.verbosity = errorVerbosity{}
 	_RerrArrayElemPool.Put(e)
 }
@@ -168,6 +168,99 @@ func TestErrorEncoding(t *testing.T) {
 	}
 }
 
+func TestVerboseErrorEncoding(t *testing.T) {
+	tests := []struct {
+		desc       string
+		verboseKey string
+		maxSize    int64
+		iface      any
+		want       map[string]any
+	}{
+		{
+			desc:       "renamed",
+			verboseKey: "stack",
+			iface:      errTooFewUsers(2),
+			want: map[string]any{
+				"k":     "2 too few users",
+				"stack": "verbose: 2 too few users",
+			},
+		},
+		{
+			desc:  "omitted",
+			iface: errTooFewUsers(2),
+			want: map[string]any{
+				"k": "2 too few users",
+			},
+		},
+		{
+			desc:       "truncated",
+			verboseKey: "stack",
+			maxSize:    7,
+			iface:      errTooFewUsers(2),
+			want: map[string]any{
+				"k":     "2 too few users",
+				"stack": "verbose",
+			},
+		},
+		{
+			desc:       "not a formatter",
+			verboseKey: "stack",
+			iface:      errors.New("egad"),
+			want: map[string]any{
+				"k": "egad",
+			},
+		},
+		{
+			desc:       "causes",
+			verboseKey: "stack",
+			maxSize:    7,
+			iface:      multierr.Combine(errTooFewUsers(1), errTooManyUsers(2)),
+			want: map[string]any{
+				"k": "1 too few users; 2 too many users",
+				"kCauses": []any{
+					map[string]any{"error": "1 too few users", "stack": "verbose"},
+					map[string]any{"error": "2 too many users"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := NewMapObjectEncoder()
+			f := Field{
+				Key:       "k",
+				Type:      VerboseErrorType,
+				Integer:   tt.maxSize,
+				String:    tt.verboseKey,
+				Interface: tt.iface,
+			}
+			f.AddTo(enc)
+			assert.Equal(t, tt.want, enc.Fields, "Unexpected output from field %+v.", f)
+		})
+	}
+}
+
+func TestVerboseErrorTruncationRespectsUTF8(t *testing.T) {
+	enc := NewMapObjectEncoder()
+	Field{
+		Key:       "k",
+		Type:      VerboseErrorType,
+		Integer:   3,
+		String:    "kVerbose",
+		Interface: verboseErr("ééé"),
+	}.AddTo(enc)
+	assert.Equal(t, "é", enc.Fields["kVerbose"], "Expected truncation on a rune boundary.")
+}
+
+type verboseErr string
+
+func (e verboseErr) Error() string { return "error" }
+
+func (e verboseErr) Format(s fmt.State, verb rune) {
+	_, _ = io.WriteString(s, string(e))
+}
+
 func TestRichErrorSupport(t *testing.T) {
 	f := Field{
 		Type:      ErrorType,
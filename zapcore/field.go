@@ -22,6 +22,8 @@ package zapcore
 
 import (
 	"bytes"
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
@@ -96,6 +98,22 @@ const (
 	// InlineMarshalerType indicates that the field carries an ObjectMarshaler
 	// that should be inlined.
 	InlineMarshalerType
+	// VerboseErrorType indicates that the field carries an error whose
+	// verbose representation is stored under the key in String, truncated to
+	// the number of bytes in Integer. An empty String omits the verbose
+	// representation and a non-positive Integer imposes no limit.
+	VerboseErrorType
+	// TextMarshalerType indicates that the field carries an
+	// encoding.TextMarshaler.
+	TextMarshalerType
+	// JSONMarshalerType indicates that the field carries a json.Marshaler,
+	// whose output is embedded as is.
+	JSONMarshalerType
+	// BinaryMarshalerType indicates that the field carries an
+	// encoding.BinaryMarshaler, whose output is encoded as binary.
+	BinaryMarshalerType
+	// RawJSONType indicates that the field carries already-encoded JSON.
+	RawJSONType
 )
 
 // A Field is a marshaling operation used to add a key-value pair to a logger's
@@ -120,7 +138,7 @@ func (f Field) AddTo(enc ObjectEncoder) {
 	case ObjectMarshalerType:
 		err = enc.AddObject(f.Key, f.Interface.(ObjectMarshaler))
 	case InlineMarshalerType:
-		err = f.Interface.(ObjectMarshaler).MarshalLogObject(enc)
+		err = marshalObject(f.Interface.(ObjectMarshaler), enc)
 	case BinaryType:
 		enc.AddBinary(f.Key, f.Interface.([]byte))
 	case BoolType:
@@ -172,8 +190,22 @@ func (f Field) AddTo(enc ObjectEncoder) {
 		enc.OpenNamespace(f.Key)
 	case StringerType:
 		err = encodeStringer(f.Key, f.Interface, enc)
+	case TextMarshalerType:
+		err = encodeTextMarshaler(f.Key, f.Interface, enc)
+	case JSONMarshalerType:
+		err = encodeJSONMarshaler(f.Key, f.Interface, enc)
+	case BinaryMarshalerType:
+		err = encodeBinaryMarshaler(f.Key, f.Interface, enc)
+	case RawJSONType:
+		err = AddRawJSON(enc, f.Key, f.Interface.([]byte))
 	case ErrorType:
 		err = encodeError(f.Key, f.Interface.(error), enc)
+	case VerboseErrorType:
+		err = encodeErrorVerbosity(f.Key, f.Interface.(error), errorVerbosity{
+			key:     f.String,
+			omit:    f.String == "",
+			maxSize: int(f.Integer),
+		}, enc)
 	case SkipType:
 		break
 	default:
@@ -196,9 +228,10 @@ func (f Field) Equals(other Field) bool {
 	}
 
 	switch f.Type {
-	case BinaryType, ByteStringType:
+	case BinaryType, ByteStringType, RawJSONType:
 		return bytes.Equal(f.Interface.([]byte), other.Interface.([]byte))
-	case ArrayMarshalerType, ObjectMarshalerType, ErrorType, ReflectType:
+	case ArrayMarshalerType, ObjectMarshalerType, ErrorType, VerboseErrorType, ReflectType,
+		TextMarshalerType, JSONMarshalerType, BinaryMarshalerType:
 		return reflect.DeepEqual(f.Interface, other.Interface)
 	default:
 		return f == other
@@ -211,6 +244,33 @@ func addFields(enc ObjectEncoder, fields []Field) {
 	}
 }
 
+// marshalObject adds an ObjectMarshaler's fields to enc, recovering from
+// panics unless enc is a JSON encoder configured to propagate them.
+func marshalObject(obj ObjectMarshaler, enc ObjectEncoder) (retErr error) {
+	if je, ok := enc.(*jsonEncoder); ok && je.PropagateMarshalerPanics {
+		return obj.MarshalLogObject(enc)
+	}
+	defer recoverMarshaler(&retErr)
+	return obj.MarshalLogObject(enc)
+}
+
+// marshalArray adds an ArrayMarshaler's elements to enc, recovering from
+// panics.
+func marshalArray(arr ArrayMarshaler, enc ArrayEncoder) (retErr error) {
+	defer recoverMarshaler(&retErr)
+	return arr.MarshalLogArray(enc)
+}
+
+// recoverMarshaler recovers from a panic in a user-supplied marshaler, and
+// reports it with ReportInternalError and as an error through retErr. It
+// must be deferred directly.
+func recoverMarshaler(retErr *error) {
+	if r := recover(); r != nil {
+		*retErr = fmt.Errorf("PANIC=%v", r)
+		ReportInternalError(*retErr, Entry{})
+	}
+}
+
 func encodeStringer(key string, stringer interface{}, enc ObjectEncoder) (retErr error) {
 	// Try to capture panics (from nil references or otherwise) when calling
 	// the String() method, similar to https://golang.org/src/fmt/print.go#L540
@@ -225,9 +285,58 @@ func encodeStringer(key string, stringer interface{}, enc ObjectEncoder) (retErr
 			}
 
 			retErr = fmt.Errorf("PANIC=%v", err)
+			ReportInternalError(retErr, Entry{})
 		}
 	}()
 
 	enc.AddString(key, stringer.(fmt.Stringer).String())
 	return nil
 }
+
+func encodeTextMarshaler(key string, marshaler interface{}, enc ObjectEncoder) (retErr error) {
+	defer recoverValueMarshaler(key, marshaler, enc, &retErr)
+
+	text, err := marshaler.(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return err
+	}
+	enc.AddByteString(key, text)
+	return nil
+}
+
+func encodeJSONMarshaler(key string, marshaler interface{}, enc ObjectEncoder) (retErr error) {
+	defer recoverValueMarshaler(key, marshaler, enc, &retErr)
+
+	b, err := marshaler.(json.Marshaler).MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return AddRawJSON(enc, key, b)
+}
+
+func encodeBinaryMarshaler(key string, marshaler interface{}, enc ObjectEncoder) (retErr error) {
+	defer recoverValueMarshaler(key, marshaler, enc, &retErr)
+
+	b, err := marshaler.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	enc.AddBinary(key, b)
+	return nil
+}
+
+// recoverValueMarshaler recovers from a panic in a MarshalText, MarshalJSON,
+// or MarshalBinary method. Like encodeStringer, it logs nil pointers as
+// "<nil>", and otherwise reports the panic through retErr. It must be
+// deferred directly.
+func recoverValueMarshaler(key string, marshaler interface{}, enc ObjectEncoder, retErr *error) {
+	if err := recover(); err != nil {
+		if v := reflect.ValueOf(marshaler); v.Kind() == reflect.Ptr && v.IsNil() {
+			enc.AddString(key, "<nil>")
+			return
+		}
+
+		*retErr = fmt.Errorf("PANIC=%v", err)
+		ReportInternalError(*retErr, Entry{})
+	}
+}
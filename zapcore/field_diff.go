@@ -0,0 +1,140 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldsEqual reports whether two lists of fields encode to the same keys and
+// values, regardless of order. Unlike comparing the fields themselves, it
+// compares what marshalers produce, so that two ObjectMarshalers with the
+// same output are equal. It's meant for tests; see also FieldsDiff.
+func FieldsEqual(a, b []Field) bool {
+	return reflect.DeepEqual(encodeFields(a), encodeFields(b))
+}
+
+// FieldsDiff describes how two lists of fields differ once encoded, one
+// difference per line, or returns an empty string if they're equal. Nested
+// values are compared element by element and reported by path, like
+//
+//	user.roles[1]: want "admin", got "viewer"
+//	user.email: missing, want "jane@example.com"
+//	retries: unexpected, got 3
+//
+// so that test failures point at the difference rather than printing both
+// lists in full.
+func FieldsDiff(want, got []Field) string {
+	var lines []string
+	diffValues(&lines, "", encodeFields(want), encodeFields(got))
+	return strings.Join(lines, "\n")
+}
+
+func encodeFields(fields []Field) map[string]interface{} {
+	enc := NewMapObjectEncoder()
+	addFields(enc, fields)
+	return enc.Fields
+}
+
+func diffValues(lines *[]string, path string, want, got interface{}) {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		if g, ok := got.(map[string]interface{}); ok {
+			diffMaps(lines, path, w, g)
+			return
+		}
+	case []interface{}:
+		if g, ok := got.([]interface{}); ok {
+			diffSlices(lines, path, w, g)
+			return
+		}
+	}
+	if !reflect.DeepEqual(want, got) {
+		*lines = append(*lines, fmt.Sprintf("%s: want %s, got %s", path, formatDiffValue(want, got), formatDiffValue(got, want)))
+	}
+}
+
+func diffMaps(lines *[]string, path string, want, got map[string]interface{}) {
+	keys := make([]string, 0, len(want)+len(got))
+	for k := range want {
+		keys = append(keys, k)
+	}
+	for k := range got {
+		if _, ok := want[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := k
+		if path != "" {
+			p = path + "." + k
+		}
+		w, inWant := want[k]
+		g, inGot := got[k]
+		switch {
+		case !inGot:
+			*lines = append(*lines, fmt.Sprintf("%s: missing, want %s", p, formatDiffValue(w, nil)))
+		case !inWant:
+			*lines = append(*lines, fmt.Sprintf("%s: unexpected, got %s", p, formatDiffValue(g, nil)))
+		default:
+			diffValues(lines, p, w, g)
+		}
+	}
+}
+
+func diffSlices(lines *[]string, path string, want, got []interface{}) {
+	for i := 0; i < len(want) || i < len(got); i++ {
+		p := path + "[" + strconv.Itoa(i) + "]"
+		switch {
+		case i >= len(got):
+			*lines = append(*lines, fmt.Sprintf("%s: missing, want %s", p, formatDiffValue(want[i], nil)))
+		case i >= len(want):
+			*lines = append(*lines, fmt.Sprintf("%s: unexpected, got %s", p, formatDiffValue(got[i], nil)))
+		default:
+			diffValues(lines, p, want[i], got[i])
+		}
+	}
+}
+
+// formatDiffValue formats v for a diff. Strings are quoted, and the type is
+// included if it differs from other's, so that int64(1) and int(1) don't
+// read as equal.
+func formatDiffValue(v, other interface{}) string {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = strconv.Quote(v)
+	case nil:
+		return "nil"
+	default:
+		s = fmt.Sprintf("%v", v)
+	}
+	if other != nil && reflect.TypeOf(v) != reflect.TypeOf(other) {
+		s = fmt.Sprintf("%T(%s)", v, s)
+	}
+	return s
+}
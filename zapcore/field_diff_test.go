@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldsEqualAndDiff(t *testing.T) {
+	user := func(name string, roles ...string) Field {
+		return Field{Key: "user", Type: ObjectMarshalerType, Interface: ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+			enc.AddString("name", name)
+			return enc.AddArray("roles", ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+				for _, r := range roles {
+					arr.AppendString(r)
+				}
+				return nil
+			}))
+		})}
+	}
+	str := func(k, v string) Field { return Field{Key: k, Type: StringType, String: v} }
+	i64 := func(k string, v int64) Field { return Field{Key: k, Type: Int64Type, Integer: v} }
+	i32 := func(k string, v int32) Field { return Field{Key: k, Type: Int32Type, Integer: int64(v)} }
+
+	tests := []struct {
+		desc string
+		want []Field
+		got  []Field
+		diff string
+	}{
+		{
+			desc: "equal",
+			want: []Field{str("a", "x"), user("jane", "admin")},
+			got:  []Field{str("a", "x"), user("jane", "admin")},
+		},
+		{
+			desc: "order doesn't matter",
+			want: []Field{str("a", "x"), i64("b", 1)},
+			got:  []Field{i64("b", 1), str("a", "x")},
+		},
+		{
+			desc: "empty",
+		},
+		{
+			desc: "scalar",
+			want: []Field{str("a", "x")},
+			got:  []Field{str("a", "y")},
+			diff: `a: want "x", got "y"`,
+		},
+		{
+			desc: "type mismatch",
+			want: []Field{i64("n", 1)},
+			got:  []Field{i32("n", 1)},
+			diff: `n: want int64(1), got int32(1)`,
+		},
+		{
+			desc: "missing and unexpected",
+			want: []Field{str("a", "x"), str("b", "y")},
+			got:  []Field{str("b", "y"), i64("c", 3)},
+			diff: "a: missing, want \"x\"\nc: unexpected, got 3",
+		},
+		{
+			desc: "nested",
+			want: []Field{user("jane", "admin", "dev")},
+			got:  []Field{user("john", "viewer")},
+			diff: "user.name: want \"jane\", got \"john\"\n" +
+				"user.roles[0]: want \"admin\", got \"viewer\"\n" +
+				"user.roles[1]: missing, want \"dev\"",
+		},
+		{
+			desc: "object versus scalar",
+			want: []Field{user("jane")},
+			got:  []Field{str("user", "jane")},
+			diff: `user: want map[string]interface {}(map[name:jane roles:[]]), got string("jane")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.diff == "", FieldsEqual(tt.want, tt.got), "Unexpected FieldsEqual result.")
+			assert.Equal(t, tt.diff, FieldsDiff(tt.want, tt.got), "Unexpected diff.")
+		})
+	}
+}
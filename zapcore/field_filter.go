@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import "context"
+
+type fieldFilterCore struct {
+	Core
+
+	only map[string]struct{} // nil if every key is allowed
+	omit map[string]struct{}
+
+	// nested is true once the context has opened a namespace that was kept,
+	// after which fields are no longer top-level and aren't filtered.
+	nested bool
+	// dropping is true once the context has opened a namespace that was
+	// omitted, after which every field belongs to it and is dropped.
+	dropping bool
+}
+
+var (
+	_ ContextCore    = (*fieldFilterCore)(nil)
+	_ leveledEnabler = (*fieldFilterCore)(nil)
+)
+
+// NewFieldFilterCore wraps a Core, dropping fields by key before they reach
+// it. If only is non-nil, fields with keys not in it are dropped; fields
+// with keys in omit are always dropped. For example, a console Core might
+// omit bulky payload fields that a Core writing JSON files keeps.
+//
+// Filters apply to the fields added with With and to the fields from the
+// log site, but only at the top level of the entry. Omitting a namespace
+// drops every field added to it; the fields in a namespace that's kept
+// aren't filtered. Fields without keys, like Inline fields, are always
+// kept.
+func NewFieldFilterCore(core Core, only, omit []string) Core {
+	if only == nil && len(omit) == 0 {
+		return core
+	}
+	return &fieldFilterCore{
+		Core: core,
+		only: keySet(only),
+		omit: keySet(omit),
+	}
+}
+
+func keySet(keys []string) map[string]struct{} {
+	if keys == nil {
+		return nil
+	}
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+	return set
+}
+
+func (c *fieldFilterCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *fieldFilterCore) With(fields []Field) Core {
+	clone := *c
+	var kept []Field
+	kept, clone.nested, clone.dropping = c.filter(fields)
+	clone.Core = c.Core.With(kept)
+	return &clone
+}
+
+func (c *fieldFilterCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	// Filtering needs the fields, which are only available in Write, so
+	// register ourselves rather than letting the wrapped Core do so.
+	if c.Core.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *fieldFilterCore) Write(ent Entry, fields []Field) error {
+	return c.WriteContext(context.Background(), ent, fields)
+}
+
+func (c *fieldFilterCore) WriteContext(ctx context.Context, ent Entry, fields []Field) error {
+	kept, _, _ := c.filter(fields)
+	return writeChecked(ctx, c.Core, ent, kept)
+}
+
+// filter returns the fields to keep, without modifying the given slice, and
+// the nesting state after them.
+func (c *fieldFilterCore) filter(fields []Field) (kept []Field, nested, dropping bool) {
+	nested, dropping = c.nested, c.dropping
+	if dropping {
+		return nil, nested, dropping
+	}
+	if nested {
+		return fields, nested, dropping
+	}
+
+	for i, f := range fields {
+		keep := c.keeps(f)
+		if keep && kept != nil {
+			kept = append(kept, f)
+		} else if !keep && kept == nil {
+			kept = make([]Field, i, len(fields))
+			copy(kept, fields[:i])
+		}
+		if f.Type != NamespaceType {
+			continue
+		}
+		if keep {
+			// Everything after a namespace is nested inside it.
+			nested = true
+			if kept != nil {
+				kept = append(kept, fields[i+1:]...)
+			}
+		} else {
+			dropping = true
+		}
+		break
+	}
+	if kept == nil && !dropping {
+		return fields, nested, dropping
+	}
+	return kept, nested, dropping
+}
+
+func (c *fieldFilterCore) keeps(f Field) bool {
+	if f.Key == "" {
+		return true
+	}
+	if _, ok := c.omit[f.Key]; ok {
+		return false
+	}
+	if c.only == nil {
+		return true
+	}
+	_, ok := c.only[f.Key]
+	return ok
+}
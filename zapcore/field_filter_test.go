@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldFilterCore(t *testing.T) {
+	var (
+		a      = makeInt64Field("a", 1)
+		b      = makeInt64Field("b", 2)
+		c      = makeInt64Field("c", 3)
+		ns     = Field{Key: "ns", Type: NamespaceType}
+		inline = Field{Type: InlineMarshalerType, Interface: users(1)}
+	)
+
+	tests := []struct {
+		desc    string
+		only    []string
+		omit    []string
+		context []Field
+		give    []Field
+		want    []Field
+	}{
+		{
+			desc: "omit",
+			omit: []string{"b"},
+			give: []Field{a, b, c},
+			want: []Field{a, c},
+		},
+		{
+			desc: "only",
+			only: []string{"a", "c"},
+			give: []Field{a, b, c},
+			want: []Field{a, c},
+		},
+		{
+			desc: "only and omit",
+			only: []string{"a", "b"},
+			omit: []string{"b"},
+			give: []Field{a, b, c},
+			want: []Field{a},
+		},
+		{
+			desc: "empty allow list",
+			only: []string{},
+			give: []Field{a, b, c},
+			want: []Field{},
+		},
+		{
+			desc:    "context",
+			omit:    []string{"a"},
+			context: []Field{a, b},
+			give:    []Field{a, c},
+			want:    []Field{b, c},
+		},
+		{
+			desc: "fields without keys",
+			only: []string{"a"},
+			give: []Field{inline, a, b},
+			want: []Field{inline, a},
+		},
+		{
+			desc: "kept namespace",
+			only: []string{"ns"},
+			give: []Field{a, ns, a, b},
+			want: []Field{ns, a, b},
+		},
+		{
+			desc: "omitted namespace",
+			omit: []string{"ns"},
+			give: []Field{a, ns, a, b},
+			want: []Field{a},
+		},
+		{
+			desc:    "kept namespace in context",
+			omit:    []string{"a"},
+			context: []Field{a, ns},
+			give:    []Field{a, b},
+			want:    []Field{ns, a, b},
+		},
+		{
+			desc:    "omitted namespace in context",
+			omit:    []string{"ns"},
+			context: []Field{a, ns, b},
+			give:    []Field{a, c},
+			want:    []Field{a},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			obs, logs := observer.New(DebugLevel)
+			core := NewFieldFilterCore(obs, tt.only, tt.omit).With(tt.context)
+
+			give := append([]Field(nil), tt.give...)
+			core.Check(Entry{Level: InfoLevel}, nil).Write(give...)
+
+			require.Equal(t, 1, logs.Len(), "Expected one entry.")
+			assert.Equal(t, tt.want, logs.All()[0].Context, "Unexpected fields.")
+			assert.Equal(t, tt.give, give, "Filtering shouldn't modify the caller's fields.")
+		})
+	}
+}
+
+func TestFieldFilterCoreNoFilters(t *testing.T) {
+	obs, _ := observer.New(DebugLevel)
+	assert.Equal(t, obs, NewFieldFilterCore(obs, nil, nil), "Expected no filters to return the core.")
+}
+
+func TestFieldFilterCoreLevel(t *testing.T) {
+	obs, logs := observer.New(WarnLevel)
+	core := NewFieldFilterCore(obs, nil, []string{"a"})
+	assert.Equal(t, WarnLevel, LevelOf(core), "Unexpected level.")
+	assert.Nil(t, core.Check(Entry{Level: InfoLevel}, nil), "Expected disabled levels to be dropped.")
+	assert.Zero(t, logs.Len(), "Expected no entries.")
+}
@@ -21,6 +21,7 @@
 package zapcore_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -82,6 +83,44 @@ func (o *obj) String() string {
 	return "obj"
 }
 
+type color int
+
+func (c color) MarshalText() ([]byte, error) {
+	switch c {
+	case 0:
+		return []byte("red"), nil
+	case 1:
+		return []byte("green"), nil
+	case 2:
+		panic("panic in MarshalText")
+	default:
+		return nil, fmt.Errorf("unknown color %d", int(c))
+	}
+}
+
+type jsonObj struct {
+	kind int
+}
+
+func (j *jsonObj) MarshalJSON() ([]byte, error) {
+	switch j.kind {
+	case 1:
+		panic("panic in MarshalJSON")
+	case 2:
+		return nil, errors.New("bad JSON")
+	}
+	return []byte(`{"a":[1, 2]}`), nil
+}
+
+type binObj []byte
+
+func (b binObj) MarshalBinary() ([]byte, error) {
+	if b == nil {
+		return nil, errors.New("no bytes")
+	}
+	return b, nil
+}
+
 type errObj struct {
 	kind   int
 	errMsg string
@@ -118,6 +157,11 @@ func TestFieldAddingError(t *testing.T) {
 		{t: StringerType, iface: &obj{2}, want: empty, err: "PANIC=panic with error"},
 		{t: StringerType, iface: &obj{3}, want: empty, err: "PANIC=<nil>"},
 		{t: ErrorType, iface: &errObj{kind: 1}, want: empty, err: "PANIC=panic in Error() method"},
+		{t: TextMarshalerType, iface: color(2), want: empty, err: "PANIC=panic in MarshalText"},
+		{t: TextMarshalerType, iface: color(7), want: empty, err: "unknown color 7"},
+		{t: JSONMarshalerType, iface: &jsonObj{kind: 1}, want: empty, err: "PANIC=panic in MarshalJSON"},
+		{t: JSONMarshalerType, iface: &jsonObj{kind: 2}, want: empty, err: "bad JSON"},
+		{t: BinaryMarshalerType, iface: binObj(nil), want: empty, err: "no bytes"},
 	}
 	for _, tt := range tests {
 		f := Field{Key: "k", Interface: tt.iface, Type: tt.t}
@@ -165,6 +209,11 @@ func TestFields(t *testing.T) {
 		{t: SkipType, want: interface{}(nil)},
 		{t: StringerType, iface: (*url.URL)(nil), want: "<nil>"},
 		{t: StringerType, iface: (*users)(nil), want: "<nil>"},
+		{t: TextMarshalerType, iface: color(1), want: "green"},
+		{t: TextMarshalerType, iface: (*color)(nil), want: "<nil>"},
+		{t: JSONMarshalerType, iface: &jsonObj{}, want: json.RawMessage(`{"a":[1, 2]}`)},
+		{t: JSONMarshalerType, iface: (*jsonObj)(nil), want: "<nil>"},
+		{t: BinaryMarshalerType, iface: binObj("foo"), want: []byte("foo")},
 		{t: ErrorType, iface: (*errObj)(nil), want: "<nil>"},
 	}
 
@@ -0,0 +1,371 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// Framed log streams are a compact binary container for encoded log entries,
+// letting tools find entries by time without parsing every one of them.
+//
+// A stream starts with an 8-byte header, followed by frames. Each frame is a
+// 4-byte length (counting everything after the length itself), a kind byte,
+// and a body. All integers are little-endian.
+//
+// Entry frames hold a level byte, the entry's time in nanoseconds since the
+// Unix epoch (8 bytes), and the entry as serialized by an Encoder.
+//
+// Index frames summarize a block of consecutive entry frames: the offset of
+// the previous index frame (or -1), the offset of the block's first frame,
+// the number of entries in the block (4 bytes), and the earliest and latest
+// times in it. They end with a footer repeating the index frame's own offset
+// and the string "ZIDX", so readers can find the last index by looking at the
+// end of the stream, then follow the chain backwards.
+//
+// A schema frame, if there is one, directly follows the stream header and
+// declares the Schema of every entry's payload: the schema's ID in a
+// registry, or zero (4 bytes), its version (4 bytes), the length of its name
+// (2 bytes), the name, and its definition, which fills the rest of the frame.
+// Readers skip frames of kinds they don't know, so streams with schemas
+// remain readable by older readers.
+const (
+	_frameMagic = "ZAPFRM\x00\x01"
+
+	_frameKindEntry  byte = 0
+	_frameKindIndex  byte = 1
+	_frameKindSchema byte = 2
+
+	_frameHeaderSize  = 5  // length and kind
+	_entryHeaderSize  = 9  // level and time
+	_schemaHeaderSize = 10 // ID, version, and name length
+	_indexBlockSize   = 36
+	_indexFooter      = "ZIDX"
+	_indexFooterSize  = 12
+	_indexBodySize    = _indexBlockSize + _indexFooterSize
+
+	// _defaultIndexInterval is the default number of entries in each block.
+	_defaultIndexInterval = 1024
+
+	// _maxFrameSize is the largest frame body writers produce and readers
+	// accept, so that a corrupt length can't make readers allocate
+	// gigabytes.
+	_maxFrameSize = 64 * 1024 * 1024
+
+	// _maxFrameScratch is the largest scratch buffer a FrameWriter keeps
+	// between writes.
+	_maxFrameScratch = 64 * 1024
+)
+
+// frameBlock summarizes the entry frames following an index frame.
+type frameBlock struct {
+	PrevIndex int64
+	First     int64
+	Count     uint32
+	MinTime   int64
+	MaxTime   int64
+}
+
+// A FrameWriter writes log entries to a WriteSyncer as a framed log stream,
+// adding an index frame after every IndexInterval entries and on Sync. Use
+// NewFrameCore to log to it, and NewFrameReader to read the stream back.
+//
+// A FrameWriter must start writing at the beginning of its destination: it
+// writes the stream header before the first frame, and records offsets
+// relative to it. It's safe for concurrent use.
+type FrameWriter struct {
+	schema   *Schema
+	registry SchemaRegistry
+
+	mu        sync.Mutex
+	out       WriteSyncer
+	interval  int
+	started   bool
+	off       int64 // bytes written so far
+	lastIndex int64 // offset of the last index frame, or -1
+	block     frameBlock
+	buf       []byte
+}
+
+// FrameWriterOption configures a FrameWriter.
+type FrameWriterOption interface {
+	apply(*FrameWriter)
+}
+
+type frameWriterOptionFunc func(*FrameWriter)
+
+func (f frameWriterOptionFunc) apply(w *FrameWriter) {
+	f(w)
+}
+
+// FrameWriterSchema declares the schema of the entries' payloads in the
+// stream, so that readers can tell which version of a service's fields each
+// stream holds. Its ID is ignored.
+func FrameWriterSchema(s Schema) FrameWriterOption {
+	return frameWriterOptionFunc(func(w *FrameWriter) {
+		w.schema = &s
+	})
+}
+
+// FrameWriterSchemaRegistry registers the stream's schema, if it has one,
+// before the first entry is written. The stream then declares only the
+// schema's ID, name, and version, and readers look up its definition in
+// the registry.
+func FrameWriterSchemaRegistry(r SchemaRegistry) FrameWriterOption {
+	return frameWriterOptionFunc(func(w *FrameWriter) {
+		w.registry = r
+	})
+}
+
+// NewFrameWriter builds a FrameWriter that indexes every indexInterval
+// entries. If indexInterval isn't positive, blocks of 1024 entries are used.
+func NewFrameWriter(ws WriteSyncer, indexInterval int, opts ...FrameWriterOption) *FrameWriter {
+	if indexInterval <= 0 {
+		indexInterval = _defaultIndexInterval
+	}
+	w := &FrameWriter{out: ws, interval: indexInterval, lastIndex: -1}
+	for _, opt := range opts {
+		opt.apply(w)
+	}
+	return w
+}
+
+// WriteFrame writes a single entry frame holding the given payload.
+func (w *FrameWriter) WriteFrame(lvl Level, t time.Time, payload []byte) error {
+	if len(payload) > _maxFrameSize-_entryHeaderSize {
+		return fmt.Errorf("log entry of %d bytes is too large to frame", len(payload))
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	buf := w.buf[:0]
+	if !w.started {
+		var err error
+		if buf, err = w.appendStreamHeader(buf); err != nil {
+			return err
+		}
+	}
+
+	nanos := t.UnixNano()
+	if w.block.Count == 0 {
+		w.block = frameBlock{First: w.off + int64(len(buf)), MinTime: nanos, MaxTime: nanos}
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(1+_entryHeaderSize+len(payload)))
+	buf = append(buf, _frameKindEntry, byte(lvl))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(nanos))
+	buf = append(buf, payload...)
+	if err := w.write(buf); err != nil {
+		return err
+	}
+
+	w.block.Count++
+	if nanos < w.block.MinTime {
+		w.block.MinTime = nanos
+	}
+	if nanos > w.block.MaxTime {
+		w.block.MaxTime = nanos
+	}
+	if int(w.block.Count) >= w.interval {
+		return w.writeIndex()
+	}
+	return nil
+}
+
+// Sync writes an index frame for any entries not yet covered by one, then
+// syncs the underlying WriteSyncer.
+func (w *FrameWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var err error
+	if w.block.Count > 0 {
+		err = w.writeIndex()
+	}
+	return multierr.Append(err, w.out.Sync())
+}
+
+func (w *FrameWriter) writeIndex() error {
+	w.block.PrevIndex = w.lastIndex
+	offset := w.off
+
+	buf := binary.LittleEndian.AppendUint32(w.buf[:0], 1+_indexBodySize)
+	buf = append(buf, _frameKindIndex)
+	buf = appendFrameBlock(buf, w.block)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(offset))
+	buf = append(buf, _indexFooter...)
+	if err := w.write(buf); err != nil {
+		return err
+	}
+
+	w.lastIndex = offset
+	w.block = frameBlock{}
+	return nil
+}
+
+// appendStreamHeader appends the stream header and, if the FrameWriter has a
+// schema, the schema frame, registering the schema first if there's a
+// registry.
+func (w *FrameWriter) appendStreamHeader(buf []byte) ([]byte, error) {
+	buf = append(buf, _frameMagic...)
+	if w.schema == nil {
+		return buf, nil
+	}
+
+	s := *w.schema
+	s.ID = 0
+	if w.registry != nil {
+		id, err := w.registry.Register(s)
+		if err != nil {
+			return nil, fmt.Errorf("can't register log schema %q: %w", s.Name, err)
+		}
+		s.ID, s.Definition = id, nil
+	}
+	if len(s.Name) > math.MaxUint16 {
+		return nil, fmt.Errorf("log schema name of %d bytes is too long to frame", len(s.Name))
+	}
+	size := _schemaHeaderSize + len(s.Name) + len(s.Definition)
+	if size > _maxFrameSize {
+		return nil, fmt.Errorf("log schema of %d bytes is too large to frame", size)
+	}
+
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(1+size))
+	buf = append(buf, _frameKindSchema)
+	buf = binary.LittleEndian.AppendUint32(buf, s.ID)
+	buf = binary.LittleEndian.AppendUint32(buf, s.Version)
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(s.Name)))
+	buf = append(buf, s.Name...)
+	return append(buf, s.Definition...), nil
+}
+
+func parseSchema(bs []byte) (Schema, bool) {
+	if len(bs) < _schemaHeaderSize {
+		return Schema{}, false
+	}
+	n := int(binary.LittleEndian.Uint16(bs[8:]))
+	if len(bs) < _schemaHeaderSize+n {
+		return Schema{}, false
+	}
+	s := Schema{
+		ID:      binary.LittleEndian.Uint32(bs[0:]),
+		Version: binary.LittleEndian.Uint32(bs[4:]),
+		Name:    string(bs[_schemaHeaderSize : _schemaHeaderSize+n]),
+	}
+	if def := bs[_schemaHeaderSize+n:]; len(def) > 0 {
+		s.Definition = def
+	}
+	return s, true
+}
+
+// write writes a complete chunk of the stream, keeping the scratch buffer
+// for re-use. Since the offsets of later frames depend on it, a failed
+// write leaves the FrameWriter's offset at its previous value.
+func (w *FrameWriter) write(buf []byte) error {
+	if cap(buf) <= _maxFrameScratch {
+		w.buf = buf
+	}
+	n, err := w.out.Write(buf)
+	if err != nil {
+		return err
+	}
+	w.started = true
+	w.off += int64(n)
+	return nil
+}
+
+func appendFrameBlock(buf []byte, b frameBlock) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(b.PrevIndex))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(b.First))
+	buf = binary.LittleEndian.AppendUint32(buf, b.Count)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(b.MinTime))
+	return binary.LittleEndian.AppendUint64(buf, uint64(b.MaxTime))
+}
+
+func parseFrameBlock(bs []byte) frameBlock {
+	return frameBlock{
+		PrevIndex: int64(binary.LittleEndian.Uint64(bs[0:])),
+		First:     int64(binary.LittleEndian.Uint64(bs[8:])),
+		Count:     binary.LittleEndian.Uint32(bs[16:]),
+		MinTime:   int64(binary.LittleEndian.Uint64(bs[20:])),
+		MaxTime:   int64(binary.LittleEndian.Uint64(bs[28:])),
+	}
+}
+
+// NewFrameCore creates a Core that serializes entries with enc and writes
+// them to a FrameWriter, one frame per entry.
+func NewFrameCore(enc Encoder, w *FrameWriter, enab LevelEnabler) Core {
+	return &frameCore{LevelEnabler: enab, enc: enc, out: w}
+}
+
+type frameCore struct {
+	LevelEnabler
+	enc Encoder
+	out *FrameWriter
+}
+
+var (
+	_ Core           = (*frameCore)(nil)
+	_ leveledEnabler = (*frameCore)(nil)
+)
+
+func (c *frameCore) Level() Level {
+	return LevelOf(c.LevelEnabler)
+}
+
+func (c *frameCore) With(fields []Field) Core {
+	enc := c.enc.Clone()
+	addFields(enc, fields)
+	return &frameCore{LevelEnabler: c.LevelEnabler, enc: enc, out: c.out}
+}
+
+func (c *frameCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *frameCore) Write(ent Entry, fields []Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	err = c.out.WriteFrame(ent.Level, ent.Time, buf.Bytes())
+	buf.Free()
+	if err != nil {
+		return err
+	}
+	if ent.Level > ErrorLevel {
+		// Since we may be crashing the program, sync the output.
+		_ = c.Sync()
+	}
+	return nil
+}
+
+func (c *frameCore) Sync() error {
+	return c.out.Sync()
+}
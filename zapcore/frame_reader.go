@@ -0,0 +1,269 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrInvalidFrameStream is returned by FrameReaders when their input isn't
+// a framed log stream, or is corrupt.
+var ErrInvalidFrameStream = errors.New("invalid framed log stream")
+
+// A Frame is a single log entry read from a framed log stream.
+type Frame struct {
+	// Offset is the position of the frame in the stream.
+	Offset int64
+	Level  Level
+	Time   time.Time
+	// Payload is the entry as serialized by the writer's Encoder.
+	Payload []byte
+}
+
+// A FrameReader reads entries from a framed log stream written by a
+// FrameWriter. Its index lets it skip to entries logged after a given time
+// without reading the entries before them.
+type FrameReader struct {
+	r   io.ReadSeeker
+	pos int64 // position of r
+	off int64 // offset of the next frame
+
+	indexed  bool
+	blocks   []frameBlock // oldest first
+	indexEnd int64        // offset just past the last index frame
+
+	schema    Schema
+	hasSchema bool
+}
+
+// NewFrameReader builds a FrameReader, checking that r holds a framed log
+// stream. Reading starts with the first entry.
+func NewFrameReader(r io.ReadSeeker) (*FrameReader, error) {
+	fr := &FrameReader{r: r, pos: -1}
+	magic := make([]byte, len(_frameMagic))
+	if err := fr.readAt(0, magic); err != nil || string(magic) != _frameMagic {
+		return nil, ErrInvalidFrameStream
+	}
+	fr.off = int64(len(_frameMagic))
+	if err := fr.readSchema(); err != nil {
+		return nil, err
+	}
+	return fr, nil
+}
+
+// Schema returns the schema the stream's writer declared for the entries'
+// payloads, and whether it declared one. If the writer registered the
+// schema, the stream holds only its ID, name, and version; look up its
+// definition in the same SchemaRegistry.
+func (fr *FrameReader) Schema() (Schema, bool) {
+	return fr.schema, fr.hasSchema
+}
+
+// readSchema reads the schema frame following the stream header, if there
+// is one. Errors reading it, as for streams without any frames yet, are left
+// for Next to report.
+func (fr *FrameReader) readSchema() error {
+	kind, n, err := fr.readHeader(fr.off)
+	if err != nil || kind != _frameKindSchema {
+		return nil
+	}
+	body := make([]byte, n)
+	if err := fr.readAt(fr.off+_frameHeaderSize, body); err != nil {
+		return nil
+	}
+	s, ok := parseSchema(body)
+	if !ok {
+		return fmt.Errorf("frame at offset %d: %w", fr.off, ErrInvalidFrameStream)
+	}
+	fr.schema, fr.hasSchema = s, true
+	fr.off += _frameHeaderSize + int64(n)
+	return nil
+}
+
+// Next returns the next entry in the stream, or io.EOF once there are no
+// more. A stream that ends partway through a frame, as it might if the
+// writer crashed, reports io.ErrUnexpectedEOF.
+func (fr *FrameReader) Next() (Frame, error) {
+	for {
+		kind, n, err := fr.readHeader(fr.off)
+		if err != nil {
+			return Frame{}, err
+		}
+		off := fr.off
+		fr.off += _frameHeaderSize + int64(n)
+		if kind != _frameKindEntry {
+			continue
+		}
+		if n < _entryHeaderSize {
+			return Frame{}, fmt.Errorf("frame at offset %d: %w", off, ErrInvalidFrameStream)
+		}
+
+		body := make([]byte, n)
+		if err := fr.readAt(off+_frameHeaderSize, body); err != nil {
+			return Frame{}, unexpectedEOF(err)
+		}
+		return Frame{
+			Offset:  off,
+			Level:   Level(int8(body[0])),
+			Time:    time.Unix(0, int64(binary.LittleEndian.Uint64(body[1:]))),
+			Payload: body[_entryHeaderSize:],
+		}, nil
+	}
+}
+
+// SeekTime positions the reader at the earliest block of entries that may
+// include entries logged at or after t, so that the following calls to Next
+// skip older blocks. Entries within a block aren't sorted, so callers
+// should still compare each Frame's time against t.
+func (fr *FrameReader) SeekTime(t time.Time) error {
+	if err := fr.loadIndex(); err != nil {
+		return err
+	}
+	nanos := t.UnixNano()
+	for _, b := range fr.blocks {
+		if b.MaxTime >= nanos {
+			fr.off = b.First
+			return nil
+		}
+	}
+	fr.off = fr.indexEnd
+	return nil
+}
+
+// loadIndex reads the stream's index frames, preferring to follow the chain
+// back from the footer at the end of the stream and falling back to
+// scanning frame headers if the stream doesn't end with an index.
+func (fr *FrameReader) loadIndex() error {
+	if fr.indexed {
+		return nil
+	}
+	end, err := fr.r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	fr.pos = end
+
+	if !fr.loadIndexChain(end) {
+		if err := fr.scanIndex(); err != nil {
+			return err
+		}
+	}
+	fr.indexed = true
+	return nil
+}
+
+func (fr *FrameReader) loadIndexChain(end int64) bool {
+	footer := make([]byte, _indexFooterSize)
+	if end < int64(len(_frameMagic))+_indexFooterSize || fr.readAt(end-_indexFooterSize, footer) != nil {
+		return false
+	}
+	if string(footer[8:]) != _indexFooter {
+		return false
+	}
+
+	var blocks []frameBlock
+	off := int64(binary.LittleEndian.Uint64(footer))
+	fr.indexEnd = off + _frameHeaderSize + _indexBodySize
+	for off >= 0 {
+		b, ok := fr.readIndex(off)
+		if !ok || b.PrevIndex >= off {
+			return false
+		}
+		blocks = append(blocks, b)
+		off = b.PrevIndex
+	}
+	for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+		blocks[i], blocks[j] = blocks[j], blocks[i]
+	}
+	fr.blocks = blocks
+	return true
+}
+
+func (fr *FrameReader) readIndex(off int64) (frameBlock, bool) {
+	kind, n, err := fr.readHeader(off)
+	if err != nil || kind != _frameKindIndex || n != _indexBodySize {
+		return frameBlock{}, false
+	}
+	body := make([]byte, n)
+	if err := fr.readAt(off+_frameHeaderSize, body); err != nil {
+		return frameBlock{}, false
+	}
+	return parseFrameBlock(body), true
+}
+
+func (fr *FrameReader) scanIndex() error {
+	fr.blocks = nil
+	fr.indexEnd = int64(len(_frameMagic))
+	for off := fr.indexEnd; ; {
+		kind, n, err := fr.readHeader(off)
+		if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		next := off + _frameHeaderSize + int64(n)
+		if kind == _frameKindIndex {
+			if b, ok := fr.readIndex(off); ok {
+				fr.blocks = append(fr.blocks, b)
+				fr.indexEnd = next
+			}
+		}
+		off = next
+	}
+}
+
+// readHeader reads the kind and body length of the frame at off.
+func (fr *FrameReader) readHeader(off int64) (kind byte, n uint32, err error) {
+	var hdr [_frameHeaderSize]byte
+	if err := fr.readAt(off, hdr[:]); err != nil {
+		return 0, 0, err
+	}
+	n = binary.LittleEndian.Uint32(hdr[:])
+	if n == 0 || n-1 > _maxFrameSize {
+		return 0, 0, fmt.Errorf("frame at offset %d: %w", off, ErrInvalidFrameStream)
+	}
+	return hdr[4], n - 1, nil
+}
+
+// readAt fills buf from the given offset, returning io.EOF only if no bytes
+// were available.
+func (fr *FrameReader) readAt(off int64, buf []byte) error {
+	if fr.pos != off {
+		if _, err := fr.r.Seek(off, io.SeekStart); err != nil {
+			return err
+		}
+	}
+	n, err := io.ReadFull(fr.r, buf)
+	fr.pos = off + int64(n)
+	return err
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
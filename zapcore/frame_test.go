@@ -0,0 +1,264 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/internal/ztest"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _frameEpoch = time.Unix(1700000000, 0)
+
+// writeFrames logs n entries a second apart, returning the stream.
+func writeFrames(t testing.TB, n, interval int, sync bool, opts ...FrameWriterOption) []byte {
+	var buf bytes.Buffer
+	w := NewFrameWriter(AddSync(&buf), interval, opts...)
+	core := NewFrameCore(NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), w, DebugLevel)
+	for i := 0; i < n; i++ {
+		ent := Entry{
+			Level:   Level(i%3 - 1),
+			Time:    _frameEpoch.Add(time.Duration(i) * time.Second),
+			Message: fmt.Sprint(i),
+		}
+		require.NoError(t, core.Write(ent, nil), "Unexpected error writing entry.")
+	}
+	if sync {
+		require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	}
+	return buf.Bytes()
+}
+
+func readFrames(t testing.TB, fr *FrameReader) []Frame {
+	var frames []Frame
+	for {
+		f, err := fr.Next()
+		if err == io.EOF {
+			return frames
+		}
+		require.NoError(t, err, "Unexpected error reading frame.")
+		frames = append(frames, f)
+	}
+}
+
+func TestFrameRoundTrip(t *testing.T) {
+	fr, err := NewFrameReader(bytes.NewReader(writeFrames(t, 25, 10, true)))
+	require.NoError(t, err, "Unexpected error opening stream.")
+
+	frames := readFrames(t, fr)
+	require.Len(t, frames, 25, "Expected every entry to be read back.")
+	for i, f := range frames {
+		assert.Equal(t, Level(i%3-1), f.Level, "Unexpected level for entry %d.", i)
+		assert.True(t, _frameEpoch.Add(time.Duration(i)*time.Second).Equal(f.Time), "Unexpected time for entry %d.", i)
+		assert.Equal(t, fmt.Sprintf(`{"msg":"%d"}`+"\n", i), string(f.Payload), "Unexpected payload for entry %d.", i)
+	}
+}
+
+func TestFrameReaderSeekTime(t *testing.T) {
+	tests := []struct {
+		desc string
+		sync bool // whether the stream ends with an index
+	}{
+		{desc: "index chain", sync: true},
+		{desc: "header scan", sync: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			fr, err := NewFrameReader(bytes.NewReader(writeFrames(t, 105, 10, tt.sync)))
+			require.NoError(t, err, "Unexpected error opening stream.")
+
+			seek := func(secs int) []Frame {
+				require.NoError(t, fr.SeekTime(_frameEpoch.Add(time.Duration(secs)*time.Second)), "Unexpected error seeking.")
+				return readFrames(t, fr)
+			}
+
+			frames := seek(55)
+			require.NotEmpty(t, frames, "Expected entries after seeking.")
+			assert.Equal(t, `{"msg":"50"}`+"\n", string(frames[0].Payload), "Expected to land at the start of the block.")
+			assert.Len(t, frames, 55, "Expected to read through the end of the stream.")
+
+			frames = seek(103)
+			require.NotEmpty(t, frames, "Expected entries after seeking.")
+			assert.Equal(t, `{"msg":"100"}`+"\n", string(frames[0].Payload), "Expected to land in the last block.")
+
+			assert.Len(t, seek(-10), 105, "Expected seeking before the stream to read everything.")
+			if tt.sync {
+				assert.Empty(t, seek(1000), "Expected seeking past the stream to read nothing.")
+			}
+		})
+	}
+}
+
+func TestFrameReaderTruncated(t *testing.T) {
+	stream := writeFrames(t, 2, 10, false)
+	fr, err := NewFrameReader(bytes.NewReader(stream[:len(stream)-3]))
+	require.NoError(t, err, "Unexpected error opening stream.")
+
+	_, err = fr.Next()
+	require.NoError(t, err, "Expected the first, complete entry to be readable.")
+	_, err = fr.Next()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF, "Expected a truncated frame to be reported.")
+}
+
+func TestFrameReaderInvalid(t *testing.T) {
+	_, err := NewFrameReader(bytes.NewReader([]byte(`{"msg":"not framed"}`)))
+	assert.ErrorIs(t, err, ErrInvalidFrameStream, "Expected JSON input to be rejected.")
+
+	_, err = NewFrameReader(bytes.NewReader(nil))
+	assert.ErrorIs(t, err, ErrInvalidFrameStream, "Expected empty input to be rejected.")
+}
+
+func TestFrameReaderOversizedFrame(t *testing.T) {
+	stream := []byte("ZAPFRM\x00\x01\xff\xff\xff\xff\x00x")
+	fr, err := NewFrameReader(bytes.NewReader(stream))
+	require.NoError(t, err, "Unexpected error opening stream.")
+
+	_, err = fr.Next()
+	assert.ErrorIs(t, err, ErrInvalidFrameStream, "Expected an implausible frame length to be reported as corruption.")
+	assert.ErrorIs(t, fr.SeekTime(_frameEpoch), ErrInvalidFrameStream, "Expected scanning for the index to report corruption.")
+}
+
+func TestFrameWriterErrors(t *testing.T) {
+	w := NewFrameWriter(AddSync(&ztest.FailWriter{}), 1)
+	assert.Error(t, w.WriteFrame(InfoLevel, _frameEpoch, []byte("foo")), "Expected write errors to be returned.")
+
+	w = NewFrameWriter(&ztest.Discarder{}, 1)
+	assert.ErrorContains(t, w.WriteFrame(InfoLevel, _frameEpoch, make([]byte, 64<<20)), "too large to frame", "Expected oversized entries to be rejected.")
+
+	sink := &ztest.Discarder{}
+	sink.SetError(fmt.Errorf("sync failed"))
+	w = NewFrameWriter(sink, 1)
+	assert.EqualError(t, w.Sync(), "sync failed", "Expected sync errors to be returned.")
+}
+
+func TestFrameSchema(t *testing.T) {
+	schema := Schema{ID: 7, Name: "checkout", Version: 3, Definition: []byte(`{"user":"string"}`)}
+	reg := NewSchemaRegistry()
+
+	tests := []struct {
+		desc   string
+		opts   []FrameWriterOption
+		want   Schema
+		wantOK bool
+	}{
+		{desc: "no schema"},
+		{
+			desc:   "inline",
+			opts:   []FrameWriterOption{FrameWriterSchema(schema)},
+			want:   Schema{Name: "checkout", Version: 3, Definition: schema.Definition},
+			wantOK: true,
+		},
+		{
+			desc:   "registered",
+			opts:   []FrameWriterOption{FrameWriterSchema(schema), FrameWriterSchemaRegistry(reg)},
+			want:   Schema{ID: 1, Name: "checkout", Version: 3},
+			wantOK: true,
+		},
+		{
+			desc: "registry without schema",
+			opts: []FrameWriterOption{FrameWriterSchemaRegistry(reg)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			fr, err := NewFrameReader(bytes.NewReader(writeFrames(t, 25, 10, true, tt.opts...)))
+			require.NoError(t, err, "Unexpected error opening stream.")
+
+			got, ok := fr.Schema()
+			assert.Equal(t, tt.wantOK, ok, "Unexpected presence of a schema.")
+			assert.Equal(t, tt.want, got, "Unexpected schema.")
+			if got.ID != 0 {
+				registered, err := reg.Lookup(got.ID)
+				require.NoError(t, err, "Unexpected error looking up schema.")
+				assert.Equal(t, schema.Definition, registered.Definition, "Expected the definition in the registry.")
+			}
+
+			assert.Len(t, readFrames(t, fr), 25, "Expected every entry to be read back.")
+			require.NoError(t, fr.SeekTime(_frameEpoch), "Unexpected error seeking.")
+			frames := readFrames(t, fr)
+			require.Len(t, frames, 25, "Expected seeking to the start to find every entry.")
+			assert.Equal(t, `{"msg":"0"}`+"\n", string(frames[0].Payload), "Expected the schema frame to be skipped.")
+		})
+	}
+}
+
+func TestFrameSchemaErrors(t *testing.T) {
+	reg := NewSchemaRegistry()
+	_, err := reg.Register(Schema{Name: "checkout", Version: 1, Definition: []byte("old")})
+	require.NoError(t, err, "Unexpected error registering schema.")
+
+	var buf bytes.Buffer
+	w := NewFrameWriter(AddSync(&buf), 0,
+		FrameWriterSchema(Schema{Name: "checkout", Version: 1, Definition: []byte("new")}),
+		FrameWriterSchemaRegistry(reg),
+	)
+	assert.ErrorContains(t, w.WriteFrame(InfoLevel, _frameEpoch, []byte("foo")), "can't register log schema", "Expected registry errors to be returned.")
+	assert.Zero(t, buf.Len(), "Expected nothing to be written without the schema.")
+
+	w = NewFrameWriter(AddSync(&buf), 0, FrameWriterSchema(Schema{Name: string(make([]byte, 1<<16))}))
+	assert.ErrorContains(t, w.WriteFrame(InfoLevel, _frameEpoch, []byte("foo")), "too long", "Expected oversized names to be rejected.")
+
+	stream := []byte("ZAPFRM\x00\x01\x03\x00\x00\x00\x02xx")
+	_, err = NewFrameReader(bytes.NewReader(stream))
+	assert.ErrorIs(t, err, ErrInvalidFrameStream, "Expected a malformed schema frame to be reported.")
+}
+
+func TestFrameCoreWith(t *testing.T) {
+	var buf bytes.Buffer
+	core := NewFrameCore(NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), NewFrameWriter(AddSync(&buf), 0), InfoLevel)
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected core level.")
+
+	core = core.With([]Field{makeInt64Field("k", 1)})
+	if ce := core.Check(Entry{Level: DebugLevel}, nil); ce != nil {
+		t.Fatal("Expected debug entries to be disabled.")
+	}
+	ce := core.Check(Entry{Level: InfoLevel, Time: _frameEpoch, Message: "hi"}, nil)
+	require.NotNil(t, ce, "Expected info entries to be enabled.")
+	ce.Write()
+
+	fr, err := NewFrameReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err, "Unexpected error opening stream.")
+	f, err := fr.Next()
+	require.NoError(t, err, "Unexpected error reading frame.")
+	assert.Equal(t, `{"msg":"hi","k":1}`+"\n", string(f.Payload), "Expected context to be encoded.")
+}
+
+func BenchmarkFrameWriter(b *testing.B) {
+	w := NewFrameWriter(AddSync(io.Discard), 0)
+	payload := []byte(`{"level":"info","msg":"benchmark"}` + "\n")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := w.WriteFrame(InfoLevel, _frameEpoch, payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
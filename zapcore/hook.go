@@ -20,7 +20,11 @@
 
 package zapcore
 
-import "go.uber.org/multierr"
+import (
+	"context"
+
+	"go.uber.org/multierr"
+)
 
 type hooked struct {
 	Core
@@ -36,7 +40,9 @@ var (
 // hooks each time a message is logged. Execution of the callbacks is blocking.
 //
 // This offers users an easy way to register simple callbacks (e.g., metrics
-// collection) without implementing the full Core interface.
+// collection) without implementing the full Core interface. The hooks are
+// copied once and shared, without locking, by the returned Core and every
+// Core derived from it with With.
 func RegisterHooks(core Core, hooks ...func(Entry) error) Core {
 	funcs := append([]func(Entry) error{}, hooks...)
 	return &hooked{
@@ -75,3 +81,70 @@ func (h *hooked) Write(ent Entry, _ []Field) error {
 	}
 	return err
 }
+
+type fieldHooked struct {
+	Core
+	funcs []func(Entry, []Field) ([]Field, error)
+}
+
+var (
+	_ ContextCore    = (*fieldHooked)(nil)
+	_ leveledEnabler = (*fieldHooked)(nil)
+)
+
+// RegisterFieldHooks wraps a Core and runs a collection of user-defined
+// callback hooks each time a message is logged, before the message is
+// written. Unlike the hooks passed to RegisterHooks, these hooks see the
+// fields passed at the log site, and they return the fields to write: a hook
+// may inspect the fields (e.g., to count errors by type), replace them (e.g.,
+// to redact secrets), or append to them (e.g., to add derived fields).
+// Hooks run in order, each receiving the fields returned by the previous
+// one. If a hook returns an error, its fields are discarded and the error is
+// reported once the message has been written.
+//
+// Hooks don't see fields added to the logger with With, since the Core has
+// already encoded them. Execution of the callbacks is blocking.
+func RegisterFieldHooks(core Core, hooks ...func(Entry, []Field) ([]Field, error)) Core {
+	funcs := append([]func(Entry, []Field) ([]Field, error){}, hooks...)
+	return &fieldHooked{
+		Core:  core,
+		funcs: funcs,
+	}
+}
+
+func (h *fieldHooked) Level() Level {
+	return LevelOf(h.Core)
+}
+
+func (h *fieldHooked) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	// The hooks must run before the wrapped Core writes the entry, so we
+	// can't let it register itself with the CheckedEntry.
+	if h.Core.Enabled(ent.Level) {
+		return ce.AddCore(ent, h)
+	}
+	return ce
+}
+
+func (h *fieldHooked) With(fields []Field) Core {
+	return &fieldHooked{
+		Core:  h.Core.With(fields),
+		funcs: h.funcs,
+	}
+}
+
+func (h *fieldHooked) Write(ent Entry, fields []Field) error {
+	return h.WriteContext(context.Background(), ent, fields)
+}
+
+func (h *fieldHooked) WriteContext(ctx context.Context, ent Entry, fields []Field) error {
+	var err error
+	for i := range h.funcs {
+		hooked, hookErr := h.funcs[i](ent, fields)
+		if hookErr != nil {
+			err = multierr.Append(err, hookErr)
+			continue
+		}
+		fields = hooked
+	}
+	return multierr.Append(writeChecked(ctx, h.Core, ent, fields), err)
+}
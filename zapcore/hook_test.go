@@ -21,6 +21,7 @@
 package zapcore_test
 
 import (
+	"errors"
 	"testing"
 
 	//revive:disable:dot-imports
@@ -81,3 +82,41 @@ func TestHooks(t *testing.T) {
 		}
 	}
 }
+
+func TestFieldHooks(t *testing.T) {
+	fac, logs := observer.New(InfoLevel)
+	secret := Field{Key: "password", Type: StringType, String: "hunter2"}
+	redacted := Field{Key: "password", Type: StringType, String: "[REDACTED]"}
+	derived := makeInt64Field("fields", 2)
+
+	redact := func(_ Entry, fields []Field) ([]Field, error) {
+		out := make([]Field, len(fields))
+		for i, f := range fields {
+			if f.Key == "password" {
+				f = redacted
+			}
+			out[i] = f
+		}
+		return out, nil
+	}
+	enrich := func(_ Entry, fields []Field) ([]Field, error) {
+		return append(fields, makeInt64Field("fields", len(fields))), nil
+	}
+	failing := func(Entry, []Field) ([]Field, error) {
+		return nil, errors.New("fail")
+	}
+
+	intField := makeInt64Field("foo", 42)
+	h := RegisterFieldHooks(fac, redact, failing, enrich).With([]Field{intField})
+	assert.Equal(t, InfoLevel, LevelOf(h), "Wrapped core has the wrong level.")
+	assert.Nil(t, h.Check(Entry{Level: DebugLevel}, nil), "Expected disabled levels to be dropped.")
+
+	ent := Entry{Message: "bar", Level: InfoLevel}
+	err := h.Write(ent, []Field{secret, makeInt64Field("n", 1)})
+	assert.EqualError(t, err, "fail", "Expected hook errors to be reported.")
+
+	assert.Equal(t, []observer.LoggedEntry{{
+		Entry:   ent,
+		Context: []Field{intField, redacted, makeInt64Field("n", 1), derived},
+	}}, logs.AllUntimed(), "Unexpected logs written out.")
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+var (
+	// ErrUnsafeReuse is reported when a CheckedEntry is written after it
+	// was returned to the pool.
+	ErrUnsafeReuse = errors.New("unsafe CheckedEntry re-use")
+
+	_internalErrorHandler atomic.Pointer[func(error, Entry)]
+)
+
+// SetInternalErrorHandler registers a function to call when zap runs into a
+// problem of its own, such as a Core failing to write an entry, so that
+// applications can count and alert on failures of their logging pipeline.
+// The handler receives the error and the entry being logged when it
+// happened; the entry is empty if there wasn't one.
+//
+// The handler is called in addition to writing the error to the Logger's
+// ErrorOutput. It may be called concurrently, and must not log with a Logger
+// that could call it again. Pass nil to remove the handler.
+//
+// SetInternalErrorHandler returns a function to restore the previous
+// handler.
+func SetInternalErrorHandler(handler func(err error, ent Entry)) func() {
+	var prev *func(error, Entry)
+	if handler == nil {
+		prev = _internalErrorHandler.Swap(nil)
+	} else {
+		prev = _internalErrorHandler.Swap(&handler)
+	}
+	return func() {
+		_internalErrorHandler.Store(prev)
+	}
+}
+
+// ReportInternalError reports an error to the handler registered with
+// SetInternalErrorHandler, if any. Core implementations may use it to report
+// errors they can't return to their caller.
+func ReportInternalError(err error, ent Entry) {
+	if h := _internalErrorHandler.Load(); h != nil {
+		(*h)(err, ent)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestInternalErrorHandler(t *testing.T) {
+	type report struct {
+		err error
+		ent Entry
+	}
+	var reports []report
+	restore := SetInternalErrorHandler(func(err error, ent Entry) {
+		reports = append(reports, report{err, ent})
+	})
+	defer restore()
+
+	failure := errors.New("fail")
+	core, logs := observer.New(InfoLevel)
+	logs.FailWrites(func(observer.LoggedEntry) error { return failure })
+
+	ent := Entry{Level: InfoLevel, Message: "hello"}
+	ce := core.Check(ent, nil)
+	require.NotNil(t, ce, "Expected entry to be accepted.")
+	ce.Write()
+	require.Len(t, reports, 1, "Expected write failure to be reported.")
+	assert.Equal(t, failure, reports[0].err, "Unexpected error reported.")
+	assert.Equal(t, ent, reports[0].ent, "Unexpected entry reported.")
+
+	ReportInternalError(failure, Entry{})
+	assert.Len(t, reports, 2, "Expected ReportInternalError to call the handler.")
+
+	restore()
+	ReportInternalError(failure, Entry{})
+	assert.Len(t, reports, 2, "Expected no handler after restoring.")
+}
+
+func TestInternalErrorHandlerUnsafeReuse(t *testing.T) {
+	var reported []error
+	defer SetInternalErrorHandler(func(err error, _ Entry) {
+		reported = append(reported, err)
+	})()
+
+	core, _ := observer.New(InfoLevel)
+	ce := core.Check(Entry{Level: InfoLevel}, nil)
+	ce.Write()
+	ce.Write()
+	assert.Equal(t, []error{ErrUnsafeReuse}, reported, "Expected unsafe re-use to be reported.")
+}
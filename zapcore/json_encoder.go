@@ -21,7 +21,9 @@
 package zapcore
 
 import (
+	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"math"
 	"time"
 	"unicode/utf8"
@@ -186,6 +188,16 @@ func (enc *jsonEncoder) AddReflected(key string, obj interface{}) error {
 	return err
 }
 
+func (enc *jsonEncoder) AddRawJSON(key string, raw []byte) error {
+	raw, err := compactRawJSON(raw)
+	if err != nil {
+		return err
+	}
+	enc.addKey(key)
+	_, err = enc.buf.Write(raw)
+	return err
+}
+
 func (enc *jsonEncoder) OpenNamespace(key string) {
 	enc.addKey(key)
 	enc.buf.AppendByte('{')
@@ -210,11 +222,21 @@ func (enc *jsonEncoder) AddUint64(key string, val uint64) {
 func (enc *jsonEncoder) AppendArray(arr ArrayMarshaler) error {
 	enc.addElementSeparator()
 	enc.buf.AppendByte('[')
-	err := arr.MarshalLogArray(enc)
+	err := enc.marshalArray(arr)
 	enc.buf.AppendByte(']')
 	return err
 }
 
+// marshalArray calls a user-supplied ArrayMarshaler, recovering from panics
+// unless the config says otherwise, so that the array is still closed.
+func (enc *jsonEncoder) marshalArray(arr ArrayMarshaler) (err error) {
+	if enc.PropagateMarshalerPanics {
+		return arr.MarshalLogArray(enc)
+	}
+	defer recoverMarshaler(&err)
+	return arr.MarshalLogArray(enc)
+}
+
 func (enc *jsonEncoder) AppendObject(obj ObjectMarshaler) error {
 	// Close ONLY new openNamespaces that are created during
 	// AppendObject().
@@ -222,13 +244,24 @@ func (enc *jsonEncoder) AppendObject(obj ObjectMarshaler) error {
 	enc.openNamespaces = 0
 	enc.addElementSeparator()
 	enc.buf.AppendByte('{')
-	err := obj.MarshalLogObject(enc)
+	err := enc.marshalObject(obj)
 	enc.buf.AppendByte('}')
 	enc.closeOpenNamespaces()
 	enc.openNamespaces = old
 	return err
 }
 
+// marshalObject calls a user-supplied ObjectMarshaler, recovering from
+// panics unless the config says otherwise, so that the object is still
+// closed.
+func (enc *jsonEncoder) marshalObject(obj ObjectMarshaler) (err error) {
+	if enc.PropagateMarshalerPanics {
+		return obj.MarshalLogObject(enc)
+	}
+	defer recoverMarshaler(&err)
+	return obj.MarshalLogObject(enc)
+}
+
 func (enc *jsonEncoder) AppendBool(val bool) {
 	enc.addElementSeparator()
 	enc.buf.AppendBool(val)
@@ -289,6 +322,16 @@ func (enc *jsonEncoder) AppendReflected(val interface{}) error {
 	return err
 }
 
+func (enc *jsonEncoder) AppendRawJSON(raw []byte) error {
+	raw, err := compactRawJSON(raw)
+	if err != nil {
+		return err
+	}
+	enc.addElementSeparator()
+	_, err = enc.buf.Write(raw)
+	return err
+}
+
 func (enc *jsonEncoder) AppendString(val string) {
 	enc.addElementSeparator()
 	enc.buf.AppendByte('"')
@@ -404,14 +447,18 @@ func (enc *jsonEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer,
 				final.AppendString(ent.Caller.String())
 			}
 		}
-		if final.FunctionKey != "" {
+		if final.FunctionKey != "" && !(final.SkipEmptyFunction && ent.Caller.Function == "") {
 			final.addKey(final.FunctionKey)
 			final.AppendString(ent.Caller.Function)
 		}
 	}
-	if final.MessageKey != "" {
-		final.addKey(enc.MessageKey)
-		final.AppendString(ent.Message)
+	if ent.Event != "" && final.EventKey != "" {
+		final.addKey(final.EventKey)
+		final.AppendString(ent.Event)
+	}
+	if msg, ok := final.entryMessage(ent, fields); ok {
+		final.addKey(final.MessageKey)
+		final.AppendString(msg)
 	}
 	if enc.buf.Len() > 0 {
 		final.addElementSeparator()
@@ -581,3 +628,19 @@ func safeAppendStringLike[S []byte | string](
 	// add remaining
 	appendTo(buf, s[last:])
 }
+
+// compactRawJSON validates raw and, if it spans several lines, compacts it,
+// so that it doesn't break up line-delimited output.
+func compactRawJSON(raw []byte) ([]byte, error) {
+	if bytes.IndexAny(raw, "\r\n") < 0 {
+		if !json.Valid(raw) {
+			return nil, errInvalidRawJSON
+		}
+		return raw, nil
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, raw); err != nil {
+		return nil, errInvalidRawJSON
+	}
+	return compacted.Bytes(), nil
+}
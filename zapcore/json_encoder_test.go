@@ -21,11 +21,13 @@
 package zapcore_test
 
 import (
+	"encoding/json"
 	"io"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -280,3 +282,79 @@ func TestJSONCustomReflectedEncoder(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONEncoderMarshalerPanics(t *testing.T) {
+	panicObj := zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+		enc.AddString("before", "ok")
+		panic("oops")
+	})
+	panicArr := zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+		enc.AppendInt(1)
+		panic("oops")
+	})
+	nested := zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+		enc.AddString("a", "b")
+		return enc.AddObject("inner", panicObj)
+	})
+
+	tests := []struct {
+		desc  string
+		field zapcore.Field
+		want  string
+	}{
+		{
+			desc:  "object",
+			field: zap.Object("k", panicObj),
+			want:  `{"k":{"before":"ok"},"kError":"PANIC=oops"}`,
+		},
+		{
+			desc:  "array",
+			field: zap.Array("k", panicArr),
+			want:  `{"k":[1],"kError":"PANIC=oops"}`,
+		},
+		{
+			desc:  "nested object",
+			field: zap.Object("k", nested),
+			want:  `{"k":{"a":"b","inner":{"before":"ok"}},"kError":"PANIC=oops"}`,
+		},
+		{
+			desc:  "inline",
+			field: zap.Inline(panicObj),
+			want:  `{"before":"ok","Error":"PANIC=oops"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var reported []error
+			defer zapcore.SetInternalErrorHandler(func(err error, _ zapcore.Entry) {
+				reported = append(reported, err)
+			})()
+
+			enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{})
+			buf, err := enc.EncodeEntry(zapcore.Entry{}, []zapcore.Field{tt.field})
+			require.NoError(t, err, "Unexpected error encoding entry.")
+			defer buf.Free()
+
+			got := buf.String()
+			assert.JSONEq(t, tt.want, got, "Unexpected output.")
+			assert.True(t, json.Valid([]byte(got)), "Expected valid JSON.")
+			require.Len(t, reported, 1, "Expected the panic to be reported once.")
+			assert.EqualError(t, reported[0], "PANIC=oops", "Unexpected error reported.")
+		})
+	}
+}
+
+func TestJSONEncoderPropagateMarshalerPanics(t *testing.T) {
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{PropagateMarshalerPanics: true})
+	panicObj := zapcore.ObjectMarshalerFunc(func(zapcore.ObjectEncoder) error {
+		panic("oops")
+	})
+
+	assert.PanicsWithValue(t, "oops", func() {
+		_, _ = enc.EncodeEntry(zapcore.Entry{}, []zapcore.Field{zap.Object("k", panicObj)})
+	}, "Expected object marshaler panic to propagate.")
+	assert.PanicsWithValue(t, "oops", func() {
+		_, _ = enc.EncodeEntry(zapcore.Entry{}, []zapcore.Field{zap.Inline(panicObj)})
+	}, "Expected inline marshaler panic to propagate.")
+}
@@ -0,0 +1,459 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A KeyCase is a naming convention for field keys.
+type KeyCase uint8
+
+const (
+	// SnakeCaseKeys converts keys to snake_case, like "request_id".
+	SnakeCaseKeys KeyCase = iota + 1
+	// CamelCaseKeys converts keys to camelCase, like "requestId".
+	CamelCaseKeys
+)
+
+// String returns the name of the key case, as accepted by UnmarshalText.
+func (kc KeyCase) String() string {
+	switch kc {
+	case SnakeCaseKeys:
+		return "snake"
+	case CamelCaseKeys:
+		return "camel"
+	default:
+		return fmt.Sprintf("KeyCase(%d)", kc)
+	}
+}
+
+// MarshalText marshals the KeyCase to text.
+func (kc KeyCase) MarshalText() ([]byte, error) {
+	return []byte(kc.String()), nil
+}
+
+// UnmarshalText unmarshals text to a KeyCase. "snake" and "snake_case" are
+// unmarshaled to SnakeCaseKeys, and "camel" and "camelCase" to
+// CamelCaseKeys.
+func (kc *KeyCase) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "snake", "snake_case":
+		*kc = SnakeCaseKeys
+	case "camel", "camelCase":
+		*kc = CamelCaseKeys
+	default:
+		return fmt.Errorf("unrecognized key case: %q", text)
+	}
+	return nil
+}
+
+// Convert returns the key in this case. Words are split at underscores,
+// hyphens, spaces, and changes of case, so "requestID", "RequestId",
+// "request-id", and "request_id" all convert to the same key. Dots are kept,
+// and the parts between them converted separately, so that dotted keys like
+// "http.statusCode" keep their structure. Leading underscores are kept too.
+//
+// Conversions are cached, so converting the same keys over and over is
+// cheap.
+func (kc KeyCase) Convert(key string) string {
+	switch kc {
+	case SnakeCaseKeys:
+		return _snakeCaseKeys.convert(key)
+	case CamelCaseKeys:
+		return _camelCaseKeys.convert(key)
+	default:
+		return key
+	}
+}
+
+// _maxCachedKeys is the most conversions a keyConverter caches. Keys are
+// usually drawn from a small set, but nothing stops callers from building
+// them from unbounded data.
+const _maxCachedKeys = 4096
+
+var (
+	_snakeCaseKeys = &keyConverter{join: joinSnake}
+	_camelCaseKeys = &keyConverter{join: joinCamel}
+)
+
+// keyConverter converts keys to one KeyCase, caching the results.
+type keyConverter struct {
+	join func(*strings.Builder, []string)
+
+	cache sync.Map // string -> string
+	size  atomic.Int64
+}
+
+func (kc *keyConverter) convert(key string) string {
+	if key == "" {
+		return key
+	}
+	if converted, ok := kc.cache.Load(key); ok {
+		return converted.(string)
+	}
+
+	var sb strings.Builder
+	for i, part := range strings.Split(key, ".") {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		trimmed := strings.TrimLeft(part, "_")
+		sb.WriteString(part[:len(part)-len(trimmed)])
+		kc.join(&sb, splitWords(trimmed))
+	}
+	converted := sb.String()
+
+	if kc.size.Load() < _maxCachedKeys {
+		if _, loaded := kc.cache.LoadOrStore(key, converted); !loaded {
+			kc.size.Add(1)
+		}
+	}
+	return converted
+}
+
+// splitWords splits a key into words at separators and changes of case. A
+// run of capitals is one word, except that its last capital starts a new
+// word if a lower-case letter follows it, so "HTTPServer" is split into
+// "HTTP" and "Server". A lone "s" after a run of capitals is taken as a
+// plural, so "userIDs" is split into "user" and "IDs".
+func splitWords(s string) []string {
+	var (
+		words []string
+		runes = []rune(s)
+		start = -1
+	)
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == ' ' {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		if !unicode.IsUpper(r) {
+			continue
+		}
+		prev := runes[i-1]
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+			(unicode.IsUpper(prev) && startsWord(runes[i+1:])) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// startsWord reports whether a capital that ends a run of capitals and is
+// followed by the given runes starts a new word.
+func startsWord(rest []rune) bool {
+	if len(rest) == 0 || !unicode.IsLower(rest[0]) {
+		return false
+	}
+	plural := rest[0] == 's' && (len(rest) == 1 || !unicode.IsLower(rest[1]))
+	return !plural
+}
+
+func joinSnake(sb *strings.Builder, words []string) {
+	for i, w := range words {
+		if i > 0 {
+			sb.WriteByte('_')
+		}
+		sb.WriteString(strings.ToLower(w))
+	}
+}
+
+func joinCamel(sb *strings.Builder, words []string) {
+	for i, w := range words {
+		w = strings.ToLower(w)
+		if i == 0 {
+			sb.WriteString(w)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(w)
+		sb.WriteRune(unicode.ToUpper(r))
+		sb.WriteString(w[size:])
+	}
+}
+
+type keyCaseCore struct {
+	Core
+
+	keyCase KeyCase
+}
+
+var (
+	_ ContextCore    = (*keyCaseCore)(nil)
+	_ leveledEnabler = (*keyCaseCore)(nil)
+)
+
+// NewKeyCaseCore wraps a Core, converting the keys of all fields to the given
+// case before they're encoded. This includes keys added with With, the
+// names of namespaces, and the keys of objects logged with ObjectMarshalers
+// and ArrayMarshalers. Keys inside values serialized by reflection, and the
+// keys an Encoder uses for entry metadata like the message and level, are
+// left alone.
+//
+// Normalizing keys is useful when logs from many teams, who may not agree on
+// naming, end up in the same dashboards.
+func NewKeyCaseCore(core Core, kc KeyCase) Core {
+	return &keyCaseCore{
+		Core:    core,
+		keyCase: kc,
+	}
+}
+
+func (c *keyCaseCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *keyCaseCore) With(fields []Field) Core {
+	return &keyCaseCore{
+		Core:    c.Core.With(c.convertFields(fields)),
+		keyCase: c.keyCase,
+	}
+}
+
+func (c *keyCaseCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	// Converting keys needs the fields, which are only available in Write,
+	// so register ourselves rather than letting the wrapped Core do so.
+	if c.Core.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *keyCaseCore) Write(ent Entry, fields []Field) error {
+	return c.WriteContext(context.Background(), ent, fields)
+}
+
+func (c *keyCaseCore) WriteContext(ctx context.Context, ent Entry, fields []Field) error {
+	return writeChecked(ctx, c.Core, ent, c.convertFields(fields))
+}
+
+// convertFields returns a copy of the fields with their keys converted, or
+// the fields themselves if none of them need to change.
+func (c *keyCaseCore) convertFields(fields []Field) []Field {
+	var converted []Field
+	for i, f := range fields {
+		g, changed := c.convertField(f)
+		if !changed {
+			if converted != nil {
+				converted[i] = f
+			}
+			continue
+		}
+		if converted == nil {
+			converted = make([]Field, len(fields))
+			copy(converted, fields[:i])
+		}
+		converted[i] = g
+	}
+	if converted == nil {
+		return fields
+	}
+	return converted
+}
+
+func (c *keyCaseCore) convertField(f Field) (Field, bool) {
+	key := c.keyCase.Convert(f.Key)
+	changed := key != f.Key
+	f.Key = key
+
+	switch f.Type {
+	case ObjectMarshalerType, InlineMarshalerType:
+		f.Interface = keyCaseObject{f.Interface.(ObjectMarshaler), c.keyCase}
+		changed = true
+	case ArrayMarshalerType:
+		f.Interface = keyCaseArray{f.Interface.(ArrayMarshaler), c.keyCase}
+		changed = true
+	}
+	return f, changed
+}
+
+// keyCaseObject converts the keys of the object a marshaler adds.
+type keyCaseObject struct {
+	m  ObjectMarshaler
+	kc KeyCase
+}
+
+func (o keyCaseObject) MarshalLogObject(enc ObjectEncoder) error {
+	return o.m.MarshalLogObject(keyCaseObjectEncoder{enc, o.kc})
+}
+
+// keyCaseArray converts the keys of any objects in the array a marshaler
+// adds.
+type keyCaseArray struct {
+	m  ArrayMarshaler
+	kc KeyCase
+}
+
+func (a keyCaseArray) MarshalLogArray(enc ArrayEncoder) error {
+	return a.m.MarshalLogArray(keyCaseArrayEncoder{enc, a.kc})
+}
+
+// keyCaseObjectEncoder converts keys before passing them on to an
+// ObjectEncoder.
+type keyCaseObjectEncoder struct {
+	enc ObjectEncoder
+	kc  KeyCase
+}
+
+var _ ObjectEncoder = keyCaseObjectEncoder{}
+
+func (e keyCaseObjectEncoder) AddArray(key string, m ArrayMarshaler) error {
+	return e.enc.AddArray(e.kc.Convert(key), keyCaseArray{m, e.kc})
+}
+
+func (e keyCaseObjectEncoder) AddObject(key string, m ObjectMarshaler) error {
+	return e.enc.AddObject(e.kc.Convert(key), keyCaseObject{m, e.kc})
+}
+
+func (e keyCaseObjectEncoder) AddBinary(key string, v []byte) {
+	e.enc.AddBinary(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddByteString(key string, v []byte) {
+	e.enc.AddByteString(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddBool(key string, v bool) {
+	e.enc.AddBool(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddComplex128(key string, v complex128) {
+	e.enc.AddComplex128(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddComplex64(key string, v complex64) {
+	e.enc.AddComplex64(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddDuration(key string, v time.Duration) {
+	e.enc.AddDuration(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddFloat64(key string, v float64) {
+	e.enc.AddFloat64(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddFloat32(key string, v float32) {
+	e.enc.AddFloat32(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddInt(key string, v int) {
+	e.enc.AddInt(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddInt64(key string, v int64) {
+	e.enc.AddInt64(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddInt32(key string, v int32) {
+	e.enc.AddInt32(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddInt16(key string, v int16) {
+	e.enc.AddInt16(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddInt8(key string, v int8) {
+	e.enc.AddInt8(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddString(key, v string) {
+	e.enc.AddString(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddTime(key string, v time.Time) {
+	e.enc.AddTime(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddUint(key string, v uint) {
+	e.enc.AddUint(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddUint64(key string, v uint64) {
+	e.enc.AddUint64(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddUint32(key string, v uint32) {
+	e.enc.AddUint32(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddUint16(key string, v uint16) {
+	e.enc.AddUint16(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddUint8(key string, v uint8) {
+	e.enc.AddUint8(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddUintptr(key string, v uintptr) {
+	e.enc.AddUintptr(e.kc.Convert(key), v)
+}
+
+func (e keyCaseObjectEncoder) AddReflected(key string, v interface{}) error {
+	return e.enc.AddReflected(e.kc.Convert(key), v)
+}
+
+// AddRawJSON converts the key, but not the keys within the raw JSON.
+func (e keyCaseObjectEncoder) AddRawJSON(key string, raw []byte) error {
+	return AddRawJSON(e.enc, e.kc.Convert(key), raw)
+}
+
+func (e keyCaseObjectEncoder) OpenNamespace(key string) {
+	e.enc.OpenNamespace(e.kc.Convert(key))
+}
+
+// keyCaseArrayEncoder converts the keys of objects appended to an
+// ArrayEncoder.
+type keyCaseArrayEncoder struct {
+	ArrayEncoder
+
+	kc KeyCase
+}
+
+func (e keyCaseArrayEncoder) AppendArray(m ArrayMarshaler) error {
+	return e.ArrayEncoder.AppendArray(keyCaseArray{m, e.kc})
+}
+
+func (e keyCaseArrayEncoder) AppendObject(m ObjectMarshaler) error {
+	return e.ArrayEncoder.AppendObject(keyCaseObject{m, e.kc})
+}
+
+func (e keyCaseArrayEncoder) AppendRawJSON(raw []byte) error {
+	return AppendRawJSON(e.ArrayEncoder, raw)
+}
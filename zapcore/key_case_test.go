@@ -0,0 +1,247 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/internal/ztest"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestKeyCaseConvert(t *testing.T) {
+	tests := []struct {
+		give      string
+		wantSnake string
+		wantCamel string
+	}{
+		{"", "", ""},
+		{"id", "id", "id"},
+		{"requestID", "request_id", "requestId"},
+		{"RequestId", "request_id", "requestId"},
+		{"request-id", "request_id", "requestId"},
+		{"request_id", "request_id", "requestId"},
+		{"request id", "request_id", "requestId"},
+		{"REQUEST_ID", "request_id", "requestId"},
+		{"HTTPServer", "http_server", "httpServer"},
+		{"userIDs", "user_ids", "userIds"},
+		{"IDsByURL", "ids_by_url", "idsByUrl"},
+		{"HTTPSession", "http_session", "httpSession"},
+		{"http2Enabled", "http2_enabled", "http2Enabled"},
+		{"utf8Bytes", "utf8_bytes", "utf8Bytes"},
+		{"http.statusCode", "http.status_code", "http.statusCode"},
+		{"Http.Status_Code", "http.status_code", "http.statusCode"},
+		{"_id", "_id", "_id"},
+		{"__Meta.__rawValue", "__meta.__raw_value", "__meta.__rawValue"},
+		{"a__b--c", "a_b_c", "aBC"},
+		{"żółwPrędkość", "żółw_prędkość", "żółwPrędkość"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			assert.Equal(t, tt.wantSnake, SnakeCaseKeys.Convert(tt.give), "Unexpected snake_case key.")
+			assert.Equal(t, tt.wantCamel, CamelCaseKeys.Convert(tt.give), "Unexpected camelCase key.")
+			// Converted keys are cached, so converting again must agree.
+			assert.Equal(t, tt.wantSnake, SnakeCaseKeys.Convert(tt.give), "Unexpected cached snake_case key.")
+			assert.Equal(t, tt.give, KeyCase(0).Convert(tt.give), "Expected unknown key case to leave key alone.")
+		})
+	}
+}
+
+func TestKeyCaseConvertManyKeys(t *testing.T) {
+	// Keys built from unbounded data mustn't grow the cache without bound, but
+	// they must still be converted.
+	for i := 0; i < 10000; i++ {
+		key := "dynamicKey" + strconv.Itoa(i)
+		require.Equal(t, "dynamic_key"+strconv.Itoa(i), SnakeCaseKeys.Convert(key), "Unexpected key.")
+	}
+}
+
+func TestKeyCaseText(t *testing.T) {
+	tests := []struct {
+		give    string
+		want    KeyCase
+		wantStr string
+	}{
+		{"snake", SnakeCaseKeys, "snake"},
+		{"snake_case", SnakeCaseKeys, "snake"},
+		{"camel", CamelCaseKeys, "camel"},
+		{"camelCase", CamelCaseKeys, "camel"},
+	}
+
+	for _, tt := range tests {
+		var kc KeyCase
+		require.NoError(t, kc.UnmarshalText([]byte(tt.give)), "Unexpected error unmarshaling %q.", tt.give)
+		assert.Equal(t, tt.want, kc, "Unexpected key case for %q.", tt.give)
+
+		text, err := kc.MarshalText()
+		require.NoError(t, err, "Unexpected error marshaling %v.", kc)
+		assert.Equal(t, tt.wantStr, string(text), "Unexpected text for %v.", kc)
+	}
+
+	var kc KeyCase
+	assert.Error(t, kc.UnmarshalText([]byte("kebab")), "Expected an error for unknown key case.")
+	assert.Equal(t, "KeyCase(42)", KeyCase(42).String(), "Unexpected name for unknown key case.")
+}
+
+func TestKeyCaseCore(t *testing.T) {
+	fac, logs := observer.New(InfoLevel)
+	core := NewKeyCaseCore(fac, SnakeCaseKeys).
+		With([]Field{makeInt64Field("hostName", 1)})
+
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+	assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected disabled levels to be dropped.")
+
+	fields := []Field{makeInt64Field("requestID", 2), makeInt64Field("status", 3)}
+	core.Check(Entry{Level: InfoLevel, Message: "hello"}, nil).Write(fields...)
+
+	assert.Equal(t, []observer.LoggedEntry{{
+		Entry: Entry{Level: InfoLevel, Message: "hello"},
+		Context: []Field{
+			makeInt64Field("host_name", 1),
+			makeInt64Field("request_id", 2),
+			makeInt64Field("status", 3),
+		},
+	}}, logs.AllUntimed(), "Unexpected logs.")
+	assert.Equal(t, "requestID", fields[0].Key, "Converting keys shouldn't modify the caller's fields.")
+}
+
+func TestKeyCaseCoreNested(t *testing.T) {
+	user := ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		enc.AddString("firstName", "Jane")
+		enc.OpenNamespace("accountInfo")
+		return enc.AddArray("recentLogins", ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+			arr.AppendString("today")
+			return arr.AppendObject(ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+				enc.AddInt("loginCount", 2)
+				return enc.AddArray("ipAddrs", ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+					return arr.AppendArray(ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+						return arr.AppendObject(ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+							enc.AddString("ipV4", "127.0.0.1")
+							return nil
+						}))
+					}))
+				}))
+			}))
+		}))
+	})
+
+	buf := &ztest.Buffer{}
+	core := NewKeyCaseCore(
+		NewCore(NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), buf, DebugLevel),
+		CamelCaseKeys,
+	).With([]Field{{Key: "Current_User", Type: ObjectMarshalerType, Interface: user}})
+
+	core.Check(Entry{Level: InfoLevel, Message: "hello"}, nil).Write(
+		Field{Key: "request-ids", Type: ArrayMarshalerType, Interface: ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+			return arr.AppendObject(ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+				enc.AddString("trace_id", "abc")
+				return nil
+			}))
+		})},
+		Field{Type: InlineMarshalerType, Interface: ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+			enc.AddBool("is_inline", true)
+			return nil
+		})},
+		Field{Key: "retry_count", Type: Int64Type, Integer: 1},
+	)
+
+	assert.Equal(t,
+		`{"msg":"hello",`+
+			`"currentUser":{"firstName":"Jane","accountInfo":{"recentLogins":["today",{"loginCount":2,"ipAddrs":[[{"ipV4":"127.0.0.1"}]]}]}},`+
+			`"requestIds":[{"traceId":"abc"}],"isInline":true,"retryCount":1}`+"\n",
+		buf.String(), "Unexpected output.")
+}
+
+func TestKeyCaseObjectEncoder(t *testing.T) {
+	now := time.Unix(0, 0)
+	obj := ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		enc.AddBinary("aBinary", []byte("b"))
+		enc.AddByteString("aByteString", []byte("s"))
+		enc.AddBool("aBool", true)
+		enc.AddComplex128("aComplex128", 1)
+		enc.AddComplex64("aComplex64", 1)
+		enc.AddDuration("aDuration", time.Second)
+		enc.AddFloat64("aFloat64", 1)
+		enc.AddFloat32("aFloat32", 1)
+		enc.AddInt("anInt", 1)
+		enc.AddInt64("anInt64", 1)
+		enc.AddInt32("anInt32", 1)
+		enc.AddInt16("anInt16", 1)
+		enc.AddInt8("anInt8", 1)
+		enc.AddString("aString", "s")
+		enc.AddTime("aTime", now)
+		enc.AddUint("aUint", 1)
+		enc.AddUint64("aUint64", 1)
+		enc.AddUint32("aUint32", 1)
+		enc.AddUint16("aUint16", 1)
+		enc.AddUint8("aUint8", 1)
+		enc.AddUintptr("aUintptr", 1)
+		if err := enc.AddReflected("aReflected", map[string]int{"keptKey": 1}); err != nil {
+			return err
+		}
+		if err := AddRawJSON(enc, "aRawJSON", []byte(`{"keptKey":1}`)); err != nil {
+			return err
+		}
+		return enc.AddObject("anObject", ObjectMarshalerFunc(func(ObjectEncoder) error { return nil }))
+	})
+
+	fac, logs := observer.New(InfoLevel)
+	core := NewKeyCaseCore(fac, SnakeCaseKeys)
+	core.Check(Entry{Level: InfoLevel}, nil).Write(Field{Key: "obj", Type: ObjectMarshalerType, Interface: obj})
+
+	require.Equal(t, 1, logs.Len(), "Expected one entry.")
+	assert.Equal(t, map[string]interface{}{
+		"obj": map[string]interface{}{
+			"a_binary":      []byte("b"),
+			"a_byte_string": "s",
+			"a_bool":        true,
+			"a_complex128":  complex128(1),
+			"a_complex64":   complex64(1),
+			"a_duration":    time.Second,
+			"a_float64":     float64(1),
+			"a_float32":     float32(1),
+			"an_int":        int(1),
+			"an_int64":      int64(1),
+			"an_int32":      int32(1),
+			"an_int16":      int16(1),
+			"an_int8":       int8(1),
+			"a_string":      "s",
+			"a_time":        now,
+			"a_uint":        uint(1),
+			"a_uint64":      uint64(1),
+			"a_uint32":      uint32(1),
+			"a_uint16":      uint16(1),
+			"a_uint8":       uint8(1),
+			"a_uintptr":     uintptr(1),
+			"a_reflected":   map[string]int{"keptKey": 1},
+			"a_raw_json":    json.RawMessage(`{"keptKey":1}`),
+			"an_object":     map[string]interface{}{},
+		},
+	}, logs.All()[0].ContextMap(), "Unexpected keys.")
+}
@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var errUnmarshalNilLevel = errors.New("can't unmarshal a nil *Level")
@@ -32,9 +33,17 @@ var errUnmarshalNilLevel = errors.New("can't unmarshal a nil *Level")
 type Level int8
 
 const (
+	// TraceLevel logs are even more detailed than DebugLevel logs, and are
+	// meant for following a program's execution step by step. They're almost
+	// always disabled.
+	//
+	// Levels below TraceLevel have no names, but they're otherwise valid:
+	// cores, encoders, and AtomicLevels all handle them. Logger.V produces
+	// such levels.
+	TraceLevel Level = iota - 2
 	// DebugLevel logs are typically voluminous, and are usually disabled in
 	// production.
-	DebugLevel Level = iota - 1
+	DebugLevel
 	// InfoLevel is the default logging priority.
 	InfoLevel
 	// WarnLevel logs are more important than Info, but don't need individual
@@ -51,7 +60,7 @@ const (
 	// FatalLevel logs a message, then calls os.Exit(1).
 	FatalLevel
 
-	_minLevel = DebugLevel
+	_minLevel = TraceLevel
 	_maxLevel = FatalLevel
 
 	// InvalidLevel is an invalid value for Level.
@@ -64,6 +73,9 @@ const (
 // representation of the log level. If the provided ASCII representation is
 // invalid an error is returned.
 //
+// Common names from other logging libraries, such as "err", "crit", and
+// "verbose", are accepted as aliases; see RegisterLevelAlias.
+//
 // This is particularly useful when dealing with text input to configure log
 // levels.
 func ParseLevel(text string) (Level, error) {
@@ -112,6 +124,8 @@ func LevelOf(enab LevelEnabler) Level {
 // String returns a lower-case ASCII representation of the log level.
 func (l Level) String() string {
 	switch l {
+	case TraceLevel:
+		return "trace"
 	case DebugLevel:
 		return "debug"
 	case InfoLevel:
@@ -127,6 +141,9 @@ func (l Level) String() string {
 	case FatalLevel:
 		return "fatal"
 	default:
+		if name, ok := customLevelName(l); ok {
+			return name
+		}
 		return fmt.Sprintf("Level(%d)", l)
 	}
 }
@@ -136,6 +153,8 @@ func (l Level) CapitalString() string {
 	// Printing levels in all-caps is common enough that we should export this
 	// functionality.
 	switch l {
+	case TraceLevel:
+		return "TRACE"
 	case DebugLevel:
 		return "DEBUG"
 	case InfoLevel:
@@ -151,6 +170,9 @@ func (l Level) CapitalString() string {
 	case FatalLevel:
 		return "FATAL"
 	default:
+		if name, ok := customLevelName(l); ok {
+			return strings.ToUpper(name)
+		}
 		return fmt.Sprintf("LEVEL(%d)", l)
 	}
 }
@@ -178,25 +200,42 @@ func (l *Level) RUnmarshalText(text []byte) error {
 }
 
 func (l *Level) unmarshalText(text []byte) bool {
-	switch string(text) {
+	name := string(text)
+	lvl, ok := builtinLevel(name)
+	if !ok {
+		lvl, ok = customLevel(name)
+	}
+	if !ok {
+		lvl, ok = _defaultLevelAliases[name]
+	}
+	if ok {
+		*l = lvl
+	}
+	return ok
+}
+
+// builtinLevel looks up one of Zap's levels by its canonical name.
+func builtinLevel(name string) (Level, bool) {
+	switch name {
+	case "trace":
+		return TraceLevel, true
 	case "debug":
-		*l = DebugLevel
+		return DebugLevel, true
 	case "info", "": // make the zero value useful
-		*l = InfoLevel
+		return InfoLevel, true
 	case "warn", "warning":
-		*l = WarnLevel
+		return WarnLevel, true
 	case "error":
-		*l = ErrorLevel
+		return ErrorLevel, true
 	case "dpanic":
-		*l = DPanicLevel
+		return DPanicLevel, true
 	case "panic":
-		*l = PanicLevel
+		return PanicLevel, true
 	case "fatal":
-		*l = FatalLevel
+		return FatalLevel, true
 	default:
-		return false
+		return 0, false
 	}
-	return true
 }
 
 // Set sets the level for the flag.Value interface.
@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// _defaultLevelAliases maps level names commonly used by other logging
+// libraries (syslog, log4j, Python's logging, and so on) to Zap's levels.
+// They may be redefined with RegisterLevelAlias.
+var _defaultLevelAliases = map[string]Level{
+	"verbose":   DebugLevel,
+	"err":       ErrorLevel,
+	"crit":      DPanicLevel,
+	"critical":  DPanicLevel,
+	"alert":     DPanicLevel,
+	"emerg":     FatalLevel,
+	"emergency": FatalLevel,
+}
+
+// levelNames holds the names of custom levels registered with RegisterLevel,
+// along with aliases registered with RegisterLevelAlias. It's never modified
+// once published, so readers don't need to lock.
+type levelNames struct {
+	byLevel map[Level]string // custom levels only
+	byName  map[string]Level // custom levels and aliases
+}
+
+func (n *levelNames) clone() *levelNames {
+	c := &levelNames{
+		byLevel: make(map[Level]string, len(n.byLevel)+1),
+		byName:  make(map[string]Level, len(n.byName)+1),
+	}
+	for l, name := range n.byLevel {
+		c.byLevel[l] = name
+	}
+	for name, l := range n.byName {
+		c.byName[name] = l
+	}
+	return c
+}
+
+var (
+	_levelNamesMu sync.Mutex // serializes registration
+	_levelNames   atomic.Pointer[levelNames]
+)
+
+// RegisterLevel gives a name to a custom level, so that it round-trips
+// through String, CapitalString, MarshalText, ParseLevel, UnmarshalText, and
+// the level encoders (and therefore through configuration files and the
+// AtomicLevel HTTP handler) instead of printing as "Level(-3)".
+//
+// Names are case-insensitive: they're stored and printed in lower case, and
+// CapitalString prints them in upper case. The names, values, and built-in
+// aliases of Zap's levels can't be reused, and each value and name may only
+// be registered once.
+//
+// RegisterLevel is intended to be called during program initialization.
+// Note that LevelOf only considers Zap's built-in levels.
+func RegisterLevel(value int8, name string) error {
+	lvl := Level(value)
+	name = strings.ToLower(name)
+	if name == "" {
+		return errors.New("can't register a level with an empty name")
+	}
+	if lvl >= _minLevel && lvl <= _maxLevel {
+		return fmt.Errorf("can't rename built-in level %v", lvl)
+	}
+	if builtin, ok := builtinLevel(name); ok {
+		return fmt.Errorf("level name %q is already used by built-in level %v", name, builtin)
+	}
+	if builtin, ok := _defaultLevelAliases[name]; ok {
+		return fmt.Errorf("level name %q is already an alias for built-in level %v", name, builtin)
+	}
+
+	_levelNamesMu.Lock()
+	defer _levelNamesMu.Unlock()
+
+	old := loadLevelNames()
+	if existing, ok := old.byLevel[lvl]; ok {
+		return fmt.Errorf("level %d is already registered as %q", value, existing)
+	}
+	if existing, ok := old.byName[name]; ok {
+		return fmt.Errorf("level name %q is already registered for level %d", name, existing)
+	}
+
+	names := old.clone()
+	names.byLevel[lvl] = name
+	names.byName[name] = lvl
+	_levelNames.Store(names)
+	return nil
+}
+
+// RegisterLevelAlias makes ParseLevel and UnmarshalText accept an
+// additional, case-insensitive name for a level. Aliases only affect
+// parsing: levels are always printed with their canonical names.
+//
+// Zap has built-in aliases for names commonly used by other logging
+// libraries:
+//
+//   - "verbose" for DebugLevel
+//   - "err" for ErrorLevel
+//   - "crit", "critical", and "alert" for DPanicLevel
+//   - "emerg" and "emergency" for FatalLevel
+//
+// These may be redefined (for example, to map "critical" to FatalLevel), but
+// the canonical level names and previously registered names and aliases
+// can't be. RegisterLevelAlias is intended to be called during program
+// initialization.
+func RegisterLevelAlias(alias string, lvl Level) error {
+	alias = strings.ToLower(alias)
+	if alias == "" {
+		return errors.New("can't register an empty level alias")
+	}
+	if builtin, ok := builtinLevel(alias); ok {
+		return fmt.Errorf("level alias %q is already used by built-in level %v", alias, builtin)
+	}
+
+	_levelNamesMu.Lock()
+	defer _levelNamesMu.Unlock()
+
+	old := loadLevelNames()
+	if existing, ok := old.byName[alias]; ok {
+		return fmt.Errorf("level alias %q is already registered for level %v", alias, existing)
+	}
+
+	names := old.clone()
+	names.byName[alias] = lvl
+	_levelNames.Store(names)
+	return nil
+}
+
+func loadLevelNames() *levelNames {
+	if names := _levelNames.Load(); names != nil {
+		return names
+	}
+	return &levelNames{}
+}
+
+// customLevelName returns the registered name of a custom level.
+func customLevelName(l Level) (string, bool) {
+	names := _levelNames.Load()
+	if names == nil {
+		return "", false
+	}
+	name, ok := names.byLevel[l]
+	return name, ok
+}
+
+// customLevel looks up a custom level or registered alias by its
+// (lower-case) name.
+func customLevel(name string) (Level, bool) {
+	names := _levelNames.Load()
+	if names == nil {
+		return 0, false
+	}
+	l, ok := names.byName[name]
+	return l, ok
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withCustomLevels discards any levels registered during the test.
+func withCustomLevels(t testing.TB) {
+	old := _levelNames.Load()
+	t.Cleanup(func() { _levelNames.Store(old) })
+}
+
+func TestRegisterLevel(t *testing.T) {
+	withCustomLevels(t)
+
+	require.NoError(t, RegisterLevel(-3, "Fine"), "Unexpected error registering level.")
+	require.NoError(t, RegisterLevel(10, "audit"), "Unexpected error registering level.")
+	require.NoError(t, RegisterLevel(11, "notice"), "Unexpected error registering a name other libraries use.")
+	audit := Level(10)
+
+	assert.Equal(t, "fine", Level(-3).String(), "Unexpected lowercase name.")
+	assert.Equal(t, "AUDIT", audit.CapitalString(), "Unexpected capital name.")
+	assert.Equal(t, "Level(-4)", Level(-4).String(), "Unregistered levels shouldn't have names.")
+
+	text, err := audit.MarshalText()
+	require.NoError(t, err, "Unexpected error marshaling level.")
+	assert.Equal(t, "audit", string(text), "Unexpected marshaled level.")
+
+	for _, s := range []string{"audit", "AUDIT", "Audit"} {
+		lvl, err := ParseLevel(s)
+		assert.NoError(t, err, "Unexpected error parsing %q.", s)
+		assert.Equal(t, audit, lvl, "Unexpected level parsed from %q.", s)
+	}
+
+	enc := NewJSONEncoder(EncoderConfig{LevelKey: "level", EncodeLevel: CapitalLevelEncoder})
+	buf, err := enc.EncodeEntry(Entry{Level: audit}, nil)
+	require.NoError(t, err, "Unexpected error encoding entry.")
+	assert.Equal(t, `{"level":"AUDIT"}`+"\n", buf.String(), "Unexpected encoded level.")
+	buf.Free()
+}
+
+func TestRegisterLevelErrors(t *testing.T) {
+	withCustomLevels(t)
+	require.NoError(t, RegisterLevel(-3, "fine"), "Unexpected error registering level.")
+
+	tests := []struct {
+		desc  string
+		value int8
+		name  string
+		err   string
+	}{
+		{"empty name", -4, "", "empty name"},
+		{"built-in value", int8(InfoLevel), "notice", "can't rename built-in level info"},
+		{"built-in name", -4, "Warning", `"warning" is already used by built-in level warn`},
+		{"built-in alias", -4, "Crit", `"crit" is already an alias for built-in level dpanic`},
+		{"duplicate value", -3, "chatty", `level -3 is already registered as "fine"`},
+		{"duplicate name", -4, "FINE", `"fine" is already registered for level -3`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.ErrorContains(t, RegisterLevel(tt.value, tt.name), tt.err, "Unexpected error.")
+		})
+	}
+	assert.Equal(t, "Level(-4)", Level(-4).String(), "Failed registrations shouldn't name levels.")
+}
+
+func TestLevelAliases(t *testing.T) {
+	withCustomLevels(t)
+
+	tests := []struct {
+		give string
+		want Level
+	}{
+		{"verbose", DebugLevel},
+		{"err", ErrorLevel},
+		{"ERR", ErrorLevel},
+		{"crit", DPanicLevel},
+		{"Critical", DPanicLevel},
+		{"alert", DPanicLevel},
+		{"emerg", FatalLevel},
+		{"emergency", FatalLevel},
+	}
+	for _, tt := range tests {
+		lvl, err := ParseLevel(tt.give)
+		assert.NoError(t, err, "Unexpected error parsing %q.", tt.give)
+		assert.Equal(t, tt.want, lvl, "Unexpected level parsed from %q.", tt.give)
+	}
+
+	require.NoError(t, RegisterLevelAlias("CRITICAL", FatalLevel), "Unexpected error redefining built-in alias.")
+	require.NoError(t, RegisterLevelAlias("severe", ErrorLevel), "Unexpected error registering alias.")
+
+	lvl, err := ParseLevel("critical")
+	require.NoError(t, err, "Unexpected error parsing redefined alias.")
+	assert.Equal(t, FatalLevel, lvl, "Expected registered alias to override the built-in one.")
+
+	lvl, err = ParseLevel("Severe")
+	require.NoError(t, err, "Unexpected error parsing registered alias.")
+	assert.Equal(t, ErrorLevel, lvl, "Unexpected level for registered alias.")
+	assert.Equal(t, "error", lvl.String(), "Aliases shouldn't change level names.")
+}
+
+func TestRegisterLevelAliasErrors(t *testing.T) {
+	withCustomLevels(t)
+	require.NoError(t, RegisterLevel(-3, "fine"), "Unexpected error registering level.")
+	require.NoError(t, RegisterLevelAlias("severe", ErrorLevel), "Unexpected error registering alias.")
+
+	tests := []struct {
+		desc  string
+		alias string
+		err   string
+	}{
+		{"empty", "", "empty level alias"},
+		{"canonical name", "Info", `"info" is already used by built-in level info`},
+		{"custom level name", "fine", `"fine" is already registered for level fine`},
+		{"duplicate alias", "SEVERE", `"severe" is already registered for level error`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.ErrorContains(t, RegisterLevelAlias(tt.alias, WarnLevel), tt.err, "Unexpected error.")
+		})
+	}
+}
@@ -20,7 +20,10 @@
 
 package zapcore
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // MapObjectEncoder is an ObjectEncoder backed by a simple
 // map[string]interface{}. It's not fast enough for production use, but it's
@@ -44,7 +47,7 @@ func NewMapObjectEncoder() *MapObjectEncoder {
 // AddArray implements ObjectEncoder.
 func (m *MapObjectEncoder) AddArray(key string, v ArrayMarshaler) error {
 	arr := &sliceArrayEncoder{elems: make([]interface{}, 0)}
-	err := v.MarshalLogArray(arr)
+	err := marshalArray(v, arr)
 	m.cur[key] = arr.elems
 	return err
 }
@@ -53,7 +56,7 @@ func (m *MapObjectEncoder) AddArray(key string, v ArrayMarshaler) error {
 func (m *MapObjectEncoder) AddObject(k string, v ObjectMarshaler) error {
 	newMap := NewMapObjectEncoder()
 	m.cur[k] = newMap.Fields
-	return v.MarshalLogObject(newMap)
+	return marshalObject(v, newMap)
 }
 
 // AddBinary implements ObjectEncoder.
@@ -125,6 +128,16 @@ func (m *MapObjectEncoder) AddReflected(k string, v interface{}) error {
 	return nil
 }
 
+// AddRawJSON implements RawJSONObjectEncoder. The JSON is stored as a copy
+// in a json.RawMessage.
+func (m *MapObjectEncoder) AddRawJSON(k string, raw []byte) error {
+	if !json.Valid(raw) {
+		return errInvalidRawJSON
+	}
+	m.cur[k] = append(json.RawMessage(nil), raw...)
+	return nil
+}
+
 // OpenNamespace implements ObjectEncoder.
 func (m *MapObjectEncoder) OpenNamespace(k string) {
 	ns := make(map[string]interface{})
@@ -140,14 +153,14 @@ type sliceArrayEncoder struct {
 
 func (s *sliceArrayEncoder) AppendArray(v ArrayMarshaler) error {
 	enc := &sliceArrayEncoder{}
-	err := v.MarshalLogArray(enc)
+	err := marshalArray(v, enc)
 	s.elems = append(s.elems, enc.elems)
 	return err
 }
 
 func (s *sliceArrayEncoder) AppendObject(v ObjectMarshaler) error {
 	m := NewMapObjectEncoder()
-	err := v.MarshalLogObject(m)
+	err := marshalObject(v, m)
 	s.elems = append(s.elems, m.Fields)
 	return err
 }
@@ -157,6 +170,14 @@ func (s *sliceArrayEncoder) AppendReflected(v interface{}) error {
 	return nil
 }
 
+func (s *sliceArrayEncoder) AppendRawJSON(raw []byte) error {
+	if !json.Valid(raw) {
+		return errInvalidRawJSON
+	}
+	s.elems = append(s.elems, append(json.RawMessage(nil), raw...))
+	return nil
+}
+
 func (s *sliceArrayEncoder) AppendBool(v bool)              { s.elems = append(s.elems, v) }
 func (s *sliceArrayEncoder) AppendByteString(v []byte)      { s.elems = append(s.elems, string(v)) }
 func (s *sliceArrayEncoder) AppendComplex128(v complex128)  { s.elems = append(s.elems, v) }
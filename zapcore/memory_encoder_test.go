@@ -368,3 +368,25 @@ func TestMapObjectEncoderReflectionFailures(t *testing.T) {
 		"Expected encoder to use empty values on errors.",
 	)
 }
+
+func TestMapObjectEncoderMarshalerPanics(t *testing.T) {
+	panicObj := ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		enc.AddString("before", "ok")
+		panic("oops")
+	})
+	panicArr := ArrayMarshalerFunc(func(enc ArrayEncoder) error {
+		panic("oops")
+	})
+
+	enc := NewMapObjectEncoder()
+	assert.EqualError(t, enc.AddObject("obj", panicObj), "PANIC=oops", "Expected AddObject to recover.")
+	assert.EqualError(t, enc.AddArray("arr", panicArr), "PANIC=oops", "Expected AddArray to recover.")
+	assert.EqualError(t, enc.AddArray("objs", ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+		return arr.AppendObject(panicObj)
+	})), "PANIC=oops", "Expected AppendObject to recover.")
+	assert.Equal(t, map[string]interface{}{
+		"obj":  map[string]interface{}{"before": "ok"},
+		"arr":  []interface{}{},
+		"objs": []interface{}{map[string]interface{}{"before": "ok"}},
+	}, enc.Fields, "Unexpected encoded fields.")
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// An OrderedField is a key-value pair recorded by an OrderedObjectEncoder.
+// Nested objects and namespaces are recorded as []OrderedField values, and
+// arrays as []interface{} values; other values are recorded as they were
+// passed to the encoder, like with MapObjectEncoder.
+type OrderedField struct {
+	Key   string
+	Value interface{}
+}
+
+// OrderedObjectEncoder is an ObjectEncoder backed by a slice, which retains
+// the order in which fields were added. Unlike MapObjectEncoder, it doesn't
+// allocate a map per object and can be reused with Reset, so it suits hooks
+// and Cores that need to inspect the fields of every entry.
+type OrderedObjectEncoder struct {
+	// levels holds the fields of the object and of each namespace opened
+	// in it. Each namespace is recorded as the last field of its parent
+	// level, and its fields are attached to it by Fields.
+	levels [][]OrderedField
+}
+
+var (
+	_ ObjectEncoder        = (*OrderedObjectEncoder)(nil)
+	_ RawJSONObjectEncoder = (*OrderedObjectEncoder)(nil)
+)
+
+// NewOrderedObjectEncoder creates a new slice-backed ObjectEncoder.
+func NewOrderedObjectEncoder() *OrderedObjectEncoder {
+	return &OrderedObjectEncoder{levels: make([][]OrderedField, 1)}
+}
+
+// Fields returns the fields added to the encoder, in order. The returned
+// slice is owned by the encoder and is only valid until it's reset.
+func (o *OrderedObjectEncoder) Fields() []OrderedField {
+	for i := len(o.levels) - 1; i > 0; i-- {
+		parent := o.levels[i-1]
+		parent[len(parent)-1].Value = o.levels[i]
+	}
+	return o.levels[0]
+}
+
+// Lookup returns the value of the last top-level field with the given key.
+func (o *OrderedObjectEncoder) Lookup(key string) (interface{}, bool) {
+	fields := o.Fields()
+	for i := len(fields) - 1; i >= 0; i-- {
+		if fields[i].Key == key {
+			return fields[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// Reset removes all fields from the encoder, keeping its capacity so that
+// it can be reused without allocating.
+func (o *OrderedObjectEncoder) Reset() {
+	top := o.levels[0]
+	for i := range top {
+		top[i] = OrderedField{} // don't retain values
+	}
+	o.levels = append(o.levels[:0], top[:0])
+}
+
+func (o *OrderedObjectEncoder) add(k string, v interface{}) {
+	n := len(o.levels) - 1
+	o.levels[n] = append(o.levels[n], OrderedField{Key: k, Value: v})
+}
+
+// AddArray implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddArray(key string, v ArrayMarshaler) error {
+	arr := &orderedArrayEncoder{elems: make([]interface{}, 0)}
+	err := marshalArray(v, arr)
+	o.add(key, arr.elems)
+	return err
+}
+
+// AddObject implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddObject(k string, v ObjectMarshaler) error {
+	obj := NewOrderedObjectEncoder()
+	err := marshalObject(v, obj)
+	o.add(k, obj.Fields())
+	return err
+}
+
+// AddBinary implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddBinary(k string, v []byte) { o.add(k, v) }
+
+// AddByteString implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddByteString(k string, v []byte) { o.add(k, string(v)) }
+
+// AddBool implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddBool(k string, v bool) { o.add(k, v) }
+
+// AddDuration implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddDuration(k string, v time.Duration) { o.add(k, v) }
+
+// AddComplex128 implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddComplex128(k string, v complex128) { o.add(k, v) }
+
+// AddComplex64 implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddComplex64(k string, v complex64) { o.add(k, v) }
+
+// AddFloat64 implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddFloat64(k string, v float64) { o.add(k, v) }
+
+// AddFloat32 implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddFloat32(k string, v float32) { o.add(k, v) }
+
+// AddInt implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddInt(k string, v int) { o.add(k, v) }
+
+// AddInt64 implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddInt64(k string, v int64) { o.add(k, v) }
+
+// AddInt32 implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddInt32(k string, v int32) { o.add(k, v) }
+
+// AddInt16 implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddInt16(k string, v int16) { o.add(k, v) }
+
+// AddInt8 implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddInt8(k string, v int8) { o.add(k, v) }
+
+// AddString implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddString(k string, v string) { o.add(k, v) }
+
+// AddTime implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddTime(k string, v time.Time) { o.add(k, v) }
+
+// AddUint implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddUint(k string, v uint) { o.add(k, v) }
+
+// AddUint64 implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddUint64(k string, v uint64) { o.add(k, v) }
+
+// AddUint32 implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddUint32(k string, v uint32) { o.add(k, v) }
+
+// AddUint16 implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddUint16(k string, v uint16) { o.add(k, v) }
+
+// AddUint8 implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddUint8(k string, v uint8) { o.add(k, v) }
+
+// AddUintptr implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddUintptr(k string, v uintptr) { o.add(k, v) }
+
+// AddReflected implements ObjectEncoder.
+func (o *OrderedObjectEncoder) AddReflected(k string, v interface{}) error {
+	o.add(k, v)
+	return nil
+}
+
+// AddRawJSON implements RawJSONObjectEncoder. The JSON is stored as a copy
+// in a json.RawMessage.
+func (o *OrderedObjectEncoder) AddRawJSON(k string, raw []byte) error {
+	if !json.Valid(raw) {
+		return errInvalidRawJSON
+	}
+	o.add(k, append(json.RawMessage(nil), raw...))
+	return nil
+}
+
+// OpenNamespace implements ObjectEncoder.
+func (o *OrderedObjectEncoder) OpenNamespace(k string) {
+	o.add(k, []OrderedField(nil))
+	o.levels = append(o.levels, nil)
+}
+
+// orderedArrayEncoder is an ArrayEncoder backed by a []interface{}, which
+// records objects with OrderedObjectEncoders.
+type orderedArrayEncoder struct {
+	elems []interface{}
+}
+
+var _ RawJSONArrayEncoder = (*orderedArrayEncoder)(nil)
+
+func (s *orderedArrayEncoder) AppendArray(v ArrayMarshaler) error {
+	enc := &orderedArrayEncoder{}
+	err := marshalArray(v, enc)
+	s.elems = append(s.elems, enc.elems)
+	return err
+}
+
+func (s *orderedArrayEncoder) AppendObject(v ObjectMarshaler) error {
+	obj := NewOrderedObjectEncoder()
+	err := marshalObject(v, obj)
+	s.elems = append(s.elems, obj.Fields())
+	return err
+}
+
+func (s *orderedArrayEncoder) AppendReflected(v interface{}) error {
+	s.elems = append(s.elems, v)
+	return nil
+}
+
+func (s *orderedArrayEncoder) AppendRawJSON(raw []byte) error {
+	if !json.Valid(raw) {
+		return errInvalidRawJSON
+	}
+	s.elems = append(s.elems, append(json.RawMessage(nil), raw...))
+	return nil
+}
+
+func (s *orderedArrayEncoder) AppendBool(v bool)              { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendByteString(v []byte)      { s.elems = append(s.elems, string(v)) }
+func (s *orderedArrayEncoder) AppendComplex128(v complex128)  { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendComplex64(v complex64)    { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendDuration(v time.Duration) { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendFloat64(v float64)        { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendFloat32(v float32)        { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendInt(v int)                { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendInt64(v int64)            { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendInt32(v int32)            { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendInt16(v int16)            { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendInt8(v int8)              { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendString(v string)          { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendTime(v time.Time)         { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendUint(v uint)              { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendUint64(v uint64)          { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendUint32(v uint32)          { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendUint16(v uint16)          { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendUint8(v uint8)            { s.elems = append(s.elems, v) }
+func (s *orderedArrayEncoder) AppendUintptr(v uintptr)        { s.elems = append(s.elems, v) }
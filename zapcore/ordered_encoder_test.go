@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderedObjectEncoderAdd(t *testing.T) {
+	now := time.Unix(0, 0)
+	enc := NewOrderedObjectEncoder()
+	enc.AddString("z", "first")
+	enc.AddInt("a", 1)
+	enc.AddBool("m", true)
+	enc.AddByteString("bytes", []byte("s"))
+	enc.AddBinary("binary", []byte("b"))
+	enc.AddDuration("duration", time.Second)
+	enc.AddTime("time", now)
+	enc.AddFloat64("float64", 1.5)
+	enc.AddUint8("uint8", 8)
+	require.NoError(t, enc.AddReflected("reflected", map[string]int{"k": 1}), "Unexpected error adding reflected value.")
+	require.NoError(t, enc.AddRawJSON("raw", []byte(`{"k":1}`)), "Unexpected error adding raw JSON.")
+	require.NoError(t, enc.AddObject("turducken", turducken{}), "Unexpected error adding object.")
+	require.NoError(t, enc.AddArray("loggable", loggable{true}), "Unexpected error adding array.")
+
+	chicken := []OrderedField{{Key: "in", Value: "chicken"}}
+	assert.Equal(t, []OrderedField{
+		{Key: "z", Value: "first"},
+		{Key: "a", Value: 1},
+		{Key: "m", Value: true},
+		{Key: "bytes", Value: "s"},
+		{Key: "binary", Value: []byte("b")},
+		{Key: "duration", Value: time.Second},
+		{Key: "time", Value: now},
+		{Key: "float64", Value: 1.5},
+		{Key: "uint8", Value: uint8(8)},
+		{Key: "reflected", Value: map[string]int{"k": 1}},
+		{Key: "raw", Value: json.RawMessage(`{"k":1}`)},
+		{Key: "turducken", Value: []OrderedField{
+			{Key: "ducks", Value: []interface{}{chicken, chicken}},
+		}},
+		{Key: "loggable", Value: []interface{}{true}},
+	}, enc.Fields(), "Unexpected fields.")
+}
+
+func TestOrderedObjectEncoderNamespaces(t *testing.T) {
+	enc := NewOrderedObjectEncoder()
+	enc.AddString("a", "outer")
+	enc.OpenNamespace("ns")
+	enc.AddInt("b", 1)
+	enc.OpenNamespace("inner")
+	enc.AddBool("c", true)
+	require.NoError(t, enc.AddObject("obj", ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		enc.OpenNamespace("objNS")
+		enc.AddString("d", "nested")
+		return nil
+	})), "Unexpected error adding object.")
+
+	want := []OrderedField{
+		{Key: "a", Value: "outer"},
+		{Key: "ns", Value: []OrderedField{
+			{Key: "b", Value: 1},
+			{Key: "inner", Value: []OrderedField{
+				{Key: "c", Value: true},
+				{Key: "obj", Value: []OrderedField{
+					{Key: "objNS", Value: []OrderedField{{Key: "d", Value: "nested"}}},
+				}},
+			}},
+		}},
+	}
+	assert.Equal(t, want, enc.Fields(), "Unexpected fields.")
+
+	// Fields is idempotent, and fields added later still land in the
+	// innermost namespace.
+	assert.Equal(t, want, enc.Fields(), "Expected Fields to be idempotent.")
+	enc.AddString("e", "late")
+	inner := enc.Fields()[1].Value.([]OrderedField)[1].Value.([]OrderedField)
+	assert.Equal(t, OrderedField{Key: "e", Value: "late"}, inner[len(inner)-1], "Expected late field in the innermost namespace.")
+}
+
+func TestOrderedObjectEncoderLookupAndReset(t *testing.T) {
+	enc := NewOrderedObjectEncoder()
+	enc.AddString("k", "v1")
+	enc.AddString("k", "v2")
+	enc.OpenNamespace("ns")
+	enc.AddString("hidden", "v")
+
+	v, ok := enc.Lookup("k")
+	assert.True(t, ok, "Expected to find key.")
+	assert.Equal(t, "v2", v, "Expected the last value for a repeated key.")
+	_, ok = enc.Lookup("hidden")
+	assert.False(t, ok, "Expected only top-level keys to be found.")
+
+	enc.Reset()
+	assert.Empty(t, enc.Fields(), "Expected no fields after Reset.")
+	enc.AddInt("after", 1)
+	assert.Equal(t, []OrderedField{{Key: "after", Value: 1}}, enc.Fields(), "Expected fields to go to the top level after Reset.")
+}
+
+func TestOrderedObjectEncoderErrors(t *testing.T) {
+	enc := NewOrderedObjectEncoder()
+	assert.Error(t, enc.AddObject("obj", loggable{false}), "Expected AddObject to fail.")
+	assert.Error(t, enc.AddRawJSON("raw", []byte(`{`)), "Expected invalid raw JSON to fail.")
+	assert.Error(t, enc.AddArray("arr", ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+		panic(errors.New("oops"))
+	})), "Expected panics to be recovered as errors.")
+	assert.Equal(t, []OrderedField{
+		{Key: "obj", Value: []OrderedField(nil)},
+		{Key: "arr", Value: []interface{}{}},
+	}, enc.Fields(), "Unexpected fields after errors.")
+}
+
+func TestOrderedArrayEncoderAppend(t *testing.T) {
+	enc := NewOrderedObjectEncoder()
+	err := enc.AddArray("arr", ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+		arr.AppendString("s")
+		arr.AppendInt64(1)
+		arr.AppendByteString([]byte("b"))
+		arr.AppendDuration(time.Second)
+		if err := AppendRawJSON(arr, []byte(`[1]`)); err != nil {
+			return err
+		}
+		if err := arr.AppendReflected(struct{}{}); err != nil {
+			return err
+		}
+		return arr.AppendArray(ArrayMarshalerFunc(func(inner ArrayEncoder) error {
+			inner.AppendBool(true)
+			return nil
+		}))
+	}))
+	require.NoError(t, err, "Unexpected error adding array.")
+	assert.Equal(t, []interface{}{
+		"s", int64(1), "b", time.Second, json.RawMessage(`[1]`), struct{}{}, []interface{}{true},
+	}, enc.Fields()[0].Value, "Unexpected array.")
+}
+
+func BenchmarkObjectEncoders(b *testing.B) {
+	add := func(enc ObjectEncoder) {
+		enc.AddString("string", "value")
+		enc.AddInt64("int", 42)
+		enc.AddBool("bool", true)
+		enc.AddDuration("duration", time.Second)
+		_ = enc.AddObject("object", loggable{true})
+	}
+
+	b.Run("Map", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			add(NewMapObjectEncoder())
+		}
+	})
+	b.Run("Ordered", func(b *testing.B) {
+		b.ReportAllocs()
+		enc := NewOrderedObjectEncoder()
+		for i := 0; i < b.N; i++ {
+			enc.Reset()
+			add(enc)
+		}
+	})
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// NewPipelineDebugCore wraps a Core to report, for every entry, which Cores
+// accepted or rejected it and why, and how long accepted entries took to
+// encode and write. It's meant to answer "why didn't my log line appear?" in
+// complex stacks of Cores, and is too slow to leave on in production.
+//
+// Reports are written to w, one line per event, like:
+//
+//	zap pipeline: core[1] (*zapcore.sampler) dropped info "hello": sampled out
+//	zap pipeline: core[0] (*zapcore.ioCore) wrote info "hello": took 4.1µs (encode 3µs, write 1.1µs)
+//
+// The wrapper descends into Cores built by NewTee and NewTeeWithOptions,
+// naming their children by index, but treats all other Cores, including
+// those wrapping Tees, as a unit. To report entries no Core enables, the
+// returned Core enables every level; its Level method still reports the
+// wrapped Core's level.
+func NewPipelineDebugCore(core Core, w WriteSyncer) Core {
+	d := &pipelineDebugger{out: w}
+	return &debugRootCore{
+		Core: wrapDebug(core, "core", d),
+		d:    d,
+	}
+}
+
+// pipelineDebugger serializes reports from all the Cores of a pipeline.
+type pipelineDebugger struct {
+	mu  sync.Mutex
+	out WriteSyncer
+}
+
+func (d *pipelineDebugger) report(name string, core Core, event string, ent Entry, format string, args ...interface{}) {
+	var who string
+	if core != nil {
+		who = fmt.Sprintf("%s (%T) ", name, core)
+	}
+	msg := fmt.Sprintf("zap pipeline: %s%s %s %q", who, event, ent.Level, ent.Message)
+	if format != "" {
+		msg += ": " + fmt.Sprintf(format, args...)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, _ = d.out.Write([]byte(msg + "\n"))
+}
+
+// wrapDebug wraps core, or the children of a Tee, with debugCores.
+func wrapDebug(core Core, name string, d *pipelineDebugger) Core {
+	switch c := core.(type) {
+	case multiCore:
+		return wrapDebugTee(c, name, d)
+	case *optionsTee:
+		return &optionsTee{
+			cores:   wrapDebugTee(c.cores, name, d),
+			onError: c.onError,
+			sem:     c.sem,
+		}
+	case *debugCore, *debugRootCore:
+		return c
+	default:
+		return &debugCore{Core: c, name: name, d: d}
+	}
+}
+
+func wrapDebugTee(mc multiCore, name string, d *pipelineDebugger) multiCore {
+	wrapped := make(multiCore, len(mc))
+	for i, c := range mc {
+		wrapped[i] = wrapDebug(c, name+"["+strconv.Itoa(i)+"]", d)
+	}
+	return wrapped
+}
+
+// debugRootCore reports entries that no Core in the pipeline accepts.
+type debugRootCore struct {
+	Core
+
+	d *pipelineDebugger
+}
+
+var (
+	_ Core           = (*debugRootCore)(nil)
+	_ leveledEnabler = (*debugRootCore)(nil)
+)
+
+func (c *debugRootCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *debugRootCore) Enabled(Level) bool {
+	return true
+}
+
+func (c *debugRootCore) With(fields []Field) Core {
+	return &debugRootCore{Core: c.Core.With(fields), d: c.d}
+}
+
+func (c *debugRootCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if !c.Core.Enabled(ent.Level) {
+		c.d.report("", nil, "dropped", ent, "level disabled for every core")
+		return ce
+	}
+	n := ce.numCores()
+	ce = c.Core.Check(ent, ce)
+	if ce.numCores() == n {
+		c.d.report("", nil, "dropped", ent, "no core accepted it")
+	}
+	return ce
+}
+
+// debugCore reports the decisions of a single Core in the pipeline.
+type debugCore struct {
+	Core
+
+	name string
+	d    *pipelineDebugger
+}
+
+var (
+	_ Core           = (*debugCore)(nil)
+	_ leveledEnabler = (*debugCore)(nil)
+)
+
+func (c *debugCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *debugCore) With(fields []Field) Core {
+	return &debugCore{Core: c.Core.With(fields), name: c.name, d: c.d}
+}
+
+func (c *debugCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if !c.Core.Enabled(ent.Level) {
+		c.d.report(c.name, c.Core, "rejected", ent, "level disabled, minimum is %v", LevelOf(c.Core))
+		return ce
+	}
+
+	n := ce.numCores()
+	ce = c.Core.Check(ent, ce)
+	if ce.numCores() == n {
+		if _, ok := c.Core.(*sampler); ok {
+			c.d.report(c.name, c.Core, "dropped", ent, "sampled out")
+		} else {
+			c.d.report(c.name, c.Core, "rejected", ent, "filtered by Check")
+		}
+		return ce
+	}
+
+	c.d.report(c.name, c.Core, "accepted", ent, "")
+	for i := n; i < len(ce.cores); i++ {
+		ce.cores[i] = &debugWriteCore{Core: ce.cores[i], name: c.name, d: c.d}
+	}
+	return ce
+}
+
+// debugWriteCore times the writes of a Core that accepted an entry.
+type debugWriteCore struct {
+	Core
+
+	name string
+	d    *pipelineDebugger
+}
+
+var _ ContextCore = (*debugWriteCore)(nil)
+
+func (c *debugWriteCore) Write(ent Entry, fields []Field) error {
+	return c.WriteContext(context.Background(), ent, fields)
+}
+
+func (c *debugWriteCore) WriteContext(ctx context.Context, ent Entry, fields []Field) error {
+	start := time.Now()
+	ioc, ok := c.Core.(*ioCore)
+	if !ok {
+		err := writeContext(ctx, c.Core, ent, fields)
+		c.reportWrite(ent, time.Since(start), err, "")
+		return err
+	}
+
+	buf, err := ioc.encoder().EncodeEntry(ent, fields)
+	encoded := time.Since(start)
+	if err != nil {
+		c.reportWrite(ent, encoded, err, "encoding")
+		return err
+	}
+	err = ioc.writeBuffer(ent.Level, buf)
+	total := time.Since(start)
+	c.reportWrite(ent, total, err, fmt.Sprintf("encode %v, write %v", encoded, total-encoded))
+	return err
+}
+
+func (c *debugWriteCore) reportWrite(ent Entry, elapsed time.Duration, err error, detail string) {
+	switch {
+	case err != nil && detail != "":
+		c.d.report(c.name, c.Core, "failed", ent, "after %v (%s): %v", elapsed, detail, err)
+	case err != nil:
+		c.d.report(c.name, c.Core, "failed", ent, "after %v: %v", elapsed, err)
+	case detail != "":
+		c.d.report(c.name, c.Core, "wrote", ent, "took %v (%s)", elapsed, detail)
+	default:
+		c.d.report(c.name, c.Core, "wrote", ent, "took %v", elapsed)
+	}
+}
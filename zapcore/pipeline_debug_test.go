@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/internal/ztest"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestPipelineDebugCore(t *testing.T) {
+	out := &ztest.Buffer{}
+	errObs, _ := observer.New(ErrorLevel)
+	infoObs, infoLogs := observer.New(InfoLevel)
+	sampled := NewSamplerWithOptions(infoObs, time.Minute, 1, 0)
+	enc := NewJSONEncoder(testEncoderConfig())
+	ioSink := &ztest.Buffer{}
+	jsonCore := NewCore(enc, ioSink, DebugLevel)
+
+	core := NewPipelineDebugCore(NewTee(errObs, sampled, jsonCore), out)
+	assert.Equal(t, DebugLevel, LevelOf(core), "Expected the wrapped core's level.")
+	assert.True(t, core.Enabled(TraceLevel), "Expected every level to be enabled.")
+
+	write := func(lvl Level, msg string) {
+		out.Reset()
+		ent := Entry{Level: lvl, Message: msg}
+		if ce := core.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	write(InfoLevel, "hello")
+	lines := out.Lines()
+	require.Len(t, lines, 5, "Unexpected number of reports: %v", lines)
+	assert.Equal(t, `zap pipeline: core[0] (*observer.contextObserver) rejected info "hello": level disabled, minimum is error`, lines[0], "Unexpected report for a disabled core.")
+	assert.Contains(t, lines[1], `core[1] (*zapcore.sampler) accepted info "hello"`, "Unexpected report for the sampler.")
+	assert.Contains(t, lines[2], `core[2] (*zapcore.ioCore) accepted info "hello"`, "Unexpected report for the ioCore.")
+	assert.Regexp(t, `^zap pipeline: core\[1\] \(\*observer.contextObserver\) wrote info "hello": took \S+$`, lines[3], "Unexpected write report for the sampled core.")
+	assert.Regexp(t, `^zap pipeline: core\[2\] \(\*zapcore.ioCore\) wrote info "hello": took \S+ \(encode \S+, write \S+\)$`, lines[4], "Unexpected write report for the ioCore.")
+	assert.Equal(t, 1, infoLogs.Len(), "Expected the entry to reach the sampled core.")
+	assert.Contains(t, ioSink.String(), "hello", "Expected the entry to reach the ioCore.")
+
+	write(InfoLevel, "hello")
+	assert.Contains(t, out.String(), `core[1] (*zapcore.sampler) dropped info "hello": sampled out`, "Expected the sampler's decision to be reported.")
+
+	write(TraceLevel, "trace")
+	assert.Equal(t, []string{
+		`zap pipeline: dropped trace "trace": level disabled for every core`,
+	}, out.Lines(), "Expected entries no core enables to be reported.")
+}
+
+func TestPipelineDebugCoreNoneAccepted(t *testing.T) {
+	out := &ztest.Buffer{}
+	obs, logs := observer.New(InfoLevel)
+	core := NewPipelineDebugCore(NewSamplerWithOptions(obs, time.Minute, 1, 0), out)
+
+	for i := 0; i < 2; i++ {
+		if ce := core.Check(Entry{Level: InfoLevel, Message: "msg"}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	assert.Equal(t, 1, logs.Len(), "Expected sampling to be unaffected.")
+	assert.Contains(t, out.String(), `zap pipeline: core (*zapcore.sampler) dropped info "msg": sampled out`, "Expected sampler decision to be reported.")
+	assert.Contains(t, out.String(), `zap pipeline: dropped info "msg": no core accepted it`, "Expected dropped entry to be reported.")
+}
+
+func TestPipelineDebugCoreWith(t *testing.T) {
+	out := &ztest.Buffer{}
+	obs, logs := observer.New(InfoLevel)
+	core := NewPipelineDebugCore(NewTeeWithOptions([]Core{obs, obs}), out).
+		With([]Field{makeInt64Field("k", 1)})
+
+	ce := core.Check(Entry{Level: InfoLevel, Message: "msg"}, nil)
+	require.NotNil(t, ce, "Expected entry to be accepted.")
+	ce.Write()
+
+	require.Equal(t, 2, logs.Len(), "Expected entry to reach both children.")
+	assert.Equal(t, []Field{makeInt64Field("k", 1)}, logs.All()[0].Context, "Expected context to be kept.")
+	assert.Contains(t, out.String(), `core[0] (*observer.contextObserver) wrote info "msg"`, "Expected first child's write to be reported.")
+	assert.Contains(t, out.String(), `core[1] (*observer.contextObserver) wrote info "msg"`, "Expected second child's write to be reported.")
+}
+
+func TestPipelineDebugCoreWriteError(t *testing.T) {
+	out := &ztest.Buffer{}
+	obs, logs := observer.New(InfoLevel)
+	logs.FailWrites(func(observer.LoggedEntry) error { return errors.New("fail") })
+	ws := &ztest.FailWriter{}
+	jsonCore := NewCore(NewJSONEncoder(testEncoderConfig()), ws, InfoLevel)
+	core := NewPipelineDebugCore(NewTee(obs, jsonCore), out)
+
+	ce := core.Check(Entry{Level: InfoLevel, Message: "msg"}, nil)
+	require.NotNil(t, ce, "Expected entry to be accepted.")
+	ce.Write()
+
+	assert.Regexp(t, `core\[0\] \(\*observer.contextObserver\) failed info "msg": after \S+: fail`, out.String(), "Expected write error to be reported.")
+	assert.Regexp(t, `core\[1\] \(\*zapcore.ioCore\) failed info "msg": after \S+ \(encode \S+, write \S+\): failed`, out.String(), "Expected sink error to be reported.")
+}
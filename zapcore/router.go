@@ -0,0 +1,289 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// A Route describes which entries a router core sends to a particular Core.
+// Routes are declared with RouteTo and narrowed with their chaining methods;
+// an entry is sent to the route's Core only if it satisfies every condition.
+//
+//	core := zapcore.NewRouterCore(
+//		zapcore.RouteTo(stdout).Levels(zapcore.InfoLevel, zapcore.WarnLevel),
+//		zapcore.RouteTo(alerts).Levels(zapcore.ErrorLevel, zapcore.FatalLevel),
+//		zapcore.RouteTo(dbLog).Names("db"),
+//		zapcore.RouteTo(auditLog).WithField(zap.Bool("audit", true)),
+//	)
+//
+// Routes can also control which fields reach their Cores, so that one
+// output can omit fields another keeps:
+//
+//	core := zapcore.NewRouterCore(
+//		zapcore.RouteTo(console).OmitFields("request_body", "response_body"),
+//		zapcore.RouteTo(jsonFile),
+//	)
+type Route struct {
+	core     Core
+	minLevel Level
+	maxLevel Level
+	names    []string
+	fields   []routeFieldCondition
+	only     []string // nil if every key is allowed
+	omit     []string
+}
+
+type routeFieldCondition struct {
+	key   string
+	match func(Field) bool
+}
+
+// RouteTo starts a Route that sends every entry to the given Core.
+func RouteTo(core Core) Route {
+	return Route{
+		core:     core,
+		minLevel: math.MinInt8,
+		maxLevel: math.MaxInt8,
+	}
+}
+
+// Levels restricts the route to entries with levels between min and max,
+// inclusive.
+func (r Route) Levels(min, max Level) Route {
+	r.minLevel, r.maxLevel = min, max
+	return r
+}
+
+// Names restricts the route to entries logged by loggers with any of the
+// given names, or by their descendants. For example, "db" matches loggers
+// named "db" and "db.pool", but not "dbx".
+func (r Route) Names(names ...string) Route {
+	r.names = append(r.names[:len(r.names):len(r.names)], names...)
+	return r
+}
+
+// WithField restricts the route to entries carrying a field equal to the
+// given one, either in the logger's context or at the log site.
+func (r Route) WithField(f Field) Route {
+	return r.WithFieldFunc(f.Key, f.Equals)
+}
+
+// WithFieldFunc restricts the route to entries carrying a field with the
+// given key for which match returns true, either in the logger's context or
+// at the log site.
+func (r Route) WithFieldFunc(key string, match func(Field) bool) Route {
+	r.fields = append(r.fields[:len(r.fields):len(r.fields)], routeFieldCondition{key: key, match: match})
+	return r
+}
+
+// OnlyFields restricts the fields the route's Core receives to those with
+// the given keys. Repeated calls allow more keys. The route's field
+// conditions still see every field. See NewFieldFilterCore for how
+// namespaces and fields without keys are handled.
+func (r Route) OnlyFields(keys ...string) Route {
+	if r.only == nil {
+		r.only = []string{}
+	}
+	r.only = append(r.only[:len(r.only):len(r.only)], keys...)
+	return r
+}
+
+// OmitFields drops the fields with the given keys before they reach the
+// route's Core. The route's field conditions still see every field. See
+// NewFieldFilterCore for how namespaces and fields without keys are handled.
+func (r Route) OmitFields(keys ...string) Route {
+	r.omit = append(r.omit[:len(r.omit):len(r.omit)], keys...)
+	return r
+}
+
+func (r *Route) matchesEntry(ent Entry) bool {
+	if ent.Level < r.minLevel || ent.Level > r.maxLevel {
+		return false
+	}
+	if len(r.names) == 0 {
+		return true
+	}
+	for _, name := range r.names {
+		if ent.LoggerName == name || strings.HasPrefix(ent.LoggerName, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// routerRoute is a Route along with the state accumulated by With.
+type routerRoute struct {
+	Route
+
+	// matched[i] records whether the context satisfied r.fields[i].
+	matched []bool
+}
+
+func (r *routerRoute) contextMatched() bool {
+	for _, ok := range r.matched {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *routerRoute) fieldsMatch(fields []Field) bool {
+	for i, cond := range r.fields {
+		if r.matched[i] {
+			continue
+		}
+		if !anyFieldMatches(cond, fields) {
+			return false
+		}
+	}
+	return true
+}
+
+func anyFieldMatches(cond routeFieldCondition, fields []Field) bool {
+	for _, f := range fields {
+		if f.Key == cond.key && cond.match(f) {
+			return true
+		}
+	}
+	return false
+}
+
+type routerCore struct {
+	routes []routerRoute
+}
+
+var (
+	_ ContextCore    = (*routerCore)(nil)
+	_ leveledEnabler = (*routerCore)(nil)
+)
+
+// NewRouterCore creates a Core that dispatches each entry to the Cores of
+// all routes whose conditions it satisfies. It replaces nested
+// combinations of NewTee and level-filtering Cores with a single
+// declaration; entries matching no route are dropped.
+//
+// Routes that only restrict levels and logger names are resolved when the
+// entry is checked. Routes with field conditions that aren't already
+// satisfied by the logger's context are resolved when the entry is written,
+// so their Cores receive Write calls without a preceding Check.
+func NewRouterCore(routes ...Route) Core {
+	if len(routes) == 0 {
+		return NewNopCore()
+	}
+	rc := &routerCore{routes: make([]routerRoute, len(routes))}
+	for i, r := range routes {
+		r.core = NewFieldFilterCore(r.core, r.only, r.omit)
+		rc.routes[i] = routerRoute{Route: r, matched: make([]bool, len(r.fields))}
+	}
+	return rc
+}
+
+func (rc *routerCore) Level() Level {
+	minLvl := InvalidLevel
+	for i := range rc.routes {
+		r := &rc.routes[i]
+		lvl := LevelOf(r.core)
+		if lvl < r.minLevel {
+			lvl = r.minLevel
+		}
+		if lvl <= r.maxLevel && lvl < minLvl {
+			minLvl = lvl
+		}
+	}
+	return minLvl
+}
+
+func (rc *routerCore) Enabled(lvl Level) bool {
+	for i := range rc.routes {
+		r := &rc.routes[i]
+		if lvl >= r.minLevel && lvl <= r.maxLevel && r.core.Enabled(lvl) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rc *routerCore) With(fields []Field) Core {
+	clone := &routerCore{routes: make([]routerRoute, len(rc.routes))}
+	for i, r := range rc.routes {
+		matched := make([]bool, len(r.matched))
+		for j, cond := range r.fields {
+			matched[j] = r.matched[j] || anyFieldMatches(cond, fields)
+		}
+		r.core = r.core.With(fields)
+		r.matched = matched
+		clone.routes[i] = r
+	}
+	return clone
+}
+
+func (rc *routerCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	var deferred bool
+	for i := range rc.routes {
+		r := &rc.routes[i]
+		if !r.matchesEntry(ent) {
+			continue
+		}
+		if r.contextMatched() {
+			ce = r.core.Check(ent, ce)
+		} else if r.core.Enabled(ent.Level) {
+			deferred = true
+		}
+	}
+	if deferred {
+		ce = ce.AddCore(ent, rc)
+	}
+	return ce
+}
+
+// Write is only called for routes whose field conditions couldn't be
+// resolved from the context in Check.
+func (rc *routerCore) Write(ent Entry, fields []Field) error {
+	return rc.WriteContext(context.Background(), ent, fields)
+}
+
+func (rc *routerCore) WriteContext(ctx context.Context, ent Entry, fields []Field) error {
+	var err error
+	for i := range rc.routes {
+		r := &rc.routes[i]
+		if r.contextMatched() || !r.matchesEntry(ent) || !r.core.Enabled(ent.Level) {
+			continue
+		}
+		if r.fieldsMatch(fields) {
+			err = multierr.Append(err, writeContext(ctx, r.core, ent, fields))
+		}
+	}
+	return err
+}
+
+func (rc *routerCore) Sync() error {
+	var err error
+	for i := range rc.routes {
+		err = multierr.Append(err, rc.routes[i].core.Sync())
+	}
+	return err
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"testing"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouterCoreNoRoutes(t *testing.T) {
+	assert.Equal(t, NewNopCore(), NewRouterCore(), "Expected no routes to produce a NopCore.")
+}
+
+func TestRouterCoreLevels(t *testing.T) {
+	infoCore, infoLogs := observer.New(DebugLevel)
+	errCore, errLogs := observer.New(DebugLevel)
+	core := NewRouterCore(
+		RouteTo(infoCore).Levels(InfoLevel, WarnLevel),
+		RouteTo(errCore).Levels(ErrorLevel, FatalLevel),
+	)
+
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected minimum level.")
+	assert.False(t, core.Enabled(DebugLevel), "Expected debug to be disabled.")
+	assert.True(t, core.Enabled(WarnLevel), "Expected warn to be enabled.")
+
+	for _, lvl := range []Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel, DPanicLevel} {
+		if ce := core.Check(Entry{Level: lvl, Message: lvl.String()}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	assert.Equal(t, []string{"info", "warn"}, messages(infoLogs), "Unexpected entries in info core.")
+	assert.Equal(t, []string{"error", "dpanic"}, messages(errLogs), "Unexpected entries in error core.")
+}
+
+func TestRouterCoreNames(t *testing.T) {
+	dbCore, dbLogs := observer.New(DebugLevel)
+	allCore, allLogs := observer.New(DebugLevel)
+	core := NewRouterCore(
+		RouteTo(dbCore).Names("db", "cache"),
+		RouteTo(allCore),
+	)
+
+	for _, name := range []string{"", "db", "db.pool", "dbx", "cache.redis", "http"} {
+		if ce := core.Check(Entry{Level: InfoLevel, LoggerName: name, Message: name}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	assert.Equal(t, []string{"db", "db.pool", "cache.redis"}, messages(dbLogs), "Unexpected entries in db core.")
+	assert.Equal(t, []string{"", "db", "db.pool", "dbx", "cache.redis", "http"}, messages(allLogs), "Unexpected entries in catch-all core.")
+}
+
+func TestRouterCoreFields(t *testing.T) {
+	auditCore, auditLogs := observer.New(DebugLevel)
+	core := NewRouterCore(
+		RouteTo(auditCore).WithField(Field{Key: "audit", Type: BoolType, Integer: 1}),
+	)
+	audit := Field{Key: "audit", Type: BoolType, Integer: 1}
+	notAudit := Field{Key: "audit", Type: BoolType, Integer: 0}
+
+	write := func(c Core, msg string, fields ...Field) {
+		if ce := c.Check(Entry{Level: InfoLevel, Message: msg}, nil); ce != nil {
+			ce.Write(fields...)
+		}
+	}
+
+	write(core, "no fields")
+	write(core, "at site", audit)
+	write(core, "false at site", notAudit)
+	write(core.With([]Field{audit}), "in context")
+	write(core.With([]Field{notAudit}), "false in context")
+	write(core.With([]Field{notAudit}), "false in context, true at site", audit)
+
+	assert.Equal(
+		t,
+		[]string{"at site", "in context", "false in context, true at site"},
+		messages(auditLogs),
+		"Unexpected entries in audit core.",
+	)
+}
+
+func TestRouterCoreFieldsRespectLevel(t *testing.T) {
+	auditCore, auditLogs := observer.New(WarnLevel)
+	core := NewRouterCore(
+		RouteTo(auditCore).WithFieldFunc("audit", func(Field) bool { return true }),
+	)
+
+	for _, lvl := range []Level{InfoLevel, WarnLevel} {
+		if ce := core.Check(Entry{Level: lvl, Message: lvl.String()}, nil); ce != nil {
+			ce.Write(Field{Key: "audit", Type: BoolType, Integer: 1})
+		}
+	}
+	assert.Equal(t, []string{"warn"}, messages(auditLogs), "Unexpected entries in audit core.")
+}
+
+func TestRouterCoreFieldFilters(t *testing.T) {
+	consoleCore, consoleLogs := observer.New(DebugLevel)
+	fileCore, fileLogs := observer.New(DebugLevel)
+	auditCore, auditLogs := observer.New(DebugLevel)
+	audit := Field{Key: "audit", Type: BoolType, Integer: 1}
+	core := NewRouterCore(
+		RouteTo(consoleCore).OmitFields("payload").OmitFields("trace"),
+		RouteTo(fileCore),
+		RouteTo(auditCore).WithField(audit).OnlyFields("user").OnlyFields("action"),
+	).With([]Field{makeInt64Field("user", 1), makeInt64Field("trace", 2)})
+
+	core.Check(Entry{Level: InfoLevel, Message: "hello"}, nil).Write(
+		audit,
+		makeInt64Field("action", 3),
+		makeInt64Field("payload", 4),
+	)
+
+	assert.Equal(t, []Field{
+		makeInt64Field("user", 1),
+		audit,
+		makeInt64Field("action", 3),
+	}, consoleLogs.All()[0].Context, "Unexpected fields in console core.")
+	assert.Equal(t, []Field{
+		makeInt64Field("user", 1),
+		makeInt64Field("trace", 2),
+		audit,
+		makeInt64Field("action", 3),
+		makeInt64Field("payload", 4),
+	}, fileLogs.All()[0].Context, "Expected file core to keep every field.")
+	require.Equal(t, 1, auditLogs.Len(), "Expected field conditions to see filtered fields.")
+	assert.Equal(t, []Field{
+		makeInt64Field("user", 1),
+		makeInt64Field("action", 3),
+	}, auditLogs.All()[0].Context, "Unexpected fields in audit core.")
+}
+
+func TestRouterCoreRoutesAreImmutable(t *testing.T) {
+	obs, _ := observer.New(DebugLevel)
+	base := RouteTo(obs).Names("a")
+	_ = base.Names("b")
+	core := NewRouterCore(base)
+	assert.Nil(t, core.Check(Entry{Level: InfoLevel, LoggerName: "b"}, nil), "Expected derived route not to affect base.")
+}
+
+func TestRouterCoreSync(t *testing.T) {
+	ok := &syncCountingCore{}
+	failing := &syncCountingCore{err: errors.New("sync failed")}
+	core := NewRouterCore(RouteTo(ok), RouteTo(failing))
+	require.EqualError(t, core.Sync(), "sync failed", "Expected sync errors to propagate.")
+	assert.Equal(t, 1, ok.syncs, "Expected every route's core to be synced.")
+}
+
+type syncCountingCore struct {
+	Core
+	err   error
+	syncs int
+}
+
+func (c *syncCountingCore) Sync() error {
+	c.syncs++
+	return c.err
+}
+
+func messages(logs *observer.ObservedLogs) []string {
+	msgs := make([]string, 0, logs.Len())
+	for _, e := range logs.All() {
+		msgs = append(msgs, e.Message)
+	}
+	return msgs
+}
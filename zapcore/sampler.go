@@ -21,6 +21,9 @@
 package zapcore
 
 import (
+	"fmt"
+	"sort"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -47,6 +50,13 @@ func (cs *counters) get(lvl Level, key string) *counter {
 	return &cs[i][j]
 }
 
+func (cs *counters) getPC(lvl Level, pc uintptr) *counter {
+	i := lvl - _minLevel
+	// Fibonacci hashing spreads nearby program counters across the table.
+	j := uint32((uint64(pc)*11400714819323198485)>>32) % _countersPerLevel
+	return &cs[i][j]
+}
+
 // fnv32a, adapted from "hash/fnv", but without a []byte(string) alloc
 func fnv32a(s string) uint32 {
 	const (
@@ -124,6 +134,79 @@ func SamplerHook(hook func(entry Entry, dec SamplingDecision)) SamplerOption {
 	})
 }
 
+// SamplingDetails describes the circumstances of a sampling decision.
+type SamplingDetails struct {
+	// Key is the entry's message, or its event name if the message is
+	// empty. Unless the sampler counts by call site, it's what the entry was
+	// counted by.
+	Key string
+	// Count is the number of entries with the same level and key, or from
+	// the same call site, seen in the current tick, including this one. For
+	// samplers created with NewBudgetSampler, it's the number of entries
+	// seen in the current tick across all keys.
+	Count uint64
+	// Tick, First, Thereafter, and Budget are the sampler's configuration.
+	// Budget is zero unless the sampler was created with NewBudgetSampler,
+	// in which case First and Thereafter are zero.
+	Tick       time.Duration
+	First      uint64
+	Thereafter uint64
+	Budget     uint64
+}
+
+// SamplerDetailedHook registers a function which will be called when Sampler
+// makes a decision, like SamplerHook, but which also receives the details of
+// the decision. It may be used alongside SamplerHook.
+//
+// Since the details include the sampling key and how many entries with that
+// key have been seen, the hook can alert on specific messages that are being
+// dropped heavily rather than only on the overall drop rate.
+//
+//	zapcore.SamplerDetailedHook(func(ent zapcore.Entry, dec zapcore.SamplingDecision, d zapcore.SamplingDetails) {
+//	  if dec&zapcore.LogDropped > 0 && d.Count > 100*d.First {
+//	    alertNoisyLog(d.Key)
+//	  }
+//	})
+func SamplerDetailedHook(hook func(Entry, SamplingDecision, SamplingDetails)) SamplerOption {
+	return optionFunc(func(s *sampler) {
+		s.detailedHook = hook
+	})
+}
+
+// SamplerByCaller makes the Sampler count entries by call site rather than
+// by message, so the first N entries logged from each line of code are
+// always written. This works better than counting by message when messages
+// interpolate data, since every such message would otherwise get its own
+// count.
+//
+// Entries are counted by call site only if their Caller.PC is set when
+// they're checked, which Loggers do if they're built with the
+// zap.AddCallerPC option; other entries are counted by message.
+func SamplerByCaller() SamplerOption {
+	return optionFunc(func(s *sampler) {
+		s.byCaller = true
+	})
+}
+
+// SamplerSummary makes the Sampler report what it dropped. At most once per
+// interval, it writes an entry for each level and key that had entries
+// dropped since the last report, with a message like
+//
+//	dropped 1523 of "cache miss" in last 10s
+//
+// and the key and count as the "sampling_key" and "dropped" fields. Reports
+// have the level of the dropped entries and go to the wrapped Core without
+// any context added with With.
+//
+// Reports are written when the Sampler checks an entry after the interval
+// has elapsed and when it's synced, so the Sampler never starts goroutines
+// of its own.
+func SamplerSummary(interval time.Duration) SamplerOption {
+	return optionFunc(func(s *sampler) {
+		s.summary = &samplerSummary{interval: interval}
+	})
+}
+
 // NewSamplerWithOptions creates a Core that samples incoming entries, which
 // caps the CPU and I/O load of logging while attempting to preserve a
 // representative subset of your logs.
@@ -144,7 +227,8 @@ func SamplerHook(hook func(entry Entry, dec SamplingDecision)) SamplerOption {
 // in that interval.
 //
 // Sampler can be configured to report sampling decisions with the SamplerHook
-// option.
+// and SamplerDetailedHook options, and to log periodic summaries of the
+// entries it drops with the SamplerSummary option.
 //
 // Keep in mind that Zap's sampling implementation is optimized for speed over
 // absolute precision; under load, each tick may be slightly over- or
@@ -161,6 +245,9 @@ func NewSamplerWithOptions(core Core, tick time.Duration, first, thereafter int,
 	for _, opt := range opts {
 		opt.apply(s)
 	}
+	if s.summary != nil {
+		s.summary.core = core
+	}
 
 	return s
 }
@@ -171,7 +258,11 @@ type sampler struct {
 	counts            *counters
 	tick              time.Duration
 	first, thereafter uint64
+	budget            *samplingBudget // nil unless sampling by budget
+	byCaller          bool
 	hook              func(Entry, SamplingDecision)
+	detailedHook      func(Entry, SamplingDecision, SamplingDetails) // may be nil
+	summary           *samplerSummary                                // may be nil
 }
 
 var (
@@ -202,12 +293,16 @@ func (s *sampler) Level() Level {
 
 func (s *sampler) With(fields []Field) Core {
 	return &sampler{
-		Core:       s.Core.With(fields),
-		tick:       s.tick,
-		counts:     s.counts,
-		first:      s.first,
-		thereafter: s.thereafter,
-		hook:       s.hook,
+		Core:         s.Core.With(fields),
+		tick:         s.tick,
+		counts:       s.counts,
+		first:        s.first,
+		thereafter:   s.thereafter,
+		budget:       s.budget,
+		byCaller:     s.byCaller,
+		hook:         s.hook,
+		detailedHook: s.detailedHook,
+		summary:      s.summary,
 	}
 }
 
@@ -215,15 +310,238 @@ func (s *sampler) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
 	if !s.Enabled(ent.Level) {
 		return ce
 	}
+	if s.summary != nil {
+		s.summary.report(ent.Time, false)
+	}
 
-	if ent.Level >= _minLevel && ent.Level <= _maxLevel {
-		counter := s.counts.get(ent.Level, ent.Message)
-		n := counter.IncCheckReset(ent.Time, s.tick)
-		if n > s.first && (s.thereafter == 0 || (n-s.first)%s.thereafter != 0) {
-			s.hook(ent, LogDropped)
-			return ce
+	// Budgets apply to every level, so that custom levels can't escape the
+	// cap, but per-message counters only cover the built-in levels.
+	if s.budget == nil && (ent.Level < _minLevel || ent.Level > _maxLevel) {
+		return s.Core.Check(ent, ce)
+	}
+
+	key := ent.Message
+	if key == "" {
+		// Events are sampled by name.
+		key = ent.Event
+	}
+	var (
+		n    uint64
+		drop bool
+	)
+	if s.budget != nil {
+		n, drop = s.budget.take(key, ent.Time)
+	} else {
+		var counter *counter
+		if s.byCaller && ent.Caller.PC != 0 {
+			counter = s.counts.getPC(ent.Level, ent.Caller.PC)
+		} else {
+			counter = s.counts.get(ent.Level, key)
+		}
+		n = counter.IncCheckReset(ent.Time, s.tick)
+		drop = n > s.first && (s.thereafter == 0 || (n-s.first)%s.thereafter != 0)
+	}
+	if drop {
+		s.decided(ent, LogDropped, key, n)
+		if s.summary != nil {
+			s.summary.drop(ent.Level, key)
 		}
-		s.hook(ent, LogSampled)
+		return ce
 	}
+	s.decided(ent, LogSampled, key, n)
 	return s.Core.Check(ent, ce)
 }
+
+func (s *sampler) decided(ent Entry, dec SamplingDecision, key string, n uint64) {
+	s.hook(ent, dec)
+	if s.detailedHook != nil {
+		s.detailedHook(ent, dec, SamplingDetails{
+			Key:        key,
+			Count:      n,
+			Tick:       s.tick,
+			First:      s.first,
+			Thereafter: s.thereafter,
+			Budget:     s.budget.size(),
+		})
+	}
+}
+
+func (s *sampler) Sync() error {
+	if s.summary != nil {
+		s.summary.report(time.Now(), true)
+	}
+	return s.Core.Sync()
+}
+
+// NewBudgetSampler creates a Core that caps the number of entries written
+// each tick across all messages and levels, for platforms that bill by log
+// volume. It's a token bucket that's refilled with budget tokens at the
+// start of every tick: each entry takes a token, and entries are dropped
+// once the tokens run out. Unlike the per-message counts of
+// NewSamplerWithOptions, which are approximate under load, the budget is
+// exact.
+//
+// For example,
+//
+//	core = NewBudgetSampler(core, time.Second, 1000)
+//
+// writes at most 1000 entries per second.
+//
+// The budget is shared fairly between messages: a message can only take a
+// token while it has taken fewer tokens this tick than remain. A single
+// noisy message therefore gets at most half of each tick's budget, and a
+// message that hasn't been logged yet this tick is written as long as any
+// tokens remain.
+//
+// The options that report sampling decisions and summaries apply to budget
+// samplers as they do to the samplers created by NewSamplerWithOptions;
+// SamplerByCaller has no effect.
+func NewBudgetSampler(core Core, tick time.Duration, budget int, opts ...SamplerOption) Core {
+	if budget < 0 {
+		budget = 0
+	}
+	s := NewSamplerWithOptions(core, tick, 0, 0, opts...).(*sampler)
+	s.budget = &samplingBudget{tick: tick, budget: uint64(budget)}
+	return s
+}
+
+// samplingBudget is a token bucket that's refilled at the start of every
+// tick.
+type samplingBudget struct {
+	tick   time.Duration
+	budget uint64
+
+	mu        sync.Mutex
+	remaining uint64
+	seen      uint64            // entries seen this tick
+	spent     map[string]uint64 // tokens taken by each message this tick
+	resetAt   int64             // UnixNano at which the current tick ends
+}
+
+func (b *samplingBudget) size() uint64 {
+	if b == nil {
+		return 0
+	}
+	return b.budget
+}
+
+// take spends a token on an entry with the given key logged at t. It returns
+// the number of entries seen this tick, including this one, and whether the
+// entry should be dropped because no tokens were left or the key has spent
+// its share.
+func (b *samplingBudget) take(key string, t time.Time) (seen uint64, drop bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if tn := t.UnixNano(); tn >= b.resetAt {
+		b.remaining = b.budget
+		b.seen = 0
+		b.spent = make(map[string]uint64)
+		b.resetAt = tn + b.tick.Nanoseconds()
+	}
+	b.seen++
+	if b.spent[key] >= b.remaining {
+		return b.seen, true
+	}
+	b.remaining--
+	b.spent[key]++
+	return b.seen, false
+}
+
+type samplingKey struct {
+	lvl Level
+	key string
+}
+
+// samplerSummary counts the entries a sampler drops and periodically
+// reports them.
+type samplerSummary struct {
+	core     Core // the sampler's wrapped Core, without context
+	interval time.Duration
+
+	// dropped maps samplingKeys to *atomic.Uint64 counts, so that dropping
+	// entries doesn't contend on a lock.
+	dropped sync.Map
+
+	mu    sync.Mutex   // serializes reports
+	start time.Time    // when the current interval started; zero before the first report
+	next  atomic.Int64 // UnixNano of the next report, checked without locking
+}
+
+// _summaryRetired marks a count that report is removing from
+// samplerSummary.dropped because its key had no drops for a whole interval.
+const _summaryRetired = 1 << 63
+
+func (ss *samplerSummary) drop(lvl Level, key string) {
+	k := samplingKey{lvl, key}
+	for {
+		c, ok := ss.dropped.Load(k)
+		if !ok {
+			c, _ = ss.dropped.LoadOrStore(k, new(atomic.Uint64))
+		}
+		if c.(*atomic.Uint64).Add(1)&_summaryRetired == 0 {
+			return
+		}
+		// The count is being removed; retry once it's gone.
+	}
+}
+
+// report writes summary entries if the interval has elapsed, or
+// unconditionally if force is true.
+func (ss *samplerSummary) report(now time.Time, force bool) {
+	if now.IsZero() || (!force && now.UnixNano() < ss.next.Load()) {
+		return
+	}
+
+	ss.mu.Lock()
+	if ss.start.IsZero() {
+		ss.start = now
+	}
+	if !force && now.Sub(ss.start) < ss.interval {
+		ss.next.Store(ss.start.Add(ss.interval).UnixNano())
+		ss.mu.Unlock()
+		return
+	}
+	dropped := make(map[samplingKey]uint64)
+	ss.dropped.Range(func(k, v interface{}) bool {
+		c := v.(*atomic.Uint64)
+		if n := c.Swap(0); n > 0 {
+			dropped[k.(samplingKey)] = n
+		} else if c.CompareAndSwap(0, _summaryRetired) {
+			// Forget keys that stopped being dropped, so that the map
+			// doesn't grow without bound.
+			ss.dropped.Delete(k)
+		}
+		return true
+	})
+	elapsed := now.Sub(ss.start)
+	ss.start = now
+	ss.next.Store(now.Add(ss.interval).UnixNano())
+	ss.mu.Unlock()
+
+	keys := make([]samplingKey, 0, len(dropped))
+	for k := range dropped {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].lvl != keys[j].lvl {
+			return keys[i].lvl < keys[j].lvl
+		}
+		return keys[i].key < keys[j].key
+	})
+
+	for _, k := range keys {
+		n := dropped[k]
+		ent := Entry{
+			Level:   k.lvl,
+			Time:    now,
+			Message: fmt.Sprintf("dropped %d of %q in last %v", n, k.key, elapsed.Round(time.Millisecond)),
+		}
+		if ce := ss.core.Check(ent, nil); ce != nil {
+			ce.Write(
+				Field{Key: "sampling_key", Type: StringType, String: k.key},
+				Field{Key: "dropped", Type: Uint64Type, Integer: int64(n)},
+			)
+		}
+	}
+}
@@ -89,6 +89,22 @@ func TestSampler(t *testing.T) {
 	}
 }
 
+func TestSamplerEvents(t *testing.T) {
+	sampler, logs := fakeSampler(DebugLevel, time.Minute, 1, 0)
+	for _, name := range []string{"signup", "login", "signup", "login"} {
+		ent := Entry{Level: InfoLevel, Event: name}
+		if ce := sampler.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	var events []string
+	for _, entry := range logs.AllUntimed() {
+		events = append(events, entry.Event)
+	}
+	assert.Equal(t, []string{"signup", "login"}, events, "Expected events to be sampled by name.")
+}
+
 func TestLevelOfSampler(t *testing.T) {
 	levels := []Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel, DPanicLevel, PanicLevel, FatalLevel}
 	for _, lvl := range levels {
@@ -272,7 +288,7 @@ func TestSamplerRaces(t *testing.T) {
 func TestSamplerUnknownLevels(t *testing.T) {
 	// Prove that out-of-bounds levels don't panic.
 	unknownLevels := []Level{
-		DebugLevel - 1,
+		TraceLevel - 1,
 		FatalLevel + 1,
 	}
 
@@ -327,3 +343,256 @@ func TestSamplerWithZeroThereafter(t *testing.T) {
 	assert.Equal(t, 4, int(counter.logs.Load()),
 		"Unexpected number of logs")
 }
+
+func TestSamplerDetailedHook(t *testing.T) {
+	var (
+		decisions []SamplingDecision
+		details   []SamplingDetails
+	)
+	var dropped int
+	core, _ := observer.New(DebugLevel)
+	sampler := NewSamplerWithOptions(core, time.Minute, 1, 2,
+		SamplerHook(func(_ Entry, dec SamplingDecision) {
+			if dec&LogDropped > 0 {
+				dropped++
+			}
+		}),
+		SamplerDetailedHook(func(_ Entry, dec SamplingDecision, d SamplingDetails) {
+			decisions = append(decisions, dec)
+			details = append(details, d)
+		}),
+	).With([]Field{makeInt64Field("foo", 1)})
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		sampler.Check(Entry{Level: InfoLevel, Message: "msg", Time: now}, nil)
+	}
+	sampler.Check(Entry{Level: InfoLevel, Event: "user.signup", Time: now}, nil)
+
+	assert.Equal(t, 1, dropped, "Expected SamplerHook to run alongside SamplerDetailedHook.")
+	assert.Equal(t, []SamplingDecision{LogSampled, LogDropped, LogSampled, LogSampled}, decisions, "Unexpected decisions.")
+	want := func(key string, count uint64) SamplingDetails {
+		return SamplingDetails{Key: key, Count: count, Tick: time.Minute, First: 1, Thereafter: 2}
+	}
+	assert.Equal(t, []SamplingDetails{
+		want("msg", 1),
+		want("msg", 2),
+		want("msg", 3),
+		want("user.signup", 1),
+	}, details, "Unexpected details.")
+}
+
+func TestSamplerSummary(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	sampler := NewSamplerWithOptions(core, time.Minute, 1, 0, SamplerSummary(10*time.Second)).
+		With([]Field{makeInt64Field("ctx", 1)})
+
+	write := func(lvl Level, msg string, ts time.Time) {
+		if ce := sampler.Check(Entry{Level: lvl, Message: msg, Time: ts}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	start := time.Unix(1000, 0)
+	for i := 0; i < 5; i++ {
+		write(InfoLevel, "cache miss", start)
+		write(WarnLevel, "slow query", start)
+	}
+	write(InfoLevel, "cache miss", start.Add(5*time.Second))
+	assert.Equal(t, []string{"cache miss", "slow query"}, messages(logs), "Expected no summary before the interval elapses.")
+	logs.TakeAll()
+
+	write(InfoLevel, "other", start.Add(10*time.Second))
+	summaries := logs.TakeAll()
+	require.Len(t, summaries, 3, "Expected two summaries and the entry.")
+	assert.Equal(t, observer.LoggedEntry{
+		Entry: Entry{Level: InfoLevel, Time: start.Add(10 * time.Second), Message: `dropped 5 of "cache miss" in last 10s`},
+		Context: []Field{
+			{Key: "sampling_key", Type: StringType, String: "cache miss"},
+			{Key: "dropped", Type: Uint64Type, Integer: 5},
+		},
+	}, summaries[0], "Unexpected info summary; it shouldn't have the logger's context.")
+	assert.Equal(t, `dropped 4 of "slow query" in last 10s`, summaries[1].Message, "Unexpected warn summary.")
+	assert.Equal(t, WarnLevel, summaries[1].Level, "Expected summary to have the dropped entries' level.")
+	assert.Equal(t, "other", summaries[2].Message, "Expected entry after summaries.")
+
+	write(InfoLevel, "first", start.Add(15*time.Second))
+	write(InfoLevel, "second", start.Add(25*time.Second))
+	assert.Equal(t, []string{"first", "second"}, messages(logs), "Expected no summaries without drops.")
+	logs.TakeAll()
+
+	write(InfoLevel, "cache miss", start.Add(26*time.Second))
+	require.NoError(t, sampler.Sync(), "Unexpected error syncing.")
+	summaries = logs.TakeAll()
+	require.Len(t, summaries, 1, "Expected Sync to write a summary.")
+	assert.Contains(t, summaries[0].Message, `dropped 1 of "cache miss" in last`, "Unexpected summary.")
+}
+
+func TestSamplerSummaryConcurrentDrops(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	sampler := NewSamplerWithOptions(core, time.Minute, 1, 0, SamplerSummary(time.Hour))
+
+	const goroutines, perGoroutine = 8, 1000
+	start := time.Unix(1000, 0)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				if ce := sampler.Check(Entry{Level: InfoLevel, Message: "hot", Time: start}, nil); ce != nil {
+					ce.Write()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	logs.TakeAll()
+
+	require.NoError(t, sampler.Sync(), "Unexpected error syncing.")
+	summaries := logs.TakeAll()
+	require.Len(t, summaries, 1, "Expected one summary.")
+	assert.Equal(t, uint64(goroutines*perGoroutine-1), summaries[0].ContextMap()["dropped"], "Expected every dropped entry to be counted.")
+
+	// Idle keys are forgotten, and counted afresh if they're dropped again.
+	require.NoError(t, sampler.Sync(), "Unexpected error syncing.")
+	assert.Zero(t, logs.Len(), "Expected no summary without new drops.")
+	assert.Nil(t, sampler.Check(Entry{Level: InfoLevel, Message: "hot", Time: start}, nil), "Expected the entry to be dropped.")
+	require.NoError(t, sampler.Sync(), "Unexpected error syncing.")
+	summaries = logs.TakeAll()
+	require.Len(t, summaries, 1, "Expected one summary.")
+	assert.Equal(t, uint64(1), summaries[0].ContextMap()["dropped"], "Unexpected count after forgetting the key.")
+
+	ent := Entry{Level: InfoLevel, Message: "hot", Time: start}
+	assert.Zero(t, testing.AllocsPerRun(100, func() { sampler.Check(ent, nil) }), "Expected counting drops not to allocate.")
+}
+
+func TestBudgetSampler(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	var details []SamplingDetails
+	sampler := NewBudgetSampler(core, time.Second, 10, SamplerDetailedHook(
+		func(_ Entry, _ SamplingDecision, d SamplingDetails) {
+			details = append(details, d)
+		})).With([]Field{makeInt64Field("ctx", 1)})
+
+	write := func(msg string, ts time.Time) {
+		if ce := sampler.Check(Entry{Level: InfoLevel, Message: msg, Time: ts}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	now := time.Now()
+	for i := 0; i < 4; i++ {
+		write(fmt.Sprintf("quiet %d", i), now)
+	}
+	for i := 0; i < 100; i++ {
+		write("noisy", now)
+	}
+	assert.Equal(t, 3, logs.FilterMessage("noisy").Len(), "Expected a noisy message to stop once it's taken as many tokens as remain.")
+	write("quiet 4", now)
+	write("quiet 5", now)
+	write("quiet 6", now)
+	write("quiet 7", now)
+	assert.Equal(t, 10, logs.Len(), "Expected new messages to spend the rest of the budget.")
+	assert.Equal(t, "quiet 6", logs.All()[9].Message, "Expected entries to be dropped once the budget is spent.")
+	require.Len(t, details, 108, "Expected a decision for every entry.")
+	assert.Equal(t, SamplingDetails{Key: "quiet 0", Count: 1, Tick: time.Second, Budget: 10}, details[0], "Unexpected details.")
+	assert.Equal(t, SamplingDetails{Key: "quiet 7", Count: 108, Tick: time.Second, Budget: 10}, details[107], "Unexpected details.")
+
+	logs.TakeAll()
+	write("noisy", now.Add(time.Second))
+	write("other", now.Add(time.Second))
+	assert.Equal(t, []string{"noisy", "other"}, messages(logs), "Expected budget to refill each tick.")
+}
+
+func TestBudgetSamplerFairShare(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	sampler := NewBudgetSampler(core, time.Minute, 100)
+
+	now := time.Now()
+	for _, msg := range []string{"first", "second", "third"} {
+		for i := 0; i < 1000; i++ {
+			if ce := sampler.Check(Entry{Level: InfoLevel, Message: msg, Time: now}, nil); ce != nil {
+				ce.Write()
+			}
+		}
+	}
+	assert.Equal(t, 50, logs.FilterMessage("first").Len(), "Expected the first message to get half the budget.")
+	assert.Equal(t, 25, logs.FilterMessage("second").Len(), "Expected the second message to get half of what's left.")
+	assert.Equal(t, 13, logs.FilterMessage("third").Len(), "Expected the third message to get about half of what's left.")
+}
+
+func TestBudgetSamplerAllLevels(t *testing.T) {
+	var counter countingCore
+	sampler := NewBudgetSampler(&counter, time.Minute, 2)
+	now := time.Now()
+	for i, lvl := range []Level{Level(-10), Level(20), DebugLevel, FatalLevel} {
+		if ce := sampler.Check(Entry{Level: lvl, Message: fmt.Sprint(i), Time: now}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	assert.Equal(t, uint32(2), counter.logs.Load(), "Expected entries at every level to spend the budget.")
+}
+
+func TestBudgetSamplerNoBudget(t *testing.T) {
+	var counter countingCore
+	sampler := NewBudgetSampler(&counter, time.Second, -1)
+	for i := 0; i < 10; i++ {
+		sampler.Check(Entry{Level: InfoLevel, Message: fmt.Sprint(i), Time: time.Now()}, nil).Write()
+	}
+	assert.Zero(t, counter.logs.Load(), "Expected no entries without a budget.")
+}
+
+func TestBudgetSamplerConcurrent(t *testing.T) {
+	const (
+		budget     = 100
+		goroutines = 8
+		perRoutine = 500
+	)
+	var counter countingCore
+	sampler := NewBudgetSampler(&counter, time.Minute, budget)
+	now := time.Now()
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perRoutine; i++ {
+				ent := Entry{Level: InfoLevel, Message: fmt.Sprintf("%d-%d", g, i), Time: now}
+				if ce := sampler.Check(ent, nil); ce != nil {
+					ce.Write()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint32(budget), counter.logs.Load(), "Expected the budget to be spent exactly.")
+}
+
+func TestSamplerByCaller(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	sampler := NewSamplerWithOptions(core, time.Minute, 2, 0, SamplerByCaller()).
+		With([]Field{makeInt64Field("ctx", 1)})
+
+	write := func(pc uintptr, msg string) {
+		ent := Entry{Level: InfoLevel, Message: msg, Time: time.Now(), Caller: EntryCaller{PC: pc}}
+		if ce := sampler.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	for i := 0; i < 5; i++ {
+		write(0x1000, fmt.Sprintf("a %d", i))
+		write(0x1004, fmt.Sprintf("b %d", i))
+		// Without a PC, entries are counted by message.
+		write(0, fmt.Sprintf("c %d", i))
+	}
+
+	assert.Equal(t, []string{
+		"a 0", "b 0", "c 0",
+		"a 1", "b 1", "c 1",
+		"c 2", "c 3", "c 4",
+	}, messages(logs), "Unexpected entries.")
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnknownSchema is returned by SchemaRegistries asked for a schema they
+// don't have.
+var ErrUnknownSchema = errors.New("unknown log schema")
+
+// A Schema describes the fields of the entries a service logs, so that
+// consumers of binary log formats can decode entries written by other
+// versions of the service. Writers declare their schema in a header, and
+// consumers can look up the fields of each version in a SchemaRegistry.
+type Schema struct {
+	// ID identifies the schema in a SchemaRegistry. It's zero for schemas
+	// that haven't been registered.
+	ID uint32
+	// Name identifies the set of fields, typically by the service that logs
+	// them.
+	Name string
+	// Version must change whenever the set of fields changes.
+	Version uint32
+	// Definition describes the fields, in a format that writers and
+	// consumers agree on, like a JSON Schema. Headers of registered schemas
+	// omit it, since consumers can look it up by ID.
+	Definition []byte
+}
+
+// A SchemaRegistry stores Schemas, so that log headers can refer to them by
+// ID. Implementations must be safe for concurrent use.
+type SchemaRegistry interface {
+	// Register stores a schema, ignoring its ID, and returns the ID it's
+	// stored under. Registering the same name and version again must return
+	// the same ID, and fail if the definition differs, since changing the
+	// fields of a version that's been logged would break its consumers.
+	Register(Schema) (uint32, error)
+	// Lookup returns the schema stored under the given ID, or an error
+	// wrapping ErrUnknownSchema if there isn't one.
+	Lookup(id uint32) (Schema, error)
+}
+
+// NewSchemaRegistry returns a SchemaRegistry that keeps schemas in memory,
+// for tests and for programs that read the logs they write. IDs are
+// assigned sequentially, starting from one.
+func NewSchemaRegistry() SchemaRegistry {
+	return &memorySchemaRegistry{ids: make(map[schemaKey]uint32)}
+}
+
+type schemaKey struct {
+	name    string
+	version uint32
+}
+
+type memorySchemaRegistry struct {
+	mu      sync.RWMutex
+	ids     map[schemaKey]uint32
+	schemas []Schema // the schema with ID i is at i-1
+}
+
+func (r *memorySchemaRegistry) Register(s Schema) (uint32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := schemaKey{s.Name, s.Version}
+	if id, ok := r.ids[key]; ok {
+		if !bytes.Equal(r.schemas[id-1].Definition, s.Definition) {
+			return 0, fmt.Errorf("log schema %q version %d is already registered with a different definition", s.Name, s.Version)
+		}
+		return id, nil
+	}
+	s.ID = uint32(len(r.schemas) + 1)
+	s.Definition = append([]byte(nil), s.Definition...)
+	r.schemas = append(r.schemas, s)
+	r.ids[key] = s.ID
+	return s.ID, nil
+}
+
+func (r *memorySchemaRegistry) Lookup(id uint32) (Schema, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if id == 0 || int64(id) > int64(len(r.schemas)) {
+		return Schema{}, fmt.Errorf("log schema %d: %w", id, ErrUnknownSchema)
+	}
+	s := r.schemas[id-1]
+	s.Definition = append([]byte(nil), s.Definition...)
+	return s, nil
+}
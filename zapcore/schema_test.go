@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaRegistry(t *testing.T) {
+	reg := NewSchemaRegistry()
+	v1 := Schema{ID: 42, Name: "checkout", Version: 1, Definition: []byte(`{"user":"string"}`)}
+
+	id, err := reg.Register(v1)
+	require.NoError(t, err, "Unexpected error registering schema.")
+	assert.Equal(t, uint32(1), id, "Expected the given ID to be ignored.")
+
+	again, err := reg.Register(v1)
+	require.NoError(t, err, "Unexpected error registering schema again.")
+	assert.Equal(t, id, again, "Expected re-registering a version to return its ID.")
+
+	v2 := Schema{Name: "checkout", Version: 2, Definition: []byte(`{"user":"string","cart":"int"}`)}
+	id2, err := reg.Register(v2)
+	require.NoError(t, err, "Unexpected error registering new version.")
+	assert.Equal(t, uint32(2), id2, "Expected a new ID for a new version.")
+
+	changed := v1
+	changed.Definition = []byte(`{"user":"int"}`)
+	_, err = reg.Register(changed)
+	assert.ErrorContains(t, err, "different definition", "Expected changing a registered version's fields to fail.")
+
+	got, err := reg.Lookup(id)
+	require.NoError(t, err, "Unexpected error looking up schema.")
+	v1.ID = id
+	assert.Equal(t, v1, got, "Unexpected schema.")
+
+	got.Definition[0] = 'x'
+	got, err = reg.Lookup(id)
+	require.NoError(t, err, "Unexpected error looking up schema.")
+	assert.Equal(t, v1, got, "Expected the registry's copy to be unaffected by callers.")
+
+	for _, unknown := range []uint32{0, 3} {
+		_, err := reg.Lookup(unknown)
+		assert.ErrorIs(t, err, ErrUnknownSchema, "Expected unknown ID %d to fail.", unknown)
+	}
+}
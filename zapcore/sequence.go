@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.uber.org/multierr"
+)
+
+type sequenceCore struct {
+	Core
+
+	key string
+	seq *atomic.Uint64 // shared with clones
+}
+
+var (
+	_ ContextCore    = (*sequenceCore)(nil)
+	_ leveledEnabler = (*sequenceCore)(nil)
+)
+
+// NewSequenceCore wraps a Core, adding a field with the given key and an
+// increasing sequence number, starting at 1, to every entry it writes. Cores
+// derived from the result with With share its counter, so the entries of a
+// logger and its children form a single sequence. Consumers can use the
+// numbers to detect entries that were dropped or reordered after they were
+// written, for example by an asynchronous shipping pipeline.
+//
+// Numbers are assigned only once the wrapped Core has decided to write an
+// entry, so entries dropped by a sampler inside it don't leave gaps.
+// Concurrent writes may reach the output in a different order than their
+// numbers.
+func NewSequenceCore(core Core, key string) Core {
+	return &sequenceCore{
+		Core: core,
+		key:  key,
+		seq:  new(atomic.Uint64),
+	}
+}
+
+func (c *sequenceCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *sequenceCore) With(fields []Field) Core {
+	clone := *c
+	clone.Core = c.Core.With(fields)
+	return &clone
+}
+
+func (c *sequenceCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	// The number is a field, which has to be added in Write, so register
+	// ourselves rather than letting the wrapped Core do so.
+	if c.Core.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *sequenceCore) Write(ent Entry, fields []Field) error {
+	return c.WriteContext(context.Background(), ent, fields)
+}
+
+func (c *sequenceCore) WriteContext(ctx context.Context, ent Entry, fields []Field) error {
+	ce := c.Core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+
+	numbered := make([]Field, len(fields)+1)
+	copy(numbered, fields)
+	numbered[len(fields)] = Field{
+		Key:     c.key,
+		Type:    Uint64Type,
+		Integer: int64(c.seq.Add(1)),
+	}
+
+	var err error
+	for i := range ce.cores {
+		err = multierr.Append(err, writeContext(ctx, ce.cores[i], ent, numbered))
+	}
+	putCheckedEntry(ce)
+	return err
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sequenceNumbers returns the value of the "seq" field in each entry.
+func sequenceNumbers(t *testing.T, logs *observer.ObservedLogs) []uint64 {
+	var seqs []uint64
+	for _, ent := range logs.All() {
+		seq, ok := ent.ContextMap()["seq"].(uint64)
+		require.True(t, ok, "Expected a sequence number in %v.", ent.Context)
+		seqs = append(seqs, seq)
+	}
+	return seqs
+}
+
+func TestSequenceCore(t *testing.T) {
+	obs, logs := observer.New(InfoLevel)
+	core := NewSequenceCore(obs, "seq")
+	assert.Equal(t, InfoLevel, LevelOf(core), "Expected the wrapped Core's level.")
+
+	child := core.With([]Field{makeInt64Field("foo", 42)})
+	for _, c := range []Core{core, child, core} {
+		for _, lvl := range []Level{DebugLevel, InfoLevel} {
+			if ce := c.Check(Entry{Level: lvl}, nil); ce != nil {
+				ce.Write()
+			}
+		}
+	}
+	assert.Equal(t, []uint64{1, 2, 3}, sequenceNumbers(t, logs),
+		"Expected disabled entries not to be numbered, and children to share the sequence.")
+	assert.Equal(t, makeInt64Field("foo", 42), logs.All()[1].Context[0],
+		"Expected context to precede the sequence number.")
+}
+
+func TestSequenceCoreSampled(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	sampled := NewSamplerWithOptions(obs, time.Minute, 2, 3)
+	core := NewSequenceCore(sampled, "seq")
+
+	for i := 0; i < 10; i++ {
+		if ce := core.Check(Entry{Level: InfoLevel, Message: "repeated"}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	assert.Equal(t, []uint64{1, 2, 3, 4}, sequenceNumbers(t, logs),
+		"Expected entries dropped by sampling not to leave gaps.")
+}
+
+func TestSequenceCoreConcurrent(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	core := NewSequenceCore(obs, "seq")
+
+	const goroutines, perGoroutine = 8, 50
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				if ce := core.Check(Entry{Level: InfoLevel}, nil); ce != nil {
+					ce.Write()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[uint64]bool)
+	for _, seq := range sequenceNumbers(t, logs) {
+		assert.False(t, seen[seq], "Sequence number %d was used twice.", seq)
+		seen[seq] = true
+	}
+	for seq := uint64(1); seq <= goroutines*perGoroutine; seq++ {
+		assert.True(t, seen[seq], "Sequence number %d is missing.", seq)
+	}
+}
@@ -20,7 +20,13 @@
 
 package zapcore
 
-import "go.uber.org/multierr"
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"go.uber.org/multierr"
+)
 
 type multiCore []Core
 
@@ -32,6 +38,10 @@ var (
 // NewTee creates a Core that duplicates log entries into two or more
 // underlying Cores.
 //
+// Cores created by NewCore that share an Encoder also share the work done
+// by With: the added fields are encoded once for all of them. To benefit,
+// pass the same Encoder to each such Core.
+//
 // Calling it with a single Core returns the input unchanged, and calling
 // it with no input returns a no-op Core.
 func NewTee(cores ...Core) Core {
@@ -47,9 +57,32 @@ func NewTee(cores ...Core) Core {
 
 func (mc multiCore) With(fields []Field) Core {
 	clone := make(multiCore, len(mc))
+	var shared sharedEncoders
 	for i := range mc {
-		clone[i] = mc[i].With(fields)
+		clone[i] = shared.with(mc[i], fields)
+	}
+	return clone
+}
+
+// sharedEncoders lets the children of a Tee that use the same Encoder share
+// the result of adding fields to it, so that With encodes the fields once
+// rather than once per child.
+type sharedEncoders []struct {
+	orig, with *ioCore
+}
+
+func (s *sharedEncoders) with(core Core, fields []Field) Core {
+	c, ok := core.(*ioCore)
+	if !ok || !reflect.TypeOf(c.enc).Comparable() {
+		return core.With(fields)
+	}
+	for _, e := range *s {
+		if e.orig.enc == c.enc && e.orig.deferred == c.deferred {
+			return c.withContext(e.with)
+		}
 	}
+	clone := c.With(fields).(*ioCore)
+	*s = append(*s, struct{ orig, with *ioCore }{c, clone})
 	return clone
 }
 
@@ -94,3 +127,196 @@ func (mc multiCore) Sync() error {
 	}
 	return err
 }
+
+// TeeOption configures a Core created by NewTeeWithOptions.
+type TeeOption interface {
+	apply(*teeOptions)
+}
+
+type teeOptions struct {
+	onError func(int, error)
+	workers int
+}
+
+type teeOptionFunc func(*teeOptions)
+
+func (f teeOptionFunc) apply(o *teeOptions) {
+	f(o)
+}
+
+// TeeErrorHandler isolates the Cores of a Tee from each other's failures.
+// Errors from writing to or syncing the Core at the given index are passed to
+// the handler instead of being returned, so a failing destination doesn't
+// cause every entry to be reported as failed. Writes to the remaining Cores
+// always proceed.
+//
+// The handler may be called concurrently if TeeParallel is also used.
+func TeeErrorHandler(handler func(core int, err error)) TeeOption {
+	return teeOptionFunc(func(o *teeOptions) {
+		o.onError = handler
+	})
+}
+
+// TeeParallel writes each entry to the Cores of a Tee concurrently, using at
+// most the given number of goroutines at a time across all entries, so that
+// a slow destination (e.g., over the network) doesn't delay writes to a fast
+// one. Write still returns only after every Core has finished.
+//
+// Fields are marshaled once per Core, so ObjectMarshalers and
+// ArrayMarshalers passed to a parallel Tee must be safe for concurrent use.
+// Values of one or less disable parallelism.
+func TeeParallel(workers int) TeeOption {
+	return teeOptionFunc(func(o *teeOptions) {
+		o.workers = workers
+	})
+}
+
+// NewTeeWithOptions creates a Core that duplicates log entries into two or
+// more underlying Cores, like NewTee, with additional control over how
+// failures and slow Cores are handled.
+//
+// Without options, or with only a single Core, it behaves exactly like NewTee.
+// Otherwise, the returned Core resolves its children when each entry is
+// written rather than when it's checked; CheckWriteHooks registered by the
+// children are not run.
+func NewTeeWithOptions(cores []Core, opts ...TeeOption) Core {
+	var o teeOptions
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	if len(cores) < 2 || (o.onError == nil && o.workers <= 1) {
+		return NewTee(cores...)
+	}
+
+	t := &optionsTee{
+		cores:   append(multiCore{}, cores...),
+		onError: o.onError,
+	}
+	if o.workers > 1 {
+		t.sem = make(chan struct{}, o.workers)
+	}
+	return t
+}
+
+type optionsTee struct {
+	cores   multiCore
+	onError func(int, error)
+	sem     chan struct{} // nil for serial writes; shared with clones
+}
+
+var (
+	_ leveledEnabler = (*optionsTee)(nil)
+	_ ContextCore    = (*optionsTee)(nil)
+)
+
+func (t *optionsTee) With(fields []Field) Core {
+	return &optionsTee{
+		cores:   t.cores.With(fields).(multiCore),
+		onError: t.onError,
+		sem:     t.sem,
+	}
+}
+
+func (t *optionsTee) Level() Level {
+	return t.cores.Level()
+}
+
+func (t *optionsTee) Enabled(lvl Level) bool {
+	return t.cores.Enabled(lvl)
+}
+
+func (t *optionsTee) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if t.Enabled(ent.Level) {
+		return ce.AddCore(ent, t)
+	}
+	return ce
+}
+
+func (t *optionsTee) Write(ent Entry, fields []Field) error {
+	return t.WriteContext(context.Background(), ent, fields)
+}
+
+func (t *optionsTee) WriteContext(ctx context.Context, ent Entry, fields []Field) error {
+	if t.sem == nil {
+		// Write serially without going through each, which would allocate
+		// on every entry.
+		var err error
+		for i, c := range t.cores {
+			err = multierr.Append(err, t.handle(i, writeChecked(ctx, c, ent, fields)))
+		}
+		return err
+	}
+	return t.writeParallel(ctx, ent, fields)
+}
+
+// writeParallel is split out of WriteContext because the closure passed to
+// each moves ent to the heap, which would otherwise cost serial writes an
+// allocation too.
+func (t *optionsTee) writeParallel(ctx context.Context, ent Entry, fields []Field) error {
+	return t.each(func(c Core) error {
+		return writeChecked(ctx, c, ent, fields)
+	})
+}
+
+func (t *optionsTee) Sync() error {
+	return t.each(Core.Sync)
+}
+
+// each runs f on every child, serially or in parallel, and reports errors
+// to the error handler if there is one. In parallel, the last child runs on
+// the calling goroutine, since it has to wait for the others anyway.
+func (t *optionsTee) each(f func(Core) error) error {
+	errs := make([]error, len(t.cores))
+	if t.sem == nil {
+		for i, c := range t.cores {
+			errs[i] = f(c)
+		}
+	} else {
+		last := len(t.cores) - 1
+		var wg sync.WaitGroup
+		for i, c := range t.cores[:last] {
+			t.sem <- struct{}{}
+			wg.Add(1)
+			go func(i int, c Core) {
+				defer func() {
+					<-t.sem
+					wg.Done()
+				}()
+				errs[i] = f(c)
+			}(i, c)
+		}
+		errs[last] = f(t.cores[last])
+		wg.Wait()
+	}
+
+	var err error
+	for i, e := range errs {
+		err = multierr.Append(err, t.handle(i, e))
+	}
+	return err
+}
+
+// handle passes an error from the child at index i to the error handler, if
+// there is one, and otherwise returns it.
+func (t *optionsTee) handle(i int, err error) error {
+	if err != nil && t.onError != nil {
+		t.onError(i, err)
+		return nil
+	}
+	return err
+}
+
+// writeChecked writes an entry to the Cores that core registers in Check,
+// giving wrappers like samplers a chance to make their decisions.
+func writeChecked(ctx context.Context, core Core, ent Entry, fields []Field) error {
+	ce := core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+	var err error
+	for i := range ce.cores {
+		err = multierr.Append(err, writeContext(ctx, ce.cores[i], ent, fields))
+	}
+	putCheckedEntry(ce)
+	return err
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build !race
+
+package zapcore_test
+
+import (
+	"testing"
+
+	"go.uber.org/zap/internal/ztest"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTeeWriteAllocs is excluded from race builds, since the race detector
+// makes more values escape to the heap.
+func TestTeeWriteAllocs(t *testing.T) {
+	newCore := func() Core {
+		return NewCore(NewJSONEncoder(testEncoderConfig()), &ztest.Discarder{}, DebugLevel)
+	}
+	nopHook := func(Entry) error { return nil }
+	nopFieldHook := func(_ Entry, fs []Field) ([]Field, error) { return fs, nil }
+	ent := Entry{Level: InfoLevel, Message: "hello"}
+	field := makeInt64Field("k", 42)
+	allocs := func(core Core) float64 {
+		return testing.AllocsPerRun(100, func() {
+			if ce := core.Check(ent, nil); ce != nil {
+				ce.Write(field)
+			}
+		})
+	}
+
+	// Writing to a single Core allocates once, to pass the fields; neither
+	// Tees nor hooks should add to that.
+	want := allocs(newCore())
+	tests := []struct {
+		desc string
+		core Core
+	}{
+		{"tee", NewTee(newCore(), newCore(), newCore())},
+		{"tee with error handler", NewTeeWithOptions(
+			[]Core{newCore(), newCore(), newCore()},
+			TeeErrorHandler(func(int, error) {}),
+		)},
+		{"hooks", RegisterHooks(newCore(), nopHook, nopHook)},
+		{"field hooks", RegisterFieldHooks(newCore(), nopFieldHook, nopFieldHook)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, want, allocs(tt.core), "Unexpected allocations writing an entry.")
+		})
+	}
+}
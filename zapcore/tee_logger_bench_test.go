@@ -21,6 +21,7 @@
 package zapcore_test
 
 import (
+	"runtime"
 	"testing"
 
 	"go.uber.org/zap/internal/ztest"
@@ -61,3 +62,88 @@ func BenchmarkTeeCheck(b *testing.B) {
 		})
 	})
 }
+
+func BenchmarkTeeWith(b *testing.B) {
+	fields := []Field{
+		{Key: "request", Type: StringType, String: "7f3c8a1e"},
+		{Key: "user", Type: StringType, String: "alice"},
+		{Key: "attempt", Type: Int64Type, Integer: 3},
+	}
+	shared := NewJSONEncoder(testEncoderConfig())
+
+	b.Run("shared encoder", func(b *testing.B) {
+		tee := NewTee(
+			NewCore(shared, &ztest.Discarder{}, DebugLevel),
+			NewCore(shared, &ztest.Discarder{}, InfoLevel),
+			NewCore(shared, &ztest.Discarder{}, WarnLevel),
+		)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			tee.With(fields)
+		}
+	})
+
+	b.Run("separate encoders", func(b *testing.B) {
+		tee := NewTee(
+			NewCore(NewJSONEncoder(testEncoderConfig()), &ztest.Discarder{}, DebugLevel),
+			NewCore(NewJSONEncoder(testEncoderConfig()), &ztest.Discarder{}, InfoLevel),
+			NewCore(NewJSONEncoder(testEncoderConfig()), &ztest.Discarder{}, WarnLevel),
+		)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			tee.With(fields)
+		}
+	})
+}
+
+// runContended writes entries to core from 64 goroutines at once, to surface
+// contention on state shared by the goroutines.
+func runContended(b *testing.B, core Core) {
+	const goroutines = 64
+	procs := runtime.GOMAXPROCS(0)
+	b.SetParallelism((goroutines + procs - 1) / procs)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		ent := Entry{Level: InfoLevel, Message: "contended"}
+		field := Field{Key: "k", Type: Int64Type, Integer: 42}
+		for pb.Next() {
+			if ce := core.Check(ent, nil); ce != nil {
+				ce.Write(field)
+			}
+		}
+	})
+}
+
+func BenchmarkContended(b *testing.B) {
+	newCore := func() Core {
+		return NewCore(NewJSONEncoder(testEncoderConfig()), &ztest.Discarder{}, DebugLevel)
+	}
+	nopHook := func(Entry) error { return nil }
+	nopFieldHook := func(_ Entry, fs []Field) ([]Field, error) { return fs, nil }
+	failing := NewCore(NewJSONEncoder(testEncoderConfig()), &ztest.FailWriter{}, DebugLevel)
+
+	tests := []struct {
+		desc string
+		core Core
+	}{
+		{"hooks", RegisterHooks(newCore(), nopHook, nopHook)},
+		{"field hooks", RegisterFieldHooks(newCore(), nopFieldHook, nopFieldHook)},
+		{"tee", NewTee(newCore(), newCore(), newCore())},
+		{"tee with error handler", NewTeeWithOptions(
+			[]Core{newCore(), newCore(), failing},
+			TeeErrorHandler(func(int, error) {}),
+		)},
+		{"parallel tee", NewTeeWithOptions(
+			[]Core{newCore(), newCore(), newCore()},
+			TeeParallel(8),
+		)},
+	}
+	for _, tt := range tests {
+		b.Run(tt.desc, func(b *testing.B) {
+			runContended(b, tt.core)
+		})
+	}
+}
@@ -21,8 +21,11 @@
 package zapcore_test
 
 import (
+	"bytes"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"go.uber.org/zap/internal/ztest"
 	//revive:disable:dot-imports
@@ -190,3 +193,166 @@ func TestTeeSync(t *testing.T) {
 	tee = NewTee(tee, noSync)
 	assert.Equal(t, err, tee.Sync(), "Expected an error when part of tee can't Sync.")
 }
+
+func TestTeeWithOptionsFallsBackToTee(t *testing.T) {
+	debugLogger, _ := observer.New(DebugLevel)
+	warnLogger, _ := observer.New(WarnLevel)
+	cores := []Core{debugLogger, warnLogger}
+
+	assert.Equal(t, NewTee(cores...), NewTeeWithOptions(cores), "Expected no options to produce a plain Tee.")
+	assert.Equal(t, debugLogger, NewTeeWithOptions(cores[:1], TeeParallel(4)), "Expected single inputs unchanged.")
+	assert.Equal(t, NewTee(cores...), NewTeeWithOptions(cores, TeeParallel(1)), "Expected one worker to produce a plain Tee.")
+}
+
+func TestTeeWithOptionsWrite(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []TeeOption
+	}{
+		{"error handler", []TeeOption{TeeErrorHandler(func(int, error) {})}},
+		{"parallel", []TeeOption{TeeParallel(2)}},
+		{"parallel with one worker per core", []TeeOption{TeeParallel(8)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			debugLogger, debugLogs := observer.New(DebugLevel)
+			warnLogger, warnLogs := observer.New(WarnLevel)
+			tee := NewTeeWithOptions([]Core{debugLogger, warnLogger}, tt.opts...)
+
+			assert.Equal(t, DebugLevel, LevelOf(tee), "Unexpected level.")
+			assert.True(t, tee.Enabled(DebugLevel), "Expected debug to be enabled.")
+
+			f := makeInt64Field("k", 42)
+			tee = tee.With([]Field{f})
+			debugEntry := Entry{Level: DebugLevel, Message: "log-at-debug"}
+			warnEntry := Entry{Level: WarnLevel, Message: "log-at-warn"}
+			for _, ent := range []Entry{debugEntry, warnEntry} {
+				if ce := tee.Check(ent, nil); ce != nil {
+					ce.Write()
+				}
+			}
+			assert.Nil(t, tee.Check(Entry{Level: DebugLevel - 1}, nil), "Expected disabled levels to be dropped.")
+
+			assert.Equal(t, []observer.LoggedEntry{
+				{Entry: debugEntry, Context: []Field{f}},
+				{Entry: warnEntry, Context: []Field{f}},
+			}, debugLogs.All())
+			assert.Equal(t, []observer.LoggedEntry{
+				{Entry: warnEntry, Context: []Field{f}},
+			}, warnLogs.All())
+			assert.NoError(t, tee.Sync(), "Unexpected error syncing.")
+		})
+	}
+}
+
+func TestTeeWithOptionsErrors(t *testing.T) {
+	failing := &ztest.FailWriter{}
+	failing.SetError(errors.New("failed"))
+	failingCore := NewCore(NewJSONEncoder(testEncoderConfig()), failing, DebugLevel)
+	okCore, okLogs := observer.New(DebugLevel)
+	ent := Entry{Level: InfoLevel, Message: "msg"}
+
+	t.Run("without handler", func(t *testing.T) {
+		tee := NewTeeWithOptions([]Core{failingCore, okCore}, TeeParallel(2))
+		assert.EqualError(t, tee.Write(ent, nil), "failed", "Expected errors to be returned.")
+		assert.EqualError(t, tee.Sync(), "failed", "Expected errors to be returned.")
+	})
+
+	t.Run("with handler", func(t *testing.T) {
+		var (
+			mu     sync.Mutex
+			failed []int
+		)
+		tee := NewTeeWithOptions([]Core{okCore, failingCore}, TeeErrorHandler(func(i int, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failed = append(failed, i)
+			assert.EqualError(t, err, "failed", "Unexpected error.")
+		}))
+		assert.NoError(t, tee.Write(ent, nil), "Expected errors to go to the handler.")
+		assert.NoError(t, tee.Sync(), "Expected errors to go to the handler.")
+		assert.Equal(t, []int{1, 1}, failed, "Unexpected failing cores.")
+	})
+
+	assert.Equal(t, 2, okLogs.Len(), "Expected healthy core to receive every entry.")
+}
+
+func TestTeeParallelDoesNotSerialize(t *testing.T) {
+	release := make(chan struct{})
+	slow := &blockingCore{LevelEnabler: DebugLevel, release: release}
+	fast, fastLogs := observer.New(DebugLevel)
+	tee := NewTeeWithOptions([]Core{slow, fast}, TeeParallel(2))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, tee.Write(Entry{Level: InfoLevel}, nil))
+	}()
+
+	assert.Eventually(t, func() bool { return fastLogs.Len() == 1 }, time.Second, time.Millisecond,
+		"Expected fast core to be written while slow core blocks.")
+	close(release)
+	<-done
+}
+
+type blockingCore struct {
+	LevelEnabler
+
+	release chan struct{}
+}
+
+func (c *blockingCore) With([]Field) Core { return c }
+
+func (c *blockingCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	return ce.AddCore(ent, c)
+}
+
+func (c *blockingCore) Write(Entry, []Field) error {
+	<-c.release
+	return nil
+}
+
+func (c *blockingCore) Sync() error { return nil }
+
+// cloneCountingEncoder counts the number of times it's cloned.
+type cloneCountingEncoder struct {
+	Encoder
+
+	clones *int
+}
+
+func (e *cloneCountingEncoder) Clone() Encoder {
+	*e.clones++
+	return &cloneCountingEncoder{Encoder: e.Encoder.Clone(), clones: e.clones}
+}
+
+func TestTeeWithSharesEncoders(t *testing.T) {
+	var clones int
+	shared := &cloneCountingEncoder{Encoder: NewJSONEncoder(testEncoderConfig()), clones: &clones}
+	other := &cloneCountingEncoder{Encoder: NewJSONEncoder(testEncoderConfig()), clones: &clones}
+
+	var buf1, buf2, buf3 bytes.Buffer
+	tee := NewTee(
+		NewCore(shared, AddSync(&buf1), DebugLevel),
+		NewCore(shared, AddSync(&buf2), InfoLevel),
+		NewCore(other, AddSync(&buf3), DebugLevel),
+	)
+
+	child := tee.With([]Field{{Key: "k", Type: ObjectMarshalerType, Interface: ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		enc.AddInt("v", 1)
+		return nil
+	})}})
+	assert.Equal(t, 2, clones, "Expected one clone per distinct encoder.")
+	grandchild := child.With([]Field{makeInt64Field("j", 2)})
+	assert.Equal(t, 2, clones, "Expected small context to be held back.")
+
+	if ce := grandchild.Check(Entry{Level: InfoLevel, Message: "hello"}, nil); ce != nil {
+		ce.Write()
+	}
+	assert.Equal(t, 4, clones, "Expected held back context to be encoded once per distinct encoder.")
+	want := `{"level":"info","msg":"hello","k":{"v":1},"j":2}` + "\n"
+	for i, buf := range []*bytes.Buffer{&buf1, &buf2, &buf3} {
+		assert.Equal(t, want, buf.String(), "Unexpected output from core %d.", i)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrSyncTimeout is returned by WriteSyncers created by NewTimeoutSyncer if
+// a Sync doesn't complete within the configured timeout.
+var ErrSyncTimeout = errors.New("sync timed out")
+
+// A HealthChecker reports whether a log destination is currently able to
+// accept writes. It's an optional extension of WriteSyncer: Cores created
+// with NewCore include the result of Healthy in the error returned by Sync,
+// so problems like a dead socket surface through Logger.Sync.
+type HealthChecker interface {
+	// Healthy returns nil if the destination is healthy, and an error
+	// describing the problem otherwise.
+	Healthy() error
+}
+
+// checkHealth returns the result of v's Healthy method, if it has one.
+func checkHealth(v interface{}) error {
+	if hc, ok := v.(HealthChecker); ok {
+		return hc.Healthy()
+	}
+	return nil
+}
+
+// NewTimeoutSyncer wraps a WriteSyncer so that Sync gives up after the given
+// timeout and returns ErrSyncTimeout, rather than blocking indefinitely on a
+// hung destination (such as an unresponsive network filesystem). This keeps
+// a stuck log destination from blocking process shutdown.
+//
+// A Sync that times out keeps running in the background. Until it returns,
+// further calls to Sync wait on the same operation instead of starting new
+// ones, and Healthy reports the destination as unhealthy.
+//
+// Writes are passed through to the wrapped WriteSyncer unchanged. A timeout of
+// zero or less disables the timeout.
+func NewTimeoutSyncer(ws WriteSyncer, timeout time.Duration) WriteSyncer {
+	return &timeoutSyncer{ws: ws, timeout: timeout}
+}
+
+type timeoutSyncer struct {
+	ws      WriteSyncer
+	timeout time.Duration
+
+	mu       sync.Mutex
+	inflight *pendingSync // non-nil while a Sync is running
+}
+
+type pendingSync struct {
+	done  chan struct{} // closed when Sync returns
+	err   error
+	start time.Time
+}
+
+var (
+	_ WriteSyncer   = (*timeoutSyncer)(nil)
+	_ HealthChecker = (*timeoutSyncer)(nil)
+)
+
+func (s *timeoutSyncer) Write(bs []byte) (int, error) {
+	return s.ws.Write(bs)
+}
+
+func (s *timeoutSyncer) Sync() error {
+	if s.timeout <= 0 {
+		return s.ws.Sync()
+	}
+
+	s.mu.Lock()
+	p := s.inflight
+	if p == nil {
+		p = &pendingSync{done: make(chan struct{}), start: time.Now()}
+		s.inflight = p
+		go s.run(p)
+	}
+	s.mu.Unlock()
+
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+	select {
+	case <-p.done:
+		return p.err
+	case <-timer.C:
+		return ErrSyncTimeout
+	}
+}
+
+func (s *timeoutSyncer) run(p *pendingSync) {
+	p.err = s.ws.Sync()
+
+	s.mu.Lock()
+	s.inflight = nil
+	s.mu.Unlock()
+	close(p.done)
+}
+
+// Healthy reports an error if a Sync has been running for longer than the
+// timeout, and otherwise defers to the wrapped WriteSyncer.
+func (s *timeoutSyncer) Healthy() error {
+	s.mu.Lock()
+	p := s.inflight
+	s.mu.Unlock()
+
+	if p != nil && s.timeout > 0 {
+		if d := time.Since(p.start); d > s.timeout {
+			return fmt.Errorf("sync blocked for %v: %w", d.Round(time.Millisecond), ErrSyncTimeout)
+		}
+	}
+	return checkHealth(s.ws)
+}
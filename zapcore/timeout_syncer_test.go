@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/internal/ztest"
+)
+
+// blockingSyncer is a WriteSyncer whose Sync blocks until released.
+type blockingSyncer struct {
+	ztest.Discarder
+
+	release chan struct{}
+	calls   chan struct{}
+}
+
+func newBlockingSyncer() *blockingSyncer {
+	return &blockingSyncer{
+		release: make(chan struct{}),
+		calls:   make(chan struct{}, 10),
+	}
+}
+
+func (s *blockingSyncer) Sync() error {
+	s.calls <- struct{}{}
+	<-s.release
+	return s.Discarder.Sync()
+}
+
+type healthySpy struct {
+	ztest.Discarder
+
+	err error
+}
+
+func (s *healthySpy) Healthy() error { return s.err }
+
+func TestTimeoutSyncerPassesThrough(t *testing.T) {
+	sink := &ztest.Discarder{}
+	ws := NewTimeoutSyncer(sink, time.Second)
+	requireWriteWorks(t, ws)
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.True(t, sink.Called(), "Expected Sync to be forwarded.")
+
+	err := errors.New("failed")
+	sink.SetError(err)
+	assert.Equal(t, err, ws.Sync(), "Expected Sync errors to be forwarded.")
+}
+
+func TestTimeoutSyncerDisabled(t *testing.T) {
+	sink := &ztest.Discarder{}
+	ws := NewTimeoutSyncer(sink, 0)
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.True(t, sink.Called(), "Expected Sync to be forwarded.")
+}
+
+func TestTimeoutSyncerTimesOut(t *testing.T) {
+	sink := newBlockingSyncer()
+	ws := NewTimeoutSyncer(sink, 10*time.Millisecond)
+
+	assert.Equal(t, ErrSyncTimeout, ws.Sync(), "Expected Sync to time out.")
+	assert.Equal(t, ErrSyncTimeout, ws.Sync(), "Expected Sync to time out again.")
+	assert.Len(t, sink.calls, 1, "Expected a hung Sync not to be retried.")
+
+	hc, ok := ws.(HealthChecker)
+	require.True(t, ok, "Expected timeout syncer to implement HealthChecker.")
+	assert.ErrorIs(t, hc.Healthy(), ErrSyncTimeout, "Expected hung Sync to be unhealthy.")
+
+	core := NewCore(NewJSONEncoder(EncoderConfig{}), ws, DebugLevel)
+	assert.ErrorIs(t, core.Sync(), ErrSyncTimeout, "Expected Core.Sync to surface the timeout.")
+
+	close(sink.release)
+	assert.Eventually(t, func() bool { return hc.Healthy() == nil }, time.Second, time.Millisecond,
+		"Expected syncer to become healthy once Sync returns.")
+	assert.NoError(t, ws.Sync(), "Expected Sync to succeed once released.")
+}
+
+func TestHealthForwarding(t *testing.T) {
+	err := errors.New("unhealthy")
+	sick := &healthySpy{err: err}
+	fine := &healthySpy{}
+
+	tests := []struct {
+		desc string
+		ws   WriteSyncer
+		want error
+	}{
+		{"locked", Lock(sick), err},
+		{"multi", NewMultiWriteSyncer(fine, sick, &ztest.Discarder{}), err},
+		{"buffered", &BufferedWriteSyncer{WS: sick}, err},
+		{"timeout", NewTimeoutSyncer(sick, time.Second), err},
+		{"healthy", Lock(fine), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			hc, ok := tt.ws.(HealthChecker)
+			require.True(t, ok, "Expected WriteSyncer to implement HealthChecker.")
+			assert.Equal(t, tt.want, hc.Healthy(), "Unexpected health.")
+
+			core := NewCore(NewJSONEncoder(EncoderConfig{}), tt.ws, DebugLevel)
+			assert.Equal(t, tt.want, core.Sync(), "Expected Core.Sync to report health.")
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import "context"
+
+// A Transform rewrites an entry and the fields logged with it before they're
+// encoded. Transforms may rename keys, drop or add fields, change the level,
+// or edit the message. They must not modify the fields slice they're given
+// in place; they should copy it instead, since it may be shared with other
+// Cores.
+type Transform func(Entry, []Field) (Entry, []Field)
+
+type transformCore struct {
+	Core
+
+	transforms []Transform
+}
+
+var (
+	_ ContextCore    = (*transformCore)(nil)
+	_ leveledEnabler = (*transformCore)(nil)
+)
+
+// NewTransformCore wraps a Core, running each entry and the fields logged
+// with it through the given transforms, in order, before writing them to the
+// wrapped Core. It's a building block for organization-specific logging
+// policies, such as key naming conventions or enrichment with derived
+// fields.
+//
+// Transforms only run for entries whose original level is enabled by the
+// wrapped Core. If a transform changes an entry's level, the wrapped Core
+// checks the new level before writing, so lowering an entry's level may
+// drop it. Transforms don't see fields added with With, since the wrapped
+// Core has already encoded them.
+func NewTransformCore(core Core, transforms ...Transform) Core {
+	if len(transforms) == 0 {
+		return core
+	}
+	return &transformCore{
+		Core:       core,
+		transforms: append([]Transform{}, transforms...),
+	}
+}
+
+func (c *transformCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *transformCore) With(fields []Field) Core {
+	return &transformCore{
+		Core:       c.Core.With(fields),
+		transforms: c.transforms,
+	}
+}
+
+func (c *transformCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	// The transforms need the fields, which are only available in Write, so
+	// register ourselves rather than letting the wrapped Core do so.
+	if c.Core.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *transformCore) Write(ent Entry, fields []Field) error {
+	return c.WriteContext(context.Background(), ent, fields)
+}
+
+func (c *transformCore) WriteContext(ctx context.Context, ent Entry, fields []Field) error {
+	for _, t := range c.transforms {
+		ent, fields = t(ent, fields)
+	}
+	return writeChecked(ctx, c.Core, ent, fields)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"strings"
+	"testing"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformCore(t *testing.T) {
+	snakeCase := func(ent Entry, fields []Field) (Entry, []Field) {
+		out := make([]Field, len(fields))
+		for i, f := range fields {
+			f.Key = strings.ReplaceAll(f.Key, "-", "_")
+			out[i] = f
+		}
+		return ent, out
+	}
+	dropDebugFields := func(ent Entry, fields []Field) (Entry, []Field) {
+		out := make([]Field, 0, len(fields))
+		for _, f := range fields {
+			if !strings.HasPrefix(f.Key, "debug_") {
+				out = append(out, f)
+			}
+		}
+		return ent, out
+	}
+	escalateTimeouts := func(ent Entry, fields []Field) (Entry, []Field) {
+		if strings.Contains(ent.Message, "timeout") {
+			ent.Level = WarnLevel
+		}
+		return ent, fields
+	}
+
+	fac, logs := observer.New(InfoLevel)
+	core := NewTransformCore(fac, snakeCase, dropDebugFields, escalateTimeouts).
+		With([]Field{makeInt64Field("ctx-field", 1)})
+
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+	assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected disabled levels to be dropped.")
+
+	fields := []Field{makeInt64Field("request-id", 2), makeInt64Field("debug-dump", 3)}
+	core.Check(Entry{Level: InfoLevel, Message: "request timeout"}, nil).Write(fields...)
+	core.Check(Entry{Level: InfoLevel, Message: "ok"}, nil).Write()
+
+	assert.Equal(t, []observer.LoggedEntry{
+		{
+			Entry:   Entry{Level: WarnLevel, Message: "request timeout"},
+			Context: []Field{makeInt64Field("ctx-field", 1), makeInt64Field("request_id", 2)},
+		},
+		{
+			Entry:   Entry{Level: InfoLevel, Message: "ok"},
+			Context: []Field{makeInt64Field("ctx-field", 1)},
+		},
+	}, logs.AllUntimed(), "Unexpected logs after transforms.")
+	assert.Equal(t, "request-id", fields[0].Key, "Transforms shouldn't modify the caller's fields.")
+}
+
+func TestTransformCoreLowersLevel(t *testing.T) {
+	fac, logs := observer.New(InfoLevel)
+	demote := func(ent Entry, fields []Field) (Entry, []Field) {
+		ent.Level = DebugLevel
+		return ent, fields
+	}
+	core := NewTransformCore(fac, demote)
+	core.Check(Entry{Level: InfoLevel}, nil).Write()
+	assert.Zero(t, logs.Len(), "Expected entries demoted below the core's level to be dropped.")
+}
+
+func TestTransformCoreNoTransforms(t *testing.T) {
+	fac, _ := observer.New(InfoLevel)
+	assert.Equal(t, fac, NewTransformCore(fac), "Expected no-op transform core to return the original core.")
+}
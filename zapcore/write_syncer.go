@@ -34,6 +34,23 @@ type WriteSyncer interface {
 	Sync() error
 }
 
+// A LevelWriter is an optional extension of WriteSyncer for destinations
+// that treat log entries differently depending on their level, such as
+// flushing buffered data as soon as an error is logged. Cores created with
+// NewCore call WriteLevel instead of Write if their WriteSyncer implements it.
+type LevelWriter interface {
+	// WriteLevel writes an encoded log entry logged at the given level.
+	WriteLevel(Level, []byte) (int, error)
+}
+
+// writeLevel writes bs to ws, using WriteLevel if it's supported.
+func writeLevel(ws WriteSyncer, lvl Level, bs []byte) (int, error) {
+	if lw, ok := ws.(LevelWriter); ok {
+		return lw.WriteLevel(lvl, bs)
+	}
+	return ws.Write(bs)
+}
+
 // AddSync converts an io.Writer to a WriteSyncer. It attempts to be
 // intelligent: if the concrete type of the io.Writer implements WriteSyncer,
 // we'll use the existing Sync method. If it doesn't, we'll add a no-op Sync.
@@ -68,6 +85,13 @@ func (s *lockedWriteSyncer) Write(bs []byte) (int, error) {
 	return n, err
 }
 
+func (s *lockedWriteSyncer) WriteLevel(lvl Level, bs []byte) (int, error) {
+	s.Lock()
+	n, err := writeLevel(s.ws, lvl, bs)
+	s.Unlock()
+	return n, err
+}
+
 func (s *lockedWriteSyncer) Sync() error {
 	s.Lock()
 	err := s.ws.Sync()
@@ -75,6 +99,10 @@ func (s *lockedWriteSyncer) Sync() error {
 	return err
 }
 
+func (s *lockedWriteSyncer) Healthy() error {
+	return checkHealth(s.ws)
+}
+
 type writerWrapper struct {
 	io.Writer
 }
@@ -99,10 +127,18 @@ func NewMultiWriteSyncer(ws ...WriteSyncer) WriteSyncer {
 // the smallest number is returned even though Write() is called on
 // all of them.
 func (ws multiWriteSyncer) Write(p []byte) (int, error) {
+	return ws.write(p, func(w WriteSyncer) (int, error) { return w.Write(p) })
+}
+
+func (ws multiWriteSyncer) WriteLevel(lvl Level, p []byte) (int, error) {
+	return ws.write(p, func(w WriteSyncer) (int, error) { return writeLevel(w, lvl, p) })
+}
+
+func (ws multiWriteSyncer) write(p []byte, write func(WriteSyncer) (int, error)) (int, error) {
 	var writeErr error
 	nWritten := 0
 	for _, w := range ws {
-		n, err := w.Write(p)
+		n, err := write(w)
 		writeErr = multierr.Append(writeErr, err)
 		if nWritten == 0 && n != 0 {
 			nWritten = n
@@ -120,3 +156,11 @@ func (ws multiWriteSyncer) Sync() error {
 	}
 	return err
 }
+
+func (ws multiWriteSyncer) Healthy() error {
+	var err error
+	for _, w := range ws {
+		err = multierr.Append(err, checkHealth(w))
+	}
+	return err
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build unix
+
+package zapmmap
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func tempPath(t testing.TB) string {
+	return filepath.Join(t.TempDir(), "app.ring")
+}
+
+func writeLines(t testing.TB, s *Sink, lines ...string) {
+	for _, line := range lines {
+		n, err := s.Write([]byte(line + "\n"))
+		require.NoError(t, err, "Unexpected error writing.")
+		require.Equal(t, len(line)+1, n, "Unexpected number of bytes written.")
+	}
+}
+
+func readTail(t testing.TB, path string) string {
+	bs, err := ReadTail(path)
+	require.NoError(t, err, "Unexpected error reading tail.")
+	return string(bs)
+}
+
+func TestSinkSurvivesCrash(t *testing.T) {
+	path := tempPath(t)
+	s, err := Open(path, 1024)
+	require.NoError(t, err, "Unexpected error opening sink.")
+	defer s.Close()
+
+	writeLines(t, s, "foo", "bar")
+
+	// Without syncing or closing, the entries are already visible to a
+	// reader, as they would be after the process crashed.
+	assert.Equal(t, "foo\nbar\n", readTail(t, path), "Unexpected recovered tail.")
+}
+
+func TestSinkIgnoresUncommittedBytes(t *testing.T) {
+	path := tempPath(t)
+	s, err := Open(path, 1024)
+	require.NoError(t, err, "Unexpected error opening sink.")
+	defer s.Close()
+
+	writeLines(t, s, "foo")
+	// Simulate a crash partway through copying the next entry.
+	copy(s.mem[4:], "torn")
+
+	assert.Equal(t, "foo\n", readTail(t, path), "Expected uncommitted bytes to be ignored.")
+}
+
+func TestSinkWrapsAround(t *testing.T) {
+	path := tempPath(t)
+	s, err := Open(path, 64)
+	require.NoError(t, err, "Unexpected error opening sink.")
+
+	var lines []string
+	for i := 0; i < 20; i++ {
+		lines = append(lines, fmt.Sprintf("line %02d", i))
+	}
+	writeLines(t, s, lines...)
+	require.NoError(t, s.Sync(), "Unexpected error syncing.")
+	require.NoError(t, s.Close(), "Unexpected error closing.")
+
+	// 64 bytes hold eight 8-byte lines; the oldest is partially overwritten.
+	assert.Equal(t, strings.Join(lines[13:], "\n")+"\n", readTail(t, path), "Unexpected recovered tail.")
+}
+
+func TestSinkOversizedWrite(t *testing.T) {
+	path := tempPath(t)
+	s, err := Open(path, 8)
+	require.NoError(t, err, "Unexpected error opening sink.")
+	defer s.Close()
+
+	writeLines(t, s, "0123456789abc")
+	assert.Equal(t, "", readTail(t, path), "Expected the partial line to be dropped.")
+	// The region starts at offset 14 % 8 = 6.
+	assert.Equal(t, "89abc\n67", string(s.mem[:8]), "Expected the end of the entry to be kept.")
+}
+
+func TestSinkResumes(t *testing.T) {
+	path := tempPath(t)
+	s, err := Open(path, 1024)
+	require.NoError(t, err, "Unexpected error opening sink.")
+	writeLines(t, s, "foo")
+	require.NoError(t, s.Close(), "Unexpected error closing.")
+
+	s, err = Open(path, 1024)
+	require.NoError(t, err, "Unexpected error reopening sink.")
+	writeLines(t, s, "bar")
+	require.NoError(t, s.Close(), "Unexpected error closing.")
+
+	assert.Equal(t, "foo\nbar\n", readTail(t, path), "Expected writes to resume after reopening.")
+
+	_, err = Open(path, 2048)
+	assert.ErrorIs(t, err, ErrInvalidFile, "Expected reopening with a different size to fail.")
+}
+
+func TestSinkClosed(t *testing.T) {
+	s, err := Open(tempPath(t), 64)
+	require.NoError(t, err, "Unexpected error opening sink.")
+	require.NoError(t, s.Close(), "Unexpected error closing.")
+
+	_, err = s.Write([]byte("foo\n"))
+	assert.ErrorIs(t, err, os.ErrClosed, "Expected writes after Close to fail.")
+	assert.ErrorIs(t, s.Sync(), os.ErrClosed, "Expected Sync after Close to fail.")
+	assert.ErrorIs(t, s.Close(), os.ErrClosed, "Expected a second Close to fail.")
+}
+
+func TestOpenErrors(t *testing.T) {
+	path := tempPath(t)
+	require.NoError(t, os.WriteFile(path, []byte("not a log file"), 0o644), "Failed to write file.")
+
+	_, err := Open(path, 64)
+	assert.ErrorIs(t, err, ErrInvalidFile, "Expected opening a foreign file to fail.")
+	_, err = ReadTail(path)
+	assert.ErrorIs(t, err, ErrInvalidFile, "Expected reading a foreign file to fail.")
+
+	_, err = Open(tempPath(t), 0)
+	assert.Error(t, err, "Expected a zero size to be rejected.")
+}
+
+func TestSinkFactory(t *testing.T) {
+	path := tempPath(t)
+	u, err := url.Parse("mmap://" + path + "?size=1kb")
+	require.NoError(t, err, "Failed to parse URL.")
+
+	sink, err := SinkFactory(u)
+	require.NoError(t, err, "Unexpected error building sink.")
+	defer sink.Close()
+
+	logger := zap.New(zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}),
+		sink,
+		zap.DebugLevel,
+	))
+	logger.Info("hello")
+	assert.Equal(t, `{"msg":"hello"}`+"\n", readTail(t, path), "Unexpected recovered tail.")
+
+	u, err = url.Parse("mmap://" + path + "?size=big")
+	require.NoError(t, err, "Failed to parse URL.")
+	_, err = SinkFactory(u)
+	assert.ErrorContains(t, err, "invalid mmap URL", "Expected invalid size to be rejected.")
+}
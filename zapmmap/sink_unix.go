@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build unix
+
+package zapmmap
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// A Sink appends log entries to a memory-mapped file. It's safe for
+// concurrent use.
+type Sink struct {
+	mu       sync.Mutex
+	f        *os.File
+	mem      []byte // the whole mapping: log region, then trailer
+	capacity int
+	commit   uint64  // total bytes written
+	trailer  *uint64 // commit counter in the mapped trailer
+}
+
+var _ zap.Sink = (*Sink)(nil)
+
+// Open maps the log file at path, creating it with a log region of the
+// given size if it doesn't exist. If the file exists, it must have been
+// written by a Sink of the same size; writing resumes after its last
+// committed entry.
+func Open(path string, size int) (*Sink, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid mmap log size %d", size)
+	}
+	capacity := regionSize(size)
+	total := capacity + _trailerSize
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	s, err := newSink(f, capacity, total)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func newSink(f *os.File, capacity, total int) (*Sink, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	fresh := info.Size() == 0
+	if fresh {
+		if err := f.Truncate(int64(total)); err != nil {
+			return nil, err
+		}
+	} else if info.Size() != int64(total) {
+		return nil, fmt.Errorf("%s: %w", f.Name(), ErrInvalidFile)
+	}
+
+	mem, err := syscall.Mmap(int(f.Fd()), 0, total, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("mmap %s: %w", f.Name(), err)
+	}
+
+	s := &Sink{
+		f:        f,
+		mem:      mem,
+		capacity: capacity,
+		trailer:  (*uint64)(unsafe.Pointer(&mem[capacity+_commitOffset])),
+	}
+	if fresh {
+		trailer := mem[capacity:]
+		copy(trailer, _magic)
+		binary.LittleEndian.PutUint64(trailer[_capacityOffset:], uint64(capacity))
+		return s, nil
+	}
+
+	if _, s.commit, err = parseTrailer(mem); err != nil {
+		_ = syscall.Munmap(mem)
+		return nil, fmt.Errorf("%s: %w", f.Name(), err)
+	}
+	return s, nil
+}
+
+// Write copies bs into the log region and commits it. If bs is larger than
+// the region, only its last bytes are kept.
+func (s *Sink) Write(bs []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.mem == nil {
+		return 0, os.ErrClosed
+	}
+
+	n := len(bs)
+	start := s.commit + uint64(n)
+	if n > s.capacity {
+		bs = bs[n-s.capacity:]
+	}
+	start -= uint64(len(bs))
+
+	data := s.mem[:s.capacity]
+	copied := copy(data[start%uint64(s.capacity):], bs)
+	copy(data, bs[copied:])
+
+	// Publish the entry only after all of it is in place, so that a crash
+	// mid-copy never exposes a torn entry.
+	s.commit += uint64(n)
+	atomic.StoreUint64(s.trailer, littleEndian(s.commit))
+	return n, nil
+}
+
+// Sync flushes the mapping to stable storage.
+func (s *Sink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.mem == nil {
+		return os.ErrClosed
+	}
+	return s.f.Sync()
+}
+
+// Close unmaps and closes the file. It doesn't flush the mapping; call Sync
+// first if that's needed.
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.mem == nil {
+		return os.ErrClosed
+	}
+	err := syscall.Munmap(s.mem)
+	s.mem = nil
+	s.trailer = nil
+	return multierr.Append(err, s.f.Close())
+}
+
+var _bigEndian = func() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 0
+}()
+
+// littleEndian converts v so that storing it natively writes it in
+// little-endian byte order.
+func littleEndian(v uint64) uint64 {
+	if _bigEndian {
+		return bits.ReverseBytes64(v)
+	}
+	return v
+}
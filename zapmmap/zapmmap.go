@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapmmap provides a crash-safe log sink backed by a memory-mapped
+// file.
+//
+// A Sink writes entries into a fixed-size region of the file, wrapping
+// around when it fills up, so the file always holds the most recent logs.
+// After copying each entry into the mapping, the Sink advances a commit
+// counter stored in a trailer at the end of the file. Since the mapping is
+// shared with the OS page cache, committed entries survive the process
+// crashing, even if nothing was flushed; use ReadTail to recover them.
+// Call Sync to also protect them against the machine crashing.
+//
+// The sink is intended for flight-recorder style logging alongside a
+// regular destination:
+//
+//	zap.RegisterSink("mmap", zapmmap.SinkFactory)
+//	cfg.OutputPaths = append(cfg.OutputPaths, "mmap:///var/run/app.ring?size=4mb")
+package zapmmap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"go.uber.org/zap"
+)
+
+const (
+	_magic       = "ZAPRING1"
+	_trailerSize = 32
+
+	// DefaultSize is the size of the log region used by SinkFactory when
+	// the URL doesn't specify one.
+	DefaultSize = 1 << 20 // 1 MiB
+)
+
+// The trailer holds, in order: the magic string, the capacity of the log
+// region, and the commit counter (the total number of bytes ever written),
+// all little-endian. The remaining eight bytes are reserved.
+const (
+	_capacityOffset = 8
+	_commitOffset   = 16
+)
+
+// ErrInvalidFile is returned when opening or reading a file that wasn't
+// written by a Sink, or that was written with a different size.
+var ErrInvalidFile = errors.New("not a zapmmap log file")
+
+// regionSize rounds size up so that the trailer stays 8-byte aligned.
+func regionSize(size int) int {
+	return (size + 7) &^ 7
+}
+
+// ReadTail returns the committed contents of the log file at path, oldest
+// first. If the Sink has wrapped around, the first, partially overwritten
+// line is dropped. Bytes written after the last commit, such as an entry
+// being copied when the process crashed, are ignored.
+func ReadTail(path string) ([]byte, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	capacity, commit, err := parseTrailer(bs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	data := bs[:capacity]
+	if commit <= uint64(capacity) {
+		return data[:commit], nil
+	}
+	start := int(commit % uint64(capacity))
+	out := append(data[start:capacity:capacity], data[:start]...)
+	if i := bytes.IndexByte(out, '\n'); i >= 0 {
+		out = out[i+1:]
+	}
+	return out, nil
+}
+
+// parseTrailer validates a log file's trailer, returning the capacity of
+// its log region and its commit counter.
+func parseTrailer(bs []byte) (capacity int, commit uint64, err error) {
+	if len(bs) < _trailerSize {
+		return 0, 0, ErrInvalidFile
+	}
+	trailer := bs[len(bs)-_trailerSize:]
+	if string(trailer[:len(_magic)]) != _magic {
+		return 0, 0, ErrInvalidFile
+	}
+	c := binary.LittleEndian.Uint64(trailer[_capacityOffset:])
+	if c != uint64(len(bs)-_trailerSize) || c == 0 {
+		return 0, 0, ErrInvalidFile
+	}
+	return int(c), binary.LittleEndian.Uint64(trailer[_commitOffset:]), nil
+}
+
+// SinkFactory builds a Sink from a URL of the form
+//
+//	mmap:///path/to/file?size=4mb
+//
+// for use with zap.RegisterSink. The size defaults to DefaultSize.
+func SinkFactory(u *url.URL) (zap.Sink, error) {
+	if u.Host != "" && u.Host != "localhost" {
+		return nil, fmt.Errorf("mmap URLs must leave host empty or use localhost: got %v", u)
+	}
+	params := zap.NewSinkParams(u)
+	size := params.Size("size", DefaultSize)
+	if err := params.Err(); err != nil {
+		return nil, fmt.Errorf("invalid mmap URL %v: %v", u, err)
+	}
+	return Open(u.Path, size)
+}
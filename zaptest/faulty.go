@@ -0,0 +1,195 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaptest
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ErrInjectedFault is the error returned by a FaultySyncer's failing writes
+// and syncs if FaultyOptions doesn't specify one.
+var ErrInjectedFault = errors.New("zaptest: injected fault")
+
+// FaultyOptions configures the faults a FaultySyncer injects. The zero value
+// injects no faults.
+//
+// Faults are injected either deterministically, on every Nth call, or at
+// random, with the given probability. If a write is chosen both to fail and
+// to be partial, it fails.
+type FaultyOptions struct {
+	// Out receives the bytes that are written successfully, and is synced by
+	// syncs that don't fail. Defaults to discarding writes.
+	Out zapcore.WriteSyncer
+
+	// FailEvery makes every Nth write fail without writing anything.
+	FailEvery int
+	// FailRate is the probability, between 0 and 1, that a write fails
+	// without writing anything.
+	FailRate float64
+	// WriteError is the error returned by failing writes. Defaults to
+	// ErrInjectedFault.
+	WriteError error
+
+	// PartialEvery makes every Nth write a partial write: only the first
+	// half of the bytes are written, and io.ErrShortWrite is returned.
+	PartialEvery int
+	// PartialRate is the probability, between 0 and 1, that a write is
+	// partial.
+	PartialRate float64
+
+	// SyncFailEvery makes every Nth sync fail without syncing Out.
+	SyncFailEvery int
+	// SyncError is the error returned by failing syncs. Defaults to
+	// ErrInjectedFault.
+	SyncError error
+
+	// Latency delays every write and sync by the given duration. Calls are
+	// serialized, so concurrent callers also wait for each other, like they
+	// would for a slow disk.
+	Latency time.Duration
+
+	// Seed seeds the random source used for FailRate and PartialRate, so
+	// that random faults are reproducible.
+	Seed int64
+}
+
+// FaultySyncer is a zapcore.WriteSyncer that injects write errors, partial
+// writes, and latency, for testing how applications behave when their
+// logging pipeline degrades. It's safe for concurrent use.
+type FaultySyncer struct {
+	mu     sync.Mutex
+	opts   FaultyOptions
+	rand   *rand.Rand
+	writes int
+	syncs  int
+	stats  FaultStats
+}
+
+// FaultStats counts the calls a FaultySyncer received and the faults it
+// injected.
+type FaultStats struct {
+	Writes        int // all writes, including failed and partial ones
+	FailedWrites  int
+	PartialWrites int
+	Syncs         int // all syncs, including failed ones
+	FailedSyncs   int
+}
+
+var _ zapcore.WriteSyncer = (*FaultySyncer)(nil)
+
+// NewFaultySyncer builds a FaultySyncer that injects the given faults.
+func NewFaultySyncer(opts FaultyOptions) *FaultySyncer {
+	s := &FaultySyncer{}
+	s.SetOptions(opts)
+	return s
+}
+
+// SetOptions replaces the faults the FaultySyncer injects, for example to
+// degrade a healthy output or to let a failing one recover. Call counts used
+// by FailEvery and the like restart, but Stats are kept.
+func (s *FaultySyncer) SetOptions(opts FaultyOptions) {
+	if opts.Out == nil {
+		opts.Out = zapcore.AddSync(io.Discard)
+	}
+	if opts.WriteError == nil {
+		opts.WriteError = ErrInjectedFault
+	}
+	if opts.SyncError == nil {
+		opts.SyncError = ErrInjectedFault
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.opts = opts
+	s.rand = rand.New(rand.NewSource(opts.Seed))
+	s.writes = 0
+	s.syncs = 0
+}
+
+// Stats reports the calls the FaultySyncer received and the faults it
+// injected so far.
+func (s *FaultySyncer) Stats() FaultStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// Write implements io.Writer, injecting faults as configured.
+func (s *FaultySyncer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sleep()
+	s.writes++
+	s.stats.Writes++
+
+	if s.inject(s.writes, s.opts.FailEvery, s.opts.FailRate) {
+		s.stats.FailedWrites++
+		return 0, s.opts.WriteError
+	}
+	if s.inject(s.writes, s.opts.PartialEvery, s.opts.PartialRate) {
+		s.stats.PartialWrites++
+		n, err := s.opts.Out.Write(p[:len(p)/2])
+		if err == nil {
+			err = io.ErrShortWrite
+		}
+		return n, err
+	}
+	return s.opts.Out.Write(p)
+}
+
+// Sync implements zapcore.WriteSyncer, injecting faults as configured.
+func (s *FaultySyncer) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sleep()
+	s.syncs++
+	s.stats.Syncs++
+
+	if s.inject(s.syncs, s.opts.SyncFailEvery, 0) {
+		s.stats.FailedSyncs++
+		return s.opts.SyncError
+	}
+	return s.opts.Out.Sync()
+}
+
+// inject reports whether the nth call should fault, given the fault's
+// period and probability.
+func (s *FaultySyncer) inject(n, every int, rate float64) bool {
+	if every > 0 && n%every == 0 {
+		return true
+	}
+	return rate > 0 && s.rand.Float64() < rate
+}
+
+func (s *FaultySyncer) sleep() {
+	if s.opts.Latency > 0 {
+		time.Sleep(s.opts.Latency)
+	}
+}
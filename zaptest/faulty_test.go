@@ -0,0 +1,167 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zaptest
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFaultySyncerNoFaults(t *testing.T) {
+	out := &Buffer{}
+	s := NewFaultySyncer(FaultyOptions{Out: out})
+
+	n, err := s.Write([]byte("foo\n"))
+	require.NoError(t, err, "Unexpected error writing without faults.")
+	assert.Equal(t, 4, n, "Unexpected number of bytes written.")
+	require.NoError(t, s.Sync(), "Unexpected error syncing without faults.")
+
+	assert.Equal(t, "foo\n", out.String(), "Expected write to reach the output.")
+	assert.True(t, out.Called(), "Expected sync to reach the output.")
+	assert.Equal(t, FaultStats{Writes: 1, Syncs: 1}, s.Stats(), "Unexpected stats.")
+}
+
+func TestFaultySyncerDiscardsByDefault(t *testing.T) {
+	s := NewFaultySyncer(FaultyOptions{})
+	n, err := s.Write([]byte("foo"))
+	assert.NoError(t, err, "Unexpected error writing.")
+	assert.Equal(t, 3, n, "Unexpected number of bytes written.")
+	assert.NoError(t, s.Sync(), "Unexpected error syncing.")
+}
+
+func TestFaultySyncerEvery(t *testing.T) {
+	failure := errors.New("disk full")
+	out := &Buffer{}
+	s := NewFaultySyncer(FaultyOptions{
+		Out:           out,
+		FailEvery:     3,
+		WriteError:    failure,
+		PartialEvery:  2,
+		SyncFailEvery: 2,
+	})
+
+	tests := []struct {
+		give  string
+		wantN int
+		want  error
+	}{
+		{give: "1111", wantN: 4},
+		{give: "2222", wantN: 2, want: io.ErrShortWrite},
+		{give: "3333", want: failure},
+		{give: "4444", wantN: 2, want: io.ErrShortWrite},
+		{give: "5555", wantN: 4},
+		{give: "6666", want: failure}, // failures win over partial writes
+	}
+	for _, tt := range tests {
+		n, err := s.Write([]byte(tt.give))
+		assert.Equal(t, tt.want, err, "Unexpected error writing %q.", tt.give)
+		assert.Equal(t, tt.wantN, n, "Unexpected number of bytes written for %q.", tt.give)
+	}
+	assert.Equal(t, "111122445555", out.String(), "Unexpected bytes reached the output.")
+
+	assert.NoError(t, s.Sync(), "Expected first sync to succeed.")
+	assert.Equal(t, ErrInjectedFault, s.Sync(), "Expected second sync to fail with the default error.")
+
+	assert.Equal(t, FaultStats{
+		Writes:        6,
+		FailedWrites:  2,
+		PartialWrites: 2,
+		Syncs:         2,
+		FailedSyncs:   1,
+	}, s.Stats(), "Unexpected stats.")
+}
+
+func TestFaultySyncerRate(t *testing.T) {
+	run := func(seed int64) []error {
+		s := NewFaultySyncer(FaultyOptions{FailRate: 0.5, Seed: seed})
+		errs := make([]error, 100)
+		for i := range errs {
+			_, errs[i] = s.Write([]byte("foo"))
+		}
+		return errs
+	}
+
+	errs := run(42)
+	assert.Equal(t, errs, run(42), "Expected the same seed to inject the same faults.")
+
+	var failed int
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	assert.True(t, failed > 25 && failed < 75, "Expected about half of the writes to fail, got %d.", failed)
+
+	s := NewFaultySyncer(FaultyOptions{FailRate: 1})
+	_, err := s.Write([]byte("foo"))
+	assert.Equal(t, ErrInjectedFault, err, "Expected all writes to fail at rate 1.")
+}
+
+func TestFaultySyncerLatency(t *testing.T) {
+	s := NewFaultySyncer(FaultyOptions{Latency: 10 * time.Millisecond})
+
+	start := time.Now()
+	_, err := s.Write([]byte("foo"))
+	require.NoError(t, err, "Unexpected error writing.")
+	require.NoError(t, s.Sync(), "Unexpected error syncing.")
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond, "Expected writes and syncs to be delayed.")
+}
+
+func TestFaultySyncerSetOptions(t *testing.T) {
+	out := &Buffer{}
+	s := NewFaultySyncer(FaultyOptions{Out: out, FailRate: 1})
+	_, err := s.Write([]byte("lost\n"))
+	assert.Error(t, err, "Expected write to fail before recovering.")
+
+	s.SetOptions(FaultyOptions{Out: out})
+	_, err = s.Write([]byte("kept\n"))
+	assert.NoError(t, err, "Expected write to succeed after recovering.")
+
+	assert.Equal(t, "kept\n", out.String(), "Unexpected bytes reached the output.")
+	assert.Equal(t, FaultStats{Writes: 2, FailedWrites: 1}, s.Stats(), "Expected stats to be kept.")
+}
+
+func TestFaultySyncerLogger(t *testing.T) {
+	out := &Buffer{}
+	errOut := &Buffer{}
+	s := NewFaultySyncer(FaultyOptions{Out: out, FailEvery: 2})
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}),
+		s,
+		zapcore.InfoLevel,
+	)
+	log := zap.New(core, zap.ErrorOutput(errOut))
+
+	log.Info("one")
+	log.Info("two")
+	log.Info("three")
+
+	assert.Equal(t, []string{`{"msg":"one"}`, `{"msg":"three"}`}, out.Lines(), "Unexpected logs written.")
+	assert.Contains(t, errOut.String(), "write error: "+ErrInjectedFault.Error(), "Expected failed write to be reported.")
+}
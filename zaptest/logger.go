@@ -22,11 +22,29 @@ package zaptest
 
 import (
 	"bytes"
+	"errors"
+	"os"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+// Environment variables that override how test loggers built by NewLogger
+// log, so that a failing test can be rerun with more detail without code
+// changes:
+//
+//	ZAPTEST_LEVEL=debug ZAPTEST_ENCODING=json go test -run TestFlaky -v ./...
+const (
+	// LevelEnv names the environment variable that sets the minimum level
+	// test loggers log at, like "debug" or "warn". It takes precedence over
+	// the Level option.
+	LevelEnv = "ZAPTEST_LEVEL"
+	// EncodingEnv names the environment variable that sets the encoding test
+	// loggers use, "console" or "json". It takes precedence over the
+	// Encoding option.
+	EncodingEnv = "ZAPTEST_ENCODING"
+)
+
 // LoggerOption configures the test logger built by NewLogger.
 type LoggerOption interface {
 	applyLoggerOption(*loggerOptions)
@@ -34,6 +52,7 @@ type LoggerOption interface {
 
 type loggerOptions struct {
 	Level      zapcore.LevelEnabler
+	Encoding   string
 	zapOptions []zap.Option
 }
 
@@ -51,6 +70,14 @@ func Level(enab zapcore.LevelEnabler) LoggerOption {
 	})
 }
 
+// Encoding sets the encoding used by a test Logger built by NewLogger:
+// "console", the default, or "json".
+func Encoding(name string) LoggerOption {
+	return loggerOptionFunc(func(opts *loggerOptions) {
+		opts.Encoding = name
+	})
+}
+
 // WrapOptions adds zap.Option's to a test Logger built by NewLogger.
 func WrapOptions(zapOpts ...zap.Option) LoggerOption {
 	return loggerOptionFunc(func(opts *loggerOptions) {
@@ -74,13 +101,21 @@ func WrapOptions(zapOpts ...zap.Option) LoggerOption {
 // You may also pass zap.Option's to customize test logger.
 //
 //	logger := zaptest.NewLogger(t, zaptest.WrapOptions(zap.AddCaller()))
+//
+// The level and encoding can also be set with the ZAPTEST_LEVEL and
+// ZAPTEST_ENCODING environment variables, which take precedence over the
+// options; see LevelEnv and EncodingEnv. They're read when NewLogger is
+// called, so tests can set them with t.Setenv. Invalid values are reported
+// to t, failing the test, and otherwise ignored.
 func NewLogger(t TestingT, opts ...LoggerOption) *zap.Logger {
 	cfg := loggerOptions{
-		Level: zapcore.DebugLevel,
+		Level:    zapcore.DebugLevel,
+		Encoding: "console",
 	}
 	for _, o := range opts {
 		o.applyLoggerOption(&cfg)
 	}
+	cfg.applyEnv(t)
 
 	writer := NewTestingWriter(t)
 	zapOptions := []zap.Option{
@@ -91,15 +126,44 @@ func NewLogger(t TestingT, opts ...LoggerOption) *zap.Logger {
 	zapOptions = append(zapOptions, cfg.zapOptions...)
 
 	return zap.New(
-		zapcore.NewCore(
-			zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()),
-			writer,
-			cfg.Level,
-		),
+		zapcore.NewCore(cfg.encoder(t), writer, cfg.Level),
 		zapOptions...,
 	)
 }
 
+// applyEnv overrides the options with the environment variables, if set.
+func (o *loggerOptions) applyEnv(t TestingT) {
+	if s, ok := os.LookupEnv(LevelEnv); ok {
+		lvl, err := zapcore.ParseLevel(s)
+		if err != nil {
+			invalid(t, LevelEnv, s, err)
+		} else {
+			o.Level = lvl
+		}
+	}
+	if s, ok := os.LookupEnv(EncodingEnv); ok {
+		o.Encoding = s
+	}
+}
+
+func (o *loggerOptions) encoder(t TestingT) zapcore.Encoder {
+	cfg := zap.NewDevelopmentEncoderConfig()
+	switch o.Encoding {
+	case "console":
+	case "json":
+		return zapcore.NewJSONEncoder(cfg)
+	default:
+		invalid(t, "encoding", o.Encoding, errors.New(`must be "console" or "json"`))
+	}
+	return zapcore.NewConsoleEncoder(cfg)
+}
+
+// invalid reports an invalid setting and marks the test as failed.
+func invalid(t TestingT, name, value string, err error) {
+	t.Logf("zaptest: ignoring invalid %s %q: %v", name, value, err)
+	t.Fail()
+}
+
 // TestingWriter is a WriteSyncer that writes to the given testing.TB.
 type TestingWriter struct {
 	t TestingT
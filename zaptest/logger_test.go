@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 	"testing"
 
 	"go.uber.org/zap"
@@ -32,6 +33,7 @@ import (
 	"go.uber.org/zap/zapcore"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTestLogger(t *testing.T) {
@@ -96,14 +98,110 @@ func TestTestLoggerSupportsWrappedZapOptions(t *testing.T) {
 	}, "log.Panic should panic")
 
 	ts.AssertMessages(
-		`INFO	zaptest/logger_test.go:89	received work order	{"k1": "v1"}`,
-		`DEBUG	zaptest/logger_test.go:90	starting work	{"k1": "v1"}`,
-		`WARN	zaptest/logger_test.go:91	work may fail	{"k1": "v1"}`,
-		`ERROR	zaptest/logger_test.go:92	work failed	{"k1": "v1", "error": "great sadness"}`,
-		`PANIC	zaptest/logger_test.go:95	failed to do work	{"k1": "v1"}`,
+		`INFO	zaptest/logger_test.go:91	received work order	{"k1": "v1"}`,
+		`DEBUG	zaptest/logger_test.go:92	starting work	{"k1": "v1"}`,
+		`WARN	zaptest/logger_test.go:93	work may fail	{"k1": "v1"}`,
+		`ERROR	zaptest/logger_test.go:94	work failed	{"k1": "v1", "error": "great sadness"}`,
+		`PANIC	zaptest/logger_test.go:97	failed to do work	{"k1": "v1"}`,
 	)
 }
 
+func TestTestLoggerLevelFromEnv(t *testing.T) {
+	t.Setenv(LevelEnv, "DEBUG")
+
+	ts := newTestLogSpy(t)
+	defer ts.AssertPassed()
+
+	log := NewLogger(ts, Level(zap.WarnLevel))
+	log.Debug("starting work")
+	log.Warn("work may fail")
+
+	ts.AssertMessages(
+		"DEBUG	starting work",
+		"WARN	work may fail",
+	)
+}
+
+func TestTestLoggerEncoding(t *testing.T) {
+	tests := []struct {
+		desc   string
+		env    string
+		opts   []LoggerOption
+		wantRe string
+	}{
+		{
+			desc:   "option",
+			opts:   []LoggerOption{Encoding("json")},
+			wantRe: `^{"L":"INFO","T":"[^"]+","M":"received work order","k":"v"}$`,
+		},
+		{
+			desc:   "env",
+			env:    "json",
+			wantRe: `^{"L":"INFO","T":"[^"]+","M":"received work order","k":"v"}$`,
+		},
+		{
+			desc:   "env overrides option",
+			env:    "console",
+			opts:   []LoggerOption{Encoding("json")},
+			wantRe: `^INFO	received work order	{"k": "v"}$`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv(EncodingEnv, tt.env)
+			}
+			ts := newTestLogSpy(t)
+			defer ts.AssertPassed()
+
+			NewLogger(ts, tt.opts...).Info("received work order", zap.String("k", "v"))
+			if assert.Len(t, ts.Messages, 1, "Expected one message.") {
+				assert.Regexp(t, tt.wantRe, ts.Messages[0], "Unexpected output.")
+			}
+		})
+	}
+}
+
+func TestTestLoggerInvalidSettings(t *testing.T) {
+	tests := []struct {
+		desc    string
+		env     map[string]string
+		opts    []LoggerOption
+		wantMsg string
+	}{
+		{
+			desc:    "level env",
+			env:     map[string]string{LevelEnv: "loud"},
+			wantMsg: `zaptest: ignoring invalid ZAPTEST_LEVEL "loud": unrecognized level: "loud"`,
+		},
+		{
+			desc:    "encoding env",
+			env:     map[string]string{EncodingEnv: "yaml"},
+			wantMsg: `zaptest: ignoring invalid encoding "yaml": must be "console" or "json"`,
+		},
+		{
+			desc:    "encoding option",
+			opts:    []LoggerOption{Encoding("xml")},
+			wantMsg: `zaptest: ignoring invalid encoding "xml": must be "console" or "json"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			ts := newTestLogSpy(t)
+			defer ts.AssertFailed()
+
+			NewLogger(ts, tt.opts...).Info("received work order")
+			assert.Equal(t, []string{tt.wantMsg, "INFO	received work order"}, ts.Messages,
+				"Expected invalid setting to be reported and ignored.")
+		})
+	}
+}
+
 func TestTestingWriter(t *testing.T) {
 	ts := newTestLogSpy(t)
 	w := NewTestingWriter(ts)
@@ -113,6 +211,62 @@ func TestTestingWriter(t *testing.T) {
 	assert.Equal(t, 7, n)
 }
 
+func TestTestingWriterPartialWrites(t *testing.T) {
+	ts := newTestLogSpy(t)
+	w := NewTestingWriter(ts)
+
+	write := func(s string) {
+		n, err := io.WriteString(w, s)
+		assert.NoError(t, err, "WriteString must not fail")
+		assert.Equal(t, len(s), n, "Unexpected number of bytes written.")
+	}
+
+	write("\tfirst entry\nmain.f()\n\t/src/main.go:12\n")
+	write("\tsecond entry")
+	write("\tthird entry")
+	assert.Equal(t, []string{"first entry\nmain.f()\n\t/src/main.go:12", "second entry", "third entry"}, ts.Messages,
+		"Expected each write to be logged right away, whether or not it ends a line.")
+	assert.NoError(t, w.Sync(), "Unexpected error syncing.")
+	assert.Len(t, ts.Messages, 3, "Expected Sync to be a no-op.")
+}
+
+func TestTestingWriterConcurrent(t *testing.T) {
+	ts := &concurrentLogSpy{}
+	w := NewTestingWriter(ts)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				fmt.Fprintf(w, "entry %d-%d\nstack line 1\nstack line 2\n", i, j)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	require.Len(t, ts.messages, 1000, "Expected one message per entry.")
+	for _, m := range ts.messages {
+		assert.Regexp(t, `^entry \d+-\d+\nstack line 1\nstack line 2$`, m, "Expected entries not to be interleaved.")
+	}
+}
+
+// concurrentLogSpy is a TestingT that records messages from many
+// goroutines.
+type concurrentLogSpy struct {
+	TestingT
+
+	mu       sync.Mutex
+	messages []string
+}
+
+func (t *concurrentLogSpy) Logf(format string, args ...interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.messages = append(t.messages, fmt.Sprintf(format, args...))
+}
+
 func TestTestLoggerErrorOutput(t *testing.T) {
 	// This test verifies that the test logger logs internal messages to the
 	// testing.T and marks the test as failed.
@@ -34,9 +34,18 @@ import (
 )
 
 // ObservedLogs is a concurrency-safe, ordered collection of observed logs.
+//
+// Besides collecting logs, ObservedLogs records how often the Core that
+// observes them is synced, and can make the Core's writes and syncs fail, so
+// that code which must sync its logger or handle write errors can be tested.
 type ObservedLogs struct {
 	mu   sync.RWMutex
 	logs []LoggedEntry
+
+	failWrite func(LoggedEntry) error // may be nil
+	failed    []LoggedEntry
+	syncErr   error
+	syncs     int
 }
 
 // Len returns the number of items in the collection.
@@ -144,12 +153,78 @@ func (o *ObservedLogs) Filter(keep func(LoggedEntry) bool) *ObservedLogs {
 	return &ObservedLogs{logs: filtered}
 }
 
-func (o *ObservedLogs) add(log LoggedEntry) {
+func (o *ObservedLogs) add(log LoggedEntry) error {
+	o.mu.RLock()
+	failWrite := o.failWrite
+	o.mu.RUnlock()
+
+	// Call failWrite without holding the lock, so that it can inspect the
+	// logs collected so far.
+	var err error
+	if failWrite != nil {
+		err = failWrite(log)
+	}
+
 	o.mu.Lock()
+	defer o.mu.Unlock()
+	if err != nil {
+		o.failed = append(o.failed, log)
+		return err
+	}
 	o.logs = append(o.logs, log)
+	return nil
+}
+
+// FailWrites makes the observing Core's writes fail. The given function is
+// called with each entry as it's written; if it returns an error, the entry
+// isn't added to the collection, and the Core's Write returns the error.
+// Entries that failed are available from FailedWrites. Passing nil stops
+// writes from failing.
+//
+//	logs.FailWrites(func(observer.LoggedEntry) error {
+//		return errors.New("disk full")
+//	})
+func (o *ObservedLogs) FailWrites(fail func(LoggedEntry) error) {
+	o.mu.Lock()
+	o.failWrite = fail
 	o.mu.Unlock()
 }
 
+// FailedWrites returns a copy of the entries whose writes were made to fail
+// by FailWrites.
+func (o *ObservedLogs) FailedWrites() []LoggedEntry {
+	o.mu.RLock()
+	ret := make([]LoggedEntry, len(o.failed))
+	copy(ret, o.failed)
+	o.mu.RUnlock()
+	return ret
+}
+
+// FailSyncs makes the observing Core's Sync return the given error. Passing
+// nil stops syncs from failing.
+func (o *ObservedLogs) FailSyncs(err error) {
+	o.mu.Lock()
+	o.syncErr = err
+	o.mu.Unlock()
+}
+
+// Syncs returns the number of times the observing Core, or a Core derived
+// from it with With, has been synced, including syncs that failed.
+func (o *ObservedLogs) Syncs() int {
+	o.mu.RLock()
+	n := o.syncs
+	o.mu.RUnlock()
+	return n
+}
+
+func (o *ObservedLogs) sync() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.syncs++
+	return o.syncErr
+}
+
 // New creates a new Core that buffers logs in memory (without any encoding).
 // It's particularly useful in tests.
 func New(enab zapcore.LevelEnabler) (zapcore.Core, *ObservedLogs) {
@@ -194,10 +269,9 @@ func (co *contextObserver) Write(ent zapcore.Entry, fields []zapcore.Field) erro
 	all := make([]zapcore.Field, 0, len(fields)+len(co.context))
 	all = append(all, co.context...)
 	all = append(all, fields...)
-	co.logs.add(LoggedEntry{ent, all})
-	return nil
+	return co.logs.add(LoggedEntry{ent, all})
 }
 
 func (co *contextObserver) Sync() error {
-	return nil
+	return co.logs.sync()
 }
@@ -21,6 +21,7 @@
 package observer_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/internal/ztest"
 	"go.uber.org/zap/zapcore"
 
 	//revive:disable:dot-imports
@@ -125,6 +127,80 @@ func TestObserverWith(t *testing.T) {
 	}, logs.All(), "expected no field sharing between With siblings")
 }
 
+func TestObserverSyncs(t *testing.T) {
+	core, logs := New(zap.InfoLevel)
+	child := core.With([]zapcore.Field{zap.Int("a", 1)})
+	assert.Zero(t, logs.Syncs(), "Expected no syncs before syncing.")
+
+	require.NoError(t, core.Sync(), "Unexpected error syncing core.")
+	require.NoError(t, child.Sync(), "Unexpected error syncing child core.")
+	assert.Equal(t, 2, logs.Syncs(), "Expected syncs of the core and its children to be counted.")
+
+	failure := errors.New("fail")
+	logs.FailSyncs(failure)
+	assert.Equal(t, failure, child.Sync(), "Expected injected sync error.")
+	assert.Equal(t, 3, logs.Syncs(), "Expected failed syncs to be counted.")
+
+	logs.FailSyncs(nil)
+	assert.NoError(t, core.Sync(), "Expected syncs to succeed after clearing the injected error.")
+	assert.Equal(t, 4, logs.Syncs(), "Unexpected number of syncs.")
+}
+
+func TestObserverFailWrites(t *testing.T) {
+	core, logs := New(zap.InfoLevel)
+	failure := errors.New("fail")
+	logs.FailWrites(func(e LoggedEntry) error {
+		if e.Message == "bad" {
+			return failure
+		}
+		return nil
+	})
+
+	good := zapcore.Entry{Level: zap.InfoLevel, Message: "good"}
+	bad := zapcore.Entry{Level: zap.WarnLevel, Message: "bad"}
+	assert.NoError(t, core.Write(good, nil), "Unexpected error writing entry.")
+	assert.Equal(t, failure, core.Write(bad, []zapcore.Field{zap.Int("i", 1)}), "Expected injected write error.")
+
+	assert.Equal(t, []LoggedEntry{{Entry: good, Context: []zapcore.Field{}}}, logs.AllUntimed(), "Expected only successful writes to be observed.")
+	assert.Equal(t, []LoggedEntry{
+		{Entry: bad, Context: []zapcore.Field{zap.Int("i", 1)}},
+	}, logs.FailedWrites(), "Expected failed writes to be recorded.")
+
+	logs.FailWrites(nil)
+	assert.NoError(t, core.Write(bad, nil), "Expected writes to succeed after clearing the injector.")
+	assert.Equal(t, 2, logs.Len(), "Unexpected number of observed logs.")
+	assert.Len(t, logs.FailedWrites(), 1, "Unexpected number of failed writes.")
+}
+
+func TestObserverFailWritesInspectsLogs(t *testing.T) {
+	core, logs := New(zap.InfoLevel)
+	logs.FailWrites(func(LoggedEntry) error {
+		if logs.Len() >= 2 {
+			return errors.New("full")
+		}
+		return nil
+	})
+
+	ent := zapcore.Entry{Level: zap.InfoLevel, Message: "msg"}
+	for i := 0; i < 3; i++ {
+		_ = core.Write(ent, nil)
+	}
+	assert.Equal(t, 2, logs.Len(), "Unexpected number of observed logs.")
+	assert.Len(t, logs.FailedWrites(), 1, "Unexpected number of failed writes.")
+}
+
+func TestObserverFailWritesReported(t *testing.T) {
+	core, logs := New(zap.InfoLevel)
+	logs.FailWrites(func(LoggedEntry) error { return errors.New("fail") })
+
+	errOut := &ztest.Buffer{}
+	logger := zap.New(core, zap.ErrorOutput(errOut))
+	logger.Info("hello")
+
+	assert.Zero(t, logs.Len(), "Expected failed write not to be observed.")
+	assert.Contains(t, errOut.String(), "write error: fail", "Expected logger to report the injected write error.")
+}
+
 func TestFilters(t *testing.T) {
 	logs := []LoggedEntry{
 		{
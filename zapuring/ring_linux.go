@@ -0,0 +1,250 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build linux && (386 || amd64 || arm || arm64 || loong64 || ppc64 || ppc64le || riscv64 || s390x)
+
+package zapuring
+
+import (
+	"fmt"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+)
+
+// These system call numbers are shared by every architecture in the build
+// constraint above.
+const (
+	_sysIOURingSetup = 425
+	_sysIOURingEnter = 426
+)
+
+const (
+	_offSQRing = 0
+	_offCQRing = 0x8000000
+	_offSQEs   = 0x10000000
+
+	_enterGetEvents = 1 << 0
+
+	// IORING_FEAT_RW_CUR_POS arrived in Linux 5.6 alongside IORING_OP_WRITE,
+	// so we use it to detect kernels that can't run our writes.
+	_featRWCurPos = 1 << 3
+
+	_opWrite = 23
+
+	_sqeSize = 64
+	_cqeSize = 16
+)
+
+// ioSQRingOffsets mirrors struct io_sqring_offsets.
+type ioSQRingOffsets struct {
+	head, tail, ringMask, ringEntries, flags, dropped, array, resv1 uint32
+	userAddr                                                        uint64
+}
+
+// ioCQRingOffsets mirrors struct io_cqring_offsets.
+type ioCQRingOffsets struct {
+	head, tail, ringMask, ringEntries, overflow, cqes, flags, resv1 uint32
+	userAddr                                                        uint64
+}
+
+// ioURingParams mirrors struct io_uring_params.
+type ioURingParams struct {
+	sqEntries, cqEntries, flags, sqThreadCPU, sqThreadIdle, features, wqFD uint32
+	resv                                                                   [3]uint32
+	sqOff                                                                  ioSQRingOffsets
+	cqOff                                                                  ioCQRingOffsets
+}
+
+// ioURingSQE mirrors the fields of struct io_uring_sqe that writes use.
+type ioURingSQE struct {
+	opcode   uint8
+	flags    uint8
+	ioprio   uint16
+	fd       int32
+	off      uint64
+	addr     uint64
+	len      uint32
+	rwFlags  uint32
+	userData uint64
+	_        [24]byte
+}
+
+// ioURingCQE mirrors struct io_uring_cqe.
+type ioURingCQE struct {
+	userData uint64
+	res      int32
+	flags    uint32
+}
+
+// ring is a minimal io_uring instance that submits writes and reaps their
+// completions. It isn't safe for concurrent use.
+type ring struct {
+	fd int
+
+	sqMem, cqMem, sqeMem []byte
+
+	sqHead, sqTail, sqMask *uint32
+	sqArray                []uint32
+	sqes                   []ioURingSQE
+
+	cqHead, cqTail, cqMask *uint32
+	cqes                   []ioURingCQE
+}
+
+func newRing(entries uint32) (_ *ring, err error) {
+	var p ioURingParams
+	fd, _, errno := syscall.Syscall(_sysIOURingSetup, uintptr(entries), uintptr(unsafe.Pointer(&p)), 0)
+	if errno != 0 {
+		return nil, fmt.Errorf("io_uring_setup: %w", errno)
+	}
+	r := &ring{fd: int(fd)}
+	defer func() {
+		if err != nil {
+			r.close()
+		}
+	}()
+
+	if p.features&_featRWCurPos == 0 {
+		return nil, fmt.Errorf("io_uring doesn't support writes on this kernel: %w", syscall.ENOTSUP)
+	}
+
+	if r.sqMem, err = mmap(r.fd, _offSQRing, int(p.sqOff.array+p.sqEntries*4)); err != nil {
+		return nil, err
+	}
+	if r.cqMem, err = mmap(r.fd, _offCQRing, int(p.cqOff.cqes+p.cqEntries*_cqeSize)); err != nil {
+		return nil, err
+	}
+	if r.sqeMem, err = mmap(r.fd, _offSQEs, int(p.sqEntries*_sqeSize)); err != nil {
+		return nil, err
+	}
+
+	r.sqHead = (*uint32)(unsafe.Pointer(&r.sqMem[p.sqOff.head]))
+	r.sqTail = (*uint32)(unsafe.Pointer(&r.sqMem[p.sqOff.tail]))
+	r.sqMask = (*uint32)(unsafe.Pointer(&r.sqMem[p.sqOff.ringMask]))
+	r.sqArray = unsafe.Slice((*uint32)(unsafe.Pointer(&r.sqMem[p.sqOff.array])), p.sqEntries)
+	r.sqes = unsafe.Slice((*ioURingSQE)(unsafe.Pointer(&r.sqeMem[0])), p.sqEntries)
+
+	r.cqHead = (*uint32)(unsafe.Pointer(&r.cqMem[p.cqOff.head]))
+	r.cqTail = (*uint32)(unsafe.Pointer(&r.cqMem[p.cqOff.tail]))
+	r.cqMask = (*uint32)(unsafe.Pointer(&r.cqMem[p.cqOff.ringMask]))
+	r.cqes = unsafe.Slice((*ioURingCQE)(unsafe.Pointer(&r.cqMem[p.cqOff.cqes])), p.cqEntries)
+	return r, nil
+}
+
+// clearAppend clears O_APPEND on fd: the kernel ignores the offsets of
+// positioned writes to files opened with it, so writes still in flight could
+// land out of order.
+func clearAppend(fd int) error {
+	flags, _, errno := syscall.Syscall(syscall.SYS_FCNTL, uintptr(fd), syscall.F_GETFL, 0)
+	if errno != 0 {
+		return fmt.Errorf("fcntl: %w", errno)
+	}
+	if flags&syscall.O_APPEND == 0 {
+		return nil
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_FCNTL, uintptr(fd), syscall.F_SETFL, flags&^syscall.O_APPEND); errno != 0 {
+		return fmt.Errorf("fcntl: %w", errno)
+	}
+	return nil
+}
+
+func mmap(fd int, off int64, size int) ([]byte, error) {
+	b, err := syscall.Mmap(fd, off, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED|syscall.MAP_POPULATE)
+	if err != nil {
+		return nil, fmt.Errorf("mmap io_uring: %w", err)
+	}
+	return b, nil
+}
+
+// write submits a write of buf to fd at the given offset. The caller must
+// keep buf alive and unmodified until the completion tagged with tag is
+// reaped, and must never have more writes in flight than the ring has
+// entries.
+func (r *ring) write(fd int, off int64, buf []byte, tag uint64) error {
+	tail := atomic.LoadUint32(r.sqTail)
+	idx := tail & *r.sqMask
+	r.sqes[idx] = ioURingSQE{
+		opcode:   _opWrite,
+		fd:       int32(fd),
+		off:      uint64(off),
+		addr:     uint64(uintptr(unsafe.Pointer(&buf[0]))),
+		len:      uint32(len(buf)),
+		userData: tag,
+	}
+	r.sqArray[idx] = idx
+	atomic.StoreUint32(r.sqTail, tail+1)
+	if err := r.enter(1, 0); err != nil {
+		// Nothing was submitted, so take the entry back.
+		atomic.StoreUint32(r.sqTail, tail)
+		return err
+	}
+	return nil
+}
+
+// reap calls fn with the number of bytes written (or the error) for each
+// available completion. If wait is true, it first blocks until at least one
+// completion is available.
+func (r *ring) reap(wait bool, fn func(tag uint64, n int, err error)) error {
+	if wait && atomic.LoadUint32(r.cqHead) == atomic.LoadUint32(r.cqTail) {
+		if err := r.enter(0, 1); err != nil {
+			return err
+		}
+	}
+	head := atomic.LoadUint32(r.cqHead)
+	tail := atomic.LoadUint32(r.cqTail)
+	for ; head != tail; head++ {
+		cqe := r.cqes[head&*r.cqMask]
+		if cqe.res < 0 {
+			fn(cqe.userData, 0, syscall.Errno(-cqe.res))
+		} else {
+			fn(cqe.userData, int(cqe.res), nil)
+		}
+	}
+	atomic.StoreUint32(r.cqHead, head)
+	return nil
+}
+
+func (r *ring) enter(submit, minComplete uint32) error {
+	var flags uintptr
+	if minComplete > 0 {
+		flags = _enterGetEvents
+	}
+	for {
+		_, _, errno := syscall.Syscall6(_sysIOURingEnter, uintptr(r.fd), uintptr(submit), uintptr(minComplete), flags, 0, 0)
+		switch errno {
+		case 0:
+			return nil
+		case syscall.EINTR:
+			continue
+		default:
+			return fmt.Errorf("io_uring_enter: %w", errno)
+		}
+	}
+}
+
+func (r *ring) close() {
+	for _, b := range [][]byte{r.sqeMem, r.cqMem, r.sqMem} {
+		if b != nil {
+			_ = syscall.Munmap(b)
+		}
+	}
+	_ = syscall.Close(r.fd)
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapuring provides an experimental file WriteSyncer that submits
+// writes through Linux's io_uring interface.
+//
+// Each Write copies the entry into a buffer owned by the WriteSyncer and
+// queues it with the kernel, returning without waiting for the write to
+// complete. For services where write syscalls are a measurable fraction of
+// CPU time, this moves the cost of the write off the logging goroutine.
+//
+// On other platforms, on kernels without io_uring (or with it disabled),
+// and for files that don't support positioned writes (like pipes and
+// terminals), WriteSyncers transparently fall back to ordinary writes.
+//
+// This package is experimental: its API may change in backwards
+// incompatible ways.
+package zapuring
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+const _defaultEntries = 64
+
+// An Option configures a WriteSyncer.
+type Option interface {
+	apply(*WriteSyncer)
+}
+
+type optionFunc func(*WriteSyncer)
+
+func (f optionFunc) apply(s *WriteSyncer) { f(s) }
+
+// Entries sets the maximum number of writes in flight at once. Writes
+// beyond this limit block until an earlier write completes. Defaults to 64.
+func Entries(n int) Option {
+	return optionFunc(func(s *WriteSyncer) {
+		if n > 0 {
+			s.entries = n
+		}
+	})
+}
+
+// A WriteSyncer writes to a file through io_uring when possible. It's safe
+// for concurrent use.
+//
+// Because writes complete asynchronously, an error from a failed write is
+// returned by the next call to Write or Sync rather than by the Write that
+// queued it. Sync waits for every queued write to complete before syncing
+// the file.
+//
+// The WriteSyncer writes at explicit offsets starting from the end of the
+// file, so it must be the only writer to the file. Since the kernel ignores
+// those offsets for files opened with O_APPEND, New clears the flag.
+type WriteSyncer struct {
+	f       *os.File
+	fd      int
+	entries int
+
+	mu    sync.Mutex
+	ring  *ring // nil when falling back to plain writes
+	off   int64 // offset of the next write
+	slots []slot
+	free  []int // indexes of slots with no write in flight
+	err   error // errors from completed writes, not yet reported
+}
+
+// A slot holds the contents of one queued write.
+type slot struct {
+	buf     []byte
+	off     int64 // offset of buf in the file
+	written int   // bytes of buf that have reached the file
+}
+
+var _ zapcore.WriteSyncer = (*WriteSyncer)(nil)
+
+// New builds a WriteSyncer for the given file, taking ownership of it: the
+// file is closed when the WriteSyncer is.
+func New(f *os.File, opts ...Option) *WriteSyncer {
+	s := &WriteSyncer{f: f, entries: _defaultEntries}
+	for _, opt := range opts {
+		opt.apply(s)
+	}
+
+	off, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		// Not seekable; positioned writes won't work.
+		return s
+	}
+	r, err := newRing(uint32(s.entries))
+	if err != nil {
+		return s
+	}
+	fd := int(f.Fd())
+	if err := clearAppend(fd); err != nil {
+		r.close()
+		return s
+	}
+
+	s.ring = r
+	s.fd = fd
+	s.off = off
+	s.slots = make([]slot, s.entries)
+	s.free = make([]int, s.entries)
+	for i := range s.free {
+		s.free[i] = i
+	}
+	return s
+}
+
+// Uring reports whether writes are submitted through io_uring, as opposed
+// to falling back to ordinary writes.
+func (s *WriteSyncer) Uring() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ring != nil
+}
+
+// Write queues bs to be written to the file. The contents of bs are copied,
+// so the caller may re-use it as soon as Write returns.
+func (s *WriteSyncer) Write(bs []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ring == nil {
+		return s.f.Write(bs)
+	}
+	if len(bs) == 0 {
+		return 0, s.takeErr()
+	}
+
+	if err := s.reap(len(s.free) == 0); err != nil {
+		return 0, err
+	}
+	for len(s.free) == 0 {
+		if err := s.reap(true); err != nil {
+			return 0, err
+		}
+	}
+
+	i := s.free[len(s.free)-1]
+	sl := &s.slots[i]
+	sl.buf = append(sl.buf[:0], bs...)
+	sl.off = s.off
+	sl.written = 0
+	if err := s.ring.write(s.fd, sl.off, sl.buf, uint64(i)); err != nil {
+		return 0, err
+	}
+	s.free = s.free[:len(s.free)-1]
+	s.off += int64(len(bs))
+	return len(bs), s.takeErr()
+}
+
+// Sync waits for all queued writes to complete, then flushes the file to
+// stable storage.
+func (s *WriteSyncer) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.drain()
+	return multierr.Append(err, s.f.Sync())
+}
+
+// Close waits for all queued writes to complete, releases the ring, and
+// closes the file.
+func (s *WriteSyncer) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.drain()
+	if s.ring != nil {
+		s.ring.close()
+		s.ring = nil
+	}
+	return multierr.Append(err, s.f.Close())
+}
+
+func (s *WriteSyncer) drain() error {
+	if s.ring == nil {
+		return nil
+	}
+	for len(s.free) < len(s.slots) {
+		if err := s.reap(true); err != nil {
+			return err
+		}
+	}
+	return s.takeErr()
+}
+
+// reap collects completed writes, returning their slots to the free list.
+// Short writes are resubmitted for the remainder of the slot, so the slot
+// stays in flight. If wait is true, it blocks until at least one write
+// completes.
+func (s *WriteSyncer) reap(wait bool) error {
+	return s.ring.reap(wait, func(tag uint64, n int, err error) {
+		i := int(tag)
+		sl := &s.slots[i]
+		if err == nil {
+			sl.written += n
+			if sl.written == len(sl.buf) {
+				s.free = append(s.free, i)
+				return
+			}
+			if n == 0 {
+				err = io.ErrShortWrite
+			} else {
+				err = s.ring.write(s.fd, sl.off+int64(sl.written), sl.buf[sl.written:], tag)
+			}
+		}
+		if err != nil {
+			s.err = multierr.Append(s.err, &os.PathError{Op: "write", Path: s.f.Name(), Err: err})
+			s.free = append(s.free, i)
+		}
+	})
+}
+
+func (s *WriteSyncer) takeErr() error {
+	err := s.err
+	s.err = nil
+	return err
+}
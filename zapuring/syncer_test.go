@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapuring
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTemp(t testing.TB, flag int) *os.File {
+	path := filepath.Join(t.TempDir(), "log")
+	f, err := os.OpenFile(path, flag|os.O_CREATE, 0o644)
+	require.NoError(t, err, "Failed to open temporary file.")
+	return f
+}
+
+func readFile(t testing.TB, f *os.File) string {
+	bs, err := os.ReadFile(f.Name())
+	require.NoError(t, err, "Failed to read file.")
+	return string(bs)
+}
+
+func TestWriteSyncerWrites(t *testing.T) {
+	f := openTemp(t, os.O_WRONLY)
+	_, err := f.WriteString("existing\n")
+	require.NoError(t, err, "Failed to seed file.")
+
+	ws := New(f, Entries(4))
+	t.Logf("io_uring enabled: %v", ws.Uring())
+
+	var want strings.Builder
+	want.WriteString("existing\n")
+	for i := 0; i < 100; i++ {
+		line := fmt.Sprintf("line %d\n", i)
+		want.WriteString(line)
+
+		n, err := ws.Write([]byte(line))
+		require.NoError(t, err, "Unexpected error writing.")
+		assert.Equal(t, len(line), n, "Unexpected number of bytes written.")
+	}
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, want.String(), readFile(t, f), "Unexpected file contents.")
+	assert.NoError(t, ws.Close(), "Unexpected error closing.")
+}
+
+func TestWriteSyncerAppendMode(t *testing.T) {
+	f := openTemp(t, os.O_WRONLY|os.O_APPEND)
+	_, err := f.WriteString("existing\n")
+	require.NoError(t, err, "Failed to seed file.")
+
+	ws := New(f, Entries(8))
+	defer ws.Close()
+
+	var want strings.Builder
+	want.WriteString("existing\n")
+	for i := 0; i < 1000; i++ {
+		line := fmt.Sprintf("line %d %s\n", i, strings.Repeat("x", i%50))
+		want.WriteString(line)
+		_, err := ws.Write([]byte(line))
+		require.NoError(t, err, "Unexpected error writing.")
+	}
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, want.String(), readFile(t, f), "Expected writes in order despite O_APPEND.")
+}
+
+func TestWriteSyncerCopiesInput(t *testing.T) {
+	ws := New(openTemp(t, os.O_WRONLY))
+	defer ws.Close()
+
+	buf := []byte("foo\n")
+	_, err := ws.Write(buf)
+	require.NoError(t, err, "Unexpected error writing.")
+	copy(buf, "bar\n")
+
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, "foo\n", readFile(t, ws.f), "Expected Write to copy its input.")
+}
+
+func TestWriteSyncerConcurrency(t *testing.T) {
+	ws := New(openTemp(t, os.O_WRONLY), Entries(8))
+	defer ws.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, err := ws.Write([]byte("line\n"))
+				assert.NoError(t, err, "Unexpected error writing.")
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, strings.Repeat("line\n", 800), readFile(t, ws.f), "Unexpected file contents.")
+}
+
+func TestWriteSyncerFallsBackForPipes(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err, "Failed to create pipe.")
+	defer r.Close()
+
+	ws := New(w)
+	assert.False(t, ws.Uring(), "Expected pipes to fall back to plain writes.")
+
+	_, err = ws.Write([]byte("foo\n"))
+	require.NoError(t, err, "Unexpected error writing.")
+	require.NoError(t, ws.Close(), "Unexpected error closing.")
+
+	buf := make([]byte, 4)
+	_, err = r.Read(buf)
+	require.NoError(t, err, "Unexpected error reading pipe.")
+	assert.Equal(t, "foo\n", string(buf), "Unexpected bytes read from pipe.")
+}
+
+func TestWriteSyncerErrors(t *testing.T) {
+	f := openTemp(t, os.O_RDONLY)
+	ws := New(f)
+
+	_, werr := ws.Write([]byte("foo\n"))
+	serr := ws.Sync()
+	if werr == nil {
+		// Queued writes report failures on the next Write or Sync.
+		assert.ErrorContains(t, serr, "write "+f.Name(), "Expected failed write to be reported by Sync.")
+	}
+	assert.True(t, werr != nil || serr != nil, "Expected writing to a read-only file to fail.")
+	assert.NoError(t, ws.Close(), "Unexpected error closing.")
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapvet
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+)
+
+// AnyAnalyzer reports calls to zap.Any whose value has a static type with a
+// matching field constructor. The exact constructors are faster and make
+// the field's representation clear to readers.
+var AnyAnalyzer = &analysis.Analyzer{
+	Name:     "anyfield",
+	Doc:      "suggest exact field constructors in place of zap.Any",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runAny,
+}
+
+func runAny(pass *analysis.Pass) (interface{}, error) {
+	inspectCalls(pass, func(call *ast.CallExpr) {
+		fn := zapCallee(pass.TypesInfo, call)
+		if fn == nil || fn.Name() != "Any" || len(call.Args) != 2 {
+			return
+		}
+		if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() != nil {
+			return
+		}
+		arg := call.Args[1]
+		t := pass.TypesInfo.TypeOf(arg)
+		if t == nil {
+			return
+		}
+		if name := constructorFor(t); name != "" {
+			pass.Reportf(call.Pos(), "zap.Any with a value of type %v; use zap.%v instead",
+				types.TypeString(types.Default(t), types.RelativeTo(pass.Pkg)), name)
+		}
+	})
+	return nil, nil
+}
+
+// _basicConstructors maps the kinds of basic types to the names of the
+// field constructors for values, pointers, and slices of them.
+var _basicConstructors = map[types.BasicKind]string{
+	types.Bool:       "Bool",
+	types.Complex128: "Complex128",
+	types.Complex64:  "Complex64",
+	types.Float64:    "Float64",
+	types.Float32:    "Float32",
+	types.Int:        "Int",
+	types.Int64:      "Int64",
+	types.Int32:      "Int32",
+	types.Int16:      "Int16",
+	types.Int8:       "Int8",
+	types.String:     "String",
+	types.Uint:       "Uint",
+	types.Uint64:     "Uint64",
+	types.Uint32:     "Uint32",
+	types.Uint16:     "Uint16",
+	types.Uint8:      "Uint8",
+	types.Uintptr:    "Uintptr",
+}
+
+// constructorFor returns the name of the zap field constructor that Any
+// would use for values of type t, or "" if there's none that t can be
+// passed to directly.
+func constructorFor(t types.Type) string {
+	t = types.Unalias(types.Default(t))
+
+	// Any checks for marshalers first, so we do too.
+	switch {
+	case hasMethod(t, "MarshalLogObject"):
+		return "Object"
+	case hasMethod(t, "MarshalLogArray"):
+		return "Array"
+	}
+
+	switch t := t.(type) {
+	case *types.Basic:
+		return _basicConstructors[t.Kind()]
+	case *types.Pointer:
+		if b, ok := t.Elem().(*types.Basic); ok {
+			if name := _basicConstructors[b.Kind()]; name != "" {
+				return name + "p"
+			}
+		}
+		switch {
+		case isTimeType(t.Elem(), "Time"):
+			return "Timep"
+		case isTimeType(t.Elem(), "Duration"):
+			return "Durationp"
+		}
+	case *types.Slice:
+		if b, ok := t.Elem().(*types.Basic); ok {
+			if b.Kind() == types.Byte {
+				return "Binary"
+			}
+			if name := _basicConstructors[b.Kind()]; name != "" {
+				return name + "s"
+			}
+		}
+		switch {
+		case isTimeType(t.Elem(), "Time"):
+			return "Times"
+		case isTimeType(t.Elem(), "Duration"):
+			return "Durations"
+		case types.Identical(t.Elem(), types.Universe.Lookup("error").Type()):
+			return "Errors"
+		}
+	case *types.Named:
+		switch {
+		case isTimeType(t, "Time"):
+			return "Time"
+		case isTimeType(t, "Duration"):
+			return "Duration"
+		}
+	}
+
+	switch {
+	case isError(t):
+		return "NamedError"
+	case hasMethod(t, "String"):
+		return "Stringer"
+	}
+	return ""
+}
+
+// isTimeType reports whether t is the named type from the time package.
+func isTimeType(t types.Type, name string) bool {
+	return isNamed(t, "time", name)
+}
+
+// hasMethod reports whether values of type t have a method with the given
+// name.
+func hasMethod(t types.Type, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, false, nil, name)
+	_, ok := obj.(*types.Func)
+	return ok
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapvet
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// MessageAnalyzer reports log messages that aren't constants. Messages that
+// vary from entry to entry make logs hard to search and aggregate; the
+// variable parts belong in fields.
+//
+// Messages that a function forwards from its own parameters, as logging
+// helpers do, aren't reported.
+var MessageAnalyzer = &analysis.Analyzer{
+	Name:     "constmsg",
+	Doc:      "report log messages that aren't constant strings",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runMessage,
+}
+
+// messageIndex returns the position of the message among the arguments of
+// the given zap method, or -1 if it doesn't take a message.
+func messageIndex(method, recv string) int {
+	switch recv {
+	case "Logger":
+		switch method {
+		case "Trace", "Debug", "Info", "Warn", "Error", "DPanic", "Panic", "Fatal":
+			return 0
+		case "Log", "Check":
+			return 1
+		}
+	case "SugaredLogger":
+		switch method {
+		case "Tracew", "Debugw", "Infow", "Warnw", "Errorw", "DPanicw", "Panicw", "Fatalw":
+			return 0
+		case "Logw":
+			return 1
+		}
+	case "Verbose":
+		if method == "Info" {
+			return 0
+		}
+	}
+	return -1
+}
+
+func runMessage(pass *analysis.Pass) (interface{}, error) {
+	params := parameters(pass)
+	inspectCalls(pass, func(call *ast.CallExpr) {
+		i := messageIndex(zapMethod(pass.TypesInfo, call))
+		if i < 0 || i >= len(call.Args) {
+			return
+		}
+		msg := call.Args[i]
+		if id, ok := msg.(*ast.Ident); ok {
+			if _, ok := params[pass.TypesInfo.Uses[id]]; ok {
+				return
+			}
+		}
+		if tv, ok := pass.TypesInfo.Types[msg]; ok && tv.Value == nil {
+			pass.Reportf(msg.Pos(), "log message %v isn't a constant; move the parts that vary into fields",
+				types.ExprString(msg))
+		}
+	})
+	return nil, nil
+}
+
+// parameters returns the objects for the parameters of all functions in the
+// pass's files.
+func parameters(pass *analysis.Pass) map[types.Object]struct{} {
+	params := make(map[types.Object]struct{})
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.FuncType)(nil)}, func(n ast.Node) {
+		ft := n.(*ast.FuncType)
+		if ft.Params == nil {
+			return
+		}
+		for _, field := range ft.Params.List {
+			for _, name := range field.Names {
+				params[pass.TypesInfo.Defs[name]] = struct{}{}
+			}
+		}
+	})
+	return params
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapvet
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+)
+
+// SugarAnalyzer reports mistakes in the loosely-typed key-value pairs passed to
+// the SugaredLogger's With and "w" methods, like Infow: keys without values,
+// keys that aren't strings, and more than one error without a key. The
+// SugaredLogger reports these mistakes too, but only at runtime, and only
+// when the affected entries are logged.
+var SugarAnalyzer = &analysis.Analyzer{
+	Name:     "sugarkv",
+	Doc:      "report malformed key-value pairs passed to the SugaredLogger",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runSugar,
+}
+
+// pairsIndex returns the position at which the key-value pairs start among
+// the arguments of the given zap method, or -1 if it doesn't take any.
+func pairsIndex(method, recv string) int {
+	if recv != "SugaredLogger" {
+		return -1
+	}
+	switch method {
+	case "With", "WithLazy":
+		return 0
+	case "Tracew", "Debugw", "Infow", "Warnw", "Errorw", "DPanicw", "Panicw", "Fatalw":
+		return 1
+	case "Logw":
+		return 2
+	}
+	return -1
+}
+
+func runSugar(pass *analysis.Pass) (interface{}, error) {
+	inspectCalls(pass, func(call *ast.CallExpr) {
+		start := pairsIndex(zapMethod(pass.TypesInfo, call))
+		if start < 0 || call.Ellipsis.IsValid() {
+			return
+		}
+		checkPairs(pass, call.Args[start:])
+	})
+	return nil, nil
+}
+
+// checkPairs mirrors the way the SugaredLogger consumes key-value pairs,
+// stopping at the first argument whose dynamic type can't be known.
+func checkPairs(pass *analysis.Pass, args []ast.Expr) {
+	var seenError bool
+	for i := 0; i < len(args); {
+		t := pass.TypesInfo.TypeOf(args[i])
+		if t == nil {
+			return
+		}
+		t = types.Unalias(types.Default(t))
+
+		switch {
+		case isField(t):
+			i++
+			continue
+		case isError(t):
+			if seenError {
+				pass.Reportf(args[i].Pos(), "more than one error passed without a key; only the first is logged under %q",
+					"error")
+			}
+			seenError = true
+			i++
+			continue
+		case types.IsInterface(t):
+			// Could be a Field, an error, or a key.
+			return
+		}
+
+		if i == len(args)-1 {
+			pass.Reportf(args[i].Pos(), "key %v has no value", types.ExprString(args[i]))
+			return
+		}
+		if b, ok := t.(*types.Basic); !ok || b.Kind() != types.String {
+			pass.Reportf(args[i].Pos(), "key %v has type %v, not string; the pair is ignored",
+				types.ExprString(args[i]), types.TypeString(t, types.RelativeTo(pass.Pkg)))
+		}
+		i += 2
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package anyfield
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type userID int64
+
+type user struct{}
+
+func (user) MarshalLogObject(zapcore.ObjectEncoder) error { return nil }
+
+func fields(v interface{}, err error, n int, p *string, id userID) []zap.Field {
+	return []zap.Field{
+		zap.Any("n", n),                       // want "zap.Any with a value of type int; use zap.Int instead"
+		zap.Any("const", 42),                  // want "type int; use zap.Int instead"
+		zap.Any("float", 1.5),                 // want "type float64; use zap.Float64 instead"
+		zap.Any("ptr", p),                     // want "type \\*string; use zap.Stringp instead"
+		zap.Any("bytes", []byte("foo")),       // want "type \\[\\]byte; use zap.Binary instead"
+		zap.Any("strs", []string{"a"}),        // want "use zap.Strings instead"
+		zap.Any("when", time.Now()),           // want "type time.Time; use zap.Time instead"
+		zap.Any("dur", time.Second),           // want "type time.Duration; use zap.Duration instead"
+		zap.Any("errs", []error{err}),         // want "use zap.Errors instead"
+		zap.Any("err", err),                   // want "type error; use zap.NamedError instead"
+		zap.Any("err", errors.New("fail")),    // want "use zap.NamedError instead"
+		zap.Any("user", user{}),               // want "type user; use zap.Object instead"
+		zap.Any("ip", net.ParseIP("1.2.3.4")), // want "type net.IP; use zap.Stringer instead"
+		zap.Any("v", v),
+		zap.Any("id", id),
+		zap.Any("m", map[string]int{}),
+		zap.Any("nil", nil),
+		zap.Typed("n", n),
+		zap.String("s", fmt.Sprint(n)),
+	}
+}
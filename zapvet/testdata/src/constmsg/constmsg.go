@@ -0,0 +1,46 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package constmsg
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const greeting = "hello"
+
+func log(logger *zap.Logger, name string, lvl zapcore.Level) {
+	logger.Info("hello")
+	logger.Info(greeting + ", world")
+	logger.Info(fmt.Sprintf("hello %v", name)) // want "log message fmt.Sprintf\\(\"hello %v\", name\\) isn't a constant"
+	logger.Log(lvl, "hello "+name)             // want "log message \"hello \" \\+ name isn't a constant"
+	logger.V(1).Info(name)
+	msg := "hello " + name
+	logger.Warn(msg)          // want "log message msg isn't a constant"
+	logger.Sugar().Infow(msg) // want "log message msg"
+	logger.Sugar().Info(msg)
+	logger.Sugar().Infof("hello %v", name)
+	if ce := logger.Check(zapcore.InfoLevel, name); ce != nil {
+		ce.Write()
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zap is a stand-in for go.uber.org/zap with just enough of its API
+// for zapvet's tests.
+package zap
+
+import (
+	"context"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type Field = zapcore.Field
+
+func Any(key string, value interface{}) Field { return Field{Key: key} }
+func Error(err error) Field                   { return Field{Key: "error"} }
+func Int(key string, value int) Field         { return Field{Key: key} }
+func String(key string, value string) Field   { return Field{Key: key} }
+func Typed[T any](key string, value T) Field  { return Field{Key: key} }
+
+type Logger struct{}
+
+func (log *Logger) Sugar() *SugaredLogger                                     { return &SugaredLogger{} }
+func (log *Logger) Named(s string) *Logger                                    { return log }
+func (log *Logger) With(fields ...Field) *Logger                              { return log }
+func (log *Logger) Ctx(ctx context.Context) *Logger                           { return log }
+func (log *Logger) Check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry { return nil }
+func (log *Logger) Log(lvl zapcore.Level, msg string, fields ...Field)        {}
+func (log *Logger) Info(msg string, fields ...Field)                          {}
+func (log *Logger) Warn(msg string, fields ...Field)                          {}
+func (log *Logger) V(n int) Verbose                                           { return Verbose{} }
+func (log *Logger) Sync() error                                               { return nil }
+
+type Verbose struct{}
+
+func (v Verbose) Info(msg string, fields ...Field) {}
+
+type SugaredLogger struct{}
+
+func (s *SugaredLogger) With(args ...interface{}) *SugaredLogger                          { return s }
+func (s *SugaredLogger) Info(args ...interface{})                                         {}
+func (s *SugaredLogger) Infof(template string, args ...interface{})                       {}
+func (s *SugaredLogger) Infow(msg string, keysAndValues ...interface{})                   {}
+func (s *SugaredLogger) Logw(lvl zapcore.Level, msg string, keysAndValues ...interface{}) {}
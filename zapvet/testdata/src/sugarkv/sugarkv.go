@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sugarkv
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type key string
+
+func log(s *zap.SugaredLogger, err error, v interface{}, kvs []interface{}) {
+	s.Infow("ok", "k", 1, "k2", "v")
+	s.Infow("ok", zap.Int("k", 1), "k2", 2, err)
+	s.Infow("dangling", "k", 1, "k2")           // want "key \"k2\" has no value"
+	s.Infow("not a string", 42, "v")            // want "key 42 has type int, not string; the pair is ignored"
+	s.Infow("named string", key("k"), "v")      // want "key key\\(\"k\"\\) has type key, not string"
+	s.Infow("two errors", err, errors.New("x")) // want "more than one error passed without a key"
+	s.Logw(zapcore.InfoLevel, "level", "k")     // want "key \"k\" has no value"
+	s.With("k")                                 // want "key \"k\" has no value"
+	s.Infow("unknown", v, "k")
+	s.Infow("spread", kvs...)
+	s.Info("not pairs", "k")
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package unusedfield
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+func log(ctx context.Context, logger *zap.Logger, err error) {
+	zap.String("k", "v")        // want "field built by zap.String is never logged"
+	_ = zap.Error(err)          // want "field built by zap.Error is never logged"
+	logger.With(zap.Error(err)) // want "logger returned by \\(\\*zap.Logger\\).With is discarded; With doesn't modify"
+	logger.Sugar().With("k", 1) // want "logger returned by \\(\\*zap.SugaredLogger\\).With is discarded"
+	logger.Named("sub")         // want "logger returned by \\(\\*zap.Logger\\).Named is discarded"
+	logger.Ctx(ctx)             // want "\\(\\*zap.Logger\\).Ctx is discarded"
+
+	logger = logger.With(zap.String("k", "v"))
+	f := zap.Int("n", 1)
+	logger.Info("ok", f)
+	_ = logger.Sync()
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapvet
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// UnusedAnalyzer reports fields that are built but never used, and loggers
+// returned by With and similar methods that are discarded. Both usually
+// mean that context was meant to be logged but isn't: With returns a new
+// logger rather than modifying the one it's called on.
+var UnusedAnalyzer = &analysis.Analyzer{
+	Name:     "unusedfield",
+	Doc:      "report discarded fields and discarded loggers returned by With",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runUnused,
+}
+
+func runUnused(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodes := []ast.Node{(*ast.ExprStmt)(nil), (*ast.AssignStmt)(nil)}
+	insp.Preorder(nodes, func(n ast.Node) {
+		var call *ast.CallExpr
+		switch n := n.(type) {
+		case *ast.ExprStmt:
+			call, _ = n.X.(*ast.CallExpr)
+		case *ast.AssignStmt:
+			// _ = zap.String(...) discards the field just the same.
+			if len(n.Lhs) == 1 && len(n.Rhs) == 1 && isBlank(n.Lhs[0]) {
+				call, _ = n.Rhs[0].(*ast.CallExpr)
+			}
+		}
+		if call != nil {
+			checkDiscarded(pass, call)
+		}
+	})
+	return nil, nil
+}
+
+func checkDiscarded(pass *analysis.Pass, call *ast.CallExpr) {
+	fn := zapCallee(pass.TypesInfo, call)
+	if fn == nil {
+		return
+	}
+	t := pass.TypesInfo.TypeOf(call)
+	if t == nil {
+		return
+	}
+
+	switch method, recv := zapMethod(pass.TypesInfo, call); {
+	case isField(t):
+		pass.Reportf(call.Pos(), "field built by %v is never logged", calleeName(fn))
+	case recv != "" && isLogger(t) && isContextMethod(method):
+		pass.Reportf(call.Pos(), "logger returned by %v is discarded; %v doesn't modify the logger it's called on",
+			calleeName(fn), method)
+	}
+}
+
+// isContextMethod reports whether the logger method with the given name
+// returns a copy of the logger with added context.
+func isContextMethod(method string) bool {
+	switch method {
+	case "With", "WithLazy", "WithOptions", "Named", "Ctx", "Sugar", "Desugar":
+		return true
+	}
+	return false
+}
+
+func isLogger(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	return isNamed(t, _zapPath, "Logger") || isNamed(t, _zapPath, "SugaredLogger")
+}
+
+func isBlank(e ast.Expr) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == "_"
+}
+
+// calleeName returns a name for fn like "zap.String" or
+// "(*zap.Logger).With".
+func calleeName(fn *types.Func) string {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return "zap." + fn.Name()
+	}
+	return "(" + types.TypeString(sig.Recv().Type(), (*types.Package).Name) + ")." + fn.Name()
+}
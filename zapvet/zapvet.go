@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapvet provides analyzers that report likely mistakes in code
+// that uses zap, like unpaired keys and values passed to the
+// SugaredLogger, or fields that are built but never logged.
+//
+// The analyzers are built on golang.org/x/tools/go/analysis, so they run
+// under any analysis driver: gopls, golangci-lint, or go vet by way of the
+// zapvet command in go.uber.org/zap/zapvet/cmd/zapvet:
+//
+//	go install go.uber.org/zap/zapvet/cmd/zapvet@latest
+//	go vet -vettool=$(which zapvet) ./...
+//
+// zapvet is its own module so that zap itself doesn't depend on
+// golang.org/x/tools.
+package zapvet // import "go.uber.org/zap/zapvet"
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const (
+	_zapPath     = "go.uber.org/zap"
+	_zapcorePath = "go.uber.org/zap/zapcore"
+)
+
+// Analyzers returns all of zapvet's analyzers.
+func Analyzers() []*analysis.Analyzer {
+	return []*analysis.Analyzer{
+		AnyAnalyzer,
+		MessageAnalyzer,
+		SugarAnalyzer,
+		UnusedAnalyzer,
+	}
+}
+
+// zapCallee returns the zap function or method called by call, or nil if
+// call doesn't call into zap.
+func zapCallee(info *types.Info, call *ast.CallExpr) *types.Func {
+	id := calleeIdent(call.Fun)
+	if id == nil {
+		return nil
+	}
+	fn, ok := info.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != _zapPath {
+		return nil
+	}
+	return fn
+}
+
+// zapMethod returns the name of the zap method called by call and the name
+// of its receiver's type, like "Infow" and "SugaredLogger". It returns empty
+// strings if call doesn't call a method of a zap type.
+func zapMethod(info *types.Info, call *ast.CallExpr) (method, recv string) {
+	fn := zapCallee(info, call)
+	if fn == nil {
+		return "", ""
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return "", ""
+	}
+	t := sig.Recv().Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return "", ""
+	}
+	return fn.Name(), named.Obj().Name()
+}
+
+// isNamed reports whether t is the named type from the given package.
+func isNamed(t types.Type, pkgPath, name string) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
+
+// isField reports whether t is zapcore.Field.
+func isField(t types.Type) bool {
+	return isNamed(t, _zapcorePath, "Field")
+}
+
+var _errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+// isError reports whether values of type t are errors.
+func isError(t types.Type) bool {
+	return types.Implements(t, _errorType)
+}
+
+// calleeIdent returns the identifier naming the function in the Fun of a
+// call expression, or nil if it isn't named.
+func calleeIdent(fun ast.Expr) *ast.Ident {
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		return fun
+	case *ast.SelectorExpr:
+		return fun.Sel
+	case *ast.ParenExpr:
+		return calleeIdent(fun.X)
+	case *ast.IndexExpr: // explicit instantiation, like zap.Typed[int]
+		return calleeIdent(fun.X)
+	default:
+		return nil
+	}
+}
+
+// inspectCalls calls f for every call expression in the pass's files.
+func inspectCalls(pass *analysis.Pass, f func(*ast.CallExpr)) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		f(n.(*ast.CallExpr))
+	})
+}